@@ -6,18 +6,38 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"mockhu-app-backend/internal/app/anonymity"
 	"mockhu-app-backend/internal/app/auth"
+	"mockhu-app-backend/internal/app/bookmark"
 	"mockhu-app-backend/internal/app/comment"
+	"mockhu-app-backend/internal/app/counter"
 	"mockhu-app-backend/internal/app/follow"
+	"mockhu-app-backend/internal/app/hashtag"
+	"mockhu-app-backend/internal/app/insights"
 	"mockhu-app-backend/internal/app/interest"
+	"mockhu-app-backend/internal/app/linkpreview"
+	"mockhu-app-backend/internal/app/media"
+	"mockhu-app-backend/internal/app/mention"
 	"mockhu-app-backend/internal/app/messaging"
+	"mockhu-app-backend/internal/app/moderation"
+	"mockhu-app-backend/internal/app/mute"
+	"mockhu-app-backend/internal/app/notification"
 	"mockhu-app-backend/internal/app/onboarding"
+	"mockhu-app-backend/internal/app/poll"
 	"mockhu-app-backend/internal/app/post"
 	"mockhu-app-backend/internal/app/profile"
+	"mockhu-app-backend/internal/app/report"
+	"mockhu-app-backend/internal/app/search"
 	"mockhu-app-backend/internal/app/share"
+	"mockhu-app-backend/internal/app/story"
+	"mockhu-app-backend/internal/app/timeline"
+	"mockhu-app-backend/internal/app/trash"
 	"mockhu-app-backend/internal/app/upload"
 	dbinfra "mockhu-app-backend/internal/infra/db"
+	previewpkg "mockhu-app-backend/internal/pkg/linkpreview"
+	"mockhu-app-backend/internal/pkg/scheduler"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/logger"
@@ -34,13 +54,18 @@ func main() {
 	defer pg.Close()
 	log.Println("✅ Database connected")
 
-	app := setupRouter(pg)
+	// Background jobs run until the server shuts down
+	jobsCtx, stopJobs := context.WithCancel(ctx)
+	defer stopJobs()
+
+	app := setupRouter(jobsCtx, pg)
 
 	// Graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 	go func() {
 		<-quit
+		stopJobs()
 		_ = app.Shutdown()
 	}()
 
@@ -50,9 +75,10 @@ func main() {
 	}
 }
 
-func setupRouter(pg *dbinfra.Postgres) *fiber.App {
+func setupRouter(ctx context.Context, pg *dbinfra.Postgres) *fiber.App {
 	app := fiber.New(fiber.Config{
-		AppName: "Mockhu API",
+		AppName:   "Mockhu API",
+		BodyLimit: 12 * 1024 * 1024, // Room for 10MB image uploads
 	})
 
 	// Middleware
@@ -63,8 +89,9 @@ func setupRouter(pg *dbinfra.Postgres) *fiber.App {
 	}))
 	app.Use(recover.New())
 
-	// Serve static files (avatars)
+	// Serve static files (avatars and post images)
 	app.Static("/avatars", "./storage/avatars")
+	app.Static("/media", "./storage/media")
 
 	// Build dependency layers: Repository -> Service -> Handler
 	authRepo := auth.NewPostgresUserRepository(pg.Pool)
@@ -81,29 +108,110 @@ func setupRouter(pg *dbinfra.Postgres) *fiber.App {
 	onboardingService := onboarding.NewService(authRepo, interestRepo)
 	onboardingHandler := onboarding.NewHandler(onboardingService)
 
+	// Initialize timeline domain (precomputed home timelines, fanned out on publish)
+	timelineStore, err := timeline.NewStore(ctx, timeline.ConfigFromEnv(), pg.Pool)
+	if err != nil {
+		log.Fatalf("Timeline store error: %v", err)
+	}
+	timelineRepo := timeline.NewPostgresTimelineRepository(pg.Pool)
+	timelineService := timeline.NewService(timelineRepo, timelineStore)
+
 	// Follow dependencies
 	followRepo := follow.NewPostgresFollowRepository(pg.Pool)
-	followService := follow.NewService(followRepo, authRepo)
+	followService := follow.NewService(followRepo, authRepo, timelineService)
 	followHandler := follow.NewHandler(followService)
 
+	// Initialize mute domain (muted accounts and keywords, applied in feeds, comments and notifications)
+	muteRepo := mute.NewPostgresMuteRepository(pg.Pool)
+	muteService := mute.NewService(muteRepo, authRepo)
+	muteHandler := mute.NewHandler(muteService)
+
+	// Notification dependencies
+	notificationRepo := notification.NewPostgresNotificationRepository(pg.Pool)
+	notificationService := notification.NewService(notificationRepo, authRepo)
+	notificationHandler := notification.NewHandler(notificationService)
+
+	// Mention dependencies
+	mentionRepo := mention.NewPostgresMentionRepository(pg.Pool)
+	mentionService := mention.NewService(mentionRepo, authRepo, notificationService)
+
+	// Media dependencies
+	mediaRepo := media.NewPostgresMediaRepository(pg.Pool)
+	mediaService := media.NewService(mediaRepo)
+	mediaHandler := media.NewHandler(mediaService)
+
+	// Poll dependencies
+	pollRepo := poll.NewPostgresPollRepository(pg.Pool)
+	pollService := poll.NewService(pollRepo)
+
+	// Moderation dependencies (classifier chain runs before posts, comments, bios and messages are saved)
+	moderationClassifiers, err := moderation.NewClassifiers(moderation.ConfigFromEnv())
+	if err != nil {
+		log.Fatalf("Moderation config error: %v", err)
+	}
+	moderationRepo := moderation.NewPostgresModerationRepository(pg.Pool)
+	moderationService := moderation.NewService(moderationRepo, moderationClassifiers)
+	moderationHandler := moderation.NewHandler(moderationService)
+
+	// Initialize anonymity domain (pseudonyms, anonymous posting limits and audited unmasking)
+	anonymityRepo := anonymity.NewPostgresAnonymityRepository(pg.Pool)
+	anonymityService := anonymity.NewService(anonymityRepo, authRepo)
+	anonymityHandler := anonymity.NewHandler(anonymityService)
+
+	// Initialize report domain (user reports and the moderator case queue)
+	reportRepo := report.NewPostgresReportRepository(pg.Pool)
+	reportService := report.NewService(reportRepo, notificationService, anonymityService)
+	reportHandler := report.NewHandler(reportService)
+
+	// Link preview dependencies (the default fetcher refuses private addresses)
+	linkPreviewRepo := linkpreview.NewPostgresLinkPreviewRepository(pg.Pool)
+	linkPreviewService := linkpreview.NewService(linkPreviewRepo, previewpkg.NewFetcher(nil))
+
+	// Hashtag dependencies
+	hashtagRepo := hashtag.NewPostgresHashtagRepository(pg.Pool)
+	hashtagService := hashtag.NewService(hashtagRepo)
+	hashtagHandler := hashtag.NewHandler(hashtagService)
+
+	// Initialize search domain (full-text search over posts, users, hashtags and interests)
+	searchRepo := search.NewPostgresSearchRepository(pg.Pool)
+	searchService := search.NewService(searchRepo, anonymityService)
+	searchHandler := search.NewHandler(searchService)
+
 	// Post dependencies
 	postRepo := post.NewPostgresPostRepository(pg.Pool)
-	postService := post.NewService(postRepo, authRepo)
+	feedScorer := post.NewWeightedScorer("default", post.DefaultWeights())
+	viewTracker := post.NewViewTracker(postRepo)
+	postService := post.NewService(postRepo, authRepo, hashtagRepo, interestRepo, mentionService, mediaService, pollService, linkPreviewService, moderationService, anonymityService, timelineService, viewTracker, feedScorer)
 	postHandler := post.NewHandler(postService)
 
+	// Initialize insights domain (author analytics from hourly metric rollups)
+	insightsRepo := insights.NewPostgresInsightsRepository(pg.Pool)
+	insightsService := insights.NewService(insightsRepo)
+	insightsHandler := insights.NewHandler(insightsService)
+
 	// Comment dependencies
 	commentRepo := comment.NewPostgresCommentRepository(pg.Pool)
-	commentService := comment.NewService(commentRepo, authRepo, postRepo)
+	commentService := comment.NewService(commentRepo, authRepo, postRepo, mentionService, moderationService, anonymityService)
 	commentHandler := comment.NewHandler(commentService)
 
+	// Initialize trash domain (restore deleted posts and comments within 30 days)
+	trashRepo := trash.NewPostgresTrashRepository(pg.Pool)
+	trashService := trash.NewService(trashRepo, timelineService)
+	trashHandler := trash.NewHandler(trashService)
+
 	// Share dependencies
 	shareRepo := share.NewPostgresShareRepository(pg.Pool)
-	shareService := share.NewService(shareRepo, authRepo, postRepo)
+	shareService := share.NewService(shareRepo, authRepo, postRepo, postService)
 	shareHandler := share.NewHandler(shareService)
 
+	// Bookmark dependencies
+	bookmarkRepo := bookmark.NewPostgresBookmarkRepository(pg.Pool)
+	bookmarkService := bookmark.NewService(bookmarkRepo, postRepo)
+	bookmarkHandler := bookmark.NewHandler(bookmarkService)
+
 	// Profile dependencies
 	profileRepo := profile.NewPostgresProfileRepository(pg.Pool)
-	profileService := profile.NewService(profileRepo, pg.Pool)
+	profileService := profile.NewService(profileRepo, moderationService, pg.Pool)
 	profileHandler := profile.NewHandler(profileService)
 
 	// Messaging dependencies
@@ -111,9 +219,28 @@ func setupRouter(pg *dbinfra.Postgres) *fiber.App {
 	msgRepo := messaging.NewPostgresMessageRepository(pg.Pool)
 	blockRepo := messaging.NewPostgresBlockRepository(pg.Pool)
 	privacyChecker := messaging.NewPrivacyChecker(authRepo, followRepo, blockRepo)
-	messagingService := messaging.NewService(convRepo, msgRepo, blockRepo, authRepo, privacyChecker)
+	messagingService := messaging.NewService(convRepo, msgRepo, blockRepo, authRepo, privacyChecker, linkPreviewService, moderationService, timelineService)
 	messagingHandler := messaging.NewHandler(messagingService)
 
+	// Initialize story domain (24-hour stories, replies are sent as direct messages)
+	storyRepo := story.NewPostgresStoryRepository(pg.Pool)
+	storyService := story.NewService(storyRepo, authRepo, mediaService, moderationService, messagingService)
+	storyHandler := story.NewHandler(storyService)
+
+	// Initialize counter domain (repairs drift in denormalized engagement counters)
+	counterRepo := counter.NewPostgresCounterRepository(pg.Pool)
+	counterService := counter.NewService(counterRepo)
+
+	// Background jobs
+	go scheduler.Every(ctx, "trending", 10*time.Minute, postService.RefreshTrending)
+	go scheduler.Every(ctx, "post-views", post.ViewFlushInterval, viewTracker.Flush)
+	go scheduler.Every(ctx, "scheduled-posts", post.ScheduledPublishInterval, postService.PublishScheduled)
+	go scheduler.Every(ctx, "link-previews", linkpreview.FetchInterval, linkPreviewService.FetchPending)
+	go scheduler.Every(ctx, "post-insights", insights.RollupInterval, insightsService.Rollup)
+	go scheduler.Every(ctx, "trash-purge", trash.PurgeInterval, trashService.Purge)
+	go scheduler.Every(ctx, "story-cleanup", story.CleanupInterval, storyService.Cleanup)
+	go scheduler.Every(ctx, "counter-reconcile", counter.ReconcileInterval, counterService.Reconcile)
+
 	// Register domain routes
 	// Register comment routes BEFORE post routes to avoid route conflicts
 	comment.RegisterRoutes(app, commentHandler)
@@ -122,10 +249,22 @@ func setupRouter(pg *dbinfra.Postgres) *fiber.App {
 	interest.RegisterRoutes(app, interestHandler)
 	onboarding.RegisterRoutes(app, onboardingHandler)
 	upload.RegisterRoutes(app)
+	media.RegisterRoutes(app, mediaHandler)
 	follow.RegisterRoutes(app, followHandler)
+	mute.RegisterRoutes(app, muteHandler)
 	post.RegisterRoutes(app, postHandler)
+	insights.RegisterRoutes(app, insightsHandler)
+	trash.RegisterRoutes(app, trashHandler)
+	bookmark.RegisterRoutes(app, bookmarkHandler)
+	hashtag.RegisterRoutes(app, hashtagHandler)
+	search.RegisterRoutes(app, searchHandler)
 	profile.RegisterRoutes(app, profileHandler)
 	messaging.RegisterRoutes(app, messagingHandler)
+	story.RegisterRoutes(app, storyHandler)
+	notification.RegisterRoutes(app, notificationHandler)
+	moderation.RegisterRoutes(app, moderationHandler)
+	report.RegisterRoutes(app, reportHandler, moderationService)
+	anonymity.RegisterRoutes(app, anonymityHandler, moderationService)
 
 	return app
 }
@@ -0,0 +1,68 @@
+package anonymity
+
+// UnmaskRequest is the request DTO for revealing the author of anonymous content
+type UnmaskRequest struct {
+	ContentType string `json:"content_type"` // post or comment
+	ContentID   string `json:"content_id"`
+	Reason      string `json:"reason"`            // Required, written to the audit log
+	Abusive     bool   `json:"abusive,omitempty"` // Record a strike against the author
+}
+
+// RevokeRequest is the request DTO for taking away a user's anonymous posting
+type RevokeRequest struct {
+	Reason string `json:"reason"`
+}
+
+// UserInfo identifies an unmasked author
+type UserInfo struct {
+	ID        string `json:"id"`
+	Username  string `json:"username"`
+	FirstName string `json:"first_name"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+// UnmaskResponse is the response for unmasking anonymous content
+type UnmaskResponse struct {
+	AuditID          string   `json:"audit_id"`
+	ContentType      string   `json:"content_type"`
+	ContentID        string   `json:"content_id"`
+	Author           UserInfo `json:"author"`
+	StrikeAdded      bool     `json:"strike_added"`      // This unmask recorded a strike
+	StrikeCount      int      `json:"strike_count"`      // Author's strikes in the last StrikeWindow, when a strike was added
+	AnonymousRevoked bool     `json:"anonymous_revoked"` // Anonymous posting is revoked for the author
+}
+
+// UnmaskEntryResponse is an audit log record
+type UnmaskEntryResponse struct {
+	ID          string  `json:"id"`
+	ModeratorID *string `json:"moderator_id"`
+	ContentType string  `json:"content_type"`
+	ContentID   string  `json:"content_id"`
+	UserID      *string `json:"user_id"`
+	Reason      string  `json:"reason"`
+	CreatedAt   string  `json:"created_at"`
+}
+
+// UnmaskLogResponse is a page of the unmask audit log
+type UnmaskLogResponse struct {
+	Entries    []*UnmaskEntryResponse `json:"entries"`
+	Pagination PaginationInfo         `json:"pagination"`
+}
+
+// StatusResponse tells a user whether they can post anonymously and how much of their
+// allowance is left
+type StatusResponse struct {
+	CanPostAnonymously bool    `json:"can_post_anonymously"`
+	RevokedAt          *string `json:"revoked_at,omitempty"`
+	RevokedReason      *string `json:"revoked_reason,omitempty"`
+	PostsRemaining     int     `json:"posts_remaining"`    // Anonymous posts left in the current window
+	CommentsRemaining  int     `json:"comments_remaining"` // Anonymous comments left in the current window
+}
+
+// PaginationInfo contains pagination metadata
+type PaginationInfo struct {
+	Page       int `json:"page"`
+	TotalPages int `json:"total_pages"`
+	TotalItems int `json:"total_items"`
+	Limit      int `json:"limit"`
+}
@@ -0,0 +1,162 @@
+package anonymity
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles HTTP requests for anonymous posting status and moderator unmasking
+type Handler struct {
+	service AnonymityService
+}
+
+// NewHandler creates a new anonymity handler
+func NewHandler(service AnonymityService) *Handler {
+	return &Handler{service: service}
+}
+
+// GetStatus handles GET /v1/users/me/anonymity
+func (h *Handler) GetStatus(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	response, err := h.service.GetStatus(c.Context(), currentUserID)
+	if err != nil {
+		if err == ErrUserNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get anonymity status",
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// Unmask handles POST /v1/anonymity/unmask
+func (h *Handler) Unmask(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	var req UnmaskRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	response, err := h.service.Unmask(c.Context(), currentUserID, &req)
+	if err != nil {
+		switch err {
+		case ErrInvalidContentType, ErrInvalidContentID, ErrReasonRequired:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case ErrContentNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to unmask author",
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// GetUnmaskLog handles GET /v1/anonymity/unmask-log?user_id=
+func (h *Handler) GetUnmaskLog(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	response, err := h.service.GetUnmaskLog(c.Context(), c.Query("user_id"), page, limit)
+	if err != nil {
+		if err == ErrUserNotFound {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid user ID",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get unmask log",
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// Revoke handles PUT /v1/anonymity/users/:userId/revocation
+func (h *Handler) Revoke(c *fiber.Ctx) error {
+	userID := c.Params("userId")
+	if _, err := uuid.Parse(userID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid user ID",
+		})
+	}
+
+	var req RevokeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	if err := h.service.Revoke(c.Context(), userID, &req); err != nil {
+		switch err {
+		case ErrReasonRequired:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case ErrUserNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to revoke anonymous posting",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "anonymous posting revoked",
+	})
+}
+
+// Restore handles DELETE /v1/anonymity/users/:userId/revocation
+func (h *Handler) Restore(c *fiber.Ctx) error {
+	userID := c.Params("userId")
+	if _, err := uuid.Parse(userID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid user ID",
+		})
+	}
+
+	if err := h.service.Restore(c.Context(), userID); err != nil {
+		if err == ErrUserNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to restore anonymous posting",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "anonymous posting restored",
+	})
+}
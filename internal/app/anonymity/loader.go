@@ -0,0 +1,17 @@
+package anonymity
+
+import (
+	"context"
+
+	"mockhu-app-backend/internal/pkg/dataloader"
+)
+
+// PseudonymLoader batches pseudonym lookups while a response is being built
+type PseudonymLoader = dataloader.Loader[ThreadKey, string]
+
+// NewPseudonymLoader creates a pseudonym loader backed by Pseudonyms. Create one per request.
+func NewPseudonymLoader(service AnonymityService) *PseudonymLoader {
+	return dataloader.New(func(ctx context.Context, keys []ThreadKey) (map[ThreadKey]string, error) {
+		return service.Pseudonyms(ctx, keys), nil
+	})
+}
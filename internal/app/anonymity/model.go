@@ -0,0 +1,67 @@
+package anonymity
+
+import "time"
+
+// Content that can be posted anonymously
+const (
+	ContentPost    = "post"
+	ContentComment = "comment"
+)
+
+// Strike sources
+const (
+	StrikeSourceReport = "report" // A moderator acted on a report about the content
+	StrikeSourceUnmask = "unmask" // A moderator unmasked the content and marked it abusive
+)
+
+// pseudonymAnimals are combined with "Anonymous" to name anonymous authors in a thread
+var pseudonymAnimals = []string{
+	"Owl", "Fox", "Otter", "Panda", "Tiger", "Koala", "Falcon", "Dolphin",
+	"Penguin", "Raccoon", "Hedgehog", "Badger", "Beaver", "Bison", "Camel", "Cheetah",
+	"Crane", "Deer", "Eagle", "Elephant", "Ferret", "Flamingo", "Gazelle", "Giraffe",
+	"Hawk", "Heron", "Ibex", "Jaguar", "Kangaroo", "Lemur", "Leopard", "Llama",
+	"Lynx", "Mongoose", "Moose", "Narwhal", "Ocelot", "Orca", "Parrot", "Pelican",
+	"Puffin", "Quokka", "Rabbit", "Seal", "Sparrow", "Squirrel", "Walrus", "Yak",
+}
+
+// ThreadKey identifies a user in a thread (a post and its comments)
+type ThreadKey struct {
+	PostID string
+	UserID string
+}
+
+// Identity is the pseudonym a user has in one thread (a post and its comments)
+type Identity struct {
+	PostID    string    `json:"post_id"`
+	UserID    string    `json:"user_id"`
+	Pseudonym string    `json:"pseudonym"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// UnmaskEntry is an audit log record of a moderator revealing an anonymous author
+type UnmaskEntry struct {
+	ID          string    `json:"id"`
+	ModeratorID *string   `json:"moderator_id"` // Nil once the moderator's account is deleted
+	ContentType string    `json:"content_type"`
+	ContentID   string    `json:"content_id"`
+	UserID      *string   `json:"user_id"` // The unmasked author
+	Reason      string    `json:"reason"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Strike records anonymous content a moderator found abusive
+type Strike struct {
+	ID          string    `json:"id"`
+	UserID      string    `json:"user_id"`
+	ContentType string    `json:"content_type"`
+	ContentID   string    `json:"content_id"`
+	ModeratorID string    `json:"moderator_id"`
+	Source      string    `json:"source"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Status is a user's anonymous posting standing
+type Status struct {
+	RevokedAt     *time.Time
+	RevokedReason *string
+}
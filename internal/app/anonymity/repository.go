@@ -0,0 +1,42 @@
+package anonymity
+
+import (
+	"context"
+	"time"
+)
+
+// AnonymityRepository defines the interface for anonymity data operations
+type AnonymityRepository interface {
+	// GetPseudonyms returns the pseudonyms users have in threads, leaving out users who have
+	// none yet
+	GetPseudonyms(ctx context.Context, keys []ThreadKey) (map[ThreadKey]string, error)
+
+	// AssignPseudonyms gives each user without a pseudonym in their thread a distinct
+	// candidate not yet taken there, in one statement. Users can be left without one if the
+	// candidates run out or a concurrent assignment takes theirs.
+	AssignPseudonyms(ctx context.Context, keys []ThreadKey, candidates []string) error
+
+	// CountRecent counts the user's anonymous published posts or comments created since a time
+	CountRecent(ctx context.Context, userID, contentType string, since time.Time) (int, error)
+
+	// GetStatus returns the user's anonymous posting standing, or nil if the user does not exist
+	GetStatus(ctx context.Context, userID string) (*Status, error)
+
+	// GetAuthor returns the author of an anonymous post or comment, or "" if the content does
+	// not exist or is not anonymous
+	GetAuthor(ctx context.Context, contentType, contentID string) (string, error)
+
+	// Unmask audit log
+	LogUnmask(ctx context.Context, entry *UnmaskEntry) error
+	GetUnmaskLog(ctx context.Context, userID string, limit, offset int) ([]*UnmaskEntry, error)
+
+	// AddStrike records a strike (once per piece of content) and revokes anonymous posting
+	// once the user has threshold strikes within window. Returns the user's strike count in
+	// the window and whether anonymous posting is revoked.
+	AddStrike(ctx context.Context, strike *Strike, window time.Duration, threshold int, reason string) (int, bool, error)
+
+	// Revoke and Restore change whether a user may post anonymously; they return false if
+	// the user does not exist. Strikes from before a restore are no longer counted.
+	Revoke(ctx context.Context, userID, reason string) (bool, error)
+	Restore(ctx context.Context, userID string) (bool, error)
+}
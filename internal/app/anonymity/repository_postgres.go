@@ -0,0 +1,299 @@
+package anonymity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresAnonymityRepository implements AnonymityRepository for PostgreSQL
+type PostgresAnonymityRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresAnonymityRepository creates a new PostgreSQL anonymity repository
+func NewPostgresAnonymityRepository(pool *pgxpool.Pool) *PostgresAnonymityRepository {
+	return &PostgresAnonymityRepository{pool: pool}
+}
+
+// GetPseudonyms returns the pseudonyms users have in threads, leaving out users who have
+// none yet
+func (r *PostgresAnonymityRepository) GetPseudonyms(ctx context.Context, keys []ThreadKey) (map[ThreadKey]string, error) {
+	pseudonyms := make(map[ThreadKey]string, len(keys))
+	if len(keys) == 0 {
+		return pseudonyms, nil
+	}
+
+	postIDs, userIDs := splitKeys(keys)
+
+	query := `
+		SELECT post_id, user_id, pseudonym
+		FROM anonymous_identities
+		WHERE (post_id, user_id) IN (SELECT * FROM unnest($1::uuid[], $2::uuid[]))
+	`
+
+	rows, err := r.pool.Query(ctx, query, postIDs, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key ThreadKey
+		var pseudonym string
+		if err := rows.Scan(&key.PostID, &key.UserID, &pseudonym); err != nil {
+			return nil, err
+		}
+		pseudonyms[key] = pseudonym
+	}
+
+	return pseudonyms, rows.Err()
+}
+
+// AssignPseudonyms gives each user without a pseudonym in their thread a distinct candidate
+// not yet taken there, in one statement. Users can be left without one if the candidates run
+// out or a concurrent assignment takes theirs.
+func (r *PostgresAnonymityRepository) AssignPseudonyms(ctx context.Context, keys []ThreadKey, candidates []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	postIDs, userIDs := splitKeys(keys)
+
+	// The nth new user in a thread (in random order) takes the nth free candidate there. A
+	// concurrent assignment can take the same candidate or give a user a pseudonym first;
+	// either way that row is skipped and the user is left for the caller to retry.
+	query := `
+		WITH wanted AS (
+			SELECT k.post_id, k.user_id, ROW_NUMBER() OVER (PARTITION BY k.post_id ORDER BY random()) AS n
+			FROM (SELECT DISTINCT * FROM unnest($1::uuid[], $2::uuid[])) AS k(post_id, user_id)
+			WHERE NOT EXISTS (
+				SELECT 1 FROM anonymous_identities ai WHERE ai.post_id = k.post_id AND ai.user_id = k.user_id
+			)
+		),
+		free AS (
+			SELECT t.post_id, c.pseudonym, ROW_NUMBER() OVER (PARTITION BY t.post_id ORDER BY c.position) AS n
+			FROM (SELECT DISTINCT post_id FROM wanted) t
+			CROSS JOIN unnest($3::text[]) WITH ORDINALITY AS c(pseudonym, position)
+			WHERE NOT EXISTS (
+				SELECT 1 FROM anonymous_identities ai WHERE ai.post_id = t.post_id AND ai.pseudonym = c.pseudonym
+			)
+		)
+		INSERT INTO anonymous_identities (post_id, user_id, pseudonym)
+		SELECT w.post_id, w.user_id, f.pseudonym
+		FROM wanted w
+		JOIN free f ON f.post_id = w.post_id AND f.n = w.n
+		ON CONFLICT DO NOTHING
+	`
+
+	_, err := r.pool.Exec(ctx, query, postIDs, userIDs, candidates)
+	return err
+}
+
+// CountRecent counts the user's anonymous published posts or comments created since a time
+func (r *PostgresAnonymityRepository) CountRecent(ctx context.Context, userID, contentType string, since time.Time) (int, error) {
+	var query string
+	switch contentType {
+	case ContentPost:
+		// Drafts don't count until published; publishing resets created_at
+		query = `SELECT COUNT(*) FROM posts WHERE user_id = $1 AND is_anonymous = true AND status = 'published' AND created_at > $2`
+	case ContentComment:
+		query = `SELECT COUNT(*) FROM post_comments WHERE user_id = $1 AND is_anonymous = true AND created_at > $2`
+	default:
+		return 0, fmt.Errorf("unknown content type %q", contentType)
+	}
+
+	var count int
+	err := r.pool.QueryRow(ctx, query, userID, since).Scan(&count)
+	return count, err
+}
+
+// GetStatus returns the user's anonymous posting standing, or nil if the user does not exist
+func (r *PostgresAnonymityRepository) GetStatus(ctx context.Context, userID string) (*Status, error) {
+	query := `SELECT anonymous_revoked_at, anonymous_revoked_reason FROM users WHERE id = $1`
+
+	status := &Status{}
+	err := r.pool.QueryRow(ctx, query, userID).Scan(&status.RevokedAt, &status.RevokedReason)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// GetAuthor returns the author of an anonymous post or comment, or "" if the content does
+// not exist or is not anonymous
+func (r *PostgresAnonymityRepository) GetAuthor(ctx context.Context, contentType, contentID string) (string, error) {
+	var query string
+	switch contentType {
+	case ContentPost:
+		query = `SELECT user_id FROM posts WHERE id = $1 AND is_anonymous = true`
+	case ContentComment:
+		query = `SELECT user_id FROM post_comments WHERE id = $1 AND is_anonymous = true`
+	default:
+		return "", fmt.Errorf("unknown content type %q", contentType)
+	}
+
+	var userID string
+	err := r.pool.QueryRow(ctx, query, contentID).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return userID, nil
+}
+
+// LogUnmask writes an unmask audit log record
+func (r *PostgresAnonymityRepository) LogUnmask(ctx context.Context, entry *UnmaskEntry) error {
+	query := `
+		INSERT INTO anonymity_unmask_log (moderator_id, content_type, content_id, user_id, reason)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+
+	return r.pool.QueryRow(ctx, query,
+		entry.ModeratorID,
+		entry.ContentType,
+		entry.ContentID,
+		entry.UserID,
+		entry.Reason,
+	).Scan(&entry.ID, &entry.CreatedAt)
+}
+
+// GetUnmaskLog retrieves unmask audit log records, newest first. A non-empty userID only
+// returns records about that author.
+func (r *PostgresAnonymityRepository) GetUnmaskLog(ctx context.Context, userID string, limit, offset int) ([]*UnmaskEntry, error) {
+	query := `
+		SELECT id, moderator_id, content_type, content_id, user_id, reason, created_at
+		FROM anonymity_unmask_log
+		WHERE $1 = '' OR user_id::text = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*UnmaskEntry
+
+	for rows.Next() {
+		entry := &UnmaskEntry{}
+		err := rows.Scan(
+			&entry.ID,
+			&entry.ModeratorID,
+			&entry.ContentType,
+			&entry.ContentID,
+			&entry.UserID,
+			&entry.Reason,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// AddStrike records a strike (once per piece of content) and revokes anonymous posting
+// once the user has threshold strikes within window. Returns the user's strike count in
+// the window and whether anonymous posting is revoked.
+func (r *PostgresAnonymityRepository) AddStrike(ctx context.Context, strike *Strike, window time.Duration, threshold int, reason string) (int, bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO anonymity_strikes (user_id, content_type, content_id, moderator_id, source)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, content_type, content_id) DO NOTHING
+	`, strike.UserID, strike.ContentType, strike.ContentID, strike.ModeratorID, strike.Source)
+	if err != nil {
+		return 0, false, err
+	}
+
+	var count int
+	err = tx.QueryRow(ctx, `
+		SELECT COUNT(*)
+		FROM anonymity_strikes s
+		JOIN users u ON u.id = s.user_id
+		WHERE s.user_id = $1 AND s.created_at > $2
+		  AND s.created_at > COALESCE(u.anonymous_restored_at, '-infinity')
+	`, strike.UserID, time.Now().Add(-window)).Scan(&count)
+	if err != nil {
+		return 0, false, err
+	}
+
+	var revoked bool
+	err = tx.QueryRow(ctx, `
+		UPDATE users
+		SET anonymous_revoked_at = COALESCE(anonymous_revoked_at, CASE WHEN $2 THEN NOW() END),
+		    anonymous_revoked_reason = CASE WHEN anonymous_revoked_at IS NULL AND $2 THEN $3 ELSE anonymous_revoked_reason END
+		WHERE id = $1
+		RETURNING anonymous_revoked_at IS NOT NULL
+	`, strike.UserID, count >= threshold, reason).Scan(&revoked)
+	if err != nil {
+		return 0, false, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, false, err
+	}
+
+	return count, revoked, nil
+}
+
+// Revoke takes away a user's anonymous posting
+func (r *PostgresAnonymityRepository) Revoke(ctx context.Context, userID, reason string) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE users
+		SET anonymous_revoked_at = NOW(), anonymous_revoked_reason = $2
+		WHERE id = $1
+	`, userID, reason)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// Restore gives a user back their anonymous posting; strikes from before are no longer counted
+func (r *PostgresAnonymityRepository) Restore(ctx context.Context, userID string) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE users
+		SET anonymous_revoked_at = NULL, anonymous_revoked_reason = NULL, anonymous_restored_at = NOW()
+		WHERE id = $1
+	`, userID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// Helper functions
+
+// splitKeys splits thread keys into post and user ID arrays, for unnest
+func splitKeys(keys []ThreadKey) ([]string, []string) {
+	postIDs := make([]string, len(keys))
+	userIDs := make([]string, len(keys))
+	for i, key := range keys {
+		postIDs[i] = key.PostID
+		userIDs[i] = key.UserID
+	}
+	return postIDs, userIDs
+}
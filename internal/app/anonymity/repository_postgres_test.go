@@ -0,0 +1,70 @@
+package anonymity
+
+import (
+	"context"
+	"testing"
+
+	"mockhu-app-backend/internal/pkg/testdb"
+)
+
+func TestAssignPseudonyms(t *testing.T) {
+	pool := testdb.Pool(t)
+	repo := NewPostgresAnonymityRepository(pool)
+	ctx := context.Background()
+
+	authorID := testdb.CreateUser(t, pool)
+	userIDs := []string{authorID, testdb.CreateUser(t, pool), testdb.CreateUser(t, pool)}
+
+	threads := make([]string, 2)
+	for i := range threads {
+		err := pool.QueryRow(ctx, `INSERT INTO posts (user_id, content, is_anonymous) VALUES ($1, 'thread', true) RETURNING id`, authorID).
+			Scan(&threads[i])
+		if err != nil {
+			t.Fatalf("insert post: %v", err)
+		}
+	}
+
+	// The author already has a name in the first thread, which new users can't take
+	existing := ThreadKey{PostID: threads[0], UserID: authorID}
+	if err := repo.AssignPseudonyms(ctx, []ThreadKey{existing}, []string{"Anonymous Owl"}); err != nil {
+		t.Fatalf("AssignPseudonyms: %v", err)
+	}
+
+	var keys []ThreadKey
+	for _, thread := range threads {
+		for _, userID := range userIDs {
+			keys = append(keys, ThreadKey{PostID: thread, UserID: userID})
+		}
+	}
+	candidates := []string{"Anonymous Owl", "Anonymous Fox", "Anonymous Otter"}
+	if err := repo.AssignPseudonyms(ctx, keys, candidates); err != nil {
+		t.Fatalf("AssignPseudonyms: %v", err)
+	}
+
+	pseudonyms, err := repo.GetPseudonyms(ctx, keys)
+	if err != nil {
+		t.Fatalf("GetPseudonyms: %v", err)
+	}
+	if pseudonyms[existing] != "Anonymous Owl" {
+		t.Errorf("existing pseudonym = %q, want it kept", pseudonyms[existing])
+	}
+	for _, thread := range threads {
+		seen := make(map[string]bool)
+		for _, userID := range userIDs {
+			name := pseudonyms[ThreadKey{PostID: thread, UserID: userID}]
+			if name == "" || seen[name] {
+				t.Errorf("pseudonym %q in thread %s is missing or given twice", name, thread)
+			}
+			seen[name] = true
+		}
+	}
+
+	// With the candidates used up, a new user is left without a name
+	latecomer := ThreadKey{PostID: threads[0], UserID: testdb.CreateUser(t, pool)}
+	if err := repo.AssignPseudonyms(ctx, []ThreadKey{latecomer}, candidates); err != nil {
+		t.Fatalf("AssignPseudonyms: %v", err)
+	}
+	if pseudonyms, err := repo.GetPseudonyms(ctx, []ThreadKey{latecomer}); err != nil || len(pseudonyms) != 0 {
+		t.Errorf("GetPseudonyms(latecomer) = %v, %v; want none", pseudonyms, err)
+	}
+}
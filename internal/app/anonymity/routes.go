@@ -0,0 +1,24 @@
+package anonymity
+
+import (
+	"mockhu-app-backend/internal/app/moderation"
+	"mockhu-app-backend/internal/pkg/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterRoutes registers the anonymous posting status route and the moderator routes
+func RegisterRoutes(app *fiber.App, handler *Handler, moderationService moderation.ModerationService) {
+	v1 := app.Group("/v1")
+
+	auth := middleware.AuthMiddleware()
+	moderatorOnly := moderation.RequireModerator(moderationService)
+
+	v1.Get("/users/me/anonymity", auth, handler.GetStatus)
+
+	// Unmasking is audited; every request needs a reason (moderators only)
+	v1.Post("/anonymity/unmask", auth, moderatorOnly, handler.Unmask)
+	v1.Get("/anonymity/unmask-log", auth, moderatorOnly, handler.GetUnmaskLog)
+	v1.Put("/anonymity/users/:userId/revocation", auth, moderatorOnly, handler.Revoke)
+	v1.Delete("/anonymity/users/:userId/revocation", auth, moderatorOnly, handler.Restore)
+}
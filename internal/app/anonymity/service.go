@@ -0,0 +1,404 @@
+package anonymity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"mockhu-app-backend/internal/app/auth"
+
+	"github.com/google/uuid"
+)
+
+// Anonymous posting limits. A user may create AnonymousPostLimit anonymous posts per
+// AnonymousPostWindow and AnonymousCommentLimit anonymous comments per AnonymousCommentWindow;
+// StrikeThreshold strikes within StrikeWindow revoke anonymous posting.
+const (
+	AnonymousPostLimit     = 5
+	AnonymousPostWindow    = 24 * time.Hour
+	AnonymousCommentLimit  = 20
+	AnonymousCommentWindow = time.Hour
+	StrikeThreshold        = 3
+	StrikeWindow           = 90 * 24 * time.Hour
+	minReasonLength        = 10
+	maxReasonLength        = 500
+	maxPseudonymRounds     = 10 // Numbered rounds of animal names tried before FallbackPseudonym
+	maxPseudonymAttempts   = 3  // Assignments tried before FallbackPseudonym when others take the names first
+)
+
+// FallbackPseudonym is shown when a pseudonym cannot be looked up; the real author is never shown instead
+const FallbackPseudonym = "Anonymous"
+
+// Errors
+var (
+	ErrAnonymousRevoked     = errors.New("anonymous posting has been revoked for your account")
+	ErrAnonymousRateLimited = errors.New("too many anonymous posts, try again later")
+	ErrInvalidContentType   = errors.New("content_type must be post or comment")
+	ErrInvalidContentID     = errors.New("invalid content ID")
+	ErrContentNotFound      = errors.New("anonymous content not found")
+	ErrReasonRequired       = errors.New("reason must be between 10 and 500 characters")
+	ErrUserNotFound         = errors.New("user not found")
+)
+
+// AnonymityService defines the business logic for accountable anonymity: pseudonyms,
+// posting limits, moderator unmasking and revoking anonymous posting
+type AnonymityService interface {
+	// CheckCanPost returns ErrAnonymousRevoked or ErrAnonymousRateLimited if the user may not
+	// post anonymous content of the type right now
+	CheckCanPost(ctx context.Context, userID, contentType string) error
+
+	// Pseudonyms returns the names of users in threads (a post and its comments), assigning
+	// them on first use, with a fixed number of queries however many there are. It never
+	// fails; FallbackPseudonym is given on errors.
+	Pseudonyms(ctx context.Context, keys []ThreadKey) map[ThreadKey]string
+
+	// GetStatus tells a user whether they can post anonymously and what allowance is left
+	GetStatus(ctx context.Context, userID string) (*StatusResponse, error)
+
+	// Moderation
+	Unmask(ctx context.Context, moderatorID string, req *UnmaskRequest) (*UnmaskResponse, error)
+	GetUnmaskLog(ctx context.Context, userID string, page, limit int) (*UnmaskLogResponse, error)
+	RecordStrike(ctx context.Context, userID, contentType, contentID, moderatorID, source string) error
+	Revoke(ctx context.Context, userID string, req *RevokeRequest) error
+	Restore(ctx context.Context, userID string) error
+}
+
+// anonymityService implements AnonymityService
+type anonymityService struct {
+	anonymityRepo AnonymityRepository
+	userRepo      auth.UserRepository
+}
+
+// NewService creates a new anonymity service
+func NewService(anonymityRepo AnonymityRepository, userRepo auth.UserRepository) AnonymityService {
+	return &anonymityService{
+		anonymityRepo: anonymityRepo,
+		userRepo:      userRepo,
+	}
+}
+
+// CheckCanPost returns ErrAnonymousRevoked or ErrAnonymousRateLimited if the user may not
+// post anonymous content of the type right now
+func (s *anonymityService) CheckCanPost(ctx context.Context, userID, contentType string) error {
+	status, err := s.anonymityRepo.GetStatus(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get anonymity status: %w", err)
+	}
+	if status == nil {
+		return ErrUserNotFound
+	}
+	if status.RevokedAt != nil {
+		return ErrAnonymousRevoked
+	}
+
+	remaining, err := s.remaining(ctx, userID, contentType)
+	if err != nil {
+		return err
+	}
+	if remaining <= 0 {
+		return ErrAnonymousRateLimited
+	}
+
+	return nil
+}
+
+// Pseudonyms returns the names of users in threads (a post and its comments), assigning
+// them on first use, with a fixed number of queries however many there are. It never
+// fails; FallbackPseudonym is given on errors.
+func (s *anonymityService) Pseudonyms(ctx context.Context, keys []ThreadKey) map[ThreadKey]string {
+	pseudonyms, err := s.anonymityRepo.GetPseudonyms(ctx, keys)
+	if err != nil {
+		log.Printf("⚠️ Failed to get pseudonyms: %v", err)
+		pseudonyms = make(map[ThreadKey]string, len(keys))
+	}
+
+	// Start at a random animal so the name says nothing about who is behind it
+	start := rand.Intn(len(pseudonymAnimals))
+	candidates := make([]string, 0, maxPseudonymRounds*len(pseudonymAnimals))
+	for round := 1; round <= maxPseudonymRounds; round++ {
+		for i := range pseudonymAnimals {
+			name := "Anonymous " + pseudonymAnimals[(start+i)%len(pseudonymAnimals)]
+			if round > 1 {
+				name = fmt.Sprintf("%s %d", name, round)
+			}
+			candidates = append(candidates, name)
+		}
+	}
+
+	// Users whose candidate a concurrent assignment took are retried
+	for attempt := 0; attempt < maxPseudonymAttempts && err == nil; attempt++ {
+		missing := make([]ThreadKey, 0)
+		for _, key := range keys {
+			if _, ok := pseudonyms[key]; !ok {
+				missing = append(missing, key)
+			}
+		}
+		if len(missing) == 0 {
+			break
+		}
+
+		if err := s.anonymityRepo.AssignPseudonyms(ctx, missing, candidates); err != nil {
+			log.Printf("⚠️ Failed to assign pseudonyms: %v", err)
+			break
+		}
+		assigned, err := s.anonymityRepo.GetPseudonyms(ctx, missing)
+		if err != nil {
+			log.Printf("⚠️ Failed to get assigned pseudonyms: %v", err)
+			break
+		}
+		for key, pseudonym := range assigned {
+			pseudonyms[key] = pseudonym
+		}
+	}
+
+	for _, key := range keys {
+		if _, ok := pseudonyms[key]; !ok {
+			pseudonyms[key] = FallbackPseudonym
+		}
+	}
+	return pseudonyms
+}
+
+// GetStatus tells a user whether they can post anonymously and what allowance is left
+func (s *anonymityService) GetStatus(ctx context.Context, userID string) (*StatusResponse, error) {
+	status, err := s.anonymityRepo.GetStatus(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get anonymity status: %w", err)
+	}
+	if status == nil {
+		return nil, ErrUserNotFound
+	}
+
+	response := &StatusResponse{
+		CanPostAnonymously: status.RevokedAt == nil,
+		RevokedReason:      status.RevokedReason,
+	}
+	if status.RevokedAt != nil {
+		revokedAt := status.RevokedAt.Format(time.RFC3339)
+		response.RevokedAt = &revokedAt
+		return response, nil
+	}
+
+	if response.PostsRemaining, err = s.remaining(ctx, userID, ContentPost); err != nil {
+		return nil, err
+	}
+	if response.CommentsRemaining, err = s.remaining(ctx, userID, ContentComment); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Unmask reveals the author of anonymous content to a moderator. The reason is written to
+// the audit log before the author is returned.
+func (s *anonymityService) Unmask(ctx context.Context, moderatorID string, req *UnmaskRequest) (*UnmaskResponse, error) {
+	if req.ContentType != ContentPost && req.ContentType != ContentComment {
+		return nil, ErrInvalidContentType
+	}
+	if _, err := uuid.Parse(req.ContentID); err != nil {
+		return nil, ErrInvalidContentID
+	}
+	reason, err := validReason(req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	authorID, err := s.anonymityRepo.GetAuthor(ctx, req.ContentType, req.ContentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get author: %w", err)
+	}
+	if authorID == "" {
+		return nil, ErrContentNotFound
+	}
+
+	author, err := s.userRepo.FindByID(ctx, authorID)
+	if err != nil || author == nil {
+		return nil, ErrContentNotFound
+	}
+
+	entry := &UnmaskEntry{
+		ModeratorID: &moderatorID,
+		ContentType: req.ContentType,
+		ContentID:   req.ContentID,
+		UserID:      &authorID,
+		Reason:      reason,
+	}
+	if err := s.anonymityRepo.LogUnmask(ctx, entry); err != nil {
+		return nil, fmt.Errorf("failed to write unmask audit log: %w", err)
+	}
+
+	response := &UnmaskResponse{
+		AuditID:     entry.ID,
+		ContentType: req.ContentType,
+		ContentID:   req.ContentID,
+		Author: UserInfo{
+			ID:        author.ID,
+			Username:  author.Username,
+			FirstName: author.FirstName,
+			AvatarURL: author.AvatarURL,
+		},
+	}
+
+	if req.Abusive {
+		count, revoked, err := s.addStrike(ctx, authorID, req.ContentType, req.ContentID, moderatorID, StrikeSourceUnmask)
+		if err != nil {
+			return nil, err
+		}
+		response.StrikeAdded = true
+		response.StrikeCount = count
+		response.AnonymousRevoked = revoked
+	} else {
+		status, err := s.anonymityRepo.GetStatus(ctx, authorID)
+		if err == nil && status != nil {
+			response.AnonymousRevoked = status.RevokedAt != nil
+		}
+	}
+
+	return response, nil
+}
+
+// GetUnmaskLog retrieves the unmask audit log, newest first, optionally for one author
+func (s *anonymityService) GetUnmaskLog(ctx context.Context, userID string, page, limit int) (*UnmaskLogResponse, error) {
+	if userID != "" {
+		if _, err := uuid.Parse(userID); err != nil {
+			return nil, ErrUserNotFound
+		}
+	}
+
+	// Validate pagination
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 20
+	}
+
+	offset := (page - 1) * limit
+
+	entries, err := s.anonymityRepo.GetUnmaskLog(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unmask log: %w", err)
+	}
+
+	responses := make([]*UnmaskEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		responses = append(responses, &UnmaskEntryResponse{
+			ID:          entry.ID,
+			ModeratorID: entry.ModeratorID,
+			ContentType: entry.ContentType,
+			ContentID:   entry.ContentID,
+			UserID:      entry.UserID,
+			Reason:      entry.Reason,
+			CreatedAt:   entry.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	// Calculate total pages (simplified)
+	totalPages := 1
+	if len(responses) == limit {
+		totalPages = page + 1
+	}
+
+	return &UnmaskLogResponse{
+		Entries: responses,
+		Pagination: PaginationInfo{
+			Page:       page,
+			TotalPages: totalPages,
+			TotalItems: len(responses),
+			Limit:      limit,
+		},
+	}, nil
+}
+
+// RecordStrike records that a moderator found a user's anonymous content abusive. Reaching
+// StrikeThreshold strikes within StrikeWindow revokes anonymous posting.
+func (s *anonymityService) RecordStrike(ctx context.Context, userID, contentType, contentID, moderatorID, source string) error {
+	_, _, err := s.addStrike(ctx, userID, contentType, contentID, moderatorID, source)
+	return err
+}
+
+// Revoke takes away a user's anonymous posting until a moderator restores it
+func (s *anonymityService) Revoke(ctx context.Context, userID string, req *RevokeRequest) error {
+	reason, err := validReason(req.Reason)
+	if err != nil {
+		return err
+	}
+
+	found, err := s.anonymityRepo.Revoke(ctx, userID, reason)
+	if err != nil {
+		return fmt.Errorf("failed to revoke anonymous posting: %w", err)
+	}
+	if !found {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+// Restore gives a user back their anonymous posting
+func (s *anonymityService) Restore(ctx context.Context, userID string) error {
+	found, err := s.anonymityRepo.Restore(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to restore anonymous posting: %w", err)
+	}
+	if !found {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+// Helper methods
+
+// remaining returns how many more anonymous posts or comments the user may create now
+func (s *anonymityService) remaining(ctx context.Context, userID, contentType string) (int, error) {
+	limit, window := AnonymousPostLimit, AnonymousPostWindow
+	if contentType == ContentComment {
+		limit, window = AnonymousCommentLimit, AnonymousCommentWindow
+	}
+
+	count, err := s.anonymityRepo.CountRecent(ctx, userID, contentType, time.Now().Add(-window))
+	if err != nil {
+		return 0, fmt.Errorf("failed to count anonymous %ss: %w", contentType, err)
+	}
+
+	if count >= limit {
+		return 0, nil
+	}
+	return limit - count, nil
+}
+
+// addStrike records a strike and returns the user's strike count and whether anonymous
+// posting is now revoked
+func (s *anonymityService) addStrike(ctx context.Context, userID, contentType, contentID, moderatorID, source string) (int, bool, error) {
+	strike := &Strike{
+		UserID:      userID,
+		ContentType: contentType,
+		ContentID:   contentID,
+		ModeratorID: moderatorID,
+		Source:      source,
+	}
+
+	reason := fmt.Sprintf("%d moderator strikes for abusive anonymous content", StrikeThreshold)
+	count, revoked, err := s.anonymityRepo.AddStrike(ctx, strike, StrikeWindow, StrikeThreshold, reason)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to record strike: %w", err)
+	}
+
+	return count, revoked, nil
+}
+
+// validReason trims a moderator's reason and checks its length
+func validReason(reason string) (string, error) {
+	reason = strings.TrimSpace(reason)
+	length := utf8.RuneCountInString(reason)
+	if length < minReasonLength || length > maxReasonLength {
+		return "", ErrReasonRequired
+	}
+	return reason, nil
+}
@@ -0,0 +1,258 @@
+package anonymity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeAnonymityRepo keeps standings, anonymous content timestamps and pseudonyms in memory
+type fakeAnonymityRepo struct {
+	AnonymityRepository
+
+	statuses   map[string]*Status
+	created    map[string][]time.Time       // "userID/contentType" -> creation times
+	pseudonyms map[string]map[string]string // Post ID -> user ID -> pseudonym
+	queries    int                          // Pseudonym reads and assignments
+}
+
+func newFakeAnonymityRepo(userIDs ...string) *fakeAnonymityRepo {
+	r := &fakeAnonymityRepo{
+		statuses:   make(map[string]*Status),
+		created:    make(map[string][]time.Time),
+		pseudonyms: make(map[string]map[string]string),
+	}
+	for _, id := range userIDs {
+		r.statuses[id] = &Status{}
+	}
+	return r
+}
+
+// post records anonymous content created by a user at a time
+func (r *fakeAnonymityRepo) post(userID, contentType string, at time.Time) {
+	key := userID + "/" + contentType
+	r.created[key] = append(r.created[key], at)
+}
+
+func (r *fakeAnonymityRepo) GetStatus(ctx context.Context, userID string) (*Status, error) {
+	return r.statuses[userID], nil
+}
+
+func (r *fakeAnonymityRepo) CountRecent(ctx context.Context, userID, contentType string, since time.Time) (int, error) {
+	count := 0
+	for _, at := range r.created[userID+"/"+contentType] {
+		if !at.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *fakeAnonymityRepo) GetPseudonyms(ctx context.Context, keys []ThreadKey) (map[ThreadKey]string, error) {
+	r.queries++
+	pseudonyms := make(map[ThreadKey]string)
+	for _, key := range keys {
+		if name, ok := r.pseudonyms[key.PostID][key.UserID]; ok {
+			pseudonyms[key] = name
+		}
+	}
+	return pseudonyms, nil
+}
+
+func (r *fakeAnonymityRepo) AssignPseudonyms(ctx context.Context, keys []ThreadKey, candidates []string) error {
+	r.queries++
+	for _, key := range keys {
+		if r.pseudonyms[key.PostID] == nil {
+			r.pseudonyms[key.PostID] = make(map[string]string)
+		}
+		if _, ok := r.pseudonyms[key.PostID][key.UserID]; ok {
+			continue
+		}
+		taken := make(map[string]bool)
+		for _, name := range r.pseudonyms[key.PostID] {
+			taken[name] = true
+		}
+		for _, name := range candidates {
+			if !taken[name] {
+				r.pseudonyms[key.PostID][key.UserID] = name
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func TestCheckCanPost(t *testing.T) {
+	now := time.Now()
+	revokedAt := now.Add(-time.Hour)
+
+	tests := []struct {
+		name        string
+		userID      string
+		contentType string
+		setup       func(r *fakeAnonymityRepo)
+		wantErr     error
+	}{
+		{
+			name:        "first post",
+			userID:      "user",
+			contentType: ContentPost,
+			wantErr:     nil,
+		},
+		{
+			name:        "one post left",
+			userID:      "user",
+			contentType: ContentPost,
+			setup: func(r *fakeAnonymityRepo) {
+				for i := 0; i < AnonymousPostLimit-1; i++ {
+					r.post("user", ContentPost, now.Add(-time.Hour))
+				}
+			},
+			wantErr: nil,
+		},
+		{
+			name:        "post limit reached",
+			userID:      "user",
+			contentType: ContentPost,
+			setup: func(r *fakeAnonymityRepo) {
+				for i := 0; i < AnonymousPostLimit; i++ {
+					r.post("user", ContentPost, now.Add(-time.Hour))
+				}
+			},
+			wantErr: ErrAnonymousRateLimited,
+		},
+		{
+			name:        "posts outside the window",
+			userID:      "user",
+			contentType: ContentPost,
+			setup: func(r *fakeAnonymityRepo) {
+				for i := 0; i < AnonymousPostLimit; i++ {
+					r.post("user", ContentPost, now.Add(-AnonymousPostWindow-time.Minute))
+				}
+			},
+			wantErr: nil,
+		},
+		{
+			name:        "comments have their own limit",
+			userID:      "user",
+			contentType: ContentComment,
+			setup: func(r *fakeAnonymityRepo) {
+				for i := 0; i < AnonymousPostLimit; i++ {
+					r.post("user", ContentPost, now.Add(-time.Minute))
+				}
+			},
+			wantErr: nil,
+		},
+		{
+			name:        "comment limit reached",
+			userID:      "user",
+			contentType: ContentComment,
+			setup: func(r *fakeAnonymityRepo) {
+				for i := 0; i < AnonymousCommentLimit; i++ {
+					r.post("user", ContentComment, now.Add(-time.Minute))
+				}
+			},
+			wantErr: ErrAnonymousRateLimited,
+		},
+		{
+			name:        "revoked",
+			userID:      "user",
+			contentType: ContentComment,
+			setup: func(r *fakeAnonymityRepo) {
+				r.statuses["user"].RevokedAt = &revokedAt
+			},
+			wantErr: ErrAnonymousRevoked,
+		},
+		{
+			name:        "unknown user",
+			userID:      "missing",
+			contentType: ContentPost,
+			wantErr:     ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeAnonymityRepo("user")
+			if tt.setup != nil {
+				tt.setup(repo)
+			}
+			s := NewService(repo, nil)
+
+			if err := s.CheckCanPost(context.Background(), tt.userID, tt.contentType); !errors.Is(err, tt.wantErr) {
+				t.Errorf("CheckCanPost: err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetStatusRemaining(t *testing.T) {
+	repo := newFakeAnonymityRepo("user")
+	repo.post("user", ContentPost, time.Now().Add(-time.Hour))
+	repo.post("user", ContentPost, time.Now().Add(-time.Hour))
+	repo.post("user", ContentComment, time.Now().Add(-2*AnonymousCommentWindow))
+	s := NewService(repo, nil)
+
+	status, err := s.GetStatus(context.Background(), "user")
+	if err != nil {
+		t.Fatalf("GetStatus: %v", err)
+	}
+	if !status.CanPostAnonymously || status.PostsRemaining != AnonymousPostLimit-2 || status.CommentsRemaining != AnonymousCommentLimit {
+		t.Errorf("status = %+v, want %d posts and %d comments left", status, AnonymousPostLimit-2, AnonymousCommentLimit)
+	}
+}
+
+func TestPseudonyms(t *testing.T) {
+	repo := newFakeAnonymityRepo()
+	s := NewService(repo, nil)
+	ctx := context.Background()
+
+	alice := ThreadKey{PostID: "thread", UserID: "alice"}
+	first := s.Pseudonyms(ctx, []ThreadKey{alice})[alice]
+	if !strings.HasPrefix(first, "Anonymous ") || first == FallbackPseudonym {
+		t.Fatalf("pseudonym = %q, want an animal name", first)
+	}
+	if again := s.Pseudonyms(ctx, []ThreadKey{alice})[alice]; again != first {
+		t.Errorf("pseudonym changed from %q to %q within a thread", first, again)
+	}
+
+	// Everyone in a thread gets a distinct name, numbered once the animals run out, and a
+	// whole page of them takes the same few queries as one
+	keys := []ThreadKey{alice, {PostID: "other", UserID: "alice"}}
+	for i := 0; i < len(pseudonymAnimals)+5; i++ {
+		keys = append(keys, ThreadKey{PostID: "thread", UserID: fmt.Sprintf("user-%d", i)})
+	}
+	repo.queries = 0
+	pseudonyms := s.Pseudonyms(ctx, keys)
+
+	if repo.queries != 3 {
+		t.Errorf("made %d queries, want 3", repo.queries)
+	}
+	if pseudonyms[alice] != first {
+		t.Errorf("alice's pseudonym changed from %q to %q", first, pseudonyms[alice])
+	}
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		name := pseudonyms[key]
+		if key.PostID != "thread" {
+			continue
+		}
+		if name == "" || name == FallbackPseudonym || seen[name] {
+			t.Fatalf("pseudonym %q of %s is missing or given twice in one thread", name, key.UserID)
+		}
+		seen[name] = true
+	}
+}
+
+func TestRevokeRequiresReason(t *testing.T) {
+	s := NewService(newFakeAnonymityRepo("user"), nil)
+
+	for _, reason := range []string{"", "   too short   ", strings.Repeat("x", maxReasonLength+1)} {
+		if err := s.Revoke(context.Background(), "user", &RevokeRequest{Reason: reason}); !errors.Is(err, ErrReasonRequired) {
+			t.Errorf("Revoke(%q): err = %v, want ErrReasonRequired", reason, err)
+		}
+	}
+}
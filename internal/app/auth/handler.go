@@ -152,6 +152,13 @@ func (h *Handler) Refresh(c *fiber.Ctx) error {
 		})
 	}
 
+	// Suspended accounts cannot keep their session alive
+	if user.IsSuspended() {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "account is suspended",
+		})
+	}
+
 	// Generate new tokens
 	accessToken, err := jwt.GenerateAccessToken(user.ID, user.Email, user.Username)
 	if err != nil {
@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"mockhu-app-backend/internal/pkg/jwt"
+
+	"github.com/gofiber/fiber/v2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// fakeUserRepo holds a single user
+type fakeUserRepo struct {
+	UserRepository
+
+	user *User
+}
+
+func (r *fakeUserRepo) FindByID(ctx context.Context, id string) (*User, error) {
+	if id != r.user.ID {
+		return nil, errors.New("user not found")
+	}
+	return r.user, nil
+}
+
+func (r *fakeUserRepo) FindByEmail(ctx context.Context, email string) (*User, error) {
+	if email != r.user.Email {
+		return nil, errors.New("user not found")
+	}
+	return r.user, nil
+}
+
+func (r *fakeUserRepo) FindByPhone(ctx context.Context, phone string) (*User, error) {
+	return nil, errors.New("user not found")
+}
+
+func (r *fakeUserRepo) UpdateLastLogin(ctx context.Context, userID string) error {
+	return nil
+}
+
+// post sends a JSON body to an auth route and returns the status code
+func post(t *testing.T, app *fiber.App, path, body string) int {
+	t.Helper()
+
+	req := httptest.NewRequest("POST", path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestSuspendedUsersCannotSignIn(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret123"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("hash password: %v", err)
+	}
+
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(24 * time.Hour)
+
+	tests := []struct {
+		name          string
+		until         *time.Time
+		wantLogin     int
+		wantRefreshed int
+	}{
+		{"never suspended", nil, fiber.StatusOK, fiber.StatusOK},
+		{"suspension over", &past, fiber.StatusOK, fiber.StatusOK},
+		{"suspended", &future, fiber.StatusUnauthorized, fiber.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{
+				ID:             "user-1",
+				Email:          "user@example.com",
+				Username:       "user",
+				PasswordHash:   string(hash),
+				IsActive:       true,
+				SuspendedUntil: tt.until,
+			}
+			app := fiber.New()
+			RegisterRoutes(app, NewHandler(NewService(&fakeUserRepo{user: user}, nil)))
+
+			if got := post(t, app, "/v1/auth/login", `{"identifier": "user@example.com", "password": "secret123"}`); got != tt.wantLogin {
+				t.Errorf("login status = %d, want %d", got, tt.wantLogin)
+			}
+
+			// A refresh token issued before the suspension stops working
+			token, err := jwt.GenerateRefreshToken(user.ID)
+			if err != nil {
+				t.Fatalf("GenerateRefreshToken: %v", err)
+			}
+			if got := post(t, app, "/v1/auth/refresh", `{"refresh_token": "`+token+`"}`); got != tt.wantRefreshed {
+				t.Errorf("refresh status = %d, want %d", got, tt.wantRefreshed)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"context"
+
+	"mockhu-app-backend/internal/pkg/dataloader"
+)
+
+// UserLoader batches user lookups by ID while a response is being built
+type UserLoader = dataloader.Loader[string, *User]
+
+// NewUserLoader creates a user loader backed by FindByIDs. Create one per request.
+func NewUserLoader(repo UserRepository) *UserLoader {
+	return dataloader.New(func(ctx context.Context, ids []string) (map[string]*User, error) {
+		users, err := repo.FindByIDs(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		byID := make(map[string]*User, len(users))
+		for _, user := range users {
+			byID[user.ID] = user
+		}
+		return byID, nil
+	})
+}
@@ -30,6 +30,12 @@ type User struct {
 	OnboardingCompleted bool       `json:"onboarding_completed"`
 	OnboardedAt         *time.Time `json:"onboarded_at,omitempty"`
 	LastLoginAt         *time.Time `json:"last_login_at,omitempty"`
+	SuspendedUntil      *time.Time `json:"suspended_until,omitempty"` // Set by moderators; login is refused until then
 	CreatedAt           time.Time  `json:"created_at"`
 	UpdatedAt           time.Time  `json:"updated_at"`
 }
+
+// IsSuspended reports whether a moderator has suspended the account and the suspension is still in effect
+func (u *User) IsSuspended() bool {
+	return u.SuspendedUntil != nil && u.SuspendedUntil.After(time.Now())
+}
@@ -6,6 +6,7 @@ import "context"
 type UserRepository interface {
 	Create(ctx context.Context, user *User) error
 	FindByID(ctx context.Context, id string) (*User, error)
+	FindByIDs(ctx context.Context, ids []string) ([]*User, error)
 	FindByEmail(ctx context.Context, email string) (*User, error)
 	FindByPhone(ctx context.Context, phone string) (*User, error)
 	FindByUsername(ctx context.Context, username string) (*User, error)
@@ -63,7 +63,7 @@ func (r *PostgresUserRepository) FindByID(ctx context.Context, id string) (*User
 		       COALESCE(avatar_url, '') as avatar_url, 
 		       is_active,
 		       onboarding_completed, onboarded_at,
-		       created_at, updated_at, last_login_at
+		       created_at, updated_at, last_login_at, suspended_until
 		FROM users WHERE id = $1
 	`
 
@@ -73,7 +73,7 @@ func (r *PostgresUserRepository) FindByID(ctx context.Context, id string) (*User
 		&user.Username, &user.PasswordHash, &user.EmailVerified, &user.Phone,
 		&user.PhoneVerified, &user.AvatarURL, &user.IsActive,
 		&user.OnboardingCompleted, &user.OnboardedAt,
-		&user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt,
+		&user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt, &user.SuspendedUntil,
 	)
 
 	if err != nil {
@@ -86,6 +86,51 @@ func (r *PostgresUserRepository) FindByID(ctx context.Context, id string) (*User
 	return &user, nil
 }
 
+// FindByIDs retrieves many users in a single query. Users that don't exist are left out,
+// in no particular order.
+func (r *PostgresUserRepository) FindByIDs(ctx context.Context, ids []string) ([]*User, error) {
+	query := `
+		SELECT id, email, 
+		       COALESCE(first_name, '') as first_name, 
+		       COALESCE(last_name, '') as last_name, 
+		       dob, 
+		       COALESCE(username, '') as username, 
+		       password_hash,
+		       email_verified, 
+		       COALESCE(phone, '') as phone, 
+		       phone_verified, 
+		       COALESCE(avatar_url, '') as avatar_url, 
+		       is_active,
+		       onboarding_completed, onboarded_at,
+		       created_at, updated_at, last_login_at, suspended_until
+		FROM users WHERE id = ANY($1::uuid[])
+	`
+
+	rows, err := r.pool.Query(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find users by ID: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*User
+	for rows.Next() {
+		var user User
+		err := rows.Scan(
+			&user.ID, &user.Email, &user.FirstName, &user.LastName, &user.DOB,
+			&user.Username, &user.PasswordHash, &user.EmailVerified, &user.Phone,
+			&user.PhoneVerified, &user.AvatarURL, &user.IsActive,
+			&user.OnboardingCompleted, &user.OnboardedAt,
+			&user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt, &user.SuspendedUntil,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, &user)
+	}
+
+	return users, rows.Err()
+}
+
 // FindByEmail retrieves a user from the database by their email address.
 // Returns the user if found, or an error if not found or query fails.
 func (r *PostgresUserRepository) FindByEmail(ctx context.Context, email string) (*User, error) {
@@ -102,7 +147,7 @@ func (r *PostgresUserRepository) FindByEmail(ctx context.Context, email string)
 		       COALESCE(avatar_url, '') as avatar_url, 
 		       is_active,
 		       onboarding_completed, onboarded_at,
-		       created_at, updated_at, last_login_at
+		       created_at, updated_at, last_login_at, suspended_until
 		FROM users WHERE email = $1
 	`
 
@@ -112,7 +157,7 @@ func (r *PostgresUserRepository) FindByEmail(ctx context.Context, email string)
 		&user.Username, &user.PasswordHash, &user.EmailVerified, &user.Phone,
 		&user.PhoneVerified, &user.AvatarURL, &user.IsActive,
 		&user.OnboardingCompleted, &user.OnboardedAt,
-		&user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt,
+		&user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt, &user.SuspendedUntil,
 	)
 
 	if err != nil {
@@ -141,7 +186,7 @@ func (r *PostgresUserRepository) FindByPhone(ctx context.Context, phone string)
 		       COALESCE(avatar_url, '') as avatar_url, 
 		       is_active,
 		       onboarding_completed, onboarded_at,
-		       created_at, updated_at, last_login_at
+		       created_at, updated_at, last_login_at, suspended_until
 		FROM users WHERE phone = $1
 	`
 
@@ -151,7 +196,7 @@ func (r *PostgresUserRepository) FindByPhone(ctx context.Context, phone string)
 		&user.Username, &user.PasswordHash, &user.EmailVerified, &user.Phone,
 		&user.PhoneVerified, &user.AvatarURL, &user.IsActive,
 		&user.OnboardingCompleted, &user.OnboardedAt,
-		&user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt,
+		&user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt, &user.SuspendedUntil,
 	)
 
 	if err != nil {
@@ -180,7 +225,7 @@ func (r *PostgresUserRepository) FindByUsername(ctx context.Context, username st
 		       COALESCE(avatar_url, '') as avatar_url, 
 		       is_active,
 		       onboarding_completed, onboarded_at,
-		       created_at, updated_at, last_login_at
+		       created_at, updated_at, last_login_at, suspended_until
 		FROM users WHERE username = $1
 	`
 
@@ -190,7 +235,7 @@ func (r *PostgresUserRepository) FindByUsername(ctx context.Context, username st
 		&user.Username, &user.PasswordHash, &user.EmailVerified, &user.Phone,
 		&user.PhoneVerified, &user.AvatarURL, &user.IsActive,
 		&user.OnboardingCompleted, &user.OnboardedAt,
-		&user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt,
+		&user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt, &user.SuspendedUntil,
 	)
 
 	if err != nil {
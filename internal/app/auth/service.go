@@ -178,6 +178,11 @@ func (s *Service) Login(ctx context.Context, identifier, password string) (*User
 		return nil, errors.New("invalid credentials")
 	}
 
+	// Check if a moderator has suspended the account
+	if user.IsSuspended() {
+		return nil, fmt.Errorf("account is suspended until %s", user.SuspendedUntil.Format(time.RFC3339))
+	}
+
 	// Update last login timestamp
 	_ = s.repo.UpdateLastLogin(ctx, user.ID)
 
@@ -0,0 +1,32 @@
+package bookmark
+
+// SaveRequest is the request DTO for saving a post
+type SaveRequest struct {
+	CollectionID *string `json:"collection_id,omitempty"` // Optional; saving again moves the post
+}
+
+// CollectionRequest is the request DTO for creating or renaming a collection
+type CollectionRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=50"`
+}
+
+// SaveResponse is the response for saving or unsaving a post
+type SaveResponse struct {
+	PostID       string  `json:"post_id"`
+	IsSaved      bool    `json:"is_saved"`
+	CollectionID *string `json:"collection_id,omitempty"`
+}
+
+// CollectionResponse is the response DTO for a collection
+type CollectionResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	PostCount int    `json:"post_count"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// CollectionListResponse is the response for listing a user's collections
+type CollectionListResponse struct {
+	Collections []*CollectionResponse `json:"collections"`
+}
@@ -0,0 +1,245 @@
+package bookmark
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles HTTP requests for bookmark operations
+type Handler struct {
+	service BookmarkService
+}
+
+// NewHandler creates a new bookmark handler
+func NewHandler(service BookmarkService) *Handler {
+	return &Handler{service: service}
+}
+
+// SavePost handles POST /v1/posts/:postId/save
+func (h *Handler) SavePost(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	postID := c.Params("postId")
+	if _, err := uuid.Parse(postID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid post ID",
+		})
+	}
+
+	// Body is optional (no collection)
+	var req SaveRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid request body",
+			})
+		}
+	}
+
+	if req.CollectionID != nil {
+		if _, err := uuid.Parse(*req.CollectionID); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid collection ID",
+			})
+		}
+	}
+
+	response, err := h.service.SavePost(c.Context(), postID, currentUserID, &req)
+	if err != nil {
+		if err == ErrPostNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "post not found",
+			})
+		}
+		if err == ErrCollectionNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "collection not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to save post",
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// UnsavePost handles DELETE /v1/posts/:postId/save
+func (h *Handler) UnsavePost(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	postID := c.Params("postId")
+	if _, err := uuid.Parse(postID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid post ID",
+		})
+	}
+
+	response, err := h.service.UnsavePost(c.Context(), postID, currentUserID)
+	if err != nil {
+		if err == ErrNotSaved {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "post is not saved",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to unsave post",
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// CreateCollection handles POST /v1/users/me/collections
+func (h *Handler) CreateCollection(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	// Parse request body
+	var req CollectionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	collection, err := h.service.CreateCollection(c.Context(), currentUserID, &req)
+	if err != nil {
+		if err == ErrInvalidCollectionName || err == ErrTooManyCollections {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if err == ErrCollectionExists {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to create collection",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(collection)
+}
+
+// GetCollections handles GET /v1/users/me/collections
+func (h *Handler) GetCollections(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	response, err := h.service.GetCollections(c.Context(), currentUserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get collections",
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// RenameCollection handles PUT /v1/users/me/collections/:collectionId
+func (h *Handler) RenameCollection(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	collectionID := c.Params("collectionId")
+	if _, err := uuid.Parse(collectionID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid collection ID",
+		})
+	}
+
+	// Parse request body
+	var req CollectionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	collection, err := h.service.RenameCollection(c.Context(), collectionID, currentUserID, &req)
+	if err != nil {
+		if err == ErrCollectionNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "collection not found",
+			})
+		}
+		if err == ErrInvalidCollectionName {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if err == ErrCollectionExists {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to rename collection",
+		})
+	}
+
+	return c.JSON(collection)
+}
+
+// DeleteCollection handles DELETE /v1/users/me/collections/:collectionId
+func (h *Handler) DeleteCollection(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	collectionID := c.Params("collectionId")
+	if _, err := uuid.Parse(collectionID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid collection ID",
+		})
+	}
+
+	err := h.service.DeleteCollection(c.Context(), collectionID, currentUserID)
+	if err != nil {
+		if err == ErrCollectionNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "collection not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to delete collection",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "collection deleted successfully",
+	})
+}
@@ -0,0 +1,27 @@
+package bookmark
+
+import "time"
+
+// Collection limits
+const (
+	MaxCollectionsPerUser   = 100
+	MaxCollectionNameLength = 50
+)
+
+// Bookmark is a post saved by a user, optionally into a collection
+type Bookmark struct {
+	UserID       string    `json:"user_id"`
+	PostID       string    `json:"post_id"`
+	CollectionID *string   `json:"collection_id,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Collection is a named, private group of saved posts
+type Collection struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Name      string    `json:"name"`
+	PostCount int       `json:"post_count"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
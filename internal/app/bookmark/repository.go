@@ -0,0 +1,21 @@
+package bookmark
+
+import "context"
+
+// BookmarkRepository defines the interface for bookmark data operations
+type BookmarkRepository interface {
+	// Save saves a post for a user, moving it to collectionID if it is already saved
+	Save(ctx context.Context, bookmark *Bookmark) error
+
+	// Unsave removes a saved post; returns false if it wasn't saved
+	Unsave(ctx context.Context, userID, postID string) (bool, error)
+
+	// Collection operations
+	CreateCollection(ctx context.Context, collection *Collection) error
+	GetCollectionByID(ctx context.Context, id, userID string) (*Collection, error)
+	GetCollections(ctx context.Context, userID string) ([]*Collection, error)
+	CountCollections(ctx context.Context, userID string) (int, error)
+	CollectionNameExists(ctx context.Context, userID, name, excludeID string) (bool, error)
+	RenameCollection(ctx context.Context, collection *Collection) error
+	DeleteCollection(ctx context.Context, id, userID string) error
+}
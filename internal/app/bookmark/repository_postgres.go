@@ -0,0 +1,180 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresBookmarkRepository implements BookmarkRepository for PostgreSQL
+type PostgresBookmarkRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresBookmarkRepository creates a new PostgreSQL bookmark repository
+func NewPostgresBookmarkRepository(pool *pgxpool.Pool) *PostgresBookmarkRepository {
+	return &PostgresBookmarkRepository{pool: pool}
+}
+
+// Save saves a post for a user, moving it to collectionID if it is already saved
+func (r *PostgresBookmarkRepository) Save(ctx context.Context, bookmark *Bookmark) error {
+	query := `
+		INSERT INTO bookmarks (user_id, post_id, collection_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, post_id) DO UPDATE
+		SET collection_id = EXCLUDED.collection_id
+		RETURNING created_at
+	`
+
+	return r.pool.QueryRow(ctx, query, bookmark.UserID, bookmark.PostID, bookmark.CollectionID).
+		Scan(&bookmark.CreatedAt)
+}
+
+// Unsave removes a saved post; returns false if it wasn't saved
+func (r *PostgresBookmarkRepository) Unsave(ctx context.Context, userID, postID string) (bool, error) {
+	query := `DELETE FROM bookmarks WHERE user_id = $1 AND post_id = $2`
+
+	result, err := r.pool.Exec(ctx, query, userID, postID)
+	if err != nil {
+		return false, err
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// CreateCollection creates a collection
+func (r *PostgresBookmarkRepository) CreateCollection(ctx context.Context, collection *Collection) error {
+	query := `
+		INSERT INTO bookmark_collections (user_id, name)
+		VALUES ($1, $2)
+		RETURNING id, created_at, updated_at
+	`
+
+	return r.pool.QueryRow(ctx, query, collection.UserID, collection.Name).
+		Scan(&collection.ID, &collection.CreatedAt, &collection.UpdatedAt)
+}
+
+// GetCollectionByID retrieves a collection owned by userID
+func (r *PostgresBookmarkRepository) GetCollectionByID(ctx context.Context, id, userID string) (*Collection, error) {
+	query := `
+		SELECT c.id, c.user_id, c.name, c.created_at, c.updated_at,
+		       (SELECT COUNT(*) FROM bookmarks b
+		        JOIN posts p ON p.id = b.post_id AND p.is_active = true AND p.status = 'published'
+		        WHERE b.collection_id = c.id)
+		FROM bookmark_collections c
+		WHERE c.id = $1 AND c.user_id = $2
+	`
+
+	collection := &Collection{}
+	err := r.pool.QueryRow(ctx, query, id, userID).Scan(
+		&collection.ID,
+		&collection.UserID,
+		&collection.Name,
+		&collection.CreatedAt,
+		&collection.UpdatedAt,
+		&collection.PostCount,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+// GetCollections retrieves a user's collections in name order. Post counts skip deleted
+// posts but not posts hidden by privacy or blocks.
+func (r *PostgresBookmarkRepository) GetCollections(ctx context.Context, userID string) ([]*Collection, error) {
+	query := `
+		SELECT c.id, c.user_id, c.name, c.created_at, c.updated_at, COUNT(p.id)
+		FROM bookmark_collections c
+		LEFT JOIN bookmarks b ON b.collection_id = c.id
+		LEFT JOIN posts p ON p.id = b.post_id AND p.is_active = true AND p.status = 'published'
+		WHERE c.user_id = $1
+		GROUP BY c.id
+		ORDER BY LOWER(c.name)
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var collections []*Collection
+
+	for rows.Next() {
+		collection := &Collection{}
+		err := rows.Scan(
+			&collection.ID,
+			&collection.UserID,
+			&collection.Name,
+			&collection.CreatedAt,
+			&collection.UpdatedAt,
+			&collection.PostCount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		collections = append(collections, collection)
+	}
+
+	return collections, rows.Err()
+}
+
+// CountCollections returns the number of collections a user has
+func (r *PostgresBookmarkRepository) CountCollections(ctx context.Context, userID string) (int, error) {
+	query := `SELECT COUNT(*) FROM bookmark_collections WHERE user_id = $1`
+
+	var count int
+	err := r.pool.QueryRow(ctx, query, userID).Scan(&count)
+	return count, err
+}
+
+// CollectionNameExists checks whether the user has another collection with the same name,
+// ignoring case. excludeID may be empty.
+func (r *PostgresBookmarkRepository) CollectionNameExists(ctx context.Context, userID, name, excludeID string) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM bookmark_collections
+			WHERE user_id = $1 AND LOWER(name) = LOWER($2) AND id::text <> $3
+		)
+	`
+
+	var exists bool
+	err := r.pool.QueryRow(ctx, query, userID, name, excludeID).Scan(&exists)
+	return exists, err
+}
+
+// RenameCollection changes a collection's name
+func (r *PostgresBookmarkRepository) RenameCollection(ctx context.Context, collection *Collection) error {
+	query := `
+		UPDATE bookmark_collections
+		SET name = $1, updated_at = NOW()
+		WHERE id = $2 AND user_id = $3
+		RETURNING updated_at
+	`
+
+	return r.pool.QueryRow(ctx, query, collection.Name, collection.ID, collection.UserID).
+		Scan(&collection.UpdatedAt)
+}
+
+// DeleteCollection deletes a collection; its posts stay saved without a collection
+func (r *PostgresBookmarkRepository) DeleteCollection(ctx context.Context, id, userID string) error {
+	query := `DELETE FROM bookmark_collections WHERE id = $1 AND user_id = $2`
+
+	result, err := r.pool.Exec(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"mockhu-app-backend/internal/app/post"
+	"mockhu-app-backend/internal/pkg/testdb"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func TestSavedPostsThatBecomeHidden(t *testing.T) {
+	pool := testdb.Pool(t)
+	repo := NewPostgresBookmarkRepository(pool)
+	postRepo := post.NewPostgresPostRepository(pool)
+	ctx := context.Background()
+
+	saverID := testdb.CreateUser(t, pool)
+	authorID := testdb.CreateUser(t, pool)
+	blockerID := testdb.CreateUser(t, pool)
+
+	collection := &Collection{UserID: saverID, Name: "later"}
+	if err := repo.CreateCollection(ctx, collection); err != nil {
+		t.Fatalf("CreateCollection: %v", err)
+	}
+
+	var postIDs []string
+	for _, userID := range []string{authorID, authorID, blockerID} {
+		p := &post.Post{UserID: userID, Content: "worth saving", IsActive: true}
+		if err := postRepo.Create(ctx, p); err != nil {
+			t.Fatalf("Create post: %v", err)
+		}
+		if err := repo.Save(ctx, &Bookmark{UserID: saverID, PostID: p.ID, CollectionID: &collection.ID}); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		postIDs = append(postIDs, p.ID)
+	}
+	kept, deleted, blocked := postIDs[0], postIDs[1], postIDs[2]
+
+	// savedIDs lists the saver's saved posts, all of them or one collection's
+	savedIDs := func(collectionID string) map[string]bool {
+		t.Helper()
+
+		posts, err := postRepo.GetSaved(ctx, saverID, collectionID, 20, 0)
+		if err != nil {
+			t.Fatalf("GetSaved: %v", err)
+		}
+		ids := make(map[string]bool, len(posts))
+		for _, p := range posts {
+			ids[p.ID] = true
+		}
+		return ids
+	}
+
+	if ids := savedIDs(collection.ID); len(ids) != 3 {
+		t.Fatalf("saved posts = %v, want all 3", ids)
+	}
+
+	if err := postRepo.Delete(ctx, deleted); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := pool.Exec(ctx, `INSERT INTO blocked_users (blocker_id, blocked_id) VALUES ($1, $2)`, blockerID, saverID); err != nil {
+		t.Fatalf("block: %v", err)
+	}
+
+	for _, collectionID := range []string{"", collection.ID} {
+		ids := savedIDs(collectionID)
+		if len(ids) != 1 || !ids[kept] {
+			t.Errorf("saved posts in %q = %v, want only %s", collectionID, ids, kept)
+		}
+	}
+
+	// Neither can be saved again
+	for _, postID := range []string{deleted, blocked} {
+		if visible, err := postRepo.IsVisibleTo(ctx, postID, saverID); err != nil || visible {
+			t.Errorf("IsVisibleTo(%s) = %v, %v; want false", postID, visible, err)
+		}
+	}
+
+	// The collection stops counting the deleted post
+	got, err := repo.GetCollectionByID(ctx, collection.ID, saverID)
+	if err != nil || got == nil {
+		t.Fatalf("GetCollectionByID = %+v, %v", got, err)
+	}
+	if got.PostCount != 2 {
+		t.Errorf("collection post count = %d, want 2", got.PostCount)
+	}
+}
+
+func TestCollectionsArePrivateToTheirOwner(t *testing.T) {
+	pool := testdb.Pool(t)
+	repo := NewPostgresBookmarkRepository(pool)
+	postRepo := post.NewPostgresPostRepository(pool)
+	ctx := context.Background()
+
+	ownerID := testdb.CreateUser(t, pool)
+	otherID := testdb.CreateUser(t, pool)
+
+	collection := &Collection{UserID: ownerID, Name: "private"}
+	if err := repo.CreateCollection(ctx, collection); err != nil {
+		t.Fatalf("CreateCollection: %v", err)
+	}
+	p := &post.Post{UserID: otherID, Content: "saved privately", IsActive: true}
+	if err := postRepo.Create(ctx, p); err != nil {
+		t.Fatalf("Create post: %v", err)
+	}
+	if err := repo.Save(ctx, &Bookmark{UserID: ownerID, PostID: p.ID, CollectionID: &collection.ID}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	if got, err := repo.GetCollectionByID(ctx, collection.ID, otherID); err != nil || got != nil {
+		t.Errorf("GetCollectionByID by another user = %+v, %v; want nil", got, err)
+	}
+	if got, err := repo.GetCollections(ctx, otherID); err != nil || len(got) != 0 {
+		t.Errorf("GetCollections by another user = %v, %v; want none", got, err)
+	}
+	if posts, err := postRepo.GetSaved(ctx, otherID, collection.ID, 20, 0); err != nil || len(posts) != 0 {
+		t.Errorf("GetSaved of another user's collection = %v, %v; want none", posts, err)
+	}
+	if saved, err := postRepo.HasUserSaved(ctx, p.ID, otherID); err != nil || saved {
+		t.Errorf("HasUserSaved by the author = %v, %v; want false", saved, err)
+	}
+	if err := repo.RenameCollection(ctx, &Collection{ID: collection.ID, UserID: otherID, Name: "taken"}); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("RenameCollection by another user: err = %v, want pgx.ErrNoRows", err)
+	}
+	if err := repo.DeleteCollection(ctx, collection.ID, otherID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("DeleteCollection by another user: err = %v, want pgx.ErrNoRows", err)
+	}
+
+	// Deleting the collection keeps its posts saved
+	if err := repo.DeleteCollection(ctx, collection.ID, ownerID); err != nil {
+		t.Fatalf("DeleteCollection: %v", err)
+	}
+	if saved, err := postRepo.HasUserSaved(ctx, p.ID, ownerID); err != nil || !saved {
+		t.Errorf("HasUserSaved after deleting the collection = %v, %v; want true", saved, err)
+	}
+}
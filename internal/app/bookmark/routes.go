@@ -0,0 +1,23 @@
+package bookmark
+
+import (
+	"mockhu-app-backend/internal/pkg/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterRoutes registers all bookmark routes (all private to the current user)
+func RegisterRoutes(app *fiber.App, handler *Handler) {
+	v1 := app.Group("/v1")
+	auth := middleware.AuthMiddleware()
+
+	// Save / unsave a post
+	v1.Post("/posts/:postId/save", auth, handler.SavePost)
+	v1.Delete("/posts/:postId/save", auth, handler.UnsavePost)
+
+	// Collections
+	v1.Get("/users/me/collections", auth, handler.GetCollections)
+	v1.Post("/users/me/collections", auth, handler.CreateCollection)
+	v1.Put("/users/me/collections/:collectionId", auth, handler.RenameCollection)
+	v1.Delete("/users/me/collections/:collectionId", auth, handler.DeleteCollection)
+}
@@ -0,0 +1,211 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"mockhu-app-backend/internal/app/post"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Errors
+var (
+	ErrPostNotFound          = errors.New("post not found")
+	ErrNotSaved              = errors.New("post is not saved")
+	ErrCollectionNotFound    = errors.New("collection not found")
+	ErrCollectionExists      = errors.New("a collection with this name already exists")
+	ErrInvalidCollectionName = errors.New("collection name must be 1 to 50 characters")
+	ErrTooManyCollections    = errors.New("too many collections (max 100)")
+)
+
+// BookmarkService defines the business logic for saved posts and collections
+type BookmarkService interface {
+	SavePost(ctx context.Context, postID, userID string, req *SaveRequest) (*SaveResponse, error)
+	UnsavePost(ctx context.Context, postID, userID string) (*SaveResponse, error)
+	CreateCollection(ctx context.Context, userID string, req *CollectionRequest) (*CollectionResponse, error)
+	GetCollections(ctx context.Context, userID string) (*CollectionListResponse, error)
+	RenameCollection(ctx context.Context, collectionID, userID string, req *CollectionRequest) (*CollectionResponse, error)
+	DeleteCollection(ctx context.Context, collectionID, userID string) error
+}
+
+// bookmarkService implements BookmarkService
+type bookmarkService struct {
+	bookmarkRepo BookmarkRepository
+	postRepo     post.PostRepository
+}
+
+// NewService creates a new bookmark service
+func NewService(bookmarkRepo BookmarkRepository, postRepo post.PostRepository) BookmarkService {
+	return &bookmarkService{
+		bookmarkRepo: bookmarkRepo,
+		postRepo:     postRepo,
+	}
+}
+
+// SavePost saves a post the user can see, optionally into one of their collections
+func (s *bookmarkService) SavePost(ctx context.Context, postID, userID string, req *SaveRequest) (*SaveResponse, error) {
+	visible, err := s.postRepo.IsVisibleTo(ctx, postID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+	if !visible {
+		return nil, ErrPostNotFound
+	}
+
+	// Collections are private, so only the user's own can be used
+	if req.CollectionID != nil {
+		collection, err := s.bookmarkRepo.GetCollectionByID(ctx, *req.CollectionID, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get collection: %w", err)
+		}
+		if collection == nil {
+			return nil, ErrCollectionNotFound
+		}
+	}
+
+	bookmark := &Bookmark{
+		UserID:       userID,
+		PostID:       postID,
+		CollectionID: req.CollectionID,
+	}
+
+	if err := s.bookmarkRepo.Save(ctx, bookmark); err != nil {
+		return nil, fmt.Errorf("failed to save post: %w", err)
+	}
+
+	return &SaveResponse{
+		PostID:       postID,
+		IsSaved:      true,
+		CollectionID: bookmark.CollectionID,
+	}, nil
+}
+
+// UnsavePost removes a post from the user's saved posts
+func (s *bookmarkService) UnsavePost(ctx context.Context, postID, userID string) (*SaveResponse, error) {
+	removed, err := s.bookmarkRepo.Unsave(ctx, userID, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unsave post: %w", err)
+	}
+	if !removed {
+		return nil, ErrNotSaved
+	}
+
+	return &SaveResponse{
+		PostID:  postID,
+		IsSaved: false,
+	}, nil
+}
+
+// CreateCollection creates a named collection
+func (s *bookmarkService) CreateCollection(ctx context.Context, userID string, req *CollectionRequest) (*CollectionResponse, error) {
+	name, err := s.validateName(ctx, userID, req.Name, "")
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.bookmarkRepo.CountCollections(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count collections: %w", err)
+	}
+	if count >= MaxCollectionsPerUser {
+		return nil, ErrTooManyCollections
+	}
+
+	collection := &Collection{
+		UserID: userID,
+		Name:   name,
+	}
+
+	if err := s.bookmarkRepo.CreateCollection(ctx, collection); err != nil {
+		return nil, fmt.Errorf("failed to create collection: %w", err)
+	}
+
+	return toCollectionResponse(collection), nil
+}
+
+// GetCollections retrieves the user's collections
+func (s *bookmarkService) GetCollections(ctx context.Context, userID string) (*CollectionListResponse, error) {
+	collections, err := s.bookmarkRepo.GetCollections(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collections: %w", err)
+	}
+
+	responses := make([]*CollectionResponse, 0, len(collections))
+	for _, collection := range collections {
+		responses = append(responses, toCollectionResponse(collection))
+	}
+
+	return &CollectionListResponse{Collections: responses}, nil
+}
+
+// RenameCollection renames one of the user's collections
+func (s *bookmarkService) RenameCollection(ctx context.Context, collectionID, userID string, req *CollectionRequest) (*CollectionResponse, error) {
+	collection, err := s.bookmarkRepo.GetCollectionByID(ctx, collectionID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collection: %w", err)
+	}
+	if collection == nil {
+		return nil, ErrCollectionNotFound
+	}
+
+	name, err := s.validateName(ctx, userID, req.Name, collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	collection.Name = name
+	if err := s.bookmarkRepo.RenameCollection(ctx, collection); err != nil {
+		return nil, fmt.Errorf("failed to rename collection: %w", err)
+	}
+
+	return toCollectionResponse(collection), nil
+}
+
+// DeleteCollection deletes one of the user's collections; its posts stay saved
+func (s *bookmarkService) DeleteCollection(ctx context.Context, collectionID, userID string) error {
+	err := s.bookmarkRepo.DeleteCollection(ctx, collectionID, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrCollectionNotFound
+		}
+		return fmt.Errorf("failed to delete collection: %w", err)
+	}
+
+	return nil
+}
+
+// Helper methods
+
+// validateName trims a collection name and checks its length and uniqueness
+func (s *bookmarkService) validateName(ctx context.Context, userID, name, excludeID string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > MaxCollectionNameLength {
+		return "", ErrInvalidCollectionName
+	}
+
+	exists, err := s.bookmarkRepo.CollectionNameExists(ctx, userID, name, excludeID)
+	if err != nil {
+		return "", fmt.Errorf("failed to check collection name: %w", err)
+	}
+	if exists {
+		return "", ErrCollectionExists
+	}
+
+	return name, nil
+}
+
+// toCollectionResponse converts a collection to its response DTO
+func toCollectionResponse(collection *Collection) *CollectionResponse {
+	return &CollectionResponse{
+		ID:        collection.ID,
+		Name:      collection.Name,
+		PostCount: collection.PostCount,
+		CreatedAt: collection.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: collection.UpdatedAt.Format(time.RFC3339),
+	}
+}
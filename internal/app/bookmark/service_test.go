@@ -0,0 +1,138 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"mockhu-app-backend/internal/app/post"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// fakeBookmarkRepo stores bookmarks and collections in memory
+type fakeBookmarkRepo struct {
+	BookmarkRepository
+
+	saved       map[string]*Bookmark   // User ID + post ID -> bookmark
+	collections map[string]*Collection // Collection ID -> collection
+}
+
+func newFakeBookmarkRepo(collections ...*Collection) *fakeBookmarkRepo {
+	r := &fakeBookmarkRepo{saved: make(map[string]*Bookmark), collections: make(map[string]*Collection)}
+	for _, c := range collections {
+		r.collections[c.ID] = c
+	}
+	return r
+}
+
+func (r *fakeBookmarkRepo) Save(ctx context.Context, bookmark *Bookmark) error {
+	r.saved[bookmark.UserID+bookmark.PostID] = bookmark
+	return nil
+}
+
+func (r *fakeBookmarkRepo) Unsave(ctx context.Context, userID, postID string) (bool, error) {
+	_, ok := r.saved[userID+postID]
+	delete(r.saved, userID+postID)
+	return ok, nil
+}
+
+func (r *fakeBookmarkRepo) GetCollectionByID(ctx context.Context, id, userID string) (*Collection, error) {
+	c, ok := r.collections[id]
+	if !ok || c.UserID != userID {
+		return nil, nil
+	}
+	return c, nil
+}
+
+func (r *fakeBookmarkRepo) CollectionNameExists(ctx context.Context, userID, name, excludeID string) (bool, error) {
+	return false, nil
+}
+
+func (r *fakeBookmarkRepo) RenameCollection(ctx context.Context, collection *Collection) error {
+	return nil
+}
+
+func (r *fakeBookmarkRepo) DeleteCollection(ctx context.Context, id, userID string) error {
+	c, ok := r.collections[id]
+	if !ok || c.UserID != userID {
+		return pgx.ErrNoRows
+	}
+	delete(r.collections, id)
+	return nil
+}
+
+// fakePostRepo knows which posts each user can see
+type fakePostRepo struct {
+	post.PostRepository
+
+	visible map[string]bool // Post ID -> visible to the saver
+}
+
+func (r *fakePostRepo) IsVisibleTo(ctx context.Context, postID, viewerID string) (bool, error) {
+	return r.visible[postID], nil
+}
+
+func TestSavePost(t *testing.T) {
+	mine := "mine"
+	theirs := "theirs"
+
+	tests := []struct {
+		name         string
+		postID       string
+		collectionID *string
+		wantErr      error
+	}{
+		{name: "save", postID: "visible"},
+		{name: "save into a collection", postID: "visible", collectionID: &mine},
+		// Hidden, deleted and nonexistent posts look the same
+		{name: "post not visible", postID: "hidden", wantErr: ErrPostNotFound},
+		{name: "someone else's collection", postID: "visible", collectionID: &theirs, wantErr: ErrCollectionNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeBookmarkRepo(&Collection{ID: mine, UserID: "saver"}, &Collection{ID: theirs, UserID: "other"})
+			s := NewService(repo, &fakePostRepo{visible: map[string]bool{"visible": true}})
+
+			resp, err := s.SavePost(context.Background(), tt.postID, "saver", &SaveRequest{CollectionID: tt.collectionID})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SavePost: err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if len(repo.saved) != 0 {
+					t.Errorf("saved %d posts, want none", len(repo.saved))
+				}
+				return
+			}
+
+			saved := repo.saved["saver"+tt.postID]
+			if !resp.IsSaved || saved == nil || saved.CollectionID != tt.collectionID {
+				t.Errorf("SavePost = %+v, saved %+v; want saved into %v", resp, saved, tt.collectionID)
+			}
+		})
+	}
+}
+
+func TestCollectionsArePrivate(t *testing.T) {
+	repo := newFakeBookmarkRepo(&Collection{ID: "theirs", UserID: "other", Name: "secret"})
+	s := NewService(repo, &fakePostRepo{})
+	ctx := context.Background()
+
+	if _, err := s.RenameCollection(ctx, "theirs", "saver", &CollectionRequest{Name: "mine now"}); !errors.Is(err, ErrCollectionNotFound) {
+		t.Errorf("RenameCollection: err = %v, want ErrCollectionNotFound", err)
+	}
+	if err := s.DeleteCollection(ctx, "theirs", "saver"); !errors.Is(err, ErrCollectionNotFound) {
+		t.Errorf("DeleteCollection: err = %v, want ErrCollectionNotFound", err)
+	}
+	if c := repo.collections["theirs"]; c == nil || c.Name != "secret" {
+		t.Errorf("collection = %+v, want it untouched", c)
+	}
+
+	if _, err := s.RenameCollection(ctx, "theirs", "other", &CollectionRequest{Name: "  renamed  "}); err != nil {
+		t.Errorf("RenameCollection by its owner: %v", err)
+	}
+	if name := repo.collections["theirs"].Name; name != "renamed" {
+		t.Errorf("renamed to %q, want %q", name, "renamed")
+	}
+}
@@ -1,5 +1,7 @@
 package comment
 
+import "mockhu-app-backend/internal/app/mention"
+
 // CreateCommentRequest is the request DTO for creating a comment
 type CreateCommentRequest struct {
 	Content         string  `json:"content" validate:"required,min=1,max=2000"`
@@ -13,6 +15,7 @@ type CommentResponse struct {
 	PostID          string       `json:"post_id"`
 	Author          AuthorInfo   `json:"author"`
 	Content         string       `json:"content"`
+	Mentions        []mention.Entity `json:"mentions"`
 	ParentCommentID *string      `json:"parent_comment_id,omitempty"`
 	Replies         []*CommentResponse `json:"replies,omitempty"`
 	ReplyCount      int          `json:"reply_count"`
@@ -20,12 +23,14 @@ type CommentResponse struct {
 	UpdatedAt       string       `json:"updated_at"`
 }
 
-// AuthorInfo contains author information for a comment
+// AuthorInfo contains author information for a comment. For anonymous comments only
+// FirstName is set, to the author's pseudonym in the thread.
 type AuthorInfo struct {
-	ID        string `json:"id"`
-	Username  string `json:"username"`
-	FirstName string `json:"first_name"`
-	AvatarURL string `json:"avatar_url"`
+	ID          string `json:"id"`
+	Username    string `json:"username"`
+	FirstName   string `json:"first_name"`
+	AvatarURL   string `json:"avatar_url"`
+	IsAnonymous bool   `json:"is_anonymous,omitempty"`
 }
 
 // PaginationInfo contains pagination metadata
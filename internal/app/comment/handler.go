@@ -1,8 +1,12 @@
 package comment
 
 import (
+	"errors"
 	"strconv"
 
+	"mockhu-app-backend/internal/app/anonymity"
+	"mockhu-app-backend/internal/app/moderation"
+
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -58,6 +62,13 @@ func (h *Handler) CreateComment(c *fiber.Ctx) error {
 	// Create comment
 	comment, err := h.service.CreateComment(c.Context(), postID, currentUserID, &req)
 	if err != nil {
+		var rejection *moderation.RejectionError
+		if errors.As(err, &rejection) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"error":      rejection.Error(),
+				"moderation": rejection,
+			})
+		}
 		if err == ErrInvalidContent {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 				"error": "invalid content",
@@ -78,6 +89,16 @@ func (h *Handler) CreateComment(c *fiber.Ctx) error {
 				"error": "cannot reply to a reply - only top-level comments can have replies",
 			})
 		}
+		if err == anonymity.ErrAnonymousRevoked {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if err == anonymity.ErrAnonymousRateLimited {
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "failed to create comment",
 		})
@@ -187,6 +208,13 @@ func (h *Handler) UpdateComment(c *fiber.Ctx) error {
 	// Update comment
 	comment, err := h.service.UpdateComment(c.Context(), commentID, currentUserID, req.Content)
 	if err != nil {
+		var rejection *moderation.RejectionError
+		if errors.As(err, &rejection) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"error":      rejection.Error(),
+				"moderation": rejection,
+			})
+		}
 		if err == ErrCommentNotFound {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"error": "comment not found",
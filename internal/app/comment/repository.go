@@ -7,14 +7,16 @@ type CommentRepository interface {
 	// Comment CRUD operations
 	Create(ctx context.Context, comment *Comment) error
 	GetByID(ctx context.Context, id string) (*Comment, error)
-	GetByPostID(ctx context.Context, postID string, limit, offset int) ([]*Comment, error)
-	GetReplies(ctx context.Context, parentCommentID string, limit, offset int) ([]*Comment, error)
+	GetByPostID(ctx context.Context, postID, viewerID string, limit, offset int) ([]*Comment, error)
+	GetReplies(ctx context.Context, parentCommentID, viewerID string, limit, offset int) ([]*Comment, error)
+	GetRepliesForParents(ctx context.Context, parentCommentIDs []string, viewerID string, limit int) ([]*Comment, error)
 	Update(ctx context.Context, comment *Comment) error
 	Delete(ctx context.Context, id string) error
 
 	// Count operations
 	GetCommentCount(ctx context.Context, postID string) (int, error)
 	GetReplyCount(ctx context.Context, parentCommentID string) (int, error)
+	GetReplyCounts(ctx context.Context, parentCommentIDs []string) (map[string]int, error)
 }
 
 
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 
+	"mockhu-app-backend/internal/app/counter"
+	"mockhu-app-backend/internal/app/mute"
+
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -26,15 +29,28 @@ func (r *PostgresCommentRepository) Create(ctx context.Context, comment *Comment
 		RETURNING id, is_active, created_at, updated_at
 	`
 
-	err := r.pool.QueryRow(ctx, query,
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, query,
 		comment.PostID,
 		comment.UserID,
 		comment.ParentCommentID,
 		comment.Content,
 		comment.IsAnonymous,
 	).Scan(&comment.ID, &comment.IsActive, &comment.CreatedAt, &comment.UpdatedAt)
+	if err != nil {
+		return err
+	}
 
-	return err
+	if err := counter.Add(ctx, tx, counter.PostComments, comment.PostID, 1); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
 
 // GetByID retrieves a single comment by its ID
@@ -72,18 +88,20 @@ func (r *PostgresCommentRepository) GetByID(ctx context.Context, id string) (*Co
 	return comment, nil
 }
 
-// GetByPostID retrieves all top-level comments for a post (no parent)
-func (r *PostgresCommentRepository) GetByPostID(ctx context.Context, postID string, limit, offset int) ([]*Comment, error) {
+// GetByPostID retrieves all top-level comments for a post (no parent), skipping comments
+// the viewer muted
+func (r *PostgresCommentRepository) GetByPostID(ctx context.Context, postID, viewerID string, limit, offset int) ([]*Comment, error) {
 	query := `
 		SELECT id, post_id, user_id, parent_comment_id, content, is_anonymous,
 		       is_active, created_at, updated_at
 		FROM post_comments
 		WHERE post_id = $1 AND parent_comment_id IS NULL AND is_active = true
+		AND ` + mute.CommentNotMuted("post_comments", "$4") + `
 		ORDER BY created_at ASC
 		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := r.pool.Query(ctx, query, postID, limit, offset)
+	rows, err := r.pool.Query(ctx, query, postID, limit, offset, viewerID)
 	if err != nil {
 		return nil, err
 	}
@@ -117,18 +135,70 @@ func (r *PostgresCommentRepository) GetByPostID(ctx context.Context, postID stri
 	return comments, rows.Err()
 }
 
-// GetReplies retrieves all replies to a parent comment
-func (r *PostgresCommentRepository) GetReplies(ctx context.Context, parentCommentID string, limit, offset int) ([]*Comment, error) {
+// GetReplies retrieves all replies to a parent comment, skipping replies the viewer muted
+func (r *PostgresCommentRepository) GetReplies(ctx context.Context, parentCommentID, viewerID string, limit, offset int) ([]*Comment, error) {
 	query := `
 		SELECT id, post_id, user_id, parent_comment_id, content, is_anonymous,
 		       is_active, created_at, updated_at
 		FROM post_comments
 		WHERE parent_comment_id = $1 AND is_active = true
+		AND ` + mute.CommentNotMuted("post_comments", "$4") + `
 		ORDER BY created_at ASC
 		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := r.pool.Query(ctx, query, parentCommentID, limit, offset)
+	rows, err := r.pool.Query(ctx, query, parentCommentID, limit, offset, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var comments []*Comment
+
+	for rows.Next() {
+		comment := &Comment{}
+		var parentCommentID *string
+
+		err := rows.Scan(
+			&comment.ID,
+			&comment.PostID,
+			&comment.UserID,
+			&parentCommentID,
+			&comment.Content,
+			&comment.IsAnonymous,
+			&comment.IsActive,
+			&comment.CreatedAt,
+			&comment.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		comment.ParentCommentID = parentCommentID
+		comments = append(comments, comment)
+	}
+
+	return comments, rows.Err()
+}
+
+// GetRepliesForParents retrieves the first replies to each of many parent comments, up to
+// limit per parent, skipping replies the viewer muted
+func (r *PostgresCommentRepository) GetRepliesForParents(ctx context.Context, parentCommentIDs []string, viewerID string, limit int) ([]*Comment, error) {
+	query := `
+		SELECT id, post_id, user_id, parent_comment_id, content, is_anonymous,
+		       is_active, created_at, updated_at
+		FROM (
+			SELECT post_comments.*,
+			       ROW_NUMBER() OVER (PARTITION BY parent_comment_id ORDER BY created_at ASC) AS rn
+			FROM post_comments
+			WHERE parent_comment_id = ANY($1::uuid[]) AND is_active = true
+			AND ` + mute.CommentNotMuted("post_comments", "$3") + `
+		) ranked
+		WHERE rn <= $2
+		ORDER BY parent_comment_id, created_at ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, parentCommentIDs, limit, viewerID)
 	if err != nil {
 		return nil, err
 	}
@@ -177,30 +247,36 @@ func (r *PostgresCommentRepository) Update(ctx context.Context, comment *Comment
 	return err
 }
 
-// Delete performs a soft delete on a comment
+// Delete performs a soft delete on a comment, moving it to the author's trash
 func (r *PostgresCommentRepository) Delete(ctx context.Context, id string) error {
 	query := `
 		UPDATE post_comments
-		SET is_active = false, updated_at = NOW()
+		SET is_active = false, deleted_at = NOW(), updated_at = NOW()
 		WHERE id = $1 AND is_active = true
+		RETURNING post_id
 	`
 
-	result, err := r.pool.Exec(ctx, query, id)
+	tx, err := r.pool.Begin(ctx)
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback(ctx)
+
+	var postID string
+	if err := tx.QueryRow(ctx, query, id).Scan(&postID); err != nil {
+		return err // pgx.ErrNoRows if the comment is not active
+	}
 
-	rowsAffected := result.RowsAffected()
-	if rowsAffected == 0 {
-		return pgx.ErrNoRows
+	if err := counter.Add(ctx, tx, counter.PostComments, postID, -1); err != nil {
+		return err
 	}
 
-	return nil
+	return tx.Commit(ctx)
 }
 
 // GetCommentCount returns the total number of comments for a post
 func (r *PostgresCommentRepository) GetCommentCount(ctx context.Context, postID string) (int, error) {
-	query := `SELECT COUNT(*) FROM post_comments WHERE post_id = $1 AND is_active = true`
+	query := `SELECT COALESCE((SELECT comment_count FROM posts WHERE id = $1), 0)`
 
 	var count int
 	err := r.pool.QueryRow(ctx, query, postID).Scan(&count)
@@ -216,4 +292,32 @@ func (r *PostgresCommentRepository) GetReplyCount(ctx context.Context, parentCom
 	return count, err
 }
 
+// GetReplyCounts returns the number of replies to each of many comments. Comments without
+// replies are left out.
+func (r *PostgresCommentRepository) GetReplyCounts(ctx context.Context, parentCommentIDs []string) (map[string]int, error) {
+	query := `
+		SELECT parent_comment_id, COUNT(*)
+		FROM post_comments
+		WHERE parent_comment_id = ANY($1::uuid[]) AND is_active = true
+		GROUP BY parent_comment_id
+	`
+
+	rows, err := r.pool.Query(ctx, query, parentCommentIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var id string
+		var count int
+		if err := rows.Scan(&id, &count); err != nil {
+			return nil, err
+		}
+		counts[id] = count
+	}
+
+	return counts, rows.Err()
+}
 
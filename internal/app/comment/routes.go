@@ -10,9 +10,9 @@ import (
 func RegisterRoutes(app *fiber.App, handler *Handler) {
 	v1 := app.Group("/v1")
 
-	// Public routes (no auth required) - register first
-	v1.Get("/comments/:commentId", handler.GetComment)
-	v1.Get("/posts/:postId/comments", handler.GetPostComments)
+	// Public routes (auth optional, so the viewer's mutes apply) - register first
+	v1.Get("/comments/:commentId", middleware.OptionalAuthMiddleware(), handler.GetComment)
+	v1.Get("/posts/:postId/comments", middleware.OptionalAuthMiddleware(), handler.GetPostComments)
 
 	// Protected routes (auth required)
 	protected := v1.Group("/v1/comments", middleware.AuthMiddleware())
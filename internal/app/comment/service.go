@@ -4,9 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"time"
 
+	"mockhu-app-backend/internal/app/anonymity"
 	"mockhu-app-backend/internal/app/auth"
+	"mockhu-app-backend/internal/app/mention"
+	"mockhu-app-backend/internal/app/moderation"
 	"mockhu-app-backend/internal/app/post"
 )
 
@@ -31,17 +35,23 @@ type CommentService interface {
 
 // commentService implements CommentService
 type commentService struct {
-	commentRepo CommentRepository
-	userRepo    auth.UserRepository
-	postRepo    post.PostRepository
+	commentRepo       CommentRepository
+	userRepo          auth.UserRepository
+	postRepo          post.PostRepository
+	mentionService    mention.MentionService
+	moderationService moderation.ModerationService
+	anonymityService  anonymity.AnonymityService
 }
 
 // NewService creates a new comment service
-func NewService(commentRepo CommentRepository, userRepo auth.UserRepository, postRepo post.PostRepository) CommentService {
+func NewService(commentRepo CommentRepository, userRepo auth.UserRepository, postRepo post.PostRepository, mentionService mention.MentionService, moderationService moderation.ModerationService, anonymityService anonymity.AnonymityService) CommentService {
 	return &commentService{
-		commentRepo: commentRepo,
-		userRepo:    userRepo,
-		postRepo:    postRepo,
+		commentRepo:       commentRepo,
+		userRepo:          userRepo,
+		postRepo:          postRepo,
+		mentionService:    mentionService,
+		moderationService: moderationService,
+		anonymityService:  anonymityService,
 	}
 }
 
@@ -80,6 +90,19 @@ func (s *commentService) CreateComment(ctx context.Context, postID, userID strin
 		}
 	}
 
+	// Anonymous comments are limited, and not allowed at all once revoked
+	if req.IsAnonymous {
+		if err := s.anonymityService.CheckCanPost(ctx, userID, anonymity.ContentComment); err != nil {
+			return nil, err
+		}
+	}
+
+	// Moderate the text before it is saved
+	moderated, err := s.moderationService.Check(ctx, moderation.ContentComment, userID, req.Content)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create comment
 	comment := &Comment{
 		PostID:          postID,
@@ -94,8 +117,12 @@ func (s *commentService) CreateComment(ctx context.Context, postID, userID strin
 		return nil, fmt.Errorf("failed to create comment: %w", err)
 	}
 
+	// Resolve mentions (the comment is already saved, so a failure here is only logged)
+	s.syncMentions(ctx, comment)
+	s.queueForReview(ctx, moderated, comment.ID)
+
 	// Get author info
-	author, err := s.getAuthorInfo(ctx, userID)
+	author, err := s.getCommentAuthor(ctx, auth.NewUserLoader(s.userRepo), anonymity.NewPseudonymLoader(s.anonymityService), comment)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get author info: %w", err)
 	}
@@ -106,6 +133,7 @@ func (s *commentService) CreateComment(ctx context.Context, postID, userID strin
 		PostID:          comment.PostID,
 		Author:          *author,
 		Content:         comment.Content,
+		Mentions:        s.mentionService.GetCommentEntities(ctx, comment.ID, comment.Content),
 		ParentCommentID: comment.ParentCommentID,
 		Replies:         []*CommentResponse{},
 		ReplyCount:       0,
@@ -142,19 +170,15 @@ func (s *commentService) GetPostComments(ctx context.Context, postID, currentUse
 	offset := (page - 1) * limit
 
 	// Get top-level comments
-	comments, err := s.commentRepo.GetByPostID(ctx, postID, limit, offset)
+	comments, err := s.commentRepo.GetByPostID(ctx, postID, currentUserID, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get comments: %w", err)
 	}
 
 	// Convert to response with replies
-	commentResponses := make([]*CommentResponse, 0, len(comments))
-	for _, comment := range comments {
-		response, err := s.convertToResponse(ctx, comment, currentUserID)
-		if err != nil {
-			continue // Skip comments with errors
-		}
-		commentResponses = append(commentResponses, response)
+	commentResponses, err := s.convertCommentsToResponse(ctx, comments, currentUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert comments: %w", err)
 	}
 
 	// Calculate total pages (simplified)
@@ -195,6 +219,11 @@ func (s *commentService) UpdateComment(ctx context.Context, commentID, userID st
 		return nil, ErrUnauthorized
 	}
 
+	moderated, err := s.moderationService.Check(ctx, moderation.ContentComment, userID, content)
+	if err != nil {
+		return nil, err
+	}
+
 	// Update comment
 	comment.Content = content
 	err = s.commentRepo.Update(ctx, comment)
@@ -202,6 +231,10 @@ func (s *commentService) UpdateComment(ctx context.Context, commentID, userID st
 		return nil, fmt.Errorf("failed to update comment: %w", err)
 	}
 
+	// Re-resolve mentions for the new content
+	s.syncMentions(ctx, comment)
+	s.queueForReview(ctx, moderated, comment.ID)
+
 	return s.convertToResponse(ctx, comment, userID)
 }
 
@@ -232,13 +265,28 @@ func (s *commentService) DeleteComment(ctx context.Context, commentID, userID st
 
 // Helper methods
 
+// syncMentions resolves the mentions in a comment and notifies mentioned users
+func (s *commentService) syncMentions(ctx context.Context, comment *Comment) {
+	err := s.mentionService.SyncCommentMentions(ctx, comment.PostID, comment.ID, comment.UserID, comment.Content, comment.IsAnonymous)
+	if err != nil {
+		log.Printf("⚠️ Failed to sync mentions for comment %s: %v", comment.ID, err)
+	}
+}
+
+// queueForReview queues a saved comment for moderator review if its text was flagged
+func (s *commentService) queueForReview(ctx context.Context, moderated *moderation.Result, commentID string) {
+	if err := s.moderationService.QueueForReview(ctx, moderated, commentID); err != nil {
+		log.Printf("⚠️ Failed to queue comment %s for review: %v", commentID, err)
+	}
+}
+
 // getAuthorInfo retrieves author information for a comment
-func (s *commentService) getAuthorInfo(ctx context.Context, userID string) (*AuthorInfo, error) {
-	user, err := s.userRepo.FindByID(ctx, userID)
+func (s *commentService) getAuthorInfo(ctx context.Context, users *auth.UserLoader, userID string) (*AuthorInfo, error) {
+	user, ok, err := users.Load(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
-	if user == nil {
+	if !ok {
 		return nil, errors.New("user not found")
 	}
 
@@ -250,48 +298,125 @@ func (s *commentService) getAuthorInfo(ctx context.Context, userID string) (*Aut
 	}, nil
 }
 
+// getCommentAuthor returns the author shown on a comment. Anonymous comments show the
+// author's pseudonym in the post's thread, the same one they have on the post itself.
+func (s *commentService) getCommentAuthor(ctx context.Context, users *auth.UserLoader, pseudonyms *anonymity.PseudonymLoader, comment *Comment) (*AuthorInfo, error) {
+	if comment.IsAnonymous {
+		pseudonym, _, err := pseudonyms.Load(ctx, anonymity.ThreadKey{PostID: comment.PostID, UserID: comment.UserID})
+		if err != nil {
+			return nil, err
+		}
+		return &AuthorInfo{
+			FirstName:   pseudonym,
+			IsAnonymous: true,
+		}, nil
+	}
+	return s.getAuthorInfo(ctx, users, comment.UserID)
+}
+
 // convertToResponse converts a comment to a response with replies
 func (s *commentService) convertToResponse(ctx context.Context, comment *Comment, currentUserID string) (*CommentResponse, error) {
-	// Get author info
-	author, err := s.getAuthorInfo(ctx, comment.UserID)
+	responses, err := s.convertCommentsToResponse(ctx, []*Comment{comment}, currentUserID)
 	if err != nil {
 		return nil, err
 	}
+	if len(responses) == 0 {
+		return nil, errors.New("user not found")
+	}
+	return responses[0], nil
+}
+
+// convertCommentsToResponse converts a page of comments to responses with a preview of their
+// replies. Authors, reply counts, replies and mentions are each loaded with one query for the
+// whole page. Comments whose author can't be found are skipped.
+func (s *commentService) convertCommentsToResponse(ctx context.Context, comments []*Comment, currentUserID string) ([]*CommentResponse, error) {
+	commentIDs := make([]string, 0, len(comments))
+	for _, comment := range comments {
+		commentIDs = append(commentIDs, comment.ID)
+	}
 
-	// Get reply count
-	replyCount, _ := s.commentRepo.GetReplyCount(ctx, comment.ID)
+	// Get reply counts
+	replyCounts, err := s.commentRepo.GetReplyCounts(ctx, commentIDs)
+	if err != nil {
+		log.Printf("⚠️ Failed to get reply counts: %v", err)
+		replyCounts = map[string]int{}
+	}
 
-	// Get replies (limit to 5 for preview)
-	replies, _ := s.commentRepo.GetReplies(ctx, comment.ID, 5, 0)
-	replyResponses := make([]*CommentResponse, 0, len(replies))
+	// Get replies (limit to 5 per comment for preview)
+	replies, err := s.commentRepo.GetRepliesForParents(ctx, commentIDs, currentUserID, 5)
+	if err != nil {
+		log.Printf("⚠️ Failed to get replies: %v", err)
+		replies = nil
+	}
+	repliesByParent := make(map[string][]*Comment, len(comments))
 	for _, reply := range replies {
-		replyAuthor, err := s.getAuthorInfo(ctx, reply.UserID)
+		repliesByParent[*reply.ParentCommentID] = append(repliesByParent[*reply.ParentCommentID], reply)
+	}
+
+	// Load authors, pseudonyms and mentions of comments and replies together
+	all := append(append([]*Comment{}, comments...), replies...)
+	users := auth.NewUserLoader(s.userRepo)
+	pseudonyms := anonymity.NewPseudonymLoader(s.anonymityService)
+	userIDs := make([]string, 0, len(all))
+	threads := make([]anonymity.ThreadKey, 0)
+	contents := make(map[string]string, len(all))
+	for _, c := range all {
+		if c.IsAnonymous {
+			threads = append(threads, anonymity.ThreadKey{PostID: c.PostID, UserID: c.UserID})
+		} else {
+			userIDs = append(userIDs, c.UserID)
+		}
+		contents[c.ID] = c.Content
+	}
+	if err := users.Prime(ctx, userIDs); err != nil {
+		return nil, err
+	}
+	if err := pseudonyms.Prime(ctx, threads); err != nil {
+		return nil, err
+	}
+	mentions := s.mentionService.GetCommentsEntities(ctx, contents)
+
+	responses := make([]*CommentResponse, 0, len(comments))
+	for _, comment := range comments {
+		author, err := s.getCommentAuthor(ctx, users, pseudonyms, comment)
 		if err != nil {
-			continue
+			continue // Skip comments with errors
 		}
-		replyResponses = append(replyResponses, &CommentResponse{
-			ID:              reply.ID,
-			PostID:          reply.PostID,
-			Author:          *replyAuthor,
-			Content:         reply.Content,
-			ParentCommentID: reply.ParentCommentID,
-			Replies:         []*CommentResponse{},
-			ReplyCount:       0,
-			CreatedAt:       reply.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:       reply.UpdatedAt.Format(time.RFC3339),
+
+		replyResponses := make([]*CommentResponse, 0, len(repliesByParent[comment.ID]))
+		for _, reply := range repliesByParent[comment.ID] {
+			replyAuthor, err := s.getCommentAuthor(ctx, users, pseudonyms, reply)
+			if err != nil {
+				continue
+			}
+			replyResponses = append(replyResponses, &CommentResponse{
+				ID:              reply.ID,
+				PostID:          reply.PostID,
+				Author:          *replyAuthor,
+				Content:         reply.Content,
+				Mentions:        mentions[reply.ID],
+				ParentCommentID: reply.ParentCommentID,
+				Replies:         []*CommentResponse{},
+				ReplyCount:      0,
+				CreatedAt:       reply.CreatedAt.Format(time.RFC3339),
+				UpdatedAt:       reply.UpdatedAt.Format(time.RFC3339),
+			})
+		}
+
+		responses = append(responses, &CommentResponse{
+			ID:              comment.ID,
+			PostID:          comment.PostID,
+			Author:          *author,
+			Content:         comment.Content,
+			Mentions:        mentions[comment.ID],
+			ParentCommentID: comment.ParentCommentID,
+			Replies:         replyResponses,
+			ReplyCount:      replyCounts[comment.ID],
+			CreatedAt:       comment.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:       comment.UpdatedAt.Format(time.RFC3339),
 		})
 	}
 
-	return &CommentResponse{
-		ID:              comment.ID,
-		PostID:          comment.PostID,
-		Author:          *author,
-		Content:         comment.Content,
-		ParentCommentID: comment.ParentCommentID,
-		Replies:         replyResponses,
-		ReplyCount:       replyCount,
-		CreatedAt:       comment.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:       comment.UpdatedAt.Format(time.RFC3339),
-	}, nil
+	return responses, nil
 }
 
@@ -0,0 +1,21 @@
+package counter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Add adds delta to a counter of the row with the given ID. Call it in the transaction that
+// inserts or deletes the rows being counted, so the two never disagree. Counters don't go
+// below zero.
+func Add(ctx context.Context, tx pgx.Tx, c Counter, id string, delta int) error {
+	if delta == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf(`UPDATE %[1]s SET %[2]s = GREATEST(%[2]s + $2, 0) WHERE id = $1`, c.Table, c.Column)
+	_, err := tx.Exec(ctx, query, id, delta)
+	return err
+}
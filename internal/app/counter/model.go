@@ -0,0 +1,66 @@
+package counter
+
+import "time"
+
+// ReconcileInterval is how often every counter is recomputed from the rows it counts
+const ReconcileInterval = time.Hour
+
+// Counter is a denormalized count kept in a column of posts or users
+type Counter struct {
+	Name   string // Reported in drift metrics
+	Table  string
+	Column string
+	Actual string // SQL computing the true value for the row aliased as t
+}
+
+// Counters
+var (
+	PostReactions = Counter{
+		Name:   "post_reactions",
+		Table:  "posts",
+		Column: "reaction_count",
+		Actual: `SELECT COUNT(*) FROM post_reactions r WHERE r.post_id = t.id`,
+	}
+	PostComments = Counter{
+		Name:   "post_comments",
+		Table:  "posts",
+		Column: "comment_count",
+		Actual: `SELECT COUNT(*) FROM post_comments c WHERE c.post_id = t.id AND c.is_active = true`,
+	}
+	PostShares = Counter{
+		Name:   "post_shares",
+		Table:  "posts",
+		Column: "share_count",
+		Actual: `SELECT COUNT(*) FROM post_shares s WHERE s.post_id = t.id`,
+	}
+	UserFollowers = Counter{
+		Name:   "user_followers",
+		Table:  "users",
+		Column: "follower_count",
+		Actual: `SELECT COUNT(*) FROM user_follows f WHERE f.following_id = t.id`,
+	}
+	UserFollowing = Counter{
+		Name:   "user_following",
+		Table:  "users",
+		Column: "following_count",
+		Actual: `SELECT COUNT(*) FROM user_follows f WHERE f.follower_id = t.id`,
+	}
+	UserPosts = Counter{
+		Name:   "user_posts",
+		Table:  "users",
+		Column: "post_count",
+		Actual: `SELECT COUNT(*) FROM posts p WHERE p.user_id = t.id AND p.is_active = true AND p.status = 'published' AND p.post_type <> 'repost'`,
+	}
+)
+
+// All lists every counter in the order they are reconciled
+var All = []Counter{PostReactions, PostComments, PostShares, UserFollowers, UserFollowing, UserPosts}
+
+// Drift is how far one counter had drifted from the rows it counts when it was reconciled
+type Drift struct {
+	Counter    string    `json:"counter"`
+	RowsFixed  int64     `json:"rows_fixed"`
+	TotalDrift int64     `json:"total_drift"` // Sum of the absolute differences
+	MaxDrift   int64     `json:"max_drift"`
+	RanAt      time.Time `json:"ran_at"`
+}
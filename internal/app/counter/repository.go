@@ -0,0 +1,12 @@
+package counter
+
+import "context"
+
+// CounterRepository defines the interface for counter reconciliation
+type CounterRepository interface {
+	// Reconcile recomputes a counter for every row and fixes the rows that drifted
+	Reconcile(ctx context.Context, c Counter) (*Drift, error)
+
+	// SaveDrift records the drift found by a reconciliation run
+	SaveDrift(ctx context.Context, drift *Drift) error
+}
@@ -0,0 +1,57 @@
+package counter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresCounterRepository implements CounterRepository for PostgreSQL
+type PostgresCounterRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresCounterRepository creates a new PostgreSQL counter repository
+func NewPostgresCounterRepository(pool *pgxpool.Pool) *PostgresCounterRepository {
+	return &PostgresCounterRepository{pool: pool}
+}
+
+// Reconcile recomputes a counter for every row and fixes the rows that drifted. A write that
+// races with the run may leave a row off by one; the next run repairs it.
+func (r *PostgresCounterRepository) Reconcile(ctx context.Context, c Counter) (*Drift, error) {
+	query := fmt.Sprintf(`
+		WITH actual AS (
+			SELECT t.id, t.%[2]s AS stored, (%[3]s) AS value
+			FROM %[1]s t
+		),
+		fixed AS (
+			UPDATE %[1]s t
+			SET %[2]s = a.value
+			FROM actual a
+			WHERE t.id = a.id AND a.stored <> a.value
+			RETURNING ABS(a.stored - a.value) AS drift
+		)
+		SELECT COUNT(*), COALESCE(SUM(drift), 0), COALESCE(MAX(drift), 0), NOW()
+		FROM fixed
+	`, c.Table, c.Column, c.Actual)
+
+	drift := &Drift{Counter: c.Name}
+	err := r.pool.QueryRow(ctx, query).Scan(&drift.RowsFixed, &drift.TotalDrift, &drift.MaxDrift, &drift.RanAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return drift, nil
+}
+
+// SaveDrift records the drift found by a reconciliation run
+func (r *PostgresCounterRepository) SaveDrift(ctx context.Context, drift *Drift) error {
+	query := `
+		INSERT INTO counter_reconciliations (counter, rows_fixed, total_drift, max_drift, ran_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := r.pool.Exec(ctx, query, drift.Counter, drift.RowsFixed, drift.TotalDrift, drift.MaxDrift, drift.RanAt)
+	return err
+}
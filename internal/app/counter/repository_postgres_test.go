@@ -0,0 +1,81 @@
+package counter
+
+import (
+	"context"
+	"testing"
+
+	"mockhu-app-backend/internal/pkg/testdb"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// postCount returns a user's stored post count
+func postCount(t *testing.T, pool *pgxpool.Pool, userID string) int {
+	t.Helper()
+
+	var count int
+	if err := pool.QueryRow(context.Background(), `SELECT post_count FROM users WHERE id = $1`, userID).Scan(&count); err != nil {
+		t.Fatalf("get post count: %v", err)
+	}
+	return count
+}
+
+func TestReconcileFixesDrift(t *testing.T) {
+	pool := testdb.Pool(t)
+	repo := NewPostgresCounterRepository(pool)
+	ctx := context.Background()
+
+	userID := testdb.CreateUser(t, pool)
+	var postIDs []string
+	for _, status := range []string{"published", "published", "draft"} {
+		var id string
+		err := pool.QueryRow(ctx, `INSERT INTO posts (user_id, content, status) VALUES ($1, 'post', $2) RETURNING id`, userID, status).
+			Scan(&id)
+		if err != nil {
+			t.Fatalf("insert post: %v", err)
+		}
+		postIDs = append(postIDs, id)
+	}
+	// Reposts aren't counted
+	_, err := pool.Exec(ctx, `INSERT INTO posts (user_id, content, post_type, quoted_post_id) VALUES ($1, '', 'repost', $2)`, userID, postIDs[0])
+	if err != nil {
+		t.Fatalf("insert repost: %v", err)
+	}
+
+	// Simulate drift, in both directions across runs
+	for _, stored := range []int{7, 0} {
+		if _, err := pool.Exec(ctx, `UPDATE users SET post_count = $2 WHERE id = $1`, userID, stored); err != nil {
+			t.Fatalf("set post count: %v", err)
+		}
+
+		drift, err := repo.Reconcile(ctx, UserPosts)
+		if err != nil {
+			t.Fatalf("Reconcile: %v", err)
+		}
+		if drift.RowsFixed < 1 || drift.MaxDrift < int64(abs(stored-2)) {
+			t.Errorf("drift = %+v, want at least this user's drift of %d", drift, abs(stored-2))
+		}
+		if got := postCount(t, pool, userID); got != 2 {
+			t.Errorf("post count after reconcile = %d, want 2 published posts", got)
+		}
+	}
+
+	// Other tests may leave drift on their own rows, so only this user's count is checked
+	drift, err := repo.Reconcile(ctx, UserPosts)
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if got := postCount(t, pool, userID); got != 2 {
+		t.Errorf("post count after a clean run = %d, want 2", got)
+	}
+	if err := repo.SaveDrift(ctx, drift); err != nil {
+		t.Errorf("SaveDrift: %v", err)
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
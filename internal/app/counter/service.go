@@ -0,0 +1,53 @@
+package counter
+
+import (
+	"context"
+	"fmt"
+	"log"
+)
+
+// CounterService defines the business logic for keeping counters consistent
+type CounterService interface {
+	// Reconcile recomputes every counter, fixes drift and records how much was found; run
+	// periodically by a background job
+	Reconcile(ctx context.Context) error
+}
+
+// counterService implements CounterService
+type counterService struct {
+	counterRepo CounterRepository
+}
+
+// NewService creates a new counter service
+func NewService(counterRepo CounterRepository) CounterService {
+	return &counterService{counterRepo: counterRepo}
+}
+
+// Reconcile recomputes every counter, fixes drift and records how much was found. A counter
+// that fails doesn't stop the others.
+func (s *counterService) Reconcile(ctx context.Context) error {
+	var failed []string
+
+	for _, c := range All {
+		drift, err := s.counterRepo.Reconcile(ctx, c)
+		if err != nil {
+			log.Printf("⚠️ Failed to reconcile counter %s: %v", c.Name, err)
+			failed = append(failed, c.Name)
+			continue
+		}
+
+		if err := s.counterRepo.SaveDrift(ctx, drift); err != nil {
+			log.Printf("⚠️ Failed to record drift of counter %s: %v", c.Name, err)
+		}
+
+		if drift.RowsFixed > 0 {
+			log.Printf("📊 Counter %s drifted: fixed %d rows, total drift %d, max drift %d",
+				c.Name, drift.RowsFixed, drift.TotalDrift, drift.MaxDrift)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to reconcile counters %v", failed)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package counter
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// fakeCounterRepo reconciles counters from canned results and records saved drift
+type fakeCounterRepo struct {
+	drift  map[string]int64 // Counter name -> rows fixed
+	failed map[string]bool
+
+	reconciled []string
+	saved      []*Drift
+}
+
+func (r *fakeCounterRepo) Reconcile(ctx context.Context, c Counter) (*Drift, error) {
+	r.reconciled = append(r.reconciled, c.Name)
+	if r.failed[c.Name] {
+		return nil, errors.New("query failed")
+	}
+	return &Drift{Counter: c.Name, RowsFixed: r.drift[c.Name]}, nil
+}
+
+func (r *fakeCounterRepo) SaveDrift(ctx context.Context, drift *Drift) error {
+	r.saved = append(r.saved, drift)
+	return nil
+}
+
+func TestReconcile(t *testing.T) {
+	repo := &fakeCounterRepo{drift: map[string]int64{UserPosts.Name: 3}}
+	s := NewService(repo)
+
+	if err := s.Reconcile(context.Background()); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	if len(repo.reconciled) != len(All) {
+		t.Errorf("reconciled %v, want every counter", repo.reconciled)
+	}
+	// Drift is recorded on every run, including clean ones, so the history has no gaps
+	if len(repo.saved) != len(All) {
+		t.Fatalf("saved drift for %d counters, want %d", len(repo.saved), len(All))
+	}
+	for _, drift := range repo.saved {
+		if drift.Counter == UserPosts.Name && drift.RowsFixed != 3 {
+			t.Errorf("saved %+v, want 3 rows fixed", drift)
+		}
+	}
+}
+
+func TestReconcileContinuesAfterFailure(t *testing.T) {
+	repo := &fakeCounterRepo{failed: map[string]bool{PostReactions.Name: true}}
+	s := NewService(repo)
+
+	err := s.Reconcile(context.Background())
+	if err == nil || !strings.Contains(err.Error(), PostReactions.Name) {
+		t.Fatalf("Reconcile: err = %v, want one naming %s", err, PostReactions.Name)
+	}
+	if len(repo.reconciled) != len(All) {
+		t.Errorf("reconciled %v, want every counter despite the failure", repo.reconciled)
+	}
+	if len(repo.saved) != len(All)-1 {
+		t.Errorf("saved drift for %d counters, want %d", len(repo.saved), len(All)-1)
+	}
+}
@@ -13,6 +13,9 @@ type FollowRepository interface {
 	// IsFollowing checks if follower follows following
 	IsFollowing(ctx context.Context, followerID, followingID string) (bool, error)
 
+	// GetFollowedAmong returns which of userIDs the follower follows
+	GetFollowedAmong(ctx context.Context, followerID string, userIDs []string) (map[string]bool, error)
+
 	// GetFollowers returns users who follow the given user
 	GetFollowers(ctx context.Context, userID string, limit, offset int) ([]*Follow, error)
 
@@ -2,7 +2,11 @@ package follow
 
 import (
 	"context"
+	"errors"
 
+	"mockhu-app-backend/internal/app/counter"
+
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -24,8 +28,7 @@ func (r *PostgresFollowRepository) Follow(ctx context.Context, followerID, follo
 		ON CONFLICT (follower_id, following_id) DO NOTHING
 	`
 
-	_, err := r.pool.Exec(ctx, query, followerID, followingID)
-	return err
+	return r.changeFollow(ctx, query, followerID, followingID, 1)
 }
 
 // Unfollow removes a follow relationship
@@ -35,8 +38,34 @@ func (r *PostgresFollowRepository) Unfollow(ctx context.Context, followerID, fol
 		WHERE follower_id = $1 AND following_id = $2
 	`
 
-	_, err := r.pool.Exec(ctx, query, followerID, followingID)
-	return err
+	return r.changeFollow(ctx, query, followerID, followingID, -1)
+}
+
+// changeFollow runs a query that inserts or deletes a follow and, if it did, adds delta to
+// both users' follow counters in the same transaction
+func (r *PostgresFollowRepository) changeFollow(ctx context.Context, query, followerID, followingID string, delta int) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, query, followerID, followingID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return nil // Already following, or not following
+	}
+
+	if err := counter.Add(ctx, tx, counter.UserFollowers, followingID, delta); err != nil {
+		return err
+	}
+	if err := counter.Add(ctx, tx, counter.UserFollowing, followerID, delta); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
 
 // IsFollowing checks if follower follows following
@@ -53,6 +82,32 @@ func (r *PostgresFollowRepository) IsFollowing(ctx context.Context, followerID,
 	return exists, err
 }
 
+// GetFollowedAmong returns which of userIDs the follower follows
+func (r *PostgresFollowRepository) GetFollowedAmong(ctx context.Context, followerID string, userIDs []string) (map[string]bool, error) {
+	query := `
+		SELECT following_id
+		FROM user_follows
+		WHERE follower_id = $1 AND following_id = ANY($2::uuid[])
+	`
+
+	rows, err := r.pool.Query(ctx, query, followerID, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	followed := make(map[string]bool)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		followed[id] = true
+	}
+
+	return followed, rows.Err()
+}
+
 // GetFollowers returns users who follow the given user
 func (r *PostgresFollowRepository) GetFollowers(ctx context.Context, userID string, limit, offset int) ([]*Follow, error) {
 	query := `
@@ -113,7 +168,7 @@ func (r *PostgresFollowRepository) GetFollowing(ctx context.Context, userID stri
 
 // GetFollowerCount returns the number of followers
 func (r *PostgresFollowRepository) GetFollowerCount(ctx context.Context, userID string) (int, error) {
-	query := `SELECT COUNT(*) FROM user_follows WHERE following_id = $1`
+	query := `SELECT COALESCE((SELECT follower_count FROM users WHERE id = $1), 0)`
 
 	var count int
 	err := r.pool.QueryRow(ctx, query, userID).Scan(&count)
@@ -122,7 +177,7 @@ func (r *PostgresFollowRepository) GetFollowerCount(ctx context.Context, userID
 
 // GetFollowingCount returns the number of users being followed
 func (r *PostgresFollowRepository) GetFollowingCount(ctx context.Context, userID string) (int, error) {
-	query := `SELECT COUNT(*) FROM user_follows WHERE follower_id = $1`
+	query := `SELECT COALESCE((SELECT following_count FROM users WHERE id = $1), 0)`
 
 	var count int
 	err := r.pool.QueryRow(ctx, query, userID).Scan(&count)
@@ -132,12 +187,15 @@ func (r *PostgresFollowRepository) GetFollowingCount(ctx context.Context, userID
 // GetFollowStats returns both follower and following counts
 func (r *PostgresFollowRepository) GetFollowStats(ctx context.Context, userID string) (*FollowStats, error) {
 	query := `
-		SELECT 
-			(SELECT COUNT(*) FROM user_follows WHERE following_id = $1) as follower_count,
-			(SELECT COUNT(*) FROM user_follows WHERE follower_id = $1) as following_count
+		SELECT follower_count, following_count
+		FROM users
+		WHERE id = $1
 	`
 
 	stats := &FollowStats{}
 	err := r.pool.QueryRow(ctx, query, userID).Scan(&stats.FollowerCount, &stats.FollowingCount)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return stats, nil
+	}
 	return stats, err
 }
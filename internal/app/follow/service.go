@@ -3,9 +3,11 @@ package follow
 import (
 	"context"
 	"errors"
+	"log"
 	"strings"
 
 	"mockhu-app-backend/internal/app/auth"
+	"mockhu-app-backend/internal/app/timeline"
 )
 
 // Errors
@@ -26,15 +28,17 @@ type FollowService interface {
 
 // followService implements FollowService
 type followService struct {
-	followRepo FollowRepository
-	userRepo   auth.UserRepository
+	followRepo      FollowRepository
+	userRepo        auth.UserRepository
+	timelineService timeline.TimelineService
 }
 
 // NewService creates a new follow service
-func NewService(followRepo FollowRepository, userRepo auth.UserRepository) FollowService {
+func NewService(followRepo FollowRepository, userRepo auth.UserRepository, timelineService timeline.TimelineService) FollowService {
 	return &followService{
-		followRepo: followRepo,
-		userRepo:   userRepo,
+		followRepo:      followRepo,
+		userRepo:        userRepo,
+		timelineService: timelineService,
 	}
 }
 
@@ -64,6 +68,11 @@ func (s *followService) Follow(ctx context.Context, followerID, followingID stri
 		return nil, err
 	}
 
+	// Show their recent posts in the follower's home feed
+	if err := s.timelineService.Follow(ctx, followerID, followingID); err != nil {
+		log.Printf("⚠️ Failed to backfill timeline of user %s: %v", followerID, err)
+	}
+
 	return &FollowResponse{
 		Message:     "followed successfully",
 		IsFollowing: true,
@@ -83,6 +92,10 @@ func (s *followService) Unfollow(ctx context.Context, followerID, followingID st
 		return nil, err
 	}
 
+	if err := s.timelineService.Unfollow(ctx, followerID, followingID); err != nil {
+		log.Printf("⚠️ Failed to prune timeline of user %s: %v", followerID, err)
+	}
+
 	return &FollowResponse{
 		Message:     "unfollowed successfully",
 		IsFollowing: false,
@@ -118,29 +131,11 @@ func (s *followService) GetFollowers(ctx context.Context, userID, currentUserID
 	}
 
 	// Build user list with details
-	users := make([]UserListItem, 0, len(follows))
+	userIDs := make([]string, 0, len(follows))
 	for _, f := range follows {
-		// Get user details
-		user, err := s.userRepo.FindByID(ctx, f.FollowerID)
-		if err != nil || user == nil {
-			continue
-		}
-
-		// Check if current user follows this follower
-		isFollowedByMe := false
-		if currentUserID != "" && currentUserID != f.FollowerID {
-			isFollowedByMe, _ = s.followRepo.IsFollowing(ctx, currentUserID, f.FollowerID)
-		}
-
-		users = append(users, UserListItem{
-			ID:             user.ID,
-			Username:       user.Username,
-			FirstName:      user.FirstName,
-			LastName:       user.LastName,
-			AvatarURL:      user.AvatarURL,
-			IsFollowedByMe: isFollowedByMe,
-		})
+		userIDs = append(userIDs, f.FollowerID)
 	}
+	users := s.buildUserList(ctx, userIDs, currentUserID)
 
 	return &UserListResponse{
 		Users:      users,
@@ -167,29 +162,11 @@ func (s *followService) GetFollowing(ctx context.Context, userID, currentUserID
 	}
 
 	// Build user list with details
-	users := make([]UserListItem, 0, len(follows))
+	userIDs := make([]string, 0, len(follows))
 	for _, f := range follows {
-		// Get user details
-		user, err := s.userRepo.FindByID(ctx, f.FollowingID)
-		if err != nil || user == nil {
-			continue
-		}
-
-		// Check if current user follows this user
-		isFollowedByMe := false
-		if currentUserID != "" && currentUserID != f.FollowingID {
-			isFollowedByMe, _ = s.followRepo.IsFollowing(ctx, currentUserID, f.FollowingID)
-		}
-
-		users = append(users, UserListItem{
-			ID:             user.ID,
-			Username:       user.Username,
-			FirstName:      user.FirstName,
-			LastName:       user.LastName,
-			AvatarURL:      user.AvatarURL,
-			IsFollowedByMe: isFollowedByMe,
-		})
+		userIDs = append(userIDs, f.FollowingID)
 	}
+	users := s.buildUserList(ctx, userIDs, currentUserID)
 
 	return &UserListResponse{
 		Users:      users,
@@ -212,3 +189,47 @@ func (s *followService) GetFollowStats(ctx context.Context, userID string) (*Fol
 		FollowingCount: stats.FollowingCount,
 	}, nil
 }
+
+// Helper methods
+
+// buildUserList builds list items for users in order, with two queries for the whole page:
+// one for the users and one for whether the current user follows them
+func (s *followService) buildUserList(ctx context.Context, userIDs []string, currentUserID string) []UserListItem {
+	found, err := s.userRepo.FindByIDs(ctx, userIDs)
+	if err != nil {
+		log.Printf("⚠️ Failed to get users: %v", err)
+		return []UserListItem{}
+	}
+	byID := make(map[string]*auth.User, len(found))
+	for _, user := range found {
+		byID[user.ID] = user
+	}
+
+	followedByMe := map[string]bool{}
+	if currentUserID != "" && len(userIDs) > 0 {
+		followedByMe, err = s.followRepo.GetFollowedAmong(ctx, currentUserID, userIDs)
+		if err != nil {
+			log.Printf("⚠️ Failed to check follows of user %s: %v", currentUserID, err)
+			followedByMe = map[string]bool{}
+		}
+	}
+
+	users := make([]UserListItem, 0, len(userIDs))
+	for _, id := range userIDs {
+		user, ok := byID[id]
+		if !ok {
+			continue
+		}
+
+		users = append(users, UserListItem{
+			ID:             user.ID,
+			Username:       user.Username,
+			FirstName:      user.FirstName,
+			LastName:       user.LastName,
+			AvatarURL:      user.AvatarURL,
+			IsFollowedByMe: id != currentUserID && followedByMe[id],
+		})
+	}
+
+	return users
+}
@@ -0,0 +1,28 @@
+package hashtag
+
+// HashtagResponse is the response DTO for a hashtag
+type HashtagResponse struct {
+	Tag          string `json:"tag"`
+	PostCount    int    `json:"post_count"`
+	InterestSlug string `json:"interest_slug,omitempty"`
+}
+
+// SearchResponse is the response for hashtag autocomplete
+type SearchResponse struct {
+	Hashtags []*HashtagResponse `json:"hashtags"`
+}
+
+// TrendingHashtagResponse is a hashtag in the trending list
+type TrendingHashtagResponse struct {
+	Tag           string  `json:"tag"`
+	RecentCount   int     `json:"recent_count"`
+	PreviousCount int     `json:"previous_count"`
+	Score         float64 `json:"score"`
+	InterestSlug  string  `json:"interest_slug,omitempty"`
+}
+
+// TrendingResponse is the response for trending hashtags
+type TrendingResponse struct {
+	Window   string                     `json:"window"`
+	Hashtags []*TrendingHashtagResponse `json:"hashtags"`
+}
@@ -0,0 +1,92 @@
+package hashtag
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Handler handles HTTP requests for hashtag operations
+type Handler struct {
+	service HashtagService
+}
+
+// NewHandler creates a new hashtag handler
+func NewHandler(service HashtagService) *Handler {
+	return &Handler{service: service}
+}
+
+// GetHashtag handles GET /v1/hashtags/:tag
+func (h *Handler) GetHashtag(c *fiber.Ctx) error {
+	tag := c.Params("tag")
+	if tag == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "hashtag is required",
+		})
+	}
+
+	response, err := h.service.GetHashtag(c.Context(), tag)
+	if err != nil {
+		if err == ErrInvalidHashtag {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid hashtag",
+			})
+		}
+		if err == ErrHashtagNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "hashtag not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get hashtag",
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// SearchHashtags handles GET /v1/hashtags/search?q=go (autocomplete)
+func (h *Handler) SearchHashtags(c *fiber.Ctx) error {
+	query := c.Query("q")
+	if query == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "query parameter 'q' is required",
+		})
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+
+	response, err := h.service.Search(c.Context(), query, limit)
+	if err != nil {
+		if err == ErrInvalidHashtag {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid hashtag",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to search hashtags",
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// GetTrending handles GET /v1/hashtags/trending?window=1h|24h|7d
+func (h *Handler) GetTrending(c *fiber.Ctx) error {
+	window := c.Query("window", defaultWindow)
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	response, err := h.service.GetTrending(c.Context(), window, limit)
+	if err != nil {
+		if err == ErrInvalidWindow {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid window. Must be '1h', '24h' or '7d'",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get trending hashtags",
+		})
+	}
+
+	return c.JSON(response)
+}
@@ -0,0 +1,41 @@
+package hashtag
+
+import "time"
+
+// Hashtag represents a normalized hashtag (lowercase, without the leading #)
+type Hashtag struct {
+	ID           string    `json:"id"`
+	Tag          string    `json:"tag"`
+	InterestID   *string   `json:"interest_id,omitempty"`
+	InterestSlug string    `json:"interest_slug,omitempty"` // Slug of the linked interest, if any
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// HashtagStats is a hashtag with its total usage
+type HashtagStats struct {
+	Hashtag
+	PostCount int `json:"post_count"`
+}
+
+// TrendingHashtag is a hashtag with its usage in the current and the previous window
+type TrendingHashtag struct {
+	Hashtag
+	RecentCount   int     `json:"recent_count"`
+	PreviousCount int     `json:"previous_count"`
+	Score         float64 `json:"score"`
+}
+
+// Trending windows supported by GET /v1/hashtags/trending
+const (
+	WindowHour    = "1h"
+	WindowDay     = "24h"
+	WindowWeek    = "7d"
+	defaultWindow = WindowDay
+)
+
+// trendingWindows maps window names to their durations
+var trendingWindows = map[string]time.Duration{
+	WindowHour: time.Hour,
+	WindowDay:  24 * time.Hour,
+	WindowWeek: 7 * 24 * time.Hour,
+}
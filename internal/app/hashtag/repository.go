@@ -0,0 +1,22 @@
+package hashtag
+
+import (
+	"context"
+	"time"
+)
+
+// HashtagRepository defines the interface for hashtag data operations
+type HashtagRepository interface {
+	// SyncPostHashtags makes tags the exact set of hashtags linked to a post,
+	// creating hashtags that do not exist yet
+	SyncPostHashtags(ctx context.Context, postID string, tags []string) error
+
+	// FindByTag retrieves a hashtag with its post count (nil if not found)
+	FindByTag(ctx context.Context, tag string) (*HashtagStats, error)
+
+	// SearchByPrefix returns hashtags starting with prefix, most used first
+	SearchByPrefix(ctx context.Context, prefix string, limit int) ([]*HashtagStats, error)
+
+	// GetTrending compares usage in the latest window against the window before it
+	GetTrending(ctx context.Context, window time.Duration, limit int) ([]*TrendingHashtag, error)
+}
@@ -0,0 +1,206 @@
+package hashtag
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresHashtagRepository implements HashtagRepository for PostgreSQL
+type PostgresHashtagRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresHashtagRepository creates a new PostgreSQL hashtag repository
+func NewPostgresHashtagRepository(pool *pgxpool.Pool) *PostgresHashtagRepository {
+	return &PostgresHashtagRepository{pool: pool}
+}
+
+// SyncPostHashtags replaces the hashtags linked to a post in a single transaction.
+// New hashtags are linked to the interest with a matching slug (#webdev, #web_dev -> web-dev).
+func (r *PostgresHashtagRepository) SyncPostHashtags(ctx context.Context, postID string, tags []string) error {
+	if tags == nil {
+		tags = []string{}
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Drop links to hashtags no longer in the post
+	_, err = tx.Exec(ctx, `
+		DELETE FROM post_hashtags ph
+		USING hashtags h
+		WHERE ph.hashtag_id = h.id
+		  AND ph.post_id = $1
+		  AND NOT (h.tag = ANY($2::text[]))
+	`, postID, tags)
+	if err != nil {
+		return err
+	}
+
+	if len(tags) > 0 {
+		// Create missing hashtags
+		_, err = tx.Exec(ctx, `
+			INSERT INTO hashtags (tag, interest_id)
+			SELECT t.tag, (
+				SELECT i.id FROM interests i
+				WHERE t.tag IN (i.slug, REPLACE(i.slug, '-', ''), REPLACE(i.slug, '-', '_'))
+				LIMIT 1
+			)
+			FROM unnest($1::text[]) AS t(tag)
+			ON CONFLICT (tag) DO NOTHING
+		`, tags)
+		if err != nil {
+			return err
+		}
+
+		// Link the post to its hashtags
+		_, err = tx.Exec(ctx, `
+			INSERT INTO post_hashtags (post_id, hashtag_id)
+			SELECT $1, h.id FROM hashtags h
+			WHERE h.tag = ANY($2::text[])
+			ON CONFLICT (post_id, hashtag_id) DO NOTHING
+		`, postID, tags)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// FindByTag retrieves a hashtag by its normalized tag
+func (r *PostgresHashtagRepository) FindByTag(ctx context.Context, tag string) (*HashtagStats, error) {
+	query := `
+		SELECT h.id, h.tag, h.interest_id, COALESCE(i.slug, ''), h.created_at,
+		       (SELECT COUNT(*) FROM post_hashtags ph
+		          JOIN posts p ON p.id = ph.post_id AND p.is_active = true
+		          WHERE ph.hashtag_id = h.id)
+		FROM hashtags h
+		LEFT JOIN interests i ON i.id = h.interest_id
+		WHERE h.tag = $1
+	`
+
+	stats := &HashtagStats{}
+	err := r.pool.QueryRow(ctx, query, tag).Scan(
+		&stats.ID,
+		&stats.Tag,
+		&stats.InterestID,
+		&stats.InterestSlug,
+		&stats.CreatedAt,
+		&stats.PostCount,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// SearchByPrefix returns hashtags starting with prefix, ordered by number of active posts
+func (r *PostgresHashtagRepository) SearchByPrefix(ctx context.Context, prefix string, limit int) ([]*HashtagStats, error) {
+	query := `
+		SELECT h.id, h.tag, h.interest_id, COALESCE(i.slug, ''), h.created_at,
+		       COUNT(p.id) AS post_count
+		FROM hashtags h
+		LEFT JOIN interests i ON i.id = h.interest_id
+		LEFT JOIN post_hashtags ph ON ph.hashtag_id = h.id
+		LEFT JOIN posts p ON p.id = ph.post_id AND p.is_active = true
+		WHERE h.tag LIKE $1 || '%'
+		GROUP BY h.id, i.slug
+		ORDER BY post_count DESC, h.tag
+		LIMIT $2
+	`
+
+	// Underscore is a LIKE wildcard but a valid hashtag character
+	pattern := strings.ReplaceAll(prefix, "_", `\_`)
+
+	rows, err := r.pool.Query(ctx, query, pattern, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hashtags []*HashtagStats
+
+	for rows.Next() {
+		stats := &HashtagStats{}
+		err := rows.Scan(
+			&stats.ID,
+			&stats.Tag,
+			&stats.InterestID,
+			&stats.InterestSlug,
+			&stats.CreatedAt,
+			&stats.PostCount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		hashtags = append(hashtags, stats)
+	}
+
+	return hashtags, rows.Err()
+}
+
+// GetTrending ranks hashtags used in the latest window by volume and growth over the
+// previous window of the same length, so a tag jumping from 2 to 20 posts outranks a
+// tag steady at 25
+func (r *PostgresHashtagRepository) GetTrending(ctx context.Context, window time.Duration, limit int) ([]*TrendingHashtag, error) {
+	query := `
+		SELECT id, tag, interest_id, interest_slug, created_at, recent, previous,
+		       recent::double precision * (recent + 1) / (previous + 1) AS score
+		FROM (
+			SELECT h.id, h.tag, h.interest_id, COALESCE(i.slug, '') AS interest_slug, h.created_at,
+			       COUNT(*) FILTER (WHERE ph.created_at > NOW() - $1 * INTERVAL '1 second') AS recent,
+			       COUNT(*) FILTER (WHERE ph.created_at <= NOW() - $1 * INTERVAL '1 second') AS previous
+			FROM post_hashtags ph
+			JOIN posts p ON p.id = ph.post_id AND p.is_active = true
+			JOIN hashtags h ON h.id = ph.hashtag_id
+			LEFT JOIN interests i ON i.id = h.interest_id
+			WHERE ph.created_at > NOW() - $1 * INTERVAL '2 seconds'
+			GROUP BY h.id, i.slug
+		) counts
+		WHERE recent > 0
+		ORDER BY score DESC, recent DESC, tag
+		LIMIT $2
+	`
+
+	rows, err := r.pool.Query(ctx, query, int64(window.Seconds()), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hashtags []*TrendingHashtag
+
+	for rows.Next() {
+		trending := &TrendingHashtag{}
+		err := rows.Scan(
+			&trending.ID,
+			&trending.Tag,
+			&trending.InterestID,
+			&trending.InterestSlug,
+			&trending.CreatedAt,
+			&trending.RecentCount,
+			&trending.PreviousCount,
+			&trending.Score,
+		)
+		if err != nil {
+			return nil, err
+		}
+		hashtags = append(hashtags, trending)
+	}
+
+	return hashtags, rows.Err()
+}
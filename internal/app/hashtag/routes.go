@@ -0,0 +1,15 @@
+package hashtag
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterRoutes registers all hashtag-related routes
+func RegisterRoutes(app *fiber.App, handler *Handler) {
+	hashtags := app.Group("/v1/hashtags")
+
+	// Register literal routes BEFORE /:tag to avoid route conflicts
+	hashtags.Get("/search", handler.SearchHashtags) // Autocomplete (?q=go)
+	hashtags.Get("/trending", handler.GetTrending)  // Trending tags (?window=1h|24h|7d)
+	hashtags.Get("/:tag", handler.GetHashtag)       // Hashtag details
+}
@@ -0,0 +1,137 @@
+package hashtag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"mockhu-app-backend/internal/pkg/entities"
+)
+
+// Errors
+var (
+	ErrHashtagNotFound = errors.New("hashtag not found")
+	ErrInvalidHashtag  = errors.New("invalid hashtag")
+	ErrInvalidWindow   = errors.New("invalid trending window")
+)
+
+// HashtagService defines the business logic for hashtag operations
+type HashtagService interface {
+	GetHashtag(ctx context.Context, tag string) (*HashtagResponse, error)
+	Search(ctx context.Context, query string, limit int) (*SearchResponse, error)
+	GetTrending(ctx context.Context, window string, limit int) (*TrendingResponse, error)
+}
+
+// hashtagService implements HashtagService
+type hashtagService struct {
+	hashtagRepo HashtagRepository
+}
+
+// NewService creates a new hashtag service
+func NewService(hashtagRepo HashtagRepository) HashtagService {
+	return &hashtagService{
+		hashtagRepo: hashtagRepo,
+	}
+}
+
+// GetHashtag retrieves a hashtag and its post count
+func (s *hashtagService) GetHashtag(ctx context.Context, tag string) (*HashtagResponse, error) {
+	normalized, ok := entities.NormalizeHashtag(tag)
+	if !ok {
+		return nil, ErrInvalidHashtag
+	}
+
+	stats, err := s.hashtagRepo.FindByTag(ctx, normalized)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hashtag: %w", err)
+	}
+	if stats == nil {
+		return nil, ErrHashtagNotFound
+	}
+
+	return toHashtagResponse(stats), nil
+}
+
+// Search autocompletes hashtags by prefix, most used first
+func (s *hashtagService) Search(ctx context.Context, query string, limit int) (*SearchResponse, error) {
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+
+	prefix, ok := entities.NormalizeHashtag(query)
+	if !ok {
+		// Prefixes without a letter yet (e.g. "#2") are still valid while typing
+		prefix, ok = normalizePrefix(query)
+		if !ok {
+			return nil, ErrInvalidHashtag
+		}
+	}
+
+	results, err := s.hashtagRepo.SearchByPrefix(ctx, prefix, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search hashtags: %w", err)
+	}
+
+	hashtags := make([]*HashtagResponse, 0, len(results))
+	for _, stats := range results {
+		hashtags = append(hashtags, toHashtagResponse(stats))
+	}
+
+	return &SearchResponse{Hashtags: hashtags}, nil
+}
+
+// GetTrending retrieves hashtags gaining usage over the given window (1h, 24h or 7d)
+func (s *hashtagService) GetTrending(ctx context.Context, window string, limit int) (*TrendingResponse, error) {
+	if window == "" {
+		window = defaultWindow
+	}
+	duration, ok := trendingWindows[window]
+	if !ok {
+		return nil, ErrInvalidWindow
+	}
+
+	if limit < 1 || limit > 50 {
+		limit = 20
+	}
+
+	results, err := s.hashtagRepo.GetTrending(ctx, duration, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trending hashtags: %w", err)
+	}
+
+	hashtags := make([]*TrendingHashtagResponse, 0, len(results))
+	for _, t := range results {
+		hashtags = append(hashtags, &TrendingHashtagResponse{
+			Tag:           t.Tag,
+			RecentCount:   t.RecentCount,
+			PreviousCount: t.PreviousCount,
+			Score:         t.Score,
+			InterestSlug:  t.InterestSlug,
+		})
+	}
+
+	return &TrendingResponse{
+		Window:   window,
+		Hashtags: hashtags,
+	}, nil
+}
+
+// Helper methods
+
+// normalizePrefix accepts a partially typed hashtag made only of digits and underscores
+func normalizePrefix(query string) (string, bool) {
+	normalized, ok := entities.NormalizeHashtag(query + "a")
+	if !ok {
+		return "", false
+	}
+	return normalized[:len(normalized)-1], true
+}
+
+// toHashtagResponse converts hashtag stats to a response
+func toHashtagResponse(stats *HashtagStats) *HashtagResponse {
+	return &HashtagResponse{
+		Tag:          stats.Tag,
+		PostCount:    stats.PostCount,
+		InterestSlug: stats.InterestSlug,
+	}
+}
@@ -0,0 +1,56 @@
+package insights
+
+// InsightsResponse is the response for post and account insights. For account insights
+// every metric is summed over the user's posts, so a viewer who saw two posts counts as
+// two unique viewers.
+type InsightsResponse struct {
+	PostID        string        `json:"post_id,omitempty"`
+	Views         int           `json:"views"`          // Counted views, deduplicated per viewer per 30 minutes
+	UniqueViewers int           `json:"unique_viewers"` // Distinct logged-in accounts that saw the post
+	Reach         ReachInfo     `json:"reach"`
+	Reactions     BreakdownInfo `json:"reactions"` // By reaction type
+	Comments      int           `json:"comments"`
+	Shares        BreakdownInfo `json:"shares"` // By shared_to_type (timeline, dm, external)
+	Series        SeriesInfo    `json:"series"`
+}
+
+// ReachInfo splits unique viewers and views between followers of the author and everyone
+// else. Follower status is taken when the view is counted.
+type ReachInfo struct {
+	Followers        int `json:"followers"`
+	NonFollowers     int `json:"non_followers"`
+	FollowerViews    int `json:"follower_views"`
+	NonFollowerViews int `json:"non_follower_views"`
+}
+
+// BreakdownInfo is a total with its split by type
+type BreakdownInfo struct {
+	Total  int            `json:"total"`
+	ByType map[string]int `json:"by_type"`
+}
+
+// SeriesInfo is activity over time in hourly or daily buckets, oldest first. Buckets
+// without activity are included with zeros.
+type SeriesInfo struct {
+	Interval string          `json:"interval"` // hour or day
+	From     string          `json:"from"`     // Start of the first bucket
+	To       string          `json:"to"`       // End of the last bucket
+	Buckets  []*SeriesBucket `json:"buckets"`
+}
+
+// SeriesBucket is the activity in one time bucket
+type SeriesBucket struct {
+	Start         string `json:"start"`
+	Views         int    `json:"views"`
+	UniqueViewers int    `json:"unique_viewers"` // Accounts that saw the post for the first time
+	Reactions     int    `json:"reactions"`
+	Comments      int    `json:"comments"`
+	Shares        int    `json:"shares"`
+}
+
+// SeriesQuery selects the time series; every field is optional
+type SeriesQuery struct {
+	Interval string // hour (default, last 48 hours) or day (default, last 30 days)
+	From     string // RFC 3339
+	To       string // RFC 3339, defaults to now
+}
@@ -0,0 +1,91 @@
+package insights
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles HTTP requests for author insights
+type Handler struct {
+	service InsightsService
+}
+
+// NewHandler creates a new insights handler
+func NewHandler(service InsightsService) *Handler {
+	return &Handler{service: service}
+}
+
+// GetPostInsights handles GET /v1/posts/:postId/insights?interval=&from=&to=
+func (h *Handler) GetPostInsights(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	postID := c.Params("postId")
+	if _, err := uuid.Parse(postID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid post ID",
+		})
+	}
+
+	response, err := h.service.GetPostInsights(c.Context(), postID, currentUserID, parseSeriesQuery(c))
+	if err != nil {
+		switch err {
+		case ErrPostNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case ErrUnauthorized:
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case ErrInvalidInterval, ErrInvalidRange:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get post insights",
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// GetUserInsights handles GET /v1/users/me/insights?interval=&from=&to=
+func (h *Handler) GetUserInsights(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	response, err := h.service.GetUserInsights(c.Context(), currentUserID, parseSeriesQuery(c))
+	if err != nil {
+		if err == ErrInvalidInterval || err == ErrInvalidRange {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get insights",
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// parseSeriesQuery reads the time series query parameters
+func parseSeriesQuery(c *fiber.Ctx) *SeriesQuery {
+	return &SeriesQuery{
+		Interval: c.Query("interval"),
+		From:     c.Query("from"),
+		To:       c.Query("to"),
+	}
+}
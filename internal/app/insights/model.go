@@ -0,0 +1,34 @@
+package insights
+
+import "time"
+
+// Metrics rolled up per post per hour in post_metrics_hourly
+const (
+	MetricViews           = "views"            // Counted views
+	MetricFollowerViews   = "follower_views"   // Counted views by followers of the author
+	MetricViewers         = "viewers"          // Accounts seeing the post for the first time
+	MetricFollowerViewers = "follower_viewers" // First-time viewers who follow the author
+	MetricReactions       = "reactions"        // Dimension is the reaction type
+	MetricComments        = "comments"         // Active comments
+	MetricShares          = "shares"           // Dimension is the shared_to_type
+)
+
+// Time series intervals
+const (
+	IntervalHour = "hour"
+	IntervalDay  = "day"
+)
+
+// Scope selects the posts insights cover: one post, or every post of a user
+type Scope struct {
+	PostID string
+	UserID string
+}
+
+// MetricValue is the sum of a metric over all time, or within one time series bucket
+type MetricValue struct {
+	Bucket    time.Time // Start of the bucket; zero for totals
+	Metric    string
+	Dimension string // Reaction type or shared_to_type; empty in time series
+	Value     int
+}
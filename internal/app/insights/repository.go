@@ -0,0 +1,22 @@
+package insights
+
+import (
+	"context"
+	"time"
+)
+
+// InsightsRepository defines the interface for reading and rolling up post metrics
+type InsightsRepository interface {
+	// GetPostAuthor returns the author of an active, published post ("" if there is none)
+	GetPostAuthor(ctx context.Context, postID string) (string, error)
+
+	// GetTotals sums every metric and dimension in the scope over all time
+	GetTotals(ctx context.Context, scope Scope) ([]*MetricValue, error)
+
+	// GetSeries sums every metric in the scope per interval bucket within [from, to)
+	GetSeries(ctx context.Context, scope Scope, interval string, from, to time.Time) ([]*MetricValue, error)
+
+	// Rollup recomputes the reaction, comment and share metrics of every hour from since
+	// (an hour boundary) on from the event tables; returns the number of rows written
+	Rollup(ctx context.Context, since time.Time) (int64, error)
+}
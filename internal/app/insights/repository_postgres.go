@@ -0,0 +1,145 @@
+package insights
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresInsightsRepository implements InsightsRepository using PostgreSQL
+type PostgresInsightsRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresInsightsRepository creates a new PostgreSQL insights repository
+func NewPostgresInsightsRepository(pool *pgxpool.Pool) *PostgresInsightsRepository {
+	return &PostgresInsightsRepository{pool: pool}
+}
+
+// inScope restricts post_metrics_hourly (aliased m) to the scope bound to $1 (post ID) and
+// $2 (user ID); deleted posts are left out
+const inScope = `m.post_id IN (
+			SELECT id FROM posts
+			WHERE is_active = true AND status = 'published'
+			AND (id = NULLIF($1::text, '')::uuid OR user_id = NULLIF($2::text, '')::uuid)
+		)`
+
+// GetPostAuthor returns the author of an active, published post ("" if there is none)
+func (r *PostgresInsightsRepository) GetPostAuthor(ctx context.Context, postID string) (string, error) {
+	query := `SELECT user_id FROM posts WHERE id = $1 AND is_active = true AND status = 'published'`
+
+	var authorID string
+	err := r.pool.QueryRow(ctx, query, postID).Scan(&authorID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	return authorID, err
+}
+
+// GetTotals sums every metric and dimension in the scope over all time
+func (r *PostgresInsightsRepository) GetTotals(ctx context.Context, scope Scope) ([]*MetricValue, error) {
+	query := `
+		SELECT m.metric, m.dimension, SUM(m.value)
+		FROM post_metrics_hourly m
+		WHERE ` + inScope + `
+		GROUP BY m.metric, m.dimension
+	`
+
+	rows, err := r.pool.Query(ctx, query, scope.PostID, scope.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []*MetricValue
+
+	for rows.Next() {
+		v := &MetricValue{}
+		if err := rows.Scan(&v.Metric, &v.Dimension, &v.Value); err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+
+	return values, rows.Err()
+}
+
+// GetSeries sums every metric in the scope per interval bucket within [from, to)
+func (r *PostgresInsightsRepository) GetSeries(ctx context.Context, scope Scope, interval string, from, to time.Time) ([]*MetricValue, error) {
+	query := `
+		SELECT date_trunc($3::text, m.bucket) AS b, m.metric, SUM(m.value)
+		FROM post_metrics_hourly m
+		WHERE ` + inScope + `
+		AND m.bucket >= $4 AND m.bucket < $5
+		GROUP BY b, m.metric
+		ORDER BY b
+	`
+
+	rows, err := r.pool.Query(ctx, query, scope.PostID, scope.UserID, interval, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []*MetricValue
+
+	for rows.Next() {
+		v := &MetricValue{}
+		if err := rows.Scan(&v.Bucket, &v.Metric, &v.Value); err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+
+	return values, rows.Err()
+}
+
+// Rollup recomputes the reaction, comment and share metrics of every hour from since on.
+// The hours are cleared first so removed reactions and deleted comments drop out.
+func (r *PostgresInsightsRepository) Rollup(ctx context.Context, since time.Time) (int64, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM post_metrics_hourly
+		WHERE bucket >= $1 AND metric IN ('reactions', 'comments', 'shares')
+	`, since)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := tx.Exec(ctx, `
+		INSERT INTO post_metrics_hourly (post_id, bucket, metric, dimension, value)
+		SELECT post_id, date_trunc('hour', created_at), 'reactions', COALESCE(reaction_type, 'fire'), COUNT(*)
+		FROM post_reactions
+		WHERE created_at >= $1
+		GROUP BY 1, 2, 4
+
+		UNION ALL
+
+		SELECT post_id, date_trunc('hour', created_at), 'comments', '', COUNT(*)
+		FROM post_comments
+		WHERE created_at >= $1 AND is_active = true
+		GROUP BY 1, 2
+
+		UNION ALL
+
+		SELECT post_id, date_trunc('hour', created_at), 'shares', shared_to_type, COUNT(*)
+		FROM post_shares
+		WHERE created_at >= $1
+		GROUP BY 1, 2, 4
+		ON CONFLICT (post_id, bucket, metric, dimension) DO UPDATE
+		SET value = EXCLUDED.value
+	`, since)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), tx.Commit(ctx)
+}
@@ -0,0 +1,116 @@
+package insights
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"mockhu-app-backend/internal/pkg/testdb"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// exec runs a statement, failing the test on error
+func exec(t *testing.T, pool *pgxpool.Pool, sql string, args ...interface{}) {
+	t.Helper()
+
+	if _, err := pool.Exec(context.Background(), sql, args...); err != nil {
+		t.Fatalf("exec %q: %v", sql, err)
+	}
+}
+
+// totals sums the scope's metrics by metric and dimension
+func totals(t *testing.T, repo *PostgresInsightsRepository, scope Scope) map[string]int {
+	t.Helper()
+
+	values, err := repo.GetTotals(context.Background(), scope)
+	if err != nil {
+		t.Fatalf("GetTotals: %v", err)
+	}
+	byKey := make(map[string]int)
+	for _, v := range values {
+		byKey[v.Metric+"/"+v.Dimension] += v.Value
+	}
+	return byKey
+}
+
+func TestRollup(t *testing.T) {
+	pool := testdb.Pool(t)
+	repo := NewPostgresInsightsRepository(pool)
+	ctx := context.Background()
+
+	authorID := testdb.CreateUser(t, pool)
+	fans := []string{testdb.CreateUser(t, pool), testdb.CreateUser(t, pool), testdb.CreateUser(t, pool)}
+
+	var postID string
+	if err := pool.QueryRow(ctx, `INSERT INTO posts (user_id, content) VALUES ($1, 'insightful') RETURNING id`, authorID).Scan(&postID); err != nil {
+		t.Fatalf("insert post: %v", err)
+	}
+
+	// Timestamps are stored without a time zone, in the database's local time
+	var hour time.Time
+	if err := pool.QueryRow(ctx, `SELECT date_trunc('hour', NOW())::timestamp`).Scan(&hour); err != nil {
+		t.Fatalf("get hour: %v", err)
+	}
+	earlier := hour.Add(-3 * time.Hour)
+
+	exec(t, pool, `INSERT INTO post_reactions (post_id, user_id, reaction_type) VALUES ($1, $2, 'fire'), ($1, $3, 'heart')`, postID, fans[0], fans[1])
+	exec(t, pool, `INSERT INTO post_reactions (post_id, user_id, reaction_type, created_at) VALUES ($1, $2, 'fire', $3)`, postID, fans[2], earlier.Add(time.Minute))
+	exec(t, pool, `INSERT INTO post_comments (post_id, user_id, content) VALUES ($1, $2, 'nice'), ($1, $3, 'agreed')`, postID, fans[0], fans[1])
+	exec(t, pool, `INSERT INTO post_comments (post_id, user_id, content, is_active) VALUES ($1, $2, 'deleted', false)`, postID, fans[2])
+	exec(t, pool, `INSERT INTO post_shares (post_id, user_id, shared_to_type) VALUES ($1, $2, 'dm'), ($1, $3, 'external')`, postID, fans[0], fans[1])
+
+	// Only the recent hours are rolled up; earlier ones were rolled up by previous runs
+	if _, err := repo.Rollup(ctx, hour.Add(-time.Hour)); err != nil {
+		t.Fatalf("Rollup: %v", err)
+	}
+
+	want := map[string]int{"reactions/fire": 1, "reactions/heart": 1, "comments/": 2, "shares/dm": 1, "shares/external": 1}
+	got := totals(t, repo, Scope{PostID: postID})
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("%s = %d, want %d", key, got[key], value)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("totals = %v, want %v", got, want)
+	}
+
+	// A rollup reaching back far enough picks up the older reaction, in its own hour
+	if _, err := repo.Rollup(ctx, earlier); err != nil {
+		t.Fatalf("Rollup: %v", err)
+	}
+	series, err := repo.GetSeries(ctx, Scope{UserID: authorID}, IntervalHour, earlier, hour.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("GetSeries: %v", err)
+	}
+	reactions := make(map[time.Time]int)
+	for _, v := range series {
+		if v.Metric == MetricReactions {
+			reactions[v.Bucket] += v.Value
+		}
+	}
+	if reactions[earlier] != 1 || reactions[hour] != 2 || len(reactions) != 2 {
+		t.Errorf("reactions per hour = %v, want 1 at %v and 2 at %v", reactions, earlier, hour)
+	}
+
+	// Removed reactions and deleted comments drop out on the next run
+	exec(t, pool, `DELETE FROM post_reactions WHERE post_id = $1 AND user_id = $2`, postID, fans[1])
+	exec(t, pool, `UPDATE post_comments SET is_active = false WHERE post_id = $1 AND user_id = $2`, postID, fans[1])
+	if _, err := repo.Rollup(ctx, hour); err != nil {
+		t.Fatalf("Rollup: %v", err)
+	}
+	got = totals(t, repo, Scope{PostID: postID})
+	if got["reactions/fire"] != 2 || got["reactions/heart"] != 0 || got["comments/"] != 1 {
+		t.Errorf("totals after removals = %v, want 2 fire reactions and 1 comment", got)
+	}
+
+	// Deleted posts have no insights
+	exec(t, pool, `UPDATE posts SET is_active = false WHERE id = $1`, postID)
+	if author, err := repo.GetPostAuthor(ctx, postID); err != nil || author != "" {
+		t.Errorf("GetPostAuthor of a deleted post = %q, %v; want none", author, err)
+	}
+	if got := totals(t, repo, Scope{UserID: authorID}); len(got) != 0 {
+		t.Errorf("account totals with only a deleted post = %v, want none", got)
+	}
+}
@@ -0,0 +1,17 @@
+package insights
+
+import (
+	"mockhu-app-backend/internal/pkg/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterRoutes registers all insights routes (author only)
+func RegisterRoutes(app *fiber.App, handler *Handler) {
+	v1 := app.Group("/v1")
+
+	auth := middleware.AuthMiddleware()
+
+	v1.Get("/posts/:postId/insights", auth, handler.GetPostInsights)
+	v1.Get("/users/me/insights", auth, handler.GetUserInsights)
+}
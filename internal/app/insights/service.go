@@ -0,0 +1,226 @@
+package insights
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Rollup and time series tuning
+const (
+	RollupInterval     = 5 * time.Minute // How often engagement is rolled up from the event tables
+	rollupLookback     = time.Hour       // Finished hours recomputed on each run, for late changes
+	defaultHourBuckets = 48
+	maxHourBuckets     = 7 * 24
+	defaultDayBuckets  = 30
+	maxDayBuckets      = 366
+)
+
+// Errors
+var (
+	ErrPostNotFound    = errors.New("post not found")
+	ErrUnauthorized    = errors.New("only the author can view post insights")
+	ErrInvalidInterval = errors.New("interval must be hour or day")
+	ErrInvalidRange    = errors.New("from and to must be RFC 3339 times, from before to, spanning at most 168 hours or 366 days")
+)
+
+// InsightsService defines the business logic for author insights
+type InsightsService interface {
+	GetPostInsights(ctx context.Context, postID, userID string, query *SeriesQuery) (*InsightsResponse, error)
+	GetUserInsights(ctx context.Context, userID string, query *SeriesQuery) (*InsightsResponse, error)
+	Rollup(ctx context.Context) error
+}
+
+// insightsService implements InsightsService
+type insightsService struct {
+	insightsRepo InsightsRepository
+}
+
+// NewService creates a new insights service
+func NewService(insightsRepo InsightsRepository) InsightsService {
+	return &insightsService{insightsRepo: insightsRepo}
+}
+
+// GetPostInsights returns the insights of one of the user's posts
+func (s *insightsService) GetPostInsights(ctx context.Context, postID, userID string, query *SeriesQuery) (*InsightsResponse, error) {
+	authorID, err := s.insightsRepo.GetPostAuthor(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+	if authorID == "" {
+		return nil, ErrPostNotFound
+	}
+	if authorID != userID {
+		return nil, ErrUnauthorized
+	}
+
+	response, err := s.getInsights(ctx, Scope{PostID: postID}, query)
+	if err != nil {
+		return nil, err
+	}
+	response.PostID = postID
+
+	return response, nil
+}
+
+// GetUserInsights returns insights summed over all of the user's posts
+func (s *insightsService) GetUserInsights(ctx context.Context, userID string, query *SeriesQuery) (*InsightsResponse, error) {
+	return s.getInsights(ctx, Scope{UserID: userID}, query)
+}
+
+// Rollup recomputes recent engagement metrics from the event tables; run periodically by a
+// background job
+func (s *insightsService) Rollup(ctx context.Context) error {
+	since := time.Now().UTC().Add(-rollupLookback).Truncate(time.Hour)
+
+	count, err := s.insightsRepo.Rollup(ctx, since)
+	if err != nil {
+		return fmt.Errorf("failed to roll up post metrics: %w", err)
+	}
+
+	log.Printf("📊 Rolled up %d post metrics since %s", count, since.Format(time.RFC3339))
+	return nil
+}
+
+// getInsights builds the totals and time series of a scope
+func (s *insightsService) getInsights(ctx context.Context, scope Scope, query *SeriesQuery) (*InsightsResponse, error) {
+	interval, from, to, err := resolveRange(query, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := s.insightsRepo.GetTotals(ctx, scope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metric totals: %w", err)
+	}
+
+	series, err := s.insightsRepo.GetSeries(ctx, scope, interval, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metric series: %w", err)
+	}
+
+	response := &InsightsResponse{
+		Reactions: BreakdownInfo{ByType: map[string]int{}},
+		Shares:    BreakdownInfo{ByType: map[string]int{}},
+	}
+
+	for _, v := range totals {
+		switch v.Metric {
+		case MetricViews:
+			response.Views += v.Value
+		case MetricFollowerViews:
+			response.Reach.FollowerViews += v.Value
+		case MetricViewers:
+			response.UniqueViewers += v.Value
+		case MetricFollowerViewers:
+			response.Reach.Followers += v.Value
+		case MetricReactions:
+			response.Reactions.Total += v.Value
+			response.Reactions.ByType[v.Dimension] += v.Value
+		case MetricComments:
+			response.Comments += v.Value
+		case MetricShares:
+			response.Shares.Total += v.Value
+			response.Shares.ByType[v.Dimension] += v.Value
+		}
+	}
+	response.Reach.NonFollowers = response.UniqueViewers - response.Reach.Followers
+	response.Reach.NonFollowerViews = response.Views - response.Reach.FollowerViews
+
+	response.Series = buildSeries(series, interval, from, to)
+
+	return response, nil
+}
+
+// Helper functions
+
+// resolveRange validates the series query and returns the interval and the bucket-aligned
+// range [from, to) it covers. Without from the range ends with the bucket containing to and
+// holds the interval's default number of buckets.
+func resolveRange(query *SeriesQuery, now time.Time) (string, time.Time, time.Time, error) {
+	interval := query.Interval
+	if interval == "" {
+		interval = IntervalHour
+	}
+
+	var step time.Duration
+	var defaultBuckets, maxBuckets int
+	switch interval {
+	case IntervalHour:
+		step, defaultBuckets, maxBuckets = time.Hour, defaultHourBuckets, maxHourBuckets
+	case IntervalDay:
+		step, defaultBuckets, maxBuckets = 24*time.Hour, defaultDayBuckets, maxDayBuckets
+	default:
+		return "", time.Time{}, time.Time{}, ErrInvalidInterval
+	}
+
+	end := now
+	if query.To != "" {
+		t, err := time.Parse(time.RFC3339, query.To)
+		if err != nil {
+			return "", time.Time{}, time.Time{}, ErrInvalidRange
+		}
+		end = t.UTC()
+	}
+	end = end.Truncate(step).Add(step) // Include the bucket containing the end
+
+	start := end.Add(-time.Duration(defaultBuckets) * step)
+	if query.From != "" {
+		t, err := time.Parse(time.RFC3339, query.From)
+		if err != nil {
+			return "", time.Time{}, time.Time{}, ErrInvalidRange
+		}
+		start = t.UTC().Truncate(step)
+	}
+
+	if !start.Before(end) || end.Sub(start) > time.Duration(maxBuckets)*step {
+		return "", time.Time{}, time.Time{}, ErrInvalidRange
+	}
+
+	return interval, start, end, nil
+}
+
+// buildSeries lays out the metric values in consecutive buckets from from to to, filling
+// buckets without activity with zeros
+func buildSeries(values []*MetricValue, interval string, from, to time.Time) SeriesInfo {
+	step := time.Hour
+	if interval == IntervalDay {
+		step = 24 * time.Hour
+	}
+
+	buckets := make([]*SeriesBucket, 0, int(to.Sub(from)/step))
+	byStart := make(map[time.Time]*SeriesBucket)
+	for t := from; t.Before(to); t = t.Add(step) {
+		bucket := &SeriesBucket{Start: t.Format(time.RFC3339)}
+		buckets = append(buckets, bucket)
+		byStart[t] = bucket
+	}
+
+	for _, v := range values {
+		bucket, ok := byStart[v.Bucket.UTC()]
+		if !ok {
+			continue
+		}
+		switch v.Metric {
+		case MetricViews:
+			bucket.Views += v.Value
+		case MetricViewers:
+			bucket.UniqueViewers += v.Value
+		case MetricReactions:
+			bucket.Reactions += v.Value
+		case MetricComments:
+			bucket.Comments += v.Value
+		case MetricShares:
+			bucket.Shares += v.Value
+		}
+	}
+
+	return SeriesInfo{
+		Interval: interval,
+		From:     from.Format(time.RFC3339),
+		To:       to.Format(time.RFC3339),
+		Buckets:  buckets,
+	}
+}
@@ -0,0 +1,179 @@
+package insights
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mockhu-app-backend/internal/pkg/jwt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// fakeInsightsRepo serves fixed metrics for the posts it knows, recording rollups
+type fakeInsightsRepo struct {
+	InsightsRepository
+
+	authors map[string]string // Post ID -> author ID
+	totals  []*MetricValue
+	rollups []time.Time
+}
+
+func (r *fakeInsightsRepo) GetPostAuthor(ctx context.Context, postID string) (string, error) {
+	return r.authors[postID], nil
+}
+
+func (r *fakeInsightsRepo) GetTotals(ctx context.Context, scope Scope) ([]*MetricValue, error) {
+	return r.totals, nil
+}
+
+func (r *fakeInsightsRepo) GetSeries(ctx context.Context, scope Scope, interval string, from, to time.Time) ([]*MetricValue, error) {
+	return []*MetricValue{{Bucket: to.Add(-time.Hour), Metric: MetricViews, Value: 3}}, nil
+}
+
+func (r *fakeInsightsRepo) Rollup(ctx context.Context, since time.Time) (int64, error) {
+	r.rollups = append(r.rollups, since)
+	return 0, nil
+}
+
+func TestPostInsightsAreOwnerOnly(t *testing.T) {
+	const postID = "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+
+	tests := []struct {
+		name       string
+		userID     string
+		path       string
+		wantStatus int
+	}{
+		{"author", "author", "/v1/posts/" + postID + "/insights", fiber.StatusOK},
+		{"another user", "viewer", "/v1/posts/" + postID + "/insights", fiber.StatusForbidden},
+		{"logged out", "", "/v1/posts/" + postID + "/insights", fiber.StatusUnauthorized},
+		{"deleted or missing post", "author", "/v1/posts/9b2d3f5e-1c4a-4e8b-9f6d-2a7c8e0b1d3f/insights", fiber.StatusNotFound},
+		{"invalid post ID", "author", "/v1/posts/nope/insights", fiber.StatusBadRequest},
+		{"invalid interval", "author", "/v1/posts/" + postID + "/insights?interval=week", fiber.StatusBadRequest},
+		{"own account", "viewer", "/v1/users/me/insights", fiber.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeInsightsRepo{
+				authors: map[string]string{postID: "author"},
+				totals: []*MetricValue{
+					{Metric: MetricViews, Value: 10},
+					{Metric: MetricFollowerViews, Value: 4},
+					{Metric: MetricViewers, Value: 6},
+					{Metric: MetricFollowerViewers, Value: 2},
+					{Metric: MetricReactions, Dimension: "fire", Value: 3},
+					{Metric: MetricReactions, Dimension: "heart", Value: 1},
+					{Metric: MetricShares, Dimension: "dm", Value: 2},
+				},
+			}
+			app := fiber.New()
+			RegisterRoutes(app, NewHandler(NewService(repo)))
+
+			req := httptest.NewRequest("GET", tt.path, nil)
+			if tt.userID != "" {
+				token, err := jwt.GenerateAccessToken(tt.userID, tt.userID+"@example.com", tt.userID)
+				if err != nil {
+					t.Fatalf("GenerateAccessToken: %v", err)
+				}
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("request: %v", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if tt.wantStatus != fiber.StatusOK {
+				return
+			}
+
+			var insights InsightsResponse
+			if err := json.NewDecoder(resp.Body).Decode(&insights); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			want := ReachInfo{Followers: 2, NonFollowers: 4, FollowerViews: 4, NonFollowerViews: 6}
+			if insights.Views != 10 || insights.UniqueViewers != 6 || insights.Reach != want {
+				t.Errorf("views %d, viewers %d, reach %+v; want 10, 6, %+v", insights.Views, insights.UniqueViewers, insights.Reach, want)
+			}
+			if insights.Reactions.Total != 4 || insights.Reactions.ByType["heart"] != 1 || insights.Shares.ByType["dm"] != 2 {
+				t.Errorf("reactions %+v, shares %+v", insights.Reactions, insights.Shares)
+			}
+			buckets := insights.Series.Buckets
+			if len(buckets) != defaultHourBuckets || buckets[len(buckets)-1].Views != 3 {
+				t.Errorf("series has %d buckets, want %d ending with 3 views", len(buckets), defaultHourBuckets)
+			}
+		})
+	}
+}
+
+func TestResolveRange(t *testing.T) {
+	now := time.Date(2026, 3, 10, 14, 25, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		query    SeriesQuery
+		wantFrom time.Time
+		wantTo   time.Time
+		wantErr  error
+	}{
+		{
+			name:     "default hours",
+			wantFrom: time.Date(2026, 3, 8, 15, 0, 0, 0, time.UTC),
+			wantTo:   time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "default days",
+			query:    SeriesQuery{Interval: IntervalDay},
+			wantFrom: time.Date(2026, 2, 9, 0, 0, 0, 0, time.UTC),
+			wantTo:   time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "range aligned to buckets",
+			query:    SeriesQuery{From: "2026-03-10T08:30:00Z", To: "2026-03-10T11:10:00+01:00"},
+			wantFrom: time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC),
+			wantTo:   time.Date(2026, 3, 10, 11, 0, 0, 0, time.UTC),
+		},
+		{name: "unknown interval", query: SeriesQuery{Interval: "week"}, wantErr: ErrInvalidInterval},
+		{name: "invalid time", query: SeriesQuery{From: "yesterday"}, wantErr: ErrInvalidRange},
+		{name: "from after to", query: SeriesQuery{From: "2026-03-10T12:00:00Z", To: "2026-03-09T12:00:00Z"}, wantErr: ErrInvalidRange},
+		{name: "too many hours", query: SeriesQuery{From: "2026-03-01T00:00:00Z"}, wantErr: ErrInvalidRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, from, to, err := resolveRange(&tt.query, now)
+			if err != tt.wantErr {
+				t.Fatalf("resolveRange: err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (!from.Equal(tt.wantFrom) || !to.Equal(tt.wantTo)) {
+				t.Errorf("range [%v, %v), want [%v, %v)", from, to, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
+
+func TestRollupRecomputesRecentHours(t *testing.T) {
+	repo := &fakeInsightsRepo{}
+	s := NewService(repo)
+
+	before := time.Now().UTC()
+	if err := s.Rollup(context.Background()); err != nil {
+		t.Fatalf("Rollup: %v", err)
+	}
+
+	// The last finished hour is recomputed along with the current one
+	if len(repo.rollups) != 1 {
+		t.Fatalf("rolled up %d times, want once", len(repo.rollups))
+	}
+	since := repo.rollups[0]
+	if since != since.Truncate(time.Hour) || before.Sub(since) < rollupLookback || before.Sub(since) > rollupLookback+time.Hour {
+		t.Errorf("rolled up since %v, want the start of the hour %v before %v", since, rollupLookback, before)
+	}
+}
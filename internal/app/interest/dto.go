@@ -62,3 +62,20 @@ type CreateInterestResponse struct {
 	Message  string   `json:"message"`
 	Interest Interest `json:"interest"`
 }
+
+// POST /v1/interests/:slug/follow - Follow an interest
+type FollowInterestResponse struct {
+	Message  string   `json:"message"`
+	Interest Interest `json:"interest"`
+}
+
+// DELETE /v1/interests/:slug/follow - Unfollow an interest
+type UnfollowInterestResponse struct {
+	Message string `json:"message"`
+}
+
+// GET /v1/users/me/followed-interests - Interests the current user follows
+type GetFollowedInterestsResponse struct {
+	Interests []Interest `json:"interests"`
+	Count     int        `json:"count"`
+}
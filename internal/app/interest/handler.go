@@ -183,3 +183,70 @@ func (h *Handler) CreateInterest(c *fiber.Ctx) error {
 		Interest: *interest,
 	})
 }
+
+// FollowInterest handles POST /v1/interests/:slug/follow
+func (h *Handler) FollowInterest(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	userID, ok := c.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	interest, err := h.service.FollowInterest(c.Context(), userID, c.Params("slug"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(FollowInterestResponse{
+		Message:  "interest followed successfully",
+		Interest: *interest,
+	})
+}
+
+// UnfollowInterest handles DELETE /v1/interests/:slug/follow
+func (h *Handler) UnfollowInterest(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	userID, ok := c.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	if err := h.service.UnfollowInterest(c.Context(), userID, c.Params("slug")); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(UnfollowInterestResponse{
+		Message: "interest unfollowed successfully",
+	})
+}
+
+// GetFollowedInterests handles GET /v1/users/me/followed-interests
+func (h *Handler) GetFollowedInterests(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	userID, ok := c.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	interests, err := h.service.GetFollowedInterests(c.Context(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(GetFollowedInterestsResponse{
+		Interests: interests,
+		Count:     len(interests),
+	})
+}
@@ -18,6 +18,11 @@ type InterestRepository interface {
 	ReplaceUserInterests(ctx context.Context, userID string, interestIDs []string) error
 	UserHasInterest(ctx context.Context, userID string, interestID string) (bool, error)
 
+	// Interest follows (posts tagged with followed interests reach the home feed)
+	FollowInterest(ctx context.Context, userID string, interestID string) error
+	UnfollowInterest(ctx context.Context, userID string, interestID string) error
+	GetFollowedInterests(ctx context.Context, userID string) ([]Interest, error)
+
 	// Statistics
 	CountByCategory(ctx context.Context) (map[string]int, error)
 	CountUserInterests(ctx context.Context, userID string) (int, error)
@@ -277,6 +277,69 @@ func (r *PostgresInterestRepository) UserHasInterest(ctx context.Context, userID
 	return exists, nil
 }
 
+// FollowInterest makes a user follow an interest; following it again is a no-op
+func (r *PostgresInterestRepository) FollowInterest(ctx context.Context, userID string, interestID string) error {
+	query := `
+		INSERT INTO interest_follows (user_id, interest_id)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, interest_id) DO NOTHING
+	`
+
+	if _, err := r.pool.Exec(ctx, query, userID, interestID); err != nil {
+		return fmt.Errorf("failed to follow interest: %w", err)
+	}
+
+	return nil
+}
+
+// UnfollowInterest stops a user following an interest
+func (r *PostgresInterestRepository) UnfollowInterest(ctx context.Context, userID string, interestID string) error {
+	query := `
+		DELETE FROM interest_follows
+		WHERE user_id = $1 AND interest_id = $2
+	`
+
+	result, err := r.pool.Exec(ctx, query, userID, interestID)
+	if err != nil {
+		return fmt.Errorf("failed to unfollow interest: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("interest not followed")
+	}
+
+	return nil
+}
+
+// GetFollowedInterests retrieves the interests a user follows
+func (r *PostgresInterestRepository) GetFollowedInterests(ctx context.Context, userID string) ([]Interest, error) {
+	query := `
+		SELECT i.id, i.name, i.slug, i.category, i.icon, i.created_at
+		FROM interests i
+		INNER JOIN interest_follows f ON i.id = f.interest_id
+		WHERE f.user_id = $1
+		ORDER BY i.category, i.name
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query followed interests: %w", err)
+	}
+	defer rows.Close()
+
+	var interests []Interest
+	for rows.Next() {
+		var interest Interest
+		err := rows.Scan(&interest.ID, &interest.Name, &interest.Slug, &interest.Category, &interest.Icon, &interest.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan interest: %w", err)
+		}
+		interests = append(interests, interest)
+	}
+
+	return interests, nil
+}
+
 // CountByCategory returns the count of interests per category
 func (r *PostgresInterestRepository) CountByCategory(ctx context.Context) (map[string]int, error) {
 	query := `
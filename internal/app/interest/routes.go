@@ -1,6 +1,8 @@
 package interest
 
 import (
+	"mockhu-app-backend/internal/pkg/middleware"
+
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -12,6 +14,11 @@ func RegisterRoutes(app *fiber.App, handler *Handler) {
 	interests.Get("/categories", handler.GetCategories) // List all categories with counts
 	interests.Post("/", handler.CreateInterest)         // Create new interest (admin)
 
+	// Interest follows (tagged posts reach the follower's home feed)
+	interests.Post("/:slug/follow", middleware.AuthMiddleware(), handler.FollowInterest)
+	interests.Delete("/:slug/follow", middleware.AuthMiddleware(), handler.UnfollowInterest)
+	app.Get("/v1/users/me/followed-interests", middleware.AuthMiddleware(), handler.GetFollowedInterests)
+
 	// User interests
 	users := app.Group("/v1/users")
 	users.Get("/:id/interests", handler.GetUserInterests)            // Get user's interests
@@ -199,6 +199,56 @@ func (s *Service) ReplaceUserInterests(ctx context.Context, userID string, slugs
 	return interests, nil
 }
 
+// FollowInterest makes a user follow an interest by slug
+func (s *Service) FollowInterest(ctx context.Context, userID string, slug string) (*Interest, error) {
+	if userID == "" {
+		return nil, errors.New("user ID is required")
+	}
+
+	interest, err := s.repo.FindBySlug(ctx, strings.ToLower(strings.TrimSpace(slug)))
+	if err != nil {
+		return nil, fmt.Errorf("interest not found: %w", err)
+	}
+
+	if err := s.repo.FollowInterest(ctx, userID, interest.ID); err != nil {
+		return nil, err
+	}
+
+	return interest, nil
+}
+
+// UnfollowInterest stops a user following an interest by slug
+func (s *Service) UnfollowInterest(ctx context.Context, userID string, slug string) error {
+	if userID == "" {
+		return errors.New("user ID is required")
+	}
+
+	interest, err := s.repo.FindBySlug(ctx, strings.ToLower(strings.TrimSpace(slug)))
+	if err != nil {
+		return fmt.Errorf("interest not found: %w", err)
+	}
+
+	return s.repo.UnfollowInterest(ctx, userID, interest.ID)
+}
+
+// GetFollowedInterests retrieves the interests a user follows
+func (s *Service) GetFollowedInterests(ctx context.Context, userID string) ([]Interest, error) {
+	if userID == "" {
+		return nil, errors.New("user ID is required")
+	}
+
+	interests, err := s.repo.GetFollowedInterests(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get followed interests: %w", err)
+	}
+
+	if interests == nil {
+		interests = []Interest{}
+	}
+
+	return interests, nil
+}
+
 // CreateInterest creates a new interest (admin function)
 func (s *Service) CreateInterest(ctx context.Context, name, slug, category, icon string) (*Interest, error) {
 	// Validate input
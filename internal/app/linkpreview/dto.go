@@ -0,0 +1,10 @@
+package linkpreview
+
+// PreviewResponse is the preview card shown for a link in a post or message
+type PreviewResponse struct {
+	URL         string `json:"url"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	ImageURL    string `json:"image_url,omitempty"`
+	SiteName    string `json:"site_name,omitempty"`
+}
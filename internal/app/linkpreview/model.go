@@ -0,0 +1,23 @@
+package linkpreview
+
+import "time"
+
+// Preview statuses
+const (
+	StatusPending = "pending" // Queued for fetching
+	StatusReady   = "ready"
+	StatusFailed  = "failed" // Gave up after maxFetchAttempts
+)
+
+// LinkPreview is the cached preview metadata for a normalized URL
+type LinkPreview struct {
+	URL         string     `json:"url"`
+	Status      string     `json:"status"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	ImageURL    string     `json:"image_url"`
+	SiteName    string     `json:"site_name"`
+	Attempts    int        `json:"attempts"`
+	FetchedAt   *time.Time `json:"fetched_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
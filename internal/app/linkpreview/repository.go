@@ -0,0 +1,28 @@
+package linkpreview
+
+import (
+	"context"
+	"time"
+)
+
+// LinkPreviewRepository defines the interface for link preview data operations
+type LinkPreviewRepository interface {
+	// Enqueue queues a URL for fetching unless it is already cached or queued
+	Enqueue(ctx context.Context, url string) error
+
+	// GetByURL retrieves a cached preview (nil if the URL has never been queued)
+	GetByURL(ctx context.Context, url string) (*LinkPreview, error)
+
+	// GetReadyByURLs retrieves the previews of many URLs that have been fetched
+	GetReadyByURLs(ctx context.Context, urls []string) ([]*LinkPreview, error)
+
+	// ClaimDue returns up to limit pending URLs that are due, counting the attempt and
+	// pushing their next attempt back by retryAfter so concurrent workers skip them
+	ClaimDue(ctx context.Context, limit int, retryAfter time.Duration) ([]*LinkPreview, error)
+
+	// SaveReady stores fetched metadata for a URL
+	SaveReady(ctx context.Context, preview *LinkPreview) error
+
+	// MarkFailed stops retrying a URL
+	MarkFailed(ctx context.Context, url string) error
+}
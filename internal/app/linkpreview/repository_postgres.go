@@ -0,0 +1,169 @@
+package linkpreview
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresLinkPreviewRepository implements LinkPreviewRepository for PostgreSQL
+type PostgresLinkPreviewRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresLinkPreviewRepository creates a new PostgreSQL link preview repository
+func NewPostgresLinkPreviewRepository(pool *pgxpool.Pool) *PostgresLinkPreviewRepository {
+	return &PostgresLinkPreviewRepository{pool: pool}
+}
+
+// Enqueue queues a URL for fetching unless it is already cached or queued
+func (r *PostgresLinkPreviewRepository) Enqueue(ctx context.Context, url string) error {
+	query := `INSERT INTO link_previews (url) VALUES ($1) ON CONFLICT (url) DO NOTHING`
+
+	_, err := r.pool.Exec(ctx, query, url)
+	return err
+}
+
+// GetByURL retrieves a cached preview (nil if the URL has never been queued)
+func (r *PostgresLinkPreviewRepository) GetByURL(ctx context.Context, url string) (*LinkPreview, error) {
+	query := `
+		SELECT url, status, title, description, image_url, site_name, attempts, fetched_at, created_at
+		FROM link_previews
+		WHERE url = $1
+	`
+
+	preview := &LinkPreview{}
+	err := r.pool.QueryRow(ctx, query, url).Scan(
+		&preview.URL,
+		&preview.Status,
+		&preview.Title,
+		&preview.Description,
+		&preview.ImageURL,
+		&preview.SiteName,
+		&preview.Attempts,
+		&preview.FetchedAt,
+		&preview.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return preview, nil
+}
+
+// GetReadyByURLs retrieves the previews of many URLs that have been fetched
+func (r *PostgresLinkPreviewRepository) GetReadyByURLs(ctx context.Context, urls []string) ([]*LinkPreview, error) {
+	query := `
+		SELECT url, status, title, description, image_url, site_name, attempts, fetched_at, created_at
+		FROM link_previews
+		WHERE url = ANY($1) AND status = $2
+	`
+
+	rows, err := r.pool.Query(ctx, query, urls, StatusReady)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var previews []*LinkPreview
+
+	for rows.Next() {
+		preview := &LinkPreview{}
+		err := rows.Scan(
+			&preview.URL,
+			&preview.Status,
+			&preview.Title,
+			&preview.Description,
+			&preview.ImageURL,
+			&preview.SiteName,
+			&preview.Attempts,
+			&preview.FetchedAt,
+			&preview.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		previews = append(previews, preview)
+	}
+
+	return previews, rows.Err()
+}
+
+// ClaimDue returns up to limit pending URLs that are due, counting the attempt and
+// pushing their next attempt back by retryAfter so concurrent workers skip them
+func (r *PostgresLinkPreviewRepository) ClaimDue(ctx context.Context, limit int, retryAfter time.Duration) ([]*LinkPreview, error) {
+	query := `
+		UPDATE link_previews
+		SET attempts = attempts + 1,
+		    next_attempt_at = NOW() + make_interval(secs => $2)
+		WHERE url IN (
+			SELECT url FROM link_previews
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING url, status, title, description, image_url, site_name, attempts, fetched_at, created_at
+	`
+
+	rows, err := r.pool.Query(ctx, query, limit, retryAfter.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var previews []*LinkPreview
+
+	for rows.Next() {
+		preview := &LinkPreview{}
+		err := rows.Scan(
+			&preview.URL,
+			&preview.Status,
+			&preview.Title,
+			&preview.Description,
+			&preview.ImageURL,
+			&preview.SiteName,
+			&preview.Attempts,
+			&preview.FetchedAt,
+			&preview.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		previews = append(previews, preview)
+	}
+
+	return previews, rows.Err()
+}
+
+// SaveReady stores fetched metadata for a URL
+func (r *PostgresLinkPreviewRepository) SaveReady(ctx context.Context, preview *LinkPreview) error {
+	query := `
+		UPDATE link_previews
+		SET status = 'ready', title = $2, description = $3, image_url = $4, site_name = $5, fetched_at = NOW()
+		WHERE url = $1
+		RETURNING status, fetched_at
+	`
+
+	return r.pool.QueryRow(ctx, query,
+		preview.URL,
+		preview.Title,
+		preview.Description,
+		preview.ImageURL,
+		preview.SiteName,
+	).Scan(&preview.Status, &preview.FetchedAt)
+}
+
+// MarkFailed stops retrying a URL
+func (r *PostgresLinkPreviewRepository) MarkFailed(ctx context.Context, url string) error {
+	query := `UPDATE link_previews SET status = 'failed' WHERE url = $1`
+
+	_, err := r.pool.Exec(ctx, query, url)
+	return err
+}
@@ -0,0 +1,192 @@
+package linkpreview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	previewpkg "mockhu-app-backend/internal/pkg/linkpreview"
+)
+
+// Background fetching tuning
+const (
+	FetchInterval    = 5 * time.Second // How often queued URLs are fetched
+	fetchBatchSize   = 20              // URLs claimed per job run
+	fetchConcurrency = 4               // Pages downloaded at once
+	maxFetchAttempts = 3
+	retryAfter       = 10 * time.Minute // Wait before retrying a failed fetch
+)
+
+// LinkPreviewService defines the business logic for link previews
+type LinkPreviewService interface {
+	// Enqueue queues the first URL in text for fetching in the background
+	Enqueue(ctx context.Context, text string) error
+
+	// GetPreview returns the preview for the first URL in text, or nil if there is no URL
+	// or its preview is not ready
+	GetPreview(ctx context.Context, text string) *PreviewResponse
+
+	// GetPreviews is GetPreview for many texts in one query. texts and the result are keyed
+	// by the caller's IDs; texts without a ready preview are left out.
+	GetPreviews(ctx context.Context, texts map[string]string) map[string]*PreviewResponse
+
+	// FetchPending fetches queued URLs that are due; run periodically by a background job
+	FetchPending(ctx context.Context) error
+}
+
+// linkPreviewService implements LinkPreviewService
+type linkPreviewService struct {
+	previewRepo LinkPreviewRepository
+	fetcher     *previewpkg.Fetcher
+}
+
+// NewService creates a new link preview service
+func NewService(previewRepo LinkPreviewRepository, fetcher *previewpkg.Fetcher) LinkPreviewService {
+	return &linkPreviewService{
+		previewRepo: previewRepo,
+		fetcher:     fetcher,
+	}
+}
+
+// Enqueue queues the first URL in text for fetching in the background
+func (s *linkPreviewService) Enqueue(ctx context.Context, text string) error {
+	url := previewpkg.FirstURL(text)
+	if url == "" {
+		return nil
+	}
+
+	if err := s.previewRepo.Enqueue(ctx, url); err != nil {
+		return fmt.Errorf("failed to queue link preview: %w", err)
+	}
+
+	return nil
+}
+
+// GetPreview returns the preview for the first URL in text, or nil if there is no URL
+// or its preview is not ready
+func (s *linkPreviewService) GetPreview(ctx context.Context, text string) *PreviewResponse {
+	url := previewpkg.FirstURL(text)
+	if url == "" {
+		return nil
+	}
+
+	preview, err := s.previewRepo.GetByURL(ctx, url)
+	if err != nil || preview == nil || preview.Status != StatusReady {
+		return nil
+	}
+
+	return toResponse(preview)
+}
+
+// GetPreviews is GetPreview for many texts in one query
+func (s *linkPreviewService) GetPreviews(ctx context.Context, texts map[string]string) map[string]*PreviewResponse {
+	result := make(map[string]*PreviewResponse)
+
+	urls := make(map[string]string, len(texts))
+	seen := make(map[string]bool, len(texts))
+	unique := make([]string, 0, len(texts))
+	for id, text := range texts {
+		url := previewpkg.FirstURL(text)
+		if url == "" {
+			continue
+		}
+		if !seen[url] {
+			seen[url] = true
+			unique = append(unique, url)
+		}
+		urls[id] = url
+	}
+	if len(unique) == 0 {
+		return result
+	}
+
+	previews, err := s.previewRepo.GetReadyByURLs(ctx, unique)
+	if err != nil {
+		log.Printf("⚠️ Failed to get link previews: %v", err)
+		return result
+	}
+
+	byURL := make(map[string]*LinkPreview, len(previews))
+	for _, preview := range previews {
+		byURL[preview.URL] = preview
+	}
+	for id, url := range urls {
+		if preview, ok := byURL[url]; ok {
+			result[id] = toResponse(preview)
+		}
+	}
+
+	return result
+}
+
+// FetchPending fetches queued URLs that are due; run periodically by a background job
+func (s *linkPreviewService) FetchPending(ctx context.Context) error {
+	previews, err := s.previewRepo.ClaimDue(ctx, fetchBatchSize, retryAfter)
+	if err != nil {
+		return fmt.Errorf("failed to claim link previews: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, fetchConcurrency)
+
+	for _, preview := range previews {
+		wg.Add(1)
+		slots <- struct{}{}
+
+		go func(preview *LinkPreview) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			s.fetch(ctx, preview)
+		}(preview)
+	}
+
+	wg.Wait()
+	return nil
+}
+
+// Helper methods
+
+// toResponse converts a ready preview to its response
+func toResponse(preview *LinkPreview) *PreviewResponse {
+	return &PreviewResponse{
+		URL:         preview.URL,
+		Title:       preview.Title,
+		Description: preview.Description,
+		ImageURL:    preview.ImageURL,
+		SiteName:    preview.SiteName,
+	}
+}
+
+// fetch downloads one claimed URL and stores its metadata. Pages that can never have a
+// preview fail immediately; other errors are retried until maxFetchAttempts.
+func (s *linkPreviewService) fetch(ctx context.Context, preview *LinkPreview) {
+	metadata, err := s.fetcher.Fetch(ctx, preview.URL)
+	if err != nil {
+		if isPermanent(err) || preview.Attempts >= maxFetchAttempts {
+			if err := s.previewRepo.MarkFailed(ctx, preview.URL); err != nil {
+				log.Printf("⚠️ Failed to mark link preview failed for %s: %v", preview.URL, err)
+			}
+		}
+		return
+	}
+
+	preview.Title = metadata.Title
+	preview.Description = metadata.Description
+	preview.ImageURL = metadata.ImageURL
+	preview.SiteName = metadata.SiteName
+
+	if err := s.previewRepo.SaveReady(ctx, preview); err != nil {
+		log.Printf("⚠️ Failed to save link preview for %s: %v", preview.URL, err)
+	}
+}
+
+// isPermanent reports whether a fetch error will not go away on retry
+func isPermanent(err error) bool {
+	return errors.Is(err, previewpkg.ErrInvalidURL) ||
+		errors.Is(err, previewpkg.ErrBlockedAddress) ||
+		errors.Is(err, previewpkg.ErrNotHTML) ||
+		errors.Is(err, previewpkg.ErrNoMetadata)
+}
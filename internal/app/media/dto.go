@@ -0,0 +1,24 @@
+package media
+
+// MediaResponse is the response for an uploaded image
+type MediaResponse struct {
+	ID     string            `json:"id"`
+	Width  int               `json:"width"`
+	Height int               `json:"height"`
+	URLs   map[string]string `json:"urls"` // Variant name (thumb, medium, large) -> URL
+}
+
+// PostMediaInput references an uploaded image when creating or editing a post
+type PostMediaInput struct {
+	MediaID string `json:"media_id"`
+	AltText string `json:"alt_text"`
+}
+
+// PostMediaResponse is an image attached to a post
+type PostMediaResponse struct {
+	ID      string            `json:"id"`
+	AltText string            `json:"alt_text"`
+	Width   int               `json:"width"`
+	Height  int               `json:"height"`
+	URLs    map[string]string `json:"urls"`
+}
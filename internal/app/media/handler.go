@@ -0,0 +1,81 @@
+package media
+
+import (
+	"errors"
+	"io"
+
+	mediapkg "mockhu-app-backend/internal/pkg/media"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Handler handles HTTP requests for media operations
+type Handler struct {
+	service MediaService
+}
+
+// NewHandler creates a new media handler
+func NewHandler(service MediaService) *Handler {
+	return &Handler{service: service}
+}
+
+// Upload handles POST /v1/media (multipart form field "file")
+func (h *Handler) Upload(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	// Parse multipart form
+	file, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "file is required",
+		})
+	}
+
+	if file.Size > mediapkg.MaxFileSize {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": mediapkg.ErrFileTooBig.Error(),
+		})
+	}
+
+	// Open and read file
+	fileHandle, err := file.Open()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to read file",
+		})
+	}
+	defer fileHandle.Close()
+
+	fileBytes, err := io.ReadAll(fileHandle)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to read file",
+		})
+	}
+
+	// Process and store
+	response, err := h.service.Upload(c.Context(), currentUserID, fileBytes)
+	if err != nil {
+		if err == mediapkg.ErrFileTooBig || err == mediapkg.ErrInvalidFileType || err == mediapkg.ErrImageTooLarge {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if errors.Is(err, mediapkg.ErrProcessingFailed) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "file is not a valid image",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to upload media",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
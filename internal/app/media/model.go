@@ -0,0 +1,31 @@
+package media
+
+import "time"
+
+// Limits
+const (
+	MaxAltTextLength = 1000
+)
+
+// Media represents an uploaded image. Its size variants are stored under its ID.
+type Media struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Width     int       `json:"width"`
+	Height    int       `json:"height"`
+	SizeBytes int       `json:"size_bytes"` // Size of the original upload
+	CreatedAt time.Time `json:"created_at"`
+
+	AttachedPostID *string `json:"attached_post_id,omitempty"` // Post using this media, if any
+	InStory        bool    `json:"in_story,omitempty"`         // Used by a story, deleted when it expires
+}
+
+// PostMedia links an uploaded image to a post
+type PostMedia struct {
+	PostID   string `json:"post_id"`
+	MediaID  string `json:"media_id"`
+	Position int    `json:"position"`
+	AltText  string `json:"alt_text"`
+	Width    int    `json:"width"`
+	Height   int    `json:"height"`
+}
@@ -0,0 +1,21 @@
+package media
+
+import "context"
+
+// MediaRepository defines the interface for media data operations
+type MediaRepository interface {
+	// Create stores an uploaded image
+	Create(ctx context.Context, media *Media) error
+
+	// FindByIDs retrieves uploaded images along with the post or story each is attached to
+	FindByIDs(ctx context.Context, ids []string) ([]*Media, error)
+
+	// ReplacePostMedia makes items the exact, ordered set of images attached to a post
+	ReplacePostMedia(ctx context.Context, postID string, items []*PostMedia) error
+
+	// GetByPostID retrieves the images attached to a post in display order
+	GetByPostID(ctx context.Context, postID string) ([]*PostMedia, error)
+
+	// GetByPostIDs retrieves the images attached to many posts, each post's in display order
+	GetByPostIDs(ctx context.Context, postIDs []string) ([]*PostMedia, error)
+}
@@ -0,0 +1,148 @@
+package media
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresMediaRepository implements MediaRepository for PostgreSQL
+type PostgresMediaRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresMediaRepository creates a new PostgreSQL media repository
+func NewPostgresMediaRepository(pool *pgxpool.Pool) *PostgresMediaRepository {
+	return &PostgresMediaRepository{pool: pool}
+}
+
+// Create stores an uploaded image
+func (r *PostgresMediaRepository) Create(ctx context.Context, media *Media) error {
+	query := `
+		INSERT INTO media (id, user_id, width, height, size_bytes)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at
+	`
+
+	return r.pool.QueryRow(ctx, query, media.ID, media.UserID, media.Width, media.Height, media.SizeBytes).
+		Scan(&media.CreatedAt)
+}
+
+// FindByIDs retrieves uploaded images along with the post or story each is attached to
+func (r *PostgresMediaRepository) FindByIDs(ctx context.Context, ids []string) ([]*Media, error) {
+	query := `
+		SELECT m.id, m.user_id, m.width, m.height, m.size_bytes, m.created_at, pm.post_id,
+		       EXISTS (SELECT 1 FROM stories s WHERE s.media_id = m.id)
+		FROM media m
+		LEFT JOIN post_media pm ON pm.media_id = m.id
+		WHERE m.id = ANY($1::uuid[])
+	`
+
+	rows, err := r.pool.Query(ctx, query, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*Media
+
+	for rows.Next() {
+		m := &Media{}
+		err := rows.Scan(
+			&m.ID,
+			&m.UserID,
+			&m.Width,
+			&m.Height,
+			&m.SizeBytes,
+			&m.CreatedAt,
+			&m.AttachedPostID,
+			&m.InStory,
+		)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, m)
+	}
+
+	return items, rows.Err()
+}
+
+// ReplacePostMedia replaces the images attached to a post in a single transaction
+func (r *PostgresMediaRepository) ReplacePostMedia(ctx context.Context, postID string, items []*PostMedia) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM post_media WHERE post_id = $1`, postID); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO post_media (post_id, media_id, position, alt_text, width, height)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	for _, item := range items {
+		_, err := tx.Exec(ctx, query, postID, item.MediaID, item.Position, item.AltText, item.Width, item.Height)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetByPostID retrieves the images attached to a post in display order
+func (r *PostgresMediaRepository) GetByPostID(ctx context.Context, postID string) ([]*PostMedia, error) {
+	query := `
+		SELECT post_id, media_id, position, alt_text, width, height
+		FROM post_media
+		WHERE post_id = $1
+		ORDER BY position
+	`
+
+	return r.queryPostMedia(ctx, query, postID)
+}
+
+// GetByPostIDs retrieves the images attached to many posts, each post's in display order
+func (r *PostgresMediaRepository) GetByPostIDs(ctx context.Context, postIDs []string) ([]*PostMedia, error) {
+	query := `
+		SELECT post_id, media_id, position, alt_text, width, height
+		FROM post_media
+		WHERE post_id = ANY($1::uuid[])
+		ORDER BY post_id, position
+	`
+
+	return r.queryPostMedia(ctx, query, postIDs)
+}
+
+// queryPostMedia runs a post media query and scans the rows
+func (r *PostgresMediaRepository) queryPostMedia(ctx context.Context, query string, args ...interface{}) ([]*PostMedia, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*PostMedia
+
+	for rows.Next() {
+		item := &PostMedia{}
+		err := rows.Scan(
+			&item.PostID,
+			&item.MediaID,
+			&item.Position,
+			&item.AltText,
+			&item.Width,
+			&item.Height,
+		)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
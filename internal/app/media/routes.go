@@ -0,0 +1,15 @@
+package media
+
+import (
+	"mockhu-app-backend/internal/pkg/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterRoutes registers all media routes
+func RegisterRoutes(app *fiber.App, handler *Handler) {
+	v1 := app.Group("/v1")
+
+	// Upload an image for use in posts (auth required)
+	v1.Post("/media", middleware.AuthMiddleware(), handler.Upload)
+}
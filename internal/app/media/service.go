@@ -0,0 +1,208 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	mediapkg "mockhu-app-backend/internal/pkg/media"
+
+	"github.com/google/uuid"
+)
+
+// Errors
+var (
+	ErrInvalidMedia   = errors.New("media not found or not owned by user")
+	ErrMediaInUse     = errors.New("media is attached to another post or story")
+	ErrDuplicateMedia = errors.New("media used more than once")
+	ErrAltTextTooLong = errors.New("alt text too long")
+)
+
+// MediaService defines the business logic for media operations
+type MediaService interface {
+	// Upload processes an image into its size variants and stores it for userID
+	Upload(ctx context.Context, userID string, fileBytes []byte) (*MediaResponse, error)
+
+	// PrepareForPost checks that every input refers to an image owned by userID that is not
+	// attached to a story or a post other than postID (empty for a new post or a story), and
+	// returns the links to save
+	PrepareForPost(ctx context.Context, userID, postID string, inputs []PostMediaInput) ([]*PostMedia, error)
+
+	// AttachToPost saves prepared links for a post, replacing any previous ones
+	AttachToPost(ctx context.Context, postID string, items []*PostMedia) error
+
+	// GetPostMedia retrieves the images attached to a post
+	GetPostMedia(ctx context.Context, postID string) ([]*PostMediaResponse, error)
+
+	// GetPostsMedia retrieves the images attached to many posts in one query, keyed by post ID
+	GetPostsMedia(ctx context.Context, postIDs []string) (map[string][]*PostMediaResponse, error)
+}
+
+// mediaService implements MediaService
+type mediaService struct {
+	mediaRepo MediaRepository
+}
+
+// NewService creates a new media service
+func NewService(mediaRepo MediaRepository) MediaService {
+	return &mediaService{
+		mediaRepo: mediaRepo,
+	}
+}
+
+// Upload processes an image into its size variants and stores it for userID
+func (s *mediaService) Upload(ctx context.Context, userID string, fileBytes []byte) (*MediaResponse, error) {
+	id := uuid.New().String()
+
+	// Resize into variants and strip metadata
+	result, err := mediapkg.ProcessAndSave(fileBytes, id)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Media{
+		ID:        id,
+		UserID:    userID,
+		Width:     result.Width,
+		Height:    result.Height,
+		SizeBytes: len(fileBytes),
+	}
+
+	if err := s.mediaRepo.Create(ctx, m); err != nil {
+		_ = mediapkg.Delete(id)
+		return nil, fmt.Errorf("failed to save media: %w", err)
+	}
+
+	return &MediaResponse{
+		ID:     m.ID,
+		Width:  m.Width,
+		Height: m.Height,
+		URLs:   VariantURLs(m.ID),
+	}, nil
+}
+
+// PrepareForPost validates the images referenced by a post
+func (s *mediaService) PrepareForPost(ctx context.Context, userID, postID string, inputs []PostMediaInput) ([]*PostMedia, error) {
+	if len(inputs) == 0 {
+		return []*PostMedia{}, nil
+	}
+
+	ids := make([]string, 0, len(inputs))
+	seen := make(map[string]bool, len(inputs))
+	for _, in := range inputs {
+		if _, err := uuid.Parse(in.MediaID); err != nil {
+			return nil, ErrInvalidMedia
+		}
+		if seen[in.MediaID] {
+			return nil, ErrDuplicateMedia
+		}
+		if len([]rune(in.AltText)) > MaxAltTextLength {
+			return nil, ErrAltTextTooLong
+		}
+		seen[in.MediaID] = true
+		ids = append(ids, in.MediaID)
+	}
+
+	found, err := s.mediaRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get media: %w", err)
+	}
+
+	byID := make(map[string]*Media, len(found))
+	for _, m := range found {
+		byID[m.ID] = m
+	}
+
+	items := make([]*PostMedia, 0, len(inputs))
+	for i, in := range inputs {
+		m, ok := byID[in.MediaID]
+		if !ok || m.UserID != userID {
+			return nil, ErrInvalidMedia
+		}
+		if (m.AttachedPostID != nil && *m.AttachedPostID != postID) || m.InStory {
+			return nil, ErrMediaInUse
+		}
+
+		items = append(items, &PostMedia{
+			PostID:   postID,
+			MediaID:  m.ID,
+			Position: i,
+			AltText:  in.AltText,
+			Width:    m.Width,
+			Height:   m.Height,
+		})
+	}
+
+	return items, nil
+}
+
+// AttachToPost saves prepared links for a post, replacing any previous ones
+func (s *mediaService) AttachToPost(ctx context.Context, postID string, items []*PostMedia) error {
+	for _, item := range items {
+		item.PostID = postID
+	}
+
+	if err := s.mediaRepo.ReplacePostMedia(ctx, postID, items); err != nil {
+		return fmt.Errorf("failed to attach media: %w", err)
+	}
+
+	return nil
+}
+
+// GetPostMedia retrieves the images attached to a post
+func (s *mediaService) GetPostMedia(ctx context.Context, postID string) ([]*PostMediaResponse, error) {
+	items, err := s.mediaRepo.GetByPostID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post media: %w", err)
+	}
+
+	responses := make([]*PostMediaResponse, 0, len(items))
+	for _, item := range items {
+		responses = append(responses, toPostMediaResponse(item))
+	}
+
+	return responses, nil
+}
+
+// GetPostsMedia retrieves the images attached to many posts in one query, keyed by post ID.
+// Posts without images are left out.
+func (s *mediaService) GetPostsMedia(ctx context.Context, postIDs []string) (map[string][]*PostMediaResponse, error) {
+	items, err := s.mediaRepo.GetByPostIDs(ctx, postIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post media: %w", err)
+	}
+
+	responses := make(map[string][]*PostMediaResponse)
+	for _, item := range items {
+		responses[item.PostID] = append(responses[item.PostID], toPostMediaResponse(item))
+	}
+
+	return responses, nil
+}
+
+// Helper methods
+
+// toPostMediaResponse converts an attached image to its response
+func toPostMediaResponse(item *PostMedia) *PostMediaResponse {
+	return &PostMediaResponse{
+		ID:      item.MediaID,
+		AltText: item.AltText,
+		Width:   item.Width,
+		Height:  item.Height,
+		URLs:    VariantURLs(item.MediaID),
+	}
+}
+
+// VariantURLs returns the URL of every size variant of an image
+func VariantURLs(mediaID string) map[string]string {
+	urls := make(map[string]string, len(mediapkg.Variants))
+	for _, v := range mediapkg.Variants {
+		urls[v.Name] = mediapkg.URL(mediaID, v.Name)
+	}
+	return urls
+}
+
+// LargeURL returns the URL of the largest variant of an image
+func LargeURL(mediaID string) string {
+	return mediapkg.URL(mediaID, mediapkg.VariantLarge)
+}
@@ -0,0 +1,96 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// fakeMediaRepo serves stored media by ID
+type fakeMediaRepo struct {
+	MediaRepository
+
+	media map[string]*Media
+}
+
+func (r *fakeMediaRepo) FindByIDs(ctx context.Context, ids []string) ([]*Media, error) {
+	found := make([]*Media, 0, len(ids))
+	for _, id := range ids {
+		if m, ok := r.media[id]; ok {
+			found = append(found, m)
+		}
+	}
+	return found, nil
+}
+
+func TestPrepareForPost(t *testing.T) {
+	postID := uuid.NewString()
+	otherPostID := uuid.NewString()
+
+	free := uuid.NewString()
+	second := uuid.NewString()
+	othersMedia := uuid.NewString()
+	onThisPost := uuid.NewString()
+	onOtherPost := uuid.NewString()
+	inStory := uuid.NewString()
+
+	repo := &fakeMediaRepo{media: map[string]*Media{
+		free:        {ID: free, UserID: "owner", Width: 800, Height: 600},
+		second:      {ID: second, UserID: "owner", Width: 600, Height: 800},
+		othersMedia: {ID: othersMedia, UserID: "someone-else"},
+		onThisPost:  {ID: onThisPost, UserID: "owner", AttachedPostID: &postID},
+		onOtherPost: {ID: onOtherPost, UserID: "owner", AttachedPostID: &otherPostID},
+		inStory:     {ID: inStory, UserID: "owner", InStory: true},
+	}}
+	s := NewService(repo)
+
+	tests := []struct {
+		name    string
+		postID  string // Empty for a new post
+		inputs  []PostMediaInput
+		wantErr error
+	}{
+		{name: "no media"},
+		{name: "new post", inputs: []PostMediaInput{{MediaID: free, AltText: "a cat"}, {MediaID: second}}},
+		{name: "edit keeps its own media", postID: postID, inputs: []PostMediaInput{{MediaID: onThisPost}, {MediaID: free}}},
+		{name: "invalid ID", inputs: []PostMediaInput{{MediaID: "nope"}}, wantErr: ErrInvalidMedia},
+		{name: "unknown media", inputs: []PostMediaInput{{MediaID: uuid.NewString()}}, wantErr: ErrInvalidMedia},
+		{name: "another user's media", inputs: []PostMediaInput{{MediaID: free}, {MediaID: othersMedia}}, wantErr: ErrInvalidMedia},
+		{name: "attached to another post", inputs: []PostMediaInput{{MediaID: onOtherPost}}, wantErr: ErrMediaInUse},
+		{name: "attached to a post, on a new post", inputs: []PostMediaInput{{MediaID: onThisPost}}, wantErr: ErrMediaInUse},
+		{name: "edit takes another post's media", postID: postID, inputs: []PostMediaInput{{MediaID: onOtherPost}}, wantErr: ErrMediaInUse},
+		{name: "used by a story", inputs: []PostMediaInput{{MediaID: inStory}}, wantErr: ErrMediaInUse},
+		{name: "same media twice", inputs: []PostMediaInput{{MediaID: free}, {MediaID: free}}, wantErr: ErrDuplicateMedia},
+		{
+			name:    "alt text too long",
+			inputs:  []PostMediaInput{{MediaID: free, AltText: strings.Repeat("é", MaxAltTextLength+1)}},
+			wantErr: ErrAltTextTooLong,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := s.PrepareForPost(context.Background(), "owner", tt.postID, tt.inputs)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PrepareForPost: err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if len(items) != len(tt.inputs) {
+				t.Fatalf("got %d items, want %d", len(items), len(tt.inputs))
+			}
+			for i, item := range items {
+				m := repo.media[tt.inputs[i].MediaID]
+				if item.MediaID != m.ID || item.PostID != tt.postID || item.Position != i || item.AltText != tt.inputs[i].AltText ||
+					item.Width != m.Width || item.Height != m.Height {
+					t.Errorf("item %d = %+v, want media %s at position %d", i, item, m.ID, i)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package mention
+
+import "time"
+
+// Mention tuning
+const (
+	maxMentionsPerContent = 10 // Usernames resolved per post or comment
+)
+
+// Mention represents a user mentioned in a post or comment
+type Mention struct {
+	ID              string    `json:"id"`
+	PostID          string    `json:"post_id"`
+	CommentID       *string   `json:"comment_id,omitempty"` // Nil for mentions in the post itself
+	AuthorID        string    `json:"author_id"`
+	MentionedUserID string    `json:"mentioned_user_id"`
+	Username        string    `json:"username"` // As written in the content
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// Entity is a resolved mention in content, with character (rune) offsets so clients
+// can render it as a link. End is exclusive and the range includes the leading @.
+type Entity struct {
+	Username string `json:"username"`
+	UserID   string `json:"user_id"`
+	Start    int    `json:"start"`
+	End      int    `json:"end"`
+}
@@ -0,0 +1,27 @@
+package mention
+
+import "context"
+
+// MentionRepository defines the interface for mention data operations
+type MentionRepository interface {
+	// ReplaceMentions makes mentions the exact set of mentions for a post (commentID nil)
+	// or comment, and returns the mentions that did not exist before
+	ReplaceMentions(ctx context.Context, postID string, commentID *string, mentions []*Mention) ([]*Mention, error)
+
+	// GetByPostID retrieves the mentions in a post's own content
+	GetByPostID(ctx context.Context, postID string) ([]*Mention, error)
+
+	// GetByCommentID retrieves the mentions in a comment
+	GetByCommentID(ctx context.Context, commentID string) ([]*Mention, error)
+
+	// GetByPostIDs retrieves the mentions in the own content of many posts
+	GetByPostIDs(ctx context.Context, postIDs []string) ([]*Mention, error)
+
+	// GetByCommentIDs retrieves the mentions in many comments
+	GetByCommentIDs(ctx context.Context, commentIDs []string) ([]*Mention, error)
+
+	// FilterNotifiable returns the users in userIDs that may be notified about content by
+	// authorID: they are active, neither user has blocked the other, and they can see the
+	// author's posts
+	FilterNotifiable(ctx context.Context, authorID string, userIDs []string) ([]string, error)
+}
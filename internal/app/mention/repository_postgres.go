@@ -0,0 +1,186 @@
+package mention
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresMentionRepository implements MentionRepository for PostgreSQL
+type PostgresMentionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresMentionRepository creates a new PostgreSQL mention repository
+func NewPostgresMentionRepository(pool *pgxpool.Pool) *PostgresMentionRepository {
+	return &PostgresMentionRepository{pool: pool}
+}
+
+// ReplaceMentions replaces the mentions of a post or comment in a single transaction
+func (r *PostgresMentionRepository) ReplaceMentions(ctx context.Context, postID string, commentID *string, mentions []*Mention) ([]*Mention, error) {
+	userIDs := make([]string, 0, len(mentions))
+	for _, m := range mentions {
+		userIDs = append(userIDs, m.MentionedUserID)
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Drop mentions no longer in the content
+	_, err = tx.Exec(ctx, `
+		DELETE FROM mentions
+		WHERE post_id = $1
+		  AND comment_id IS NOT DISTINCT FROM $2::uuid
+		  AND NOT (mentioned_user_id = ANY($3::uuid[]))
+	`, postID, commentID, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Insert new mentions; existing ones hit the unique index and are skipped
+	query := `
+		INSERT INTO mentions (post_id, comment_id, author_id, mentioned_user_id, username)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT DO NOTHING
+		RETURNING id, created_at
+	`
+
+	var added []*Mention
+	for _, m := range mentions {
+		err := tx.QueryRow(ctx, query, postID, commentID, m.AuthorID, m.MentionedUserID, m.Username).
+			Scan(&m.ID, &m.CreatedAt)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue // Already mentioned
+			}
+			return nil, err
+		}
+		added = append(added, m)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return added, nil
+}
+
+// GetByPostID retrieves the mentions in a post's own content
+func (r *PostgresMentionRepository) GetByPostID(ctx context.Context, postID string) ([]*Mention, error) {
+	query := `
+		SELECT id, post_id, comment_id, author_id, mentioned_user_id, username, created_at
+		FROM mentions
+		WHERE post_id = $1 AND comment_id IS NULL
+	`
+
+	return r.query(ctx, query, postID)
+}
+
+// GetByCommentID retrieves the mentions in a comment
+func (r *PostgresMentionRepository) GetByCommentID(ctx context.Context, commentID string) ([]*Mention, error) {
+	query := `
+		SELECT id, post_id, comment_id, author_id, mentioned_user_id, username, created_at
+		FROM mentions
+		WHERE comment_id = $1
+	`
+
+	return r.query(ctx, query, commentID)
+}
+
+// GetByPostIDs retrieves the mentions in the own content of many posts
+func (r *PostgresMentionRepository) GetByPostIDs(ctx context.Context, postIDs []string) ([]*Mention, error) {
+	query := `
+		SELECT id, post_id, comment_id, author_id, mentioned_user_id, username, created_at
+		FROM mentions
+		WHERE post_id = ANY($1::uuid[]) AND comment_id IS NULL
+	`
+
+	return r.query(ctx, query, postIDs)
+}
+
+// GetByCommentIDs retrieves the mentions in many comments
+func (r *PostgresMentionRepository) GetByCommentIDs(ctx context.Context, commentIDs []string) ([]*Mention, error) {
+	query := `
+		SELECT id, post_id, comment_id, author_id, mentioned_user_id, username, created_at
+		FROM mentions
+		WHERE comment_id = ANY($1::uuid[])
+	`
+
+	return r.query(ctx, query, commentIDs)
+}
+
+// FilterNotifiable returns the users in userIDs that may be notified about authorID's content
+func (r *PostgresMentionRepository) FilterNotifiable(ctx context.Context, authorID string, userIDs []string) ([]string, error) {
+	query := `
+		SELECT m.id
+		FROM users m
+		JOIN users a ON a.id = $1
+		WHERE m.id = ANY($2::uuid[])
+		  AND m.is_active = true
+		  AND NOT EXISTS (
+			SELECT 1 FROM blocked_users b
+			WHERE (b.blocker_id = m.id AND b.blocked_id = a.id)
+			   OR (b.blocker_id = a.id AND b.blocked_id = m.id)
+		  )
+		  AND (
+			a.who_can_see_posts = 'everyone'
+			OR (a.who_can_see_posts = 'followers' AND EXISTS (
+				SELECT 1 FROM user_follows f
+				WHERE f.follower_id = m.id AND f.following_id = a.id
+			))
+		  )
+	`
+
+	rows, err := r.pool.Query(ctx, query, authorID, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notifiable []string
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		notifiable = append(notifiable, id)
+	}
+
+	return notifiable, rows.Err()
+}
+
+// query runs a mention query and scans the rows
+func (r *PostgresMentionRepository) query(ctx context.Context, query string, args ...interface{}) ([]*Mention, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var mentions []*Mention
+
+	for rows.Next() {
+		m := &Mention{}
+		err := rows.Scan(
+			&m.ID,
+			&m.PostID,
+			&m.CommentID,
+			&m.AuthorID,
+			&m.MentionedUserID,
+			&m.Username,
+			&m.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		mentions = append(mentions, m)
+	}
+
+	return mentions, rows.Err()
+}
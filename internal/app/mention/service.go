@@ -0,0 +1,229 @@
+package mention
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"mockhu-app-backend/internal/app/auth"
+	"mockhu-app-backend/internal/app/notification"
+	"mockhu-app-backend/internal/pkg/entities"
+)
+
+// MentionService defines the business logic for @mentions
+type MentionService interface {
+	// SyncPostMentions resolves the mentions in a post and notifies newly mentioned users
+	SyncPostMentions(ctx context.Context, postID, authorID, content string, isAnonymous bool) error
+
+	// SyncCommentMentions resolves the mentions in a comment and notifies newly mentioned users
+	SyncCommentMentions(ctx context.Context, postID, commentID, authorID, content string, isAnonymous bool) error
+
+	// GetPostEntities returns the resolved mention entities in a post's content
+	GetPostEntities(ctx context.Context, postID, content string) []Entity
+
+	// GetCommentEntities returns the resolved mention entities in a comment's content
+	GetCommentEntities(ctx context.Context, commentID, content string) []Entity
+
+	// GetPostsEntities returns the resolved mention entities of many posts in one query.
+	// contents maps post IDs to their content.
+	GetPostsEntities(ctx context.Context, contents map[string]string) map[string][]Entity
+
+	// GetCommentsEntities returns the resolved mention entities of many comments in one
+	// query. contents maps comment IDs to their content.
+	GetCommentsEntities(ctx context.Context, contents map[string]string) map[string][]Entity
+}
+
+// mentionService implements MentionService
+type mentionService struct {
+	mentionRepo         MentionRepository
+	userRepo            auth.UserRepository
+	notificationService notification.NotificationService
+}
+
+// NewService creates a new mention service
+func NewService(mentionRepo MentionRepository, userRepo auth.UserRepository, notificationService notification.NotificationService) MentionService {
+	return &mentionService{
+		mentionRepo:         mentionRepo,
+		userRepo:            userRepo,
+		notificationService: notificationService,
+	}
+}
+
+// SyncPostMentions resolves the mentions in a post and notifies newly mentioned users
+func (s *mentionService) SyncPostMentions(ctx context.Context, postID, authorID, content string, isAnonymous bool) error {
+	return s.sync(ctx, postID, nil, authorID, content, isAnonymous)
+}
+
+// SyncCommentMentions resolves the mentions in a comment and notifies newly mentioned users
+func (s *mentionService) SyncCommentMentions(ctx context.Context, postID, commentID, authorID, content string, isAnonymous bool) error {
+	return s.sync(ctx, postID, &commentID, authorID, content, isAnonymous)
+}
+
+// GetPostEntities returns the resolved mention entities in a post's content
+func (s *mentionService) GetPostEntities(ctx context.Context, postID, content string) []Entity {
+	mentions, err := s.mentionRepo.GetByPostID(ctx, postID)
+	if err != nil {
+		return []Entity{}
+	}
+	return buildEntities(content, mentions)
+}
+
+// GetCommentEntities returns the resolved mention entities in a comment's content
+func (s *mentionService) GetCommentEntities(ctx context.Context, commentID, content string) []Entity {
+	mentions, err := s.mentionRepo.GetByCommentID(ctx, commentID)
+	if err != nil {
+		return []Entity{}
+	}
+	return buildEntities(content, mentions)
+}
+
+// GetPostsEntities returns the resolved mention entities of many posts in one query
+func (s *mentionService) GetPostsEntities(ctx context.Context, contents map[string]string) map[string][]Entity {
+	if len(contents) == 0 {
+		return map[string][]Entity{}
+	}
+
+	mentions, err := s.mentionRepo.GetByPostIDs(ctx, keys(contents))
+	if err != nil {
+		log.Printf("⚠️ Failed to get post mentions: %v", err)
+		mentions = nil
+	}
+
+	byPost := make(map[string][]*Mention, len(contents))
+	for _, m := range mentions {
+		byPost[m.PostID] = append(byPost[m.PostID], m)
+	}
+	return buildEntitiesByID(contents, byPost)
+}
+
+// GetCommentsEntities returns the resolved mention entities of many comments in one query
+func (s *mentionService) GetCommentsEntities(ctx context.Context, contents map[string]string) map[string][]Entity {
+	if len(contents) == 0 {
+		return map[string][]Entity{}
+	}
+
+	mentions, err := s.mentionRepo.GetByCommentIDs(ctx, keys(contents))
+	if err != nil {
+		log.Printf("⚠️ Failed to get comment mentions: %v", err)
+		mentions = nil
+	}
+
+	byComment := make(map[string][]*Mention, len(contents))
+	for _, m := range mentions {
+		if m.CommentID != nil {
+			byComment[*m.CommentID] = append(byComment[*m.CommentID], m)
+		}
+	}
+	return buildEntitiesByID(contents, byComment)
+}
+
+// Helper methods
+
+// sync stores the mentions in content and notifies users mentioned for the first time,
+// so editing a post does not notify the same user twice
+func (s *mentionService) sync(ctx context.Context, postID string, commentID *string, authorID, content string, isAnonymous bool) error {
+	usernames := entities.MentionValues(content)
+	if len(usernames) > maxMentionsPerContent {
+		usernames = usernames[:maxMentionsPerContent]
+	}
+
+	// Resolve usernames, ignoring ones that don't belong to an active user
+	mentions := make([]*Mention, 0, len(usernames))
+	for _, username := range usernames {
+		user, err := s.userRepo.FindByUsername(ctx, username)
+		if err != nil || user == nil || !user.IsActive {
+			continue
+		}
+		mentions = append(mentions, &Mention{
+			PostID:          postID,
+			CommentID:       commentID,
+			AuthorID:        authorID,
+			MentionedUserID: user.ID,
+			Username:        username,
+		})
+	}
+
+	added, err := s.mentionRepo.ReplaceMentions(ctx, postID, commentID, mentions)
+	if err != nil {
+		return fmt.Errorf("failed to save mentions: %w", err)
+	}
+
+	// Notify new mentions, respecting blocks and the author's post visibility
+	candidates := make([]string, 0, len(added))
+	for _, m := range added {
+		if m.MentionedUserID != authorID {
+			candidates = append(candidates, m.MentionedUserID)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	notifiable, err := s.mentionRepo.FilterNotifiable(ctx, authorID, candidates)
+	if err != nil {
+		return fmt.Errorf("failed to check mention privacy: %w", err)
+	}
+
+	// Don't reveal who wrote anonymous content
+	var actorID *string
+	if !isAnonymous {
+		actorID = &authorID
+	}
+
+	for _, userID := range notifiable {
+		err := s.notificationService.Notify(ctx, &notification.Notification{
+			UserID:    userID,
+			ActorID:   actorID,
+			Type:      notification.TypeMention,
+			PostID:    &postID,
+			CommentID: commentID,
+		})
+		if err != nil {
+			log.Printf("⚠️ Failed to send mention notification: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// buildEntities matches the mentions written in content against the stored mentions
+func buildEntities(content string, mentions []*Mention) []Entity {
+	userIDs := make(map[string]string, len(mentions))
+	for _, m := range mentions {
+		userIDs[m.Username] = m.MentionedUserID
+	}
+
+	result := make([]Entity, 0, len(mentions))
+	for _, e := range entities.ExtractMentions(content) {
+		userID, ok := userIDs[e.Value]
+		if !ok {
+			continue // Not a known user
+		}
+		result = append(result, Entity{
+			Username: e.Value,
+			UserID:   userID,
+			Start:    e.Start,
+			End:      e.End,
+		})
+	}
+
+	return result
+}
+
+// buildEntitiesByID builds the entities of each piece of content from its mentions
+func buildEntitiesByID(contents map[string]string, mentions map[string][]*Mention) map[string][]Entity {
+	result := make(map[string][]Entity, len(contents))
+	for id, content := range contents {
+		result[id] = buildEntities(content, mentions[id])
+	}
+	return result
+}
+
+// keys returns the keys of a map
+func keys(m map[string]string) []string {
+	ids := make([]string, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	return ids
+}
@@ -0,0 +1,129 @@
+package mention
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"mockhu-app-backend/internal/app/auth"
+	"mockhu-app-backend/internal/app/notification"
+)
+
+// fakeMentionRepo treats every mention as new and every user as notifiable except the
+// ones in blocked
+type fakeMentionRepo struct {
+	MentionRepository
+
+	saved   []*Mention
+	blocked map[string]bool
+}
+
+func (r *fakeMentionRepo) ReplaceMentions(ctx context.Context, postID string, commentID *string, mentions []*Mention) ([]*Mention, error) {
+	r.saved = mentions
+	return mentions, nil
+}
+
+func (r *fakeMentionRepo) FilterNotifiable(ctx context.Context, authorID string, userIDs []string) ([]string, error) {
+	var notifiable []string
+	for _, id := range userIDs {
+		if !r.blocked[id] {
+			notifiable = append(notifiable, id)
+		}
+	}
+	return notifiable, nil
+}
+
+// fakeUserRepo resolves usernames to users with ID "id-<username>"
+type fakeUserRepo struct {
+	auth.UserRepository
+
+	inactive map[string]bool
+}
+
+func (r *fakeUserRepo) FindByUsername(ctx context.Context, username string) (*auth.User, error) {
+	if username == "nobody" {
+		return nil, nil
+	}
+	return &auth.User{ID: "id-" + username, Username: username, IsActive: !r.inactive[username]}, nil
+}
+
+type fakeNotificationService struct {
+	notification.NotificationService
+
+	sent []*notification.Notification
+}
+
+func (s *fakeNotificationService) Notify(ctx context.Context, n *notification.Notification) error {
+	s.sent = append(s.sent, n)
+	return nil
+}
+
+func TestSyncPostMentions(t *testing.T) {
+	repo := &fakeMentionRepo{blocked: map[string]bool{"id-blocked": true}}
+	users := &fakeUserRepo{inactive: map[string]bool{"gone": true}}
+	notifications := &fakeNotificationService{}
+	s := NewService(repo, users, notifications)
+
+	content := "hi @alice @nobody @gone @blocked @author @alice"
+	if err := s.SyncPostMentions(context.Background(), "post", "id-author", content, false); err != nil {
+		t.Fatalf("SyncPostMentions: %v", err)
+	}
+
+	var saved []string
+	for _, m := range repo.saved {
+		saved = append(saved, m.MentionedUserID)
+	}
+	if want := []string{"id-alice", "id-blocked", "id-author"}; !reflect.DeepEqual(saved, want) {
+		t.Errorf("saved mentions = %v, want %v", saved, want)
+	}
+
+	// Self-mentions and blocked users are not notified
+	if len(notifications.sent) != 1 || notifications.sent[0].UserID != "id-alice" {
+		t.Fatalf("notifications = %+v, want one for id-alice", notifications.sent)
+	}
+	if actor := notifications.sent[0].ActorID; actor == nil || *actor != "id-author" {
+		t.Errorf("actor = %v, want id-author", actor)
+	}
+}
+
+func TestSyncPostMentionsAnonymous(t *testing.T) {
+	notifications := &fakeNotificationService{}
+	s := NewService(&fakeMentionRepo{}, &fakeUserRepo{}, notifications)
+
+	if err := s.SyncPostMentions(context.Background(), "post", "id-author", "hi @alice", true); err != nil {
+		t.Fatalf("SyncPostMentions: %v", err)
+	}
+	if len(notifications.sent) != 1 || notifications.sent[0].ActorID != nil {
+		t.Errorf("notifications = %+v, want one without an actor", notifications.sent)
+	}
+}
+
+func TestSyncPostMentionsLimit(t *testing.T) {
+	repo := &fakeMentionRepo{}
+	s := NewService(repo, &fakeUserRepo{}, &fakeNotificationService{})
+
+	content := ""
+	for i := 0; i < maxMentionsPerContent+5; i++ {
+		content += fmt.Sprintf("@user%d ", i)
+	}
+	if err := s.SyncPostMentions(context.Background(), "post", "id-author", content, false); err != nil {
+		t.Fatalf("SyncPostMentions: %v", err)
+	}
+	if len(repo.saved) != maxMentionsPerContent {
+		t.Errorf("saved %d mentions, want %d", len(repo.saved), maxMentionsPerContent)
+	}
+}
+
+func TestBuildEntities(t *testing.T) {
+	mentions := []*Mention{{Username: "alice", MentionedUserID: "id-alice"}}
+
+	got := buildEntities("@bob and @alice, @alice", mentions)
+	want := []Entity{
+		{Username: "alice", UserID: "id-alice", Start: 9, End: 15},
+		{Username: "alice", UserID: "id-alice", Start: 17, End: 23},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildEntities = %+v, want %+v", got, want)
+	}
+}
@@ -1,6 +1,10 @@
 package messaging
 
-import "time"
+import (
+	"time"
+
+	"mockhu-app-backend/internal/app/linkpreview"
+)
 
 // ===============================
 // REQUEST DTOs
@@ -79,16 +83,18 @@ type ConversationListResponse struct {
 
 // MessageResponse for single message with sender info
 type MessageResponse struct {
-	ID             string               `json:"id"`
-	ConversationID string               `json:"conversation_id"`
-	Sender         UserBasicInfo        `json:"sender"`
-	MessageType    string               `json:"message_type"`
-	Content        string               `json:"content,omitempty"`
-	Attachments    []AttachmentMetadata `json:"attachments,omitempty"`
-	Status         string               `json:"status"`
-	IsRead         bool                 `json:"is_read"`
-	ReadAt         *time.Time           `json:"read_at,omitempty"`
-	CreatedAt      time.Time            `json:"created_at"`
+	ID             string                       `json:"id"`
+	ConversationID string                       `json:"conversation_id"`
+	Sender         UserBasicInfo                `json:"sender"`
+	MessageType    string                       `json:"message_type"`
+	Content        string                       `json:"content,omitempty"`
+	Attachments    []AttachmentMetadata         `json:"attachments,omitempty"`
+	LinkPreview    *linkpreview.PreviewResponse `json:"link_preview,omitempty"` // Preview of the first link, once fetched
+	StoryID        *string                      `json:"story_id,omitempty"`     // Story this message replies to, if it still exists
+	Status         string                       `json:"status"`
+	IsRead         bool                         `json:"is_read"`
+	ReadAt         *time.Time                   `json:"read_at,omitempty"`
+	CreatedAt      time.Time                    `json:"created_at"`
 }
 
 // MessageListResponse for list of messages
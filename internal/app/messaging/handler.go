@@ -1,9 +1,12 @@
 package messaging
 
 import (
+	"errors"
 	"strconv"
 	"strings"
 
+	"mockhu-app-backend/internal/app/moderation"
+
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -486,6 +489,15 @@ func (h *Handler) GetBlockedUsers(c *fiber.Ctx) error {
 
 // handleError handles service errors and returns appropriate HTTP responses
 func (h *Handler) handleError(c *fiber.Ctx, err error) error {
+	var rejection *moderation.RejectionError
+	if errors.As(err, &rejection) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"success":    false,
+			"error":      rejection.Error(),
+			"moderation": rejection,
+		})
+	}
+
 	errMsg := err.Error()
 
 	// Map common errors to HTTP status codes
@@ -26,7 +26,8 @@ type Message struct {
 	MessageType    string               `json:"message_type"` // "text", "image", "file"
 	Content        *string              `json:"content,omitempty"`
 	Attachments    []AttachmentMetadata `json:"attachments,omitempty"`
-	Status         string               `json:"status"` // "sent", "delivered", "read"
+	StoryID        *string              `json:"story_id,omitempty"` // Story this message replies to
+	Status         string               `json:"status"`             // "sent", "delivered", "read"
 	IsRead         bool                 `json:"is_read"`
 	ReadAt         *time.Time           `json:"read_at,omitempty"`
 	IsDeleted      bool                 `json:"is_deleted"`
@@ -238,9 +238,9 @@ func (r *PostgresMessageRepository) CreateMessage(ctx context.Context, message *
 	query := `
 		INSERT INTO messages (
 			conversation_id, sender_id, message_type, content, attachments,
-			status, is_read, created_at, updated_at
+			story_id, status, is_read, created_at, updated_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW())
 		RETURNING id, created_at, updated_at
 	`
 
@@ -257,6 +257,7 @@ func (r *PostgresMessageRepository) CreateMessage(ctx context.Context, message *
 		message.MessageType,
 		message.Content,
 		attachmentsParam,
+		message.StoryID,
 		message.Status,
 		message.IsRead,
 	).Scan(&message.ID, &message.CreatedAt, &message.UpdatedAt)
@@ -272,7 +273,7 @@ func (r *PostgresMessageRepository) CreateMessage(ctx context.Context, message *
 func (r *PostgresMessageRepository) GetMessageByID(ctx context.Context, messageID string) (*Message, error) {
 	query := `
 		SELECT id, conversation_id, sender_id, message_type, content, attachments,
-		       status, is_read, read_at, is_deleted, deleted_at, deleted_by,
+		       story_id, status, is_read, read_at, is_deleted, deleted_at, deleted_by,
 		       created_at, updated_at
 		FROM messages
 		WHERE id = $1 AND is_deleted = FALSE
@@ -287,6 +288,7 @@ func (r *PostgresMessageRepository) GetMessageByID(ctx context.Context, messageI
 		&msg.MessageType,
 		&msg.Content,
 		&attachmentsJSON,
+		&msg.StoryID,
 		&msg.Status,
 		&msg.IsRead,
 		&msg.ReadAt,
@@ -321,7 +323,7 @@ func (r *PostgresMessageRepository) GetConversationMessages(ctx context.Context,
 	// Query messages (ordered by created_at DESC for chat UX)
 	query := `
 		SELECT id, conversation_id, sender_id, message_type, content, attachments,
-		       status, is_read, read_at, is_deleted, deleted_at, deleted_by,
+		       story_id, status, is_read, read_at, is_deleted, deleted_at, deleted_by,
 		       created_at, updated_at
 		FROM messages
 		WHERE conversation_id = $1 AND is_deleted = FALSE
@@ -347,6 +349,7 @@ func (r *PostgresMessageRepository) GetConversationMessages(ctx context.Context,
 			&msg.MessageType,
 			&msg.Content,
 			&attachmentsJSON,
+			&msg.StoryID,
 			&msg.Status,
 			&msg.IsRead,
 			&msg.ReadAt,
@@ -3,10 +3,14 @@ package messaging
 import (
 	"context"
 	"fmt"
+	"log"
 	"strings"
 	"time"
 
 	"mockhu-app-backend/internal/app/auth"
+	"mockhu-app-backend/internal/app/linkpreview"
+	"mockhu-app-backend/internal/app/moderation"
+	"mockhu-app-backend/internal/app/timeline"
 )
 
 // MessagingService defines the business logic for messaging operations
@@ -19,6 +23,7 @@ type MessagingService interface {
 
 	// Message operations
 	SendMessage(ctx context.Context, conversationID, senderID string, req *SendMessageRequest) (*MessageResponse, error)
+	ReplyToStory(ctx context.Context, senderID, authorID, storyID, content string) (*MessageResponse, error)
 	GetMessages(ctx context.Context, conversationID, currentUserID string, page, limit int) (*MessageListResponse, error)
 	DeleteMessage(ctx context.Context, messageID, currentUserID string) error
 
@@ -36,11 +41,14 @@ type MessagingService interface {
 
 // messagingService implements MessagingService
 type messagingService struct {
-	convRepo       ConversationRepository
-	msgRepo        MessageRepository
-	blockRepo      BlockRepository
-	userRepo       auth.UserRepository
-	privacyChecker *PrivacyChecker
+	convRepo           ConversationRepository
+	msgRepo            MessageRepository
+	blockRepo          BlockRepository
+	userRepo           auth.UserRepository
+	privacyChecker     *PrivacyChecker
+	linkPreviewService linkpreview.LinkPreviewService
+	moderationService  moderation.ModerationService
+	timelineService    timeline.TimelineService
 }
 
 // NewService creates a new messaging service
@@ -50,13 +58,19 @@ func NewService(
 	blockRepo BlockRepository,
 	userRepo auth.UserRepository,
 	privacyChecker *PrivacyChecker,
+	linkPreviewService linkpreview.LinkPreviewService,
+	moderationService moderation.ModerationService,
+	timelineService timeline.TimelineService,
 ) MessagingService {
 	return &messagingService{
-		convRepo:       convRepo,
-		msgRepo:        msgRepo,
-		blockRepo:      blockRepo,
-		userRepo:       userRepo,
-		privacyChecker: privacyChecker,
+		convRepo:           convRepo,
+		msgRepo:            msgRepo,
+		blockRepo:          blockRepo,
+		userRepo:           userRepo,
+		privacyChecker:     privacyChecker,
+		linkPreviewService: linkPreviewService,
+		moderationService:  moderationService,
+		timelineService:    timelineService,
 	}
 }
 
@@ -307,6 +321,22 @@ func (s *messagingService) DeleteConversation(ctx context.Context, conversationI
 
 // SendMessage sends a message in a conversation
 func (s *messagingService) SendMessage(ctx context.Context, conversationID, senderID string, req *SendMessageRequest) (*MessageResponse, error) {
+	return s.sendMessage(ctx, conversationID, senderID, req, nil)
+}
+
+// ReplyToStory sends a text reply to a story to its author, in the conversation between the
+// two users (created if needed). The usual messaging privacy rules apply.
+func (s *messagingService) ReplyToStory(ctx context.Context, senderID, authorID, storyID, content string) (*MessageResponse, error) {
+	conv, err := s.CreateOrGetConversation(ctx, senderID, authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.sendMessage(ctx, conv.ID, senderID, &SendMessageRequest{MessageType: "text", Content: content}, &storyID)
+}
+
+// sendMessage sends a message, optionally as a reply to a story
+func (s *messagingService) sendMessage(ctx context.Context, conversationID, senderID string, req *SendMessageRequest, storyID *string) (*MessageResponse, error) {
 	// Validate input
 	if err := s.validateSendMessageRequest(req); err != nil {
 		return nil, err
@@ -335,6 +365,12 @@ func (s *messagingService) SendMessage(ctx context.Context, conversationID, send
 		return nil, fmt.Errorf("%s", reason)
 	}
 
+	// Moderate the text (or image caption) before it is sent
+	moderated, err := s.moderationService.Check(ctx, moderation.ContentMessage, senderID, req.Content)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create message
 	message := &Message{
 		ConversationID: conversationID,
@@ -342,6 +378,7 @@ func (s *messagingService) SendMessage(ctx context.Context, conversationID, send
 		MessageType:    req.MessageType,
 		Content:        &req.Content,
 		Attachments:    req.Attachments,
+		StoryID:        storyID,
 		Status:         "sent",
 		IsRead:         false,
 	}
@@ -357,6 +394,17 @@ func (s *messagingService) SendMessage(ctx context.Context, conversationID, send
 		fmt.Printf("failed to update last message: %v\n", err)
 	}
 
+	if err := s.moderationService.QueueForReview(ctx, moderated, message.ID); err != nil {
+		log.Printf("⚠️ Failed to queue message %s for review: %v", message.ID, err)
+	}
+
+	// Fetch a preview for the first link in the background
+	if message.MessageType == "text" {
+		if err := s.linkPreviewService.Enqueue(ctx, req.Content); err != nil {
+			log.Printf("⚠️ Failed to queue link preview for message %s: %v", message.ID, err)
+		}
+	}
+
 	// Get sender info
 	sender, err := s.userRepo.FindByID(ctx, senderID)
 	if err != nil {
@@ -371,6 +419,8 @@ func (s *messagingService) SendMessage(ctx context.Context, conversationID, send
 		MessageType:    message.MessageType,
 		Content:        req.Content,
 		Attachments:    message.Attachments,
+		LinkPreview:    s.getLinkPreview(ctx, message),
+		StoryID:        message.StoryID,
 		Status:         message.Status,
 		IsRead:         message.IsRead,
 		CreatedAt:      message.CreatedAt,
@@ -422,6 +472,8 @@ func (s *messagingService) GetMessages(ctx context.Context, conversationID, curr
 			MessageType:    msg.MessageType,
 			Content:        getStringValue(msg.Content),
 			Attachments:    msg.Attachments,
+			LinkPreview:    s.getLinkPreview(ctx, &msg),
+			StoryID:        msg.StoryID,
 			Status:         msg.Status,
 			IsRead:         msg.IsRead,
 			ReadAt:         msg.ReadAt,
@@ -600,6 +652,11 @@ func (s *messagingService) BlockUser(ctx context.Context, blockerID, blockedID,
 		return fmt.Errorf("failed to block user: %w", err)
 	}
 
+	// Neither user sees the other's posts in their home feed anymore
+	if err := s.timelineService.Block(ctx, blockerID, blockedID); err != nil {
+		log.Printf("⚠️ Failed to prune timelines after block: %v", err)
+	}
+
 	return nil
 }
 
@@ -724,6 +781,14 @@ func (s *messagingService) getLastMessageText(req *SendMessageRequest) string {
 	}
 }
 
+// getLinkPreview returns the preview for the first link in a text message, once fetched
+func (s *messagingService) getLinkPreview(ctx context.Context, msg *Message) *linkpreview.PreviewResponse {
+	if msg.MessageType != "text" || msg.Content == nil {
+		return nil
+	}
+	return s.linkPreviewService.GetPreview(ctx, *msg.Content)
+}
+
 // Helper functions for pointer values
 func getStringValue(ptr *string) string {
 	if ptr == nil {
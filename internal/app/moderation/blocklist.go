@@ -0,0 +1,153 @@
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// BlocklistRule blocks a word, phrase or pattern. Exactly one of Term and Pattern is set.
+type BlocklistRule struct {
+	Term     string `json:"term,omitempty"`    // Word or phrase, matched on normalized words
+	Pattern  string `json:"pattern,omitempty"` // Regular expression, matched on lowercased text
+	Decision string `json:"decision"`          // flag or reject
+	Category string `json:"category"`
+}
+
+// BlocklistClassifier matches text against configured terms and patterns. Terms are
+// compared after normalization (see tokenize and phonetic), so Devanagari, romanized
+// Hindi spellings and simple obfuscation are caught without listing every variant.
+type BlocklistClassifier struct {
+	rules []*compiledRule
+}
+
+// compiledRule is a BlocklistRule ready for matching
+type compiledRule struct {
+	BlocklistRule
+	words   []string // Normalized words of Term
+	pattern *regexp.Regexp
+}
+
+// blocklistFile is the format of the blocklist config file
+type blocklistFile struct {
+	Rules []BlocklistRule `json:"rules"`
+}
+
+// LoadBlocklist reads a blocklist config file
+func LoadBlocklist(path string) (*BlocklistClassifier, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file blocklistFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid blocklist %s: %w", path, err)
+	}
+
+	return NewBlocklistClassifier(file.Rules)
+}
+
+// NewBlocklistClassifier validates and compiles blocklist rules
+func NewBlocklistClassifier(rules []BlocklistRule) (*BlocklistClassifier, error) {
+	compiled := make([]*compiledRule, 0, len(rules))
+
+	for i, rule := range rules {
+		if rule.Decision != DecisionFlag && rule.Decision != DecisionReject {
+			return nil, fmt.Errorf("blocklist rule %d: decision must be flag or reject", i)
+		}
+		if (rule.Term == "") == (rule.Pattern == "") {
+			return nil, fmt.Errorf("blocklist rule %d: set exactly one of term and pattern", i)
+		}
+
+		c := &compiledRule{BlocklistRule: rule}
+		if rule.Term != "" {
+			c.words = tokenize(rule.Term)
+			if len(c.words) == 0 {
+				return nil, fmt.Errorf("blocklist rule %d: term has no words", i)
+			}
+		} else {
+			pattern, err := regexp.Compile(rule.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("blocklist rule %d: %w", i, err)
+			}
+			c.pattern = pattern
+		}
+		compiled = append(compiled, c)
+	}
+
+	return &BlocklistClassifier{rules: compiled}, nil
+}
+
+// Name identifies the classifier in verdicts
+func (b *BlocklistClassifier) Name() string {
+	return "blocklist"
+}
+
+// Classify returns the strongest matching rule's decision, preferring earlier rules
+func (b *BlocklistClassifier) Classify(ctx context.Context, input *Input) (*Verdict, error) {
+	tokens := tokenize(input.Text)
+	lowered := strings.ToLower(input.Text)
+	normalized := strings.Join(tokens, " ")
+
+	var matched *compiledRule
+	for _, rule := range b.rules {
+		if !rule.matches(tokens, lowered, normalized) {
+			continue
+		}
+		if matched == nil || rank(rule.Decision) > rank(matched.Decision) {
+			matched = rule
+		}
+		if matched.Decision == DecisionReject {
+			break
+		}
+	}
+
+	if matched == nil {
+		return nil, nil
+	}
+
+	reason := "contains a blocked word or phrase"
+	if matched.pattern != nil {
+		reason = "matches a blocked pattern"
+	}
+
+	return &Verdict{
+		Decision: matched.Decision,
+		Category: matched.Category,
+		Reason:   reason,
+	}, nil
+}
+
+// matches reports whether the rule matches text given as tokens, lowercased and normalized
+func (r *compiledRule) matches(tokens []string, lowered, normalized string) bool {
+	if r.pattern != nil {
+		return r.pattern.MatchString(lowered) || r.pattern.MatchString(normalized)
+	}
+
+	for start := 0; start+len(r.words) <= len(tokens); start++ {
+		found := true
+		for i, word := range r.words {
+			if !wordMatches(tokens[start+i], word) {
+				found = false
+				break
+			}
+		}
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
+// wordMatches compares a text token with a blocklist word, loosely for longer words
+func wordMatches(token, word string) bool {
+	if token == word {
+		return true
+	}
+	folded := phonetic(word)
+	return len([]rune(folded)) >= minPhoneticLength && phonetic(token) == folded
+}
@@ -0,0 +1,117 @@
+package moderation
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"Hello, World!", []string{"hello", "world"}},
+		{"f.u.c.k you", []string{"fuck", "you"}},
+		{"sh1t h@ppens", []string{"shit", "happens"}},
+		{"wow! 2024", []string{"wow", "2024"}},
+		{"ड़र", []string{"डर"}},
+		{"... --- ...", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := tokenize(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tokenize(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlocklistClassifier(t *testing.T) {
+	classifier, err := NewBlocklistClassifier([]BlocklistRule{
+		{Term: "chutiya", Decision: DecisionReject, Category: "profanity"},
+		{Term: "bad word", Decision: DecisionFlag, Category: "profanity"},
+		{Term: "ass", Decision: DecisionFlag, Category: "profanity"},
+		{Pattern: `buy\s+followers`, Decision: DecisionFlag, Category: "spam"},
+	})
+	if err != nil {
+		t.Fatalf("NewBlocklistClassifier: %v", err)
+	}
+
+	tests := []struct {
+		text         string
+		wantDecision string // Empty for no verdict
+	}{
+		{"you chutiya", DecisionReject},
+		{"CHOOTIYA!!", DecisionReject},
+		{"chuuuutiyaa", DecisionReject},
+		{"ch.u.t.i.y.a", DecisionReject},
+		{"what a bad word", DecisionFlag},
+		{"bad, word", DecisionFlag},
+		{"bad words", ""},
+		{"ass", DecisionFlag},
+		{"a class assignment", ""}, // Short terms match whole words only
+		{"buy   followers now", DecisionFlag},
+		{"bad word from a chutiya", DecisionReject}, // Strongest rule wins
+		{"a friendly comment", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			verdict, err := classifier.Classify(context.Background(), &Input{Text: tt.text})
+			if err != nil {
+				t.Fatalf("Classify: %v", err)
+			}
+			got := ""
+			if verdict != nil {
+				got = verdict.Decision
+			}
+			if got != tt.wantDecision {
+				t.Errorf("Classify(%q) = %q, want %q", tt.text, got, tt.wantDecision)
+			}
+		})
+	}
+}
+
+func TestNewBlocklistClassifierRejectsInvalidRules(t *testing.T) {
+	tests := []struct {
+		name string
+		rule BlocklistRule
+	}{
+		{"allow decision", BlocklistRule{Term: "x", Decision: DecisionAllow}},
+		{"term and pattern", BlocklistRule{Term: "x", Pattern: "y", Decision: DecisionFlag}},
+		{"neither", BlocklistRule{Decision: DecisionFlag}},
+		{"term without words", BlocklistRule{Term: "!!!", Decision: DecisionFlag}},
+		{"bad pattern", BlocklistRule{Pattern: "(", Decision: DecisionFlag}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewBlocklistClassifier([]BlocklistRule{tt.rule}); err == nil {
+				t.Error("NewBlocklistClassifier: want error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadBlocklist(t *testing.T) {
+	// The shipped blocklist must always load
+	if _, err := LoadBlocklist(filepath.Join("..", "..", "..", defaultBlocklistPath)); err != nil {
+		t.Fatalf("LoadBlocklist(%s): %v", defaultBlocklistPath, err)
+	}
+
+	if _, err := LoadBlocklist(filepath.Join(t.TempDir(), "missing.json")); !os.IsNotExist(err) {
+		t.Errorf("LoadBlocklist(missing): err = %v, want not exist", err)
+	}
+
+	invalid := filepath.Join(t.TempDir(), "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`{"rules": [`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadBlocklist(invalid); err == nil {
+		t.Error("LoadBlocklist(invalid): want error, got nil")
+	}
+}
@@ -0,0 +1,118 @@
+package moderation
+
+import (
+	"context"
+	"log"
+	"os"
+)
+
+// Classifier decides whether a piece of user text is allowed. It returns nil to allow
+// the input. Classifiers are run as a chain; see runChain.
+type Classifier interface {
+	Name() string
+	Classify(ctx context.Context, input *Input) (*Verdict, error)
+}
+
+// Config selects and configures the classifiers in the chain
+type Config struct {
+	BlocklistPath string // JSON blocklist file; skipped if it does not exist
+	ClassifierURL string // External classifier endpoint; skipped if empty
+}
+
+// defaultBlocklistPath is used when MODERATION_BLOCKLIST_PATH is not set
+const defaultBlocklistPath = "configs/moderation_blocklist.json"
+
+// ConfigFromEnv reads the moderation config from the environment
+func ConfigFromEnv() Config {
+	cfg := Config{
+		BlocklistPath: defaultBlocklistPath,
+		ClassifierURL: os.Getenv("MODERATION_CLASSIFIER_URL"),
+	}
+	if v := os.Getenv("MODERATION_BLOCKLIST_PATH"); v != "" {
+		cfg.BlocklistPath = v
+	}
+	return cfg
+}
+
+// NewClassifiers builds the classifier chain: the blocklist, spam heuristics and, if
+// configured, the external classifier. Cheap local checks run first.
+func NewClassifiers(cfg Config) ([]Classifier, error) {
+	var classifiers []Classifier
+
+	blocklist, err := LoadBlocklist(cfg.BlocklistPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		log.Printf("⚠️ Moderation blocklist %s not found, running without it", cfg.BlocklistPath)
+	} else {
+		classifiers = append(classifiers, blocklist)
+	}
+
+	classifiers = append(classifiers, NewSpamClassifier())
+
+	if cfg.ClassifierURL != "" {
+		classifiers = append(classifiers, NewExternalClassifier(cfg.ClassifierURL, nil))
+	}
+
+	return classifiers, nil
+}
+
+// runChain runs input through each classifier in order and keeps the strongest decision.
+// A reject stops the chain. A classifier that errors is logged and treated as allowing
+// the input, so an outage never blocks posting.
+func runChain(ctx context.Context, classifiers []Classifier, input *Input) *Result {
+	result := &Result{Input: *input, Decision: DecisionAllow}
+
+	for _, classifier := range classifiers {
+		verdict, err := classifier.Classify(ctx, input)
+		if err != nil {
+			log.Printf("⚠️ Moderation classifier %s failed: %v", classifier.Name(), err)
+			continue
+		}
+		if verdict == nil || verdict.Decision == DecisionAllow {
+			continue
+		}
+
+		if verdict.Classifier == "" {
+			verdict.Classifier = classifier.Name()
+		}
+		result.Verdicts = append(result.Verdicts, verdict)
+
+		if rank(verdict.Decision) > rank(result.Decision) {
+			result.Decision = verdict.Decision
+		}
+		if result.Decision == DecisionReject {
+			break
+		}
+	}
+
+	return result
+}
+
+// firstVerdict returns the first verdict with the given decision
+func (r *Result) firstVerdict(decision string) *Verdict {
+	for _, v := range r.Verdicts {
+		if v.Decision == decision {
+			return v
+		}
+	}
+	return nil
+}
+
+// rank orders decisions from weakest to strongest
+func rank(decision string) int {
+	switch decision {
+	case DecisionFlag:
+		return 1
+	case DecisionReject:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// validDecision reports whether a classifier may return decision
+func validDecision(decision string) bool {
+	return decision == DecisionAllow || decision == DecisionFlag || decision == DecisionReject
+}
@@ -0,0 +1,142 @@
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// stubClassifier returns a fixed verdict and counts its calls
+type stubClassifier struct {
+	name    string
+	verdict *Verdict
+	err     error
+	calls   int
+}
+
+func (s *stubClassifier) Name() string {
+	return s.name
+}
+
+func (s *stubClassifier) Classify(ctx context.Context, input *Input) (*Verdict, error) {
+	s.calls++
+	if s.verdict == nil {
+		return nil, s.err
+	}
+	verdict := *s.verdict
+	return &verdict, s.err
+}
+
+func TestRunChain(t *testing.T) {
+	allow := func(name string) *stubClassifier { return &stubClassifier{name: name} }
+	flag := func(name string) *stubClassifier {
+		return &stubClassifier{name: name, verdict: &Verdict{Decision: DecisionFlag, Category: "spam"}}
+	}
+	reject := func(name string) *stubClassifier {
+		return &stubClassifier{name: name, verdict: &Verdict{Decision: DecisionReject, Category: "profanity"}}
+	}
+	failing := func(name string) *stubClassifier {
+		return &stubClassifier{name: name, err: errors.New("unavailable")}
+	}
+
+	tests := []struct {
+		name         string
+		chain        []*stubClassifier
+		wantDecision string
+		wantVerdicts []string // Classifier names
+		wantCalls    []int
+	}{
+		{"empty chain", nil, DecisionAllow, nil, nil},
+		{"all allow", []*stubClassifier{allow("a"), allow("b")}, DecisionAllow, nil, []int{1, 1}},
+		{"flag", []*stubClassifier{allow("a"), flag("b")}, DecisionFlag, []string{"b"}, []int{1, 1}},
+		{"flags accumulate", []*stubClassifier{flag("a"), flag("b")}, DecisionFlag, []string{"a", "b"}, []int{1, 1}},
+		{"reject stops the chain", []*stubClassifier{flag("a"), reject("b"), flag("c")}, DecisionReject, []string{"a", "b"}, []int{1, 1, 0}},
+		{"errors allow", []*stubClassifier{failing("a"), flag("b")}, DecisionFlag, []string{"b"}, []int{1, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			classifiers := make([]Classifier, len(tt.chain))
+			for i, c := range tt.chain {
+				classifiers[i] = c
+			}
+
+			result := runChain(context.Background(), classifiers, &Input{ContentType: ContentPost, Text: "text"})
+
+			if result.Decision != tt.wantDecision {
+				t.Errorf("decision = %s, want %s", result.Decision, tt.wantDecision)
+			}
+			var names []string
+			for _, v := range result.Verdicts {
+				names = append(names, v.Classifier)
+			}
+			if len(names) != len(tt.wantVerdicts) {
+				t.Fatalf("verdicts from %v, want %v", names, tt.wantVerdicts)
+			}
+			for i := range names {
+				if names[i] != tt.wantVerdicts[i] {
+					t.Errorf("verdicts from %v, want %v", names, tt.wantVerdicts)
+				}
+			}
+			for i, c := range tt.chain {
+				if c.calls != tt.wantCalls[i] {
+					t.Errorf("classifier %s called %d times, want %d", c.name, c.calls, tt.wantCalls[i])
+				}
+			}
+		})
+	}
+}
+
+func TestExternalClassifier(t *testing.T) {
+	var response string
+	var status int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req externalRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Text == "" || req.ContentType != ContentComment {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	classifier := NewExternalClassifier(server.URL, server.Client())
+	input := &Input{ContentType: ContentComment, Text: "hello"}
+
+	tests := []struct {
+		name         string
+		status       int
+		response     string
+		wantDecision string // Empty for no verdict
+		wantErr      bool
+	}{
+		{"allow", http.StatusOK, `{"decision": "allow"}`, "", false},
+		{"flag", http.StatusOK, `{"decision": "flag", "category": "harassment"}`, DecisionFlag, false},
+		{"reject", http.StatusOK, `{"decision": "reject", "category": "spam"}`, DecisionReject, false},
+		{"unknown decision", http.StatusOK, `{"decision": "maybe"}`, "", true},
+		{"invalid body", http.StatusOK, `not json`, "", true},
+		{"server error", http.StatusInternalServerError, `{}`, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, response = tt.status, tt.response
+
+			verdict, err := classifier.Classify(context.Background(), input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Classify: err = %v, wantErr %v", err, tt.wantErr)
+			}
+			got := ""
+			if verdict != nil {
+				got = verdict.Decision
+			}
+			if got != tt.wantDecision {
+				t.Errorf("decision = %q, want %q", got, tt.wantDecision)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package moderation
+
+// Review actions
+const (
+	ActionApprove = "approve" // Keep the content
+	ActionRemove  = "remove"  // Take the content down
+)
+
+// ResolveRequest is the request DTO for resolving a review queue item
+type ResolveRequest struct {
+	Action string `json:"action"` // approve or remove
+}
+
+// QueueItemResponse is a review queue item
+type QueueItemResponse struct {
+	ID          string  `json:"id"`
+	ContentType string  `json:"content_type"`
+	ContentID   string  `json:"content_id"`
+	AuthorID    string  `json:"author_id,omitempty"` // Empty for anonymous content
+	IsAnonymous bool    `json:"is_anonymous"`
+	Text        string  `json:"text"`
+	Classifier  string  `json:"classifier"`
+	Category    string  `json:"category"`
+	Reason      string  `json:"reason"`
+	Status      string  `json:"status"`
+	ReviewedBy  *string `json:"reviewed_by,omitempty"`
+	ReviewedAt  *string `json:"reviewed_at,omitempty"`
+	CreatedAt   string  `json:"created_at"`
+}
+
+// QueueListResponse is a page of review queue items
+type QueueListResponse struct {
+	Items      []*QueueItemResponse `json:"items"`
+	Pagination PaginationInfo       `json:"pagination"`
+}
+
+// PaginationInfo contains pagination metadata
+type PaginationInfo struct {
+	Page       int `json:"page"`
+	TotalPages int `json:"total_pages"`
+	TotalItems int `json:"total_items"`
+	Limit      int `json:"limit"`
+}
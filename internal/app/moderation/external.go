@@ -0,0 +1,90 @@
+package moderation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// externalTimeout bounds a call to the external classifier; posting waits on it
+const externalTimeout = 2 * time.Second
+
+// ExternalClassifier sends text to an HTTP classification service. The service receives
+// {"content_type", "text"} as JSON and answers {"decision", "category", "reason"}.
+type ExternalClassifier struct {
+	url    string
+	client *http.Client
+}
+
+// externalRequest is the body sent to the external classifier
+type externalRequest struct {
+	ContentType string `json:"content_type"`
+	Text        string `json:"text"`
+}
+
+// externalResponse is the body expected from the external classifier
+type externalResponse struct {
+	Decision string `json:"decision"`
+	Category string `json:"category"`
+	Reason   string `json:"reason"`
+}
+
+// NewExternalClassifier creates a classifier calling url, using client if not nil
+func NewExternalClassifier(url string, client *http.Client) *ExternalClassifier {
+	if client == nil {
+		client = &http.Client{Timeout: externalTimeout}
+	}
+	return &ExternalClassifier{url: url, client: client}
+}
+
+// Name identifies the classifier in verdicts
+func (e *ExternalClassifier) Name() string {
+	return "external"
+}
+
+// Classify asks the external service for a decision
+func (e *ExternalClassifier) Classify(ctx context.Context, input *Input) (*Verdict, error) {
+	body, err := json.Marshal(externalRequest{ContentType: input.ContentType, Text: input.Text})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, externalTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("classifier returned status %d", resp.StatusCode)
+	}
+
+	var out externalResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("invalid classifier response: %w", err)
+	}
+	if !validDecision(out.Decision) {
+		return nil, fmt.Errorf("invalid classifier decision %q", out.Decision)
+	}
+	if out.Decision == DecisionAllow {
+		return nil, nil
+	}
+
+	return &Verdict{
+		Decision: out.Decision,
+		Category: out.Category,
+		Reason:   out.Reason,
+	}, nil
+}
@@ -0,0 +1,82 @@
+package moderation
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles HTTP requests for the moderation review queue
+type Handler struct {
+	service ModerationService
+}
+
+// NewHandler creates a new moderation handler
+func NewHandler(service ModerationService) *Handler {
+	return &Handler{service: service}
+}
+
+// GetQueue handles GET /v1/moderation/queue?status=pending
+func (h *Handler) GetQueue(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	response, err := h.service.GetQueue(c.Context(), c.Query("status"), page, limit)
+	if err != nil {
+		if err == ErrInvalidStatus {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get review queue",
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// ResolveItem handles POST /v1/moderation/queue/:itemId/resolve
+func (h *Handler) ResolveItem(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	itemID := c.Params("itemId")
+	if _, err := uuid.Parse(itemID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid item ID",
+		})
+	}
+
+	var req ResolveRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	item, err := h.service.ResolveItem(c.Context(), itemID, currentUserID, &req)
+	if err != nil {
+		if err == ErrInvalidAction {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if err == ErrItemNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to resolve queue item",
+		})
+	}
+
+	return c.JSON(item)
+}
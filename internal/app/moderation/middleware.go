@@ -0,0 +1,29 @@
+package moderation
+
+import "github.com/gofiber/fiber/v2"
+
+// RequireModerator only lets moderators through; use it after middleware.AuthMiddleware
+func RequireModerator(service ModerationService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, ok := c.Locals("user_id").(string)
+		if !ok || userID == "" {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "unauthorized",
+			})
+		}
+
+		isModerator, err := service.IsModerator(c.Context(), userID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "failed to check permissions",
+			})
+		}
+		if !isModerator {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "moderators only",
+			})
+		}
+
+		return c.Next()
+	}
+}
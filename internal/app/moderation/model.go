@@ -0,0 +1,80 @@
+package moderation
+
+import (
+	"fmt"
+	"time"
+)
+
+// Decisions, from weakest to strongest
+const (
+	DecisionAllow  = "allow"
+	DecisionFlag   = "flag"   // Published, but queued for review
+	DecisionReject = "reject" // Not saved
+)
+
+// Content types that are moderated
+const (
+	ContentPost    = "post"
+	ContentComment = "comment"
+	ContentMessage = "message"
+	ContentBio     = "bio"
+)
+
+// Review queue statuses
+const (
+	QueueStatusPending  = "pending"
+	QueueStatusApproved = "approved" // Reviewed and kept
+	QueueStatusRemoved  = "removed"  // Reviewed and taken down
+)
+
+// Input is a piece of user text to classify
+type Input struct {
+	ContentType string
+	AuthorID    string
+	Text        string
+}
+
+// Verdict is a classifier's decision about an input
+type Verdict struct {
+	Decision   string `json:"decision"`
+	Classifier string `json:"classifier"`
+	Category   string `json:"category"` // e.g. profanity, spam, harassment
+	Reason     string `json:"reason"`
+}
+
+// Result is the outcome of running an input through the classifier chain. Decision is the
+// strongest verdict; Verdicts holds every non-allow verdict in chain order.
+type Result struct {
+	Input    Input
+	Decision string
+	Verdicts []*Verdict
+}
+
+// QueueItem is flagged content waiting for (or after) moderator review
+type QueueItem struct {
+	ID          string     `json:"id"`
+	ContentType string     `json:"content_type"`
+	ContentID   string     `json:"content_id"`
+	AuthorID    string     `json:"author_id"`
+	Text        string     `json:"text"`
+	Classifier  string     `json:"classifier"`
+	Category    string     `json:"category"`
+	Reason      string     `json:"reason"`
+	Status      string     `json:"status"`
+	ReviewedBy  *string    `json:"reviewed_by,omitempty"`
+	ReviewedAt  *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	IsAnonymous bool       `json:"is_anonymous"` // Anonymous post or comment
+}
+
+// RejectionError is returned when the classifier chain rejects content. Handlers return
+// it to the client so the user knows why the text was not accepted.
+type RejectionError struct {
+	ContentType string `json:"content_type"`
+	Category    string `json:"category"`
+	Reason      string `json:"reason"`
+}
+
+func (e *RejectionError) Error() string {
+	return fmt.Sprintf("%s rejected by moderation: %s", e.ContentType, e.Reason)
+}
@@ -0,0 +1,91 @@
+package moderation
+
+import (
+	"strings"
+	"unicode"
+)
+
+// leetReplacer undoes common character substitutions inside words ("@" for "a", "0" for "o")
+var leetReplacer = strings.NewReplacer(
+	"@", "a", "4", "a", "3", "e", "1", "i", "!", "i", "0", "o", "$", "s", "5", "s", "7", "t",
+)
+
+// devanagariReplacer folds Devanagari spelling variants: the nukta is dropped (ड़ → ड),
+// chandrabindu becomes anusvara, and zero-width joiners are removed
+var devanagariReplacer = strings.NewReplacer(
+	"\u093c", "", "\u0901", "\u0902", "\u200c", "", "\u200d", "",
+)
+
+// hinglishReplacer folds spelling variants common in romanized Hindi and deliberate
+// misspellings, so "chootiya" and "chutiya", or "madarchodd" and "madarchod", compare equal
+var hinglishReplacer = strings.NewReplacer(
+	"oo", "u", "ee", "i", "aa", "a", "ph", "f", "ck", "k", "q", "k", "w", "v", "z", "j",
+)
+
+// minPhoneticLength is the shortest folded term compared loosely; shorter terms must match
+// exactly so common short words are not caught by accident
+const minPhoneticLength = 4
+
+// tokenize lowercases text and splits it into words of letters, digits and combining marks
+// (needed for Devanagari). Inside words, substitutions are undone and punctuation is
+// dropped, so "f.u.c.k" and "fu©k" are seen as one word.
+func tokenize(text string) []string {
+	var tokens []string
+
+	for _, field := range strings.Fields(strings.ToLower(text)) {
+		// Sentence punctuation around a word is not a substitution ("wow!" is not "wowi")
+		field = strings.TrimFunc(field, func(r rune) bool {
+			return !isWordPart(r) && r != '@' && r != '$'
+		})
+		if strings.IndexFunc(field, unicode.IsLetter) >= 0 {
+			field = leetReplacer.Replace(field)
+		}
+		field = devanagariReplacer.Replace(field)
+
+		token := strings.Map(func(r rune) rune {
+			if isWordPart(r) {
+				return r
+			}
+			return -1
+		}, field)
+
+		if token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+
+	return tokens
+}
+
+// isWordPart reports whether r is kept in a token
+func isWordPart(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.In(r, unicode.Mn, unicode.Mc)
+}
+
+// phonetic folds a token for loose comparison: elongations ("chuuutiya") are shortened,
+// Hinglish spelling variants are folded and doubled letters are collapsed
+func phonetic(token string) string {
+	token = collapseRuns(token, 2)
+	token = hinglishReplacer.Replace(token)
+	return collapseRuns(token, 1)
+}
+
+// collapseRuns shortens runs of the same rune to at most max
+func collapseRuns(s string, max int) string {
+	var b strings.Builder
+	var last rune
+	run := 0
+
+	for _, r := range s {
+		if r == last {
+			run++
+		} else {
+			last, run = r, 1
+		}
+		if run <= max {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
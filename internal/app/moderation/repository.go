@@ -0,0 +1,20 @@
+package moderation
+
+import "context"
+
+// ModerationRepository defines the interface for moderation data operations
+type ModerationRepository interface {
+	// Enqueue adds flagged content to the review queue, replacing the pending item for the
+	// same content if there is one (e.g. after an edit)
+	Enqueue(ctx context.Context, item *QueueItem) error
+
+	// GetQueue retrieves queue items with a status, oldest first
+	GetQueue(ctx context.Context, status string, limit, offset int) ([]*QueueItem, error)
+
+	// Resolve marks a pending item reviewed and, for removed items, takes the content down.
+	// Returns pgx.ErrNoRows if the item does not exist or was already reviewed.
+	Resolve(ctx context.Context, id, reviewerID, status string) (*QueueItem, error)
+
+	// IsModerator checks whether a user may review moderation queues
+	IsModerator(ctx context.Context, userID string) (bool, error)
+}
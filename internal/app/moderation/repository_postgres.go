@@ -0,0 +1,229 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"mockhu-app-backend/internal/app/counter"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresModerationRepository implements ModerationRepository for PostgreSQL
+type PostgresModerationRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresModerationRepository creates a new PostgreSQL moderation repository
+func NewPostgresModerationRepository(pool *pgxpool.Pool) *PostgresModerationRepository {
+	return &PostgresModerationRepository{pool: pool}
+}
+
+// Enqueue adds flagged content to the review queue, replacing the pending item for the
+// same content if there is one (e.g. after an edit)
+func (r *PostgresModerationRepository) Enqueue(ctx context.Context, item *QueueItem) error {
+	query := `
+		INSERT INTO moderation_queue (content_type, content_id, author_id, content_text, classifier, category, reason)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (content_type, content_id) WHERE status = 'pending' DO UPDATE
+		SET content_text = EXCLUDED.content_text,
+		    classifier = EXCLUDED.classifier,
+		    category = EXCLUDED.category,
+		    reason = EXCLUDED.reason
+		RETURNING id, status, created_at
+	`
+
+	return r.pool.QueryRow(ctx, query,
+		item.ContentType,
+		item.ContentID,
+		item.AuthorID,
+		item.Text,
+		item.Classifier,
+		item.Category,
+		item.Reason,
+	).Scan(&item.ID, &item.Status, &item.CreatedAt)
+}
+
+// queueIsAnonymous reports whether a queued post or comment was posted anonymously
+const queueIsAnonymous = `
+	COALESCE(CASE moderation_queue.content_type
+		WHEN 'post' THEN (SELECT p.is_anonymous FROM posts p WHERE p.id = moderation_queue.content_id)
+		WHEN 'comment' THEN (SELECT pc.is_anonymous FROM post_comments pc WHERE pc.id = moderation_queue.content_id)
+	END, false)
+`
+
+// GetQueue retrieves queue items with a status, oldest first
+func (r *PostgresModerationRepository) GetQueue(ctx context.Context, status string, limit, offset int) ([]*QueueItem, error) {
+	query := `
+		SELECT id, content_type, content_id, author_id, content_text, classifier, category, reason,
+		       status, reviewed_by, reviewed_at, created_at, ` + queueIsAnonymous + `
+		FROM moderation_queue
+		WHERE status = $1
+		ORDER BY created_at ASC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*QueueItem
+
+	for rows.Next() {
+		item := &QueueItem{}
+		err := rows.Scan(
+			&item.ID,
+			&item.ContentType,
+			&item.ContentID,
+			&item.AuthorID,
+			&item.Text,
+			&item.Classifier,
+			&item.Category,
+			&item.Reason,
+			&item.Status,
+			&item.ReviewedBy,
+			&item.ReviewedAt,
+			&item.CreatedAt,
+			&item.IsAnonymous,
+		)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// Resolve marks a pending item reviewed and, for removed items, takes the content down.
+// Returns pgx.ErrNoRows if the item does not exist or was already reviewed.
+func (r *PostgresModerationRepository) Resolve(ctx context.Context, id, reviewerID, status string) (*QueueItem, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	item := &QueueItem{}
+	err = tx.QueryRow(ctx, `
+		UPDATE moderation_queue
+		SET status = $3, reviewed_by = $2, reviewed_at = NOW()
+		WHERE id = $1 AND status = 'pending'
+		RETURNING id, content_type, content_id, author_id, content_text, classifier, category, reason,
+		          status, reviewed_by, reviewed_at, created_at, `+queueIsAnonymous+`
+	`, id, reviewerID, status).Scan(
+		&item.ID,
+		&item.ContentType,
+		&item.ContentID,
+		&item.AuthorID,
+		&item.Text,
+		&item.Classifier,
+		&item.Category,
+		&item.Reason,
+		&item.Status,
+		&item.ReviewedBy,
+		&item.ReviewedAt,
+		&item.CreatedAt,
+		&item.IsAnonymous,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if status == QueueStatusRemoved {
+		if err := RemoveContent(ctx, tx, item.ContentType, item.ContentID, reviewerID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// IsModerator checks whether a user may review moderation queues
+func (r *PostgresModerationRepository) IsModerator(ctx context.Context, userID string) (bool, error) {
+	query := `SELECT is_moderator FROM users WHERE id = $1`
+
+	var isModerator bool
+	err := r.pool.QueryRow(ctx, query, userID).Scan(&isModerator)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return isModerator, nil
+}
+
+// RemoveContent takes moderated content down the same way its owner would delete it:
+// posts, comments and messages are soft deleted and a bio is cleared. Removed posts and
+// comments are kept out of their author's trash, even if the author deleted them first.
+func RemoveContent(ctx context.Context, tx pgx.Tx, contentType, contentID, moderatorID string) error {
+	var err error
+
+	switch contentType {
+	case ContentPost:
+		// A live post comes off its author's post count; reposts were never on it
+		var userID string
+		err = tx.QueryRow(ctx, `
+			SELECT user_id FROM posts
+			WHERE id = $1 AND is_active = true AND status = 'published' AND post_type <> 'repost'
+			FOR UPDATE
+		`, contentID).Scan(&userID)
+		if err == nil {
+			err = counter.Add(ctx, tx, counter.UserPosts, userID, -1)
+		} else if errors.Is(err, pgx.ErrNoRows) {
+			err = nil
+		}
+		if err == nil {
+			_, err = tx.Exec(ctx, `
+				UPDATE posts
+				SET is_active = false, removed_by_moderation = true, deleted_at = COALESCE(deleted_at, NOW()), updated_at = NOW()
+				WHERE id = $1
+			`, contentID)
+		}
+		if err == nil {
+			_, err = tx.Exec(ctx, `DELETE FROM post_pins WHERE post_id = $1`, contentID)
+		}
+	case ContentComment:
+		// A live comment comes off its post's comment count
+		var postID string
+		err = tx.QueryRow(ctx, `
+			SELECT post_id FROM post_comments
+			WHERE id = $1 AND is_active = true
+			FOR UPDATE
+		`, contentID).Scan(&postID)
+		if err == nil {
+			err = counter.Add(ctx, tx, counter.PostComments, postID, -1)
+		} else if errors.Is(err, pgx.ErrNoRows) {
+			err = nil
+		}
+		if err == nil {
+			_, err = tx.Exec(ctx, `
+				UPDATE post_comments
+				SET is_active = false, removed_by_moderation = true, deleted_at = COALESCE(deleted_at, NOW()), updated_at = NOW()
+				WHERE id = $1
+			`, contentID)
+		}
+	case ContentMessage:
+		_, err = tx.Exec(ctx, `
+			UPDATE messages
+			SET is_deleted = TRUE, deleted_at = NOW(), deleted_by = $2, updated_at = NOW()
+			WHERE id = $1 AND is_deleted = FALSE
+		`, contentID, moderatorID)
+	case ContentBio:
+		_, err = tx.Exec(ctx, `UPDATE users SET bio = NULL, updated_at = NOW() WHERE id = $1`, contentID)
+	default:
+		return fmt.Errorf("unknown content type %q", contentType)
+	}
+
+	return err
+}
@@ -0,0 +1,15 @@
+package moderation
+
+import (
+	"mockhu-app-backend/internal/pkg/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterRoutes registers the review queue routes (moderators only)
+func RegisterRoutes(app *fiber.App, handler *Handler) {
+	moderation := app.Group("/v1/moderation", middleware.AuthMiddleware(), RequireModerator(handler.service))
+
+	moderation.Get("/queue", handler.GetQueue)
+	moderation.Post("/queue/:itemId/resolve", handler.ResolveItem)
+}
@@ -0,0 +1,218 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Errors
+var (
+	ErrItemNotFound  = errors.New("queue item not found or already reviewed")
+	ErrInvalidAction = errors.New("action must be approve or remove")
+	ErrInvalidStatus = errors.New("status must be pending, approved or removed")
+)
+
+// ModerationService defines the business logic for content moderation
+type ModerationService interface {
+	// Check runs text through the classifier chain before it is saved. Rejected text
+	// returns a *RejectionError.
+	Check(ctx context.Context, contentType, authorID, text string) (*Result, error)
+
+	// QueueForReview adds content to the review queue once it is saved, if Check flagged it
+	QueueForReview(ctx context.Context, result *Result, contentID string) error
+
+	// CheckPublished checks content that is already public (e.g. a scheduled post) and
+	// queues it for review if it is flagged or rejected
+	CheckPublished(ctx context.Context, contentType, contentID, authorID, text string) error
+
+	// Review queue (moderators only)
+	IsModerator(ctx context.Context, userID string) (bool, error)
+	GetQueue(ctx context.Context, status string, page, limit int) (*QueueListResponse, error)
+	ResolveItem(ctx context.Context, itemID, moderatorID string, req *ResolveRequest) (*QueueItemResponse, error)
+}
+
+// moderationService implements ModerationService
+type moderationService struct {
+	moderationRepo ModerationRepository
+	classifiers    []Classifier
+}
+
+// NewService creates a new moderation service running classifiers in order
+func NewService(moderationRepo ModerationRepository, classifiers []Classifier) ModerationService {
+	return &moderationService{
+		moderationRepo: moderationRepo,
+		classifiers:    classifiers,
+	}
+}
+
+// Check runs text through the classifier chain before it is saved. Rejected text
+// returns a *RejectionError.
+func (s *moderationService) Check(ctx context.Context, contentType, authorID, text string) (*Result, error) {
+	input := &Input{ContentType: contentType, AuthorID: authorID, Text: text}
+	if strings.TrimSpace(text) == "" {
+		return &Result{Input: *input, Decision: DecisionAllow}, nil
+	}
+
+	result := runChain(ctx, s.classifiers, input)
+
+	if result.Decision == DecisionReject {
+		verdict := result.firstVerdict(DecisionReject)
+		return nil, &RejectionError{
+			ContentType: contentType,
+			Category:    verdict.Category,
+			Reason:      verdict.Reason,
+		}
+	}
+
+	return result, nil
+}
+
+// QueueForReview adds content to the review queue once it is saved, if Check flagged it
+func (s *moderationService) QueueForReview(ctx context.Context, result *Result, contentID string) error {
+	if result == nil || result.Decision != DecisionFlag {
+		return nil
+	}
+
+	verdict := result.firstVerdict(DecisionFlag)
+	item := &QueueItem{
+		ContentType: result.Input.ContentType,
+		ContentID:   contentID,
+		AuthorID:    result.Input.AuthorID,
+		Text:        result.Input.Text,
+		Classifier:  verdict.Classifier,
+		Category:    verdict.Category,
+		Reason:      verdict.Reason,
+	}
+
+	if err := s.moderationRepo.Enqueue(ctx, item); err != nil {
+		return fmt.Errorf("failed to queue %s for review: %w", item.ContentType, err)
+	}
+
+	return nil
+}
+
+// CheckPublished checks content that is already public (e.g. a scheduled post) and
+// queues it for review if it is flagged or rejected
+func (s *moderationService) CheckPublished(ctx context.Context, contentType, contentID, authorID, text string) error {
+	input := &Input{ContentType: contentType, AuthorID: authorID, Text: text}
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+
+	result := runChain(ctx, s.classifiers, input)
+	if result.Decision == DecisionReject {
+		// Too late to refuse it, so a moderator decides
+		result.Decision = DecisionFlag
+		for _, v := range result.Verdicts {
+			v.Decision = DecisionFlag
+		}
+	}
+
+	return s.QueueForReview(ctx, result, contentID)
+}
+
+// IsModerator checks whether a user may review moderation queues
+func (s *moderationService) IsModerator(ctx context.Context, userID string) (bool, error) {
+	return s.moderationRepo.IsModerator(ctx, userID)
+}
+
+// GetQueue retrieves review queue items with a status (pending by default), oldest first
+func (s *moderationService) GetQueue(ctx context.Context, status string, page, limit int) (*QueueListResponse, error) {
+	if status == "" {
+		status = QueueStatusPending
+	}
+	if status != QueueStatusPending && status != QueueStatusApproved && status != QueueStatusRemoved {
+		return nil, ErrInvalidStatus
+	}
+
+	// Validate pagination
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 20
+	}
+
+	offset := (page - 1) * limit
+
+	items, err := s.moderationRepo.GetQueue(ctx, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review queue: %w", err)
+	}
+
+	responses := make([]*QueueItemResponse, 0, len(items))
+	for _, item := range items {
+		responses = append(responses, toQueueItemResponse(item))
+	}
+
+	// Calculate total pages (simplified)
+	totalPages := 1
+	if len(responses) == limit {
+		totalPages = page + 1
+	}
+
+	return &QueueListResponse{
+		Items: responses,
+		Pagination: PaginationInfo{
+			Page:       page,
+			TotalPages: totalPages,
+			TotalItems: len(responses),
+			Limit:      limit,
+		},
+	}, nil
+}
+
+// ResolveItem approves or removes flagged content
+func (s *moderationService) ResolveItem(ctx context.Context, itemID, moderatorID string, req *ResolveRequest) (*QueueItemResponse, error) {
+	var status string
+	switch req.Action {
+	case ActionApprove:
+		status = QueueStatusApproved
+	case ActionRemove:
+		status = QueueStatusRemoved
+	default:
+		return nil, ErrInvalidAction
+	}
+
+	item, err := s.moderationRepo.Resolve(ctx, itemID, moderatorID, status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to resolve queue item: %w", err)
+	}
+
+	return toQueueItemResponse(item), nil
+}
+
+// toQueueItemResponse converts a queue item to its response DTO
+func toQueueItemResponse(item *QueueItem) *QueueItemResponse {
+	response := &QueueItemResponse{
+		ID:          item.ID,
+		ContentType: item.ContentType,
+		ContentID:   item.ContentID,
+		AuthorID:    item.AuthorID,
+		IsAnonymous: item.IsAnonymous,
+		Text:        item.Text,
+		Classifier:  item.Classifier,
+		Category:    item.Category,
+		Reason:      item.Reason,
+		Status:      item.Status,
+		ReviewedBy:  item.ReviewedBy,
+		CreatedAt:   item.CreatedAt.Format(time.RFC3339),
+	}
+	if item.ReviewedAt != nil {
+		reviewedAt := item.ReviewedAt.Format(time.RFC3339)
+		response.ReviewedAt = &reviewedAt
+	}
+	// Anonymous authors are only revealed through the audited unmask endpoint
+	if item.IsAnonymous {
+		response.AuthorID = ""
+	}
+	return response
+}
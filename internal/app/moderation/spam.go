@@ -0,0 +1,144 @@
+package moderation
+
+import (
+	"context"
+	"crypto/sha256"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Spam heuristics tuning
+const (
+	maxLinks             = 8                // More links than this is rejected
+	flagLinks            = 3                // This many links is flagged
+	maxWordsPerLink      = 4                // Two or more links with fewer words each is flagged
+	duplicateWindow      = 10 * time.Minute // Identical text by the same author within this window is repeated
+	minDuplicateLength   = 20               // Shorter text ("thanks!") may repeat freely
+	maxRecentPerAuthor   = 50
+	maxTrackedAuthors    = 10000
+	minRepetitionWords   = 10  // Word repetition is only judged on longer text
+	maxSingleWordShare   = 0.5 // One word making up more than this share of the text is flagged
+	rejectDuplicateCount = 2   // Earlier copies in the window before a repeat is rejected
+)
+
+// linkPattern counts links in text, with or without a scheme
+var linkPattern = regexp.MustCompile(`(?i)\bhttps?://\S+|\bwww\.\S+`)
+
+// SpamClassifier flags or rejects link-heavy, repetitive and repeated text. Recently seen
+// text is kept in memory per author, so duplicate detection is per server instance.
+type SpamClassifier struct {
+	mu     sync.Mutex
+	recent map[string][]submission // Author ID -> recent submissions, oldest first
+}
+
+// submission is a fingerprint of text an author recently submitted
+type submission struct {
+	hash [sha256.Size]byte
+	at   time.Time
+}
+
+// NewSpamClassifier creates a spam classifier
+func NewSpamClassifier() *SpamClassifier {
+	return &SpamClassifier{recent: make(map[string][]submission)}
+}
+
+// Name identifies the classifier in verdicts
+func (s *SpamClassifier) Name() string {
+	return "spam"
+}
+
+// Classify applies the link density, word repetition and repeated content heuristics
+func (s *SpamClassifier) Classify(ctx context.Context, input *Input) (*Verdict, error) {
+	words := strings.Fields(input.Text)
+	links := len(linkPattern.FindAllString(input.Text, -1))
+
+	if links > maxLinks {
+		return &Verdict{Decision: DecisionReject, Category: "spam", Reason: "too many links"}, nil
+	}
+
+	if copies := s.record(input); copies > 0 {
+		// Sending the same greeting to several friends is normal, so messages are only flagged
+		if copies >= rejectDuplicateCount && input.ContentType != ContentMessage {
+			return &Verdict{Decision: DecisionReject, Category: "spam", Reason: "the same text was posted repeatedly"}, nil
+		}
+		return &Verdict{Decision: DecisionFlag, Category: "spam", Reason: "the same text was posted recently"}, nil
+	}
+
+	if links >= flagLinks || (links >= 2 && len(words) < links*maxWordsPerLink) {
+		return &Verdict{Decision: DecisionFlag, Category: "spam", Reason: "link-heavy text"}, nil
+	}
+
+	if isRepetitive(tokenize(input.Text)) {
+		return &Verdict{Decision: DecisionFlag, Category: "spam", Reason: "repetitive text"}, nil
+	}
+
+	return nil, nil
+}
+
+// record remembers the input for its author and returns how many identical texts the
+// author submitted within duplicateWindow
+func (s *SpamClassifier) record(input *Input) int {
+	if input.AuthorID == "" || utf8.RuneCountInString(input.Text) < minDuplicateLength {
+		return 0
+	}
+
+	hash := sha256.Sum256([]byte(strings.Join(tokenize(input.Text), " ")))
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.recent) >= maxTrackedAuthors {
+		s.prune(now)
+	}
+
+	kept := s.recent[input.AuthorID][:0]
+	copies := 0
+	for _, sub := range s.recent[input.AuthorID] {
+		if now.Sub(sub.at) > duplicateWindow {
+			continue
+		}
+		if sub.hash == hash {
+			copies++
+		}
+		kept = append(kept, sub)
+	}
+
+	kept = append(kept, submission{hash: hash, at: now})
+	if len(kept) > maxRecentPerAuthor {
+		kept = kept[len(kept)-maxRecentPerAuthor:]
+	}
+	s.recent[input.AuthorID] = kept
+
+	return copies
+}
+
+// prune drops authors with nothing submitted in the window; s.mu must be held
+func (s *SpamClassifier) prune(now time.Time) {
+	for authorID, subs := range s.recent {
+		if len(subs) == 0 || now.Sub(subs[len(subs)-1].at) > duplicateWindow {
+			delete(s.recent, authorID)
+		}
+	}
+}
+
+// isRepetitive reports whether one word makes up most of a longer text
+func isRepetitive(tokens []string) bool {
+	if len(tokens) < minRepetitionWords {
+		return false
+	}
+
+	counts := make(map[string]int, len(tokens))
+	top := 0
+	for _, token := range tokens {
+		counts[token]++
+		if counts[token] > top {
+			top = counts[token]
+		}
+	}
+
+	return float64(top)/float64(len(tokens)) > maxSingleWordShare
+}
@@ -0,0 +1,100 @@
+package moderation
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func classifySpam(t *testing.T, s *SpamClassifier, input *Input) string {
+	t.Helper()
+
+	verdict, err := s.Classify(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Classify: %v", err)
+	}
+	if verdict == nil {
+		return ""
+	}
+	return verdict.Decision
+}
+
+func TestSpamClassifierLinks(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		wantDecision string
+	}{
+		{"one link", "have a look at https://example.com it is a good read", ""},
+		{"two links with text", "compare https://a.example and https://b.example before you decide which one to use", ""},
+		{"two bare links", "https://a.example www.b.example", DecisionFlag},
+		{"three links", "see https://a.example and https://b.example and also https://c.example for the full story today", DecisionFlag},
+		{"too many links", strings.Repeat("https://spam.example ", maxLinks+1), DecisionReject},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSpamClassifier()
+			if got := classifySpam(t, s, &Input{ContentType: ContentPost, Text: tt.text}); got != tt.wantDecision {
+				t.Errorf("decision = %q, want %q", got, tt.wantDecision)
+			}
+		})
+	}
+}
+
+func TestSpamClassifierRepetition(t *testing.T) {
+	s := NewSpamClassifier()
+
+	if got := classifySpam(t, s, &Input{Text: strings.Repeat("buy ", 8) + "now please"}); got != DecisionFlag {
+		t.Errorf("repetitive text: decision = %q, want flag", got)
+	}
+	if got := classifySpam(t, s, &Input{Text: "no no no"}); got != "" {
+		t.Errorf("short text: decision = %q, want none", got)
+	}
+}
+
+func TestSpamClassifierDuplicates(t *testing.T) {
+	text := "Check out my new channel, link in bio!"
+
+	t.Run("posts", func(t *testing.T) {
+		s := NewSpamClassifier()
+		input := &Input{ContentType: ContentPost, AuthorID: "author", Text: text}
+
+		want := []string{"", DecisionFlag, DecisionReject}
+		for i, w := range want {
+			if got := classifySpam(t, s, input); got != w {
+				t.Errorf("copy %d: decision = %q, want %q", i+1, got, w)
+			}
+		}
+
+		// Other authors, and different text, are unaffected
+		if got := classifySpam(t, s, &Input{ContentType: ContentPost, AuthorID: "other", Text: text}); got != "" {
+			t.Errorf("other author: decision = %q, want none", got)
+		}
+		if got := classifySpam(t, s, &Input{ContentType: ContentPost, AuthorID: "author", Text: "Something else entirely here"}); got != "" {
+			t.Errorf("different text: decision = %q, want none", got)
+		}
+	})
+
+	t.Run("messages are only flagged", func(t *testing.T) {
+		s := NewSpamClassifier()
+		input := &Input{ContentType: ContentMessage, AuthorID: "author", Text: text}
+
+		for i, w := range []string{"", DecisionFlag, DecisionFlag} {
+			if got := classifySpam(t, s, input); got != w {
+				t.Errorf("copy %d: decision = %q, want %q", i+1, got, w)
+			}
+		}
+	})
+
+	t.Run("short text repeats freely", func(t *testing.T) {
+		s := NewSpamClassifier()
+		input := &Input{ContentType: ContentComment, AuthorID: "author", Text: "thanks!"}
+
+		for i := 0; i < 3; i++ {
+			if got := classifySpam(t, s, input); got != "" {
+				t.Errorf("copy %d: decision = %q, want none", i+1, got)
+			}
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package mute
+
+// MuteUserRequest is the request DTO for muting an account
+type MuteUserRequest struct {
+	Duration string `json:"duration"` // 1h, 24h, 7d, 30d, or empty until unmuted
+}
+
+// MuteKeywordRequest is the request DTO for muting a word, phrase or hashtag
+type MuteKeywordRequest struct {
+	Keyword  string `json:"keyword"`  // "spoilers", "season finale" or "#spoilers"
+	Duration string `json:"duration"` // 1h, 24h, 7d, 30d, or empty until unmuted
+}
+
+// MutedUserResponse is a muted account
+type MutedUserResponse struct {
+	UserID    string  `json:"user_id"`
+	Username  string  `json:"username"`
+	FirstName string  `json:"first_name"`
+	AvatarURL string  `json:"avatar_url"`
+	ExpiresAt *string `json:"expires_at,omitempty"`
+	MutedAt   string  `json:"muted_at"`
+}
+
+// MutedUserListResponse is a page of muted accounts
+type MutedUserListResponse struct {
+	Users      []*MutedUserResponse `json:"users"`
+	Pagination PaginationInfo       `json:"pagination"`
+}
+
+// KeywordMuteResponse is a muted word, phrase or hashtag
+type KeywordMuteResponse struct {
+	ID        string  `json:"id"`
+	Keyword   string  `json:"keyword"`
+	ExpiresAt *string `json:"expires_at,omitempty"`
+	CreatedAt string  `json:"created_at"`
+}
+
+// KeywordMuteListResponse lists a user's muted keywords
+type KeywordMuteListResponse struct {
+	Keywords []*KeywordMuteResponse `json:"keywords"`
+}
+
+// PaginationInfo contains pagination metadata
+type PaginationInfo struct {
+	Page       int `json:"page"`
+	TotalPages int `json:"total_pages"`
+	TotalItems int `json:"total_items"`
+	Limit      int `json:"limit"`
+}
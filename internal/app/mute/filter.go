@@ -0,0 +1,70 @@
+package mute
+
+import "fmt"
+
+// SQL predicates other repositories use to hide muted content. viewerParam is the query
+// parameter holding the viewer's ID; an empty ID (logged-out viewer) mutes nothing.
+// Account mutes never apply to anonymous content, so muting cannot reveal who wrote it.
+
+// PostNotMuted returns a SQL predicate that is true unless the viewer muted the author of
+// the post aliased as postAlias or a keyword in it. For reposts and quotes the original
+// post is checked too.
+func PostNotMuted(postAlias, viewerParam string) string {
+	viewer := viewerExpr(viewerParam)
+
+	return fmt.Sprintf(`NOT %[2]s
+		AND NOT EXISTS (
+			SELECT 1 FROM posts mo
+			WHERE %[1]s.post_type IN ('repost', 'quote') AND mo.id = %[1]s.quoted_post_id
+			AND %[3]s
+		)`, postAlias,
+		contentMuted(postAlias+".user_id", postAlias+".is_anonymous", postAlias+".content", viewer),
+		contentMuted("mo.user_id", "mo.is_anonymous", "mo.content", viewer))
+}
+
+// CommentNotMuted returns a SQL predicate that is true unless the viewer muted the author
+// of the comment aliased as commentAlias or a keyword in it
+func CommentNotMuted(commentAlias, viewerParam string) string {
+	return "NOT " + contentMuted(commentAlias+".user_id", commentAlias+".is_anonymous", commentAlias+".content", viewerExpr(viewerParam))
+}
+
+// UserNotMuted returns a SQL predicate that is true unless the viewer muted the user whose
+// ID is userExpr (NULL, e.g. an anonymous actor, is never muted)
+func UserNotMuted(userExpr, viewerParam string) string {
+	return "NOT " + userMuted(userExpr, viewerExpr(viewerParam))
+}
+
+// TextNotMuted returns a SQL predicate that is true unless textExpr contains a keyword
+// the viewer muted (NULL text contains none)
+func TextNotMuted(textExpr, viewerParam string) string {
+	return "NOT " + keywordMuted(textExpr, viewerExpr(viewerParam))
+}
+
+// viewerExpr converts a viewer ID parameter that may be empty to a nullable UUID
+func viewerExpr(param string) string {
+	return fmt.Sprintf("NULLIF(%s::text, '')::uuid", param)
+}
+
+// contentMuted is true if the viewer muted the (non-anonymous) author or a keyword in the text
+func contentMuted(authorExpr, anonymousExpr, textExpr, viewer string) string {
+	return fmt.Sprintf(`((%s = false AND %s) OR %s)`, anonymousExpr, userMuted(authorExpr, viewer), keywordMuted(textExpr, viewer))
+}
+
+// userMuted is true if the viewer has an active mute on the user
+func userMuted(userExpr, viewer string) string {
+	return fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM user_mutes um
+			WHERE um.muter_id = %[2]s AND um.muted_id = %[1]s
+			AND (um.expires_at IS NULL OR um.expires_at > NOW())
+		)`, userExpr, viewer)
+}
+
+// keywordMuted is true if the text matches one of the viewer's active keyword mutes
+func keywordMuted(textExpr, viewer string) string {
+	return fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM muted_keywords mk
+			WHERE mk.user_id = %[2]s
+			AND (mk.expires_at IS NULL OR mk.expires_at > NOW())
+			AND %[1]s ~* mk.pattern
+		)`, textExpr, viewer)
+}
@@ -0,0 +1,208 @@
+package mute
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles HTTP requests for muting accounts and keywords
+type Handler struct {
+	service MuteService
+}
+
+// NewHandler creates a new mute handler
+func NewHandler(service MuteService) *Handler {
+	return &Handler{service: service}
+}
+
+// MuteUser handles POST /v1/users/:userId/mute
+func (h *Handler) MuteUser(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	userID := c.Params("userId")
+	if _, err := uuid.Parse(userID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid user ID",
+		})
+	}
+
+	// The body is optional; without one the mute lasts until removed
+	var req MuteUserRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid request body",
+			})
+		}
+	}
+
+	if err := h.service.MuteUser(c.Context(), currentUserID, userID, &req); err != nil {
+		switch err {
+		case ErrCannotMuteSelf, ErrInvalidDuration:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case ErrUserNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to mute user",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "user muted",
+	})
+}
+
+// UnmuteUser handles DELETE /v1/users/:userId/mute
+func (h *Handler) UnmuteUser(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	userID := c.Params("userId")
+	if _, err := uuid.Parse(userID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid user ID",
+		})
+	}
+
+	if err := h.service.UnmuteUser(c.Context(), currentUserID, userID); err != nil {
+		if err == ErrNotMuted {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to unmute user",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "user unmuted",
+	})
+}
+
+// GetMutedUsers handles GET /v1/users/me/mutes
+func (h *Handler) GetMutedUsers(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	response, err := h.service.GetMutedUsers(c.Context(), currentUserID, page, limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get muted users",
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// MuteKeyword handles POST /v1/users/me/muted-keywords
+func (h *Handler) MuteKeyword(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	var req MuteKeywordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	response, err := h.service.MuteKeyword(c.Context(), currentUserID, &req)
+	if err != nil {
+		switch err {
+		case ErrInvalidKeyword, ErrInvalidDuration, ErrTooManyKeywords:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to mute keyword",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+// GetMutedKeywords handles GET /v1/users/me/muted-keywords
+func (h *Handler) GetMutedKeywords(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	response, err := h.service.GetMutedKeywords(c.Context(), currentUserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get muted keywords",
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// UnmuteKeyword handles DELETE /v1/users/me/muted-keywords/:keywordId
+func (h *Handler) UnmuteKeyword(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	keywordID := c.Params("keywordId")
+	if _, err := uuid.Parse(keywordID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid keyword ID",
+		})
+	}
+
+	if err := h.service.UnmuteKeyword(c.Context(), currentUserID, keywordID); err != nil {
+		if err == ErrKeywordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to unmute keyword",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "keyword unmuted",
+	})
+}
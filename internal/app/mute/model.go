@@ -0,0 +1,35 @@
+package mute
+
+import "time"
+
+// muteDurations are the accepted mute lengths; an empty duration mutes until removed
+var muteDurations = map[string]time.Duration{
+	"1h":  time.Hour,
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+	"30d": 30 * 24 * time.Hour,
+}
+
+// UserMute is an account muted by a user
+type UserMute struct {
+	ID        string
+	MuterID   string
+	MutedID   string
+	ExpiresAt *time.Time // Nil mutes until removed
+	CreatedAt time.Time
+
+	// Muted account, loaded by GetMutedUsers
+	Username  string
+	FirstName string
+	AvatarURL string
+}
+
+// KeywordMute is a word, phrase or hashtag muted by a user
+type KeywordMute struct {
+	ID        string
+	UserID    string
+	Keyword   string // Lowercase word or phrase, or #hashtag
+	Pattern   string // Postgres regular expression matching Keyword as a whole word
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,28 @@
+package mute
+
+import (
+	"context"
+	"time"
+)
+
+// MuteRepository defines the interface for mute data operations. Expired mutes are
+// ignored everywhere.
+type MuteRepository interface {
+	// MuteUser mutes an account, or changes when an existing mute ends
+	MuteUser(ctx context.Context, muterID, mutedID string, expiresAt *time.Time) error
+
+	// UnmuteUser removes a mute; returns false if the account was not muted
+	UnmuteUser(ctx context.Context, muterID, mutedID string) (bool, error)
+
+	// GetMutedUsers retrieves the accounts a user muted, most recent first
+	GetMutedUsers(ctx context.Context, muterID string, limit, offset int) ([]*UserMute, error)
+
+	// AddKeyword mutes a keyword, or changes when an existing keyword mute ends
+	AddKeyword(ctx context.Context, mute *KeywordMute) error
+
+	// GetKeywords retrieves a user's muted keywords, most recent first
+	GetKeywords(ctx context.Context, userID string) ([]*KeywordMute, error)
+
+	// DeleteKeyword removes one of the user's keyword mutes; returns false if it does not exist
+	DeleteKeyword(ctx context.Context, id, userID string) (bool, error)
+}
@@ -0,0 +1,154 @@
+package mute
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresMuteRepository implements MuteRepository for PostgreSQL
+type PostgresMuteRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresMuteRepository creates a new PostgreSQL mute repository
+func NewPostgresMuteRepository(pool *pgxpool.Pool) *PostgresMuteRepository {
+	return &PostgresMuteRepository{pool: pool}
+}
+
+// MuteUser mutes an account, or changes when an existing mute ends
+func (r *PostgresMuteRepository) MuteUser(ctx context.Context, muterID, mutedID string, expiresAt *time.Time) error {
+	query := `
+		INSERT INTO user_mutes (muter_id, muted_id, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (muter_id, muted_id) DO UPDATE
+		SET expires_at = EXCLUDED.expires_at, created_at = NOW()
+	`
+
+	_, err := r.pool.Exec(ctx, query, muterID, mutedID, expiresAt)
+	return err
+}
+
+// UnmuteUser removes a mute; returns false if the account was not muted
+func (r *PostgresMuteRepository) UnmuteUser(ctx context.Context, muterID, mutedID string) (bool, error) {
+	query := `
+		DELETE FROM user_mutes
+		WHERE muter_id = $1 AND muted_id = $2
+		AND (expires_at IS NULL OR expires_at > NOW())
+	`
+
+	result, err := r.pool.Exec(ctx, query, muterID, mutedID)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// GetMutedUsers retrieves the accounts a user muted, most recent first
+func (r *PostgresMuteRepository) GetMutedUsers(ctx context.Context, muterID string, limit, offset int) ([]*UserMute, error) {
+	query := `
+		SELECT um.id, um.muter_id, um.muted_id, um.expires_at, um.created_at,
+		       COALESCE(u.username, ''), COALESCE(u.first_name, ''), COALESCE(u.avatar_url, '')
+		FROM user_mutes um
+		JOIN users u ON u.id = um.muted_id
+		WHERE um.muter_id = $1
+		AND (um.expires_at IS NULL OR um.expires_at > NOW())
+		ORDER BY um.created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, muterID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var mutes []*UserMute
+
+	for rows.Next() {
+		m := &UserMute{}
+		err := rows.Scan(
+			&m.ID,
+			&m.MuterID,
+			&m.MutedID,
+			&m.ExpiresAt,
+			&m.CreatedAt,
+			&m.Username,
+			&m.FirstName,
+			&m.AvatarURL,
+		)
+		if err != nil {
+			return nil, err
+		}
+		mutes = append(mutes, m)
+	}
+
+	return mutes, rows.Err()
+}
+
+// AddKeyword mutes a keyword, or changes when an existing keyword mute ends
+func (r *PostgresMuteRepository) AddKeyword(ctx context.Context, mute *KeywordMute) error {
+	query := `
+		INSERT INTO muted_keywords (user_id, keyword, pattern, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, keyword) DO UPDATE
+		SET pattern = EXCLUDED.pattern, expires_at = EXCLUDED.expires_at, created_at = NOW()
+		RETURNING id, created_at
+	`
+
+	return r.pool.QueryRow(ctx, query,
+		mute.UserID,
+		mute.Keyword,
+		mute.Pattern,
+		mute.ExpiresAt,
+	).Scan(&mute.ID, &mute.CreatedAt)
+}
+
+// GetKeywords retrieves a user's muted keywords, most recent first
+func (r *PostgresMuteRepository) GetKeywords(ctx context.Context, userID string) ([]*KeywordMute, error) {
+	query := `
+		SELECT id, user_id, keyword, pattern, expires_at, created_at
+		FROM muted_keywords
+		WHERE user_id = $1
+		AND (expires_at IS NULL OR expires_at > NOW())
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var mutes []*KeywordMute
+
+	for rows.Next() {
+		m := &KeywordMute{}
+		err := rows.Scan(
+			&m.ID,
+			&m.UserID,
+			&m.Keyword,
+			&m.Pattern,
+			&m.ExpiresAt,
+			&m.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		mutes = append(mutes, m)
+	}
+
+	return mutes, rows.Err()
+}
+
+// DeleteKeyword removes one of the user's keyword mutes; returns false if it does not exist
+func (r *PostgresMuteRepository) DeleteKeyword(ctx context.Context, id, userID string) (bool, error) {
+	query := `DELETE FROM muted_keywords WHERE id = $1 AND user_id = $2`
+
+	result, err := r.pool.Exec(ctx, query, id, userID)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
@@ -0,0 +1,24 @@
+package mute
+
+import (
+	"mockhu-app-backend/internal/pkg/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterRoutes registers all mute routes (auth required)
+func RegisterRoutes(app *fiber.App, handler *Handler) {
+	v1 := app.Group("/v1")
+
+	auth := middleware.AuthMiddleware()
+
+	// Muted accounts
+	v1.Get("/users/me/mutes", auth, handler.GetMutedUsers)
+	v1.Post("/users/:userId/mute", auth, handler.MuteUser)
+	v1.Delete("/users/:userId/mute", auth, handler.UnmuteUser)
+
+	// Muted keywords and hashtags
+	v1.Get("/users/me/muted-keywords", auth, handler.GetMutedKeywords)
+	v1.Post("/users/me/muted-keywords", auth, handler.MuteKeyword)
+	v1.Delete("/users/me/muted-keywords/:keywordId", auth, handler.UnmuteKeyword)
+}
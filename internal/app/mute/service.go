@@ -0,0 +1,277 @@
+package mute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"mockhu-app-backend/internal/app/auth"
+	"mockhu-app-backend/internal/pkg/entities"
+)
+
+// Mute limits
+const (
+	maxKeywordLength = 100
+	maxKeywordMutes  = 100
+)
+
+// Errors
+var (
+	ErrCannotMuteSelf  = errors.New("cannot mute yourself")
+	ErrUserNotFound    = errors.New("user not found")
+	ErrNotMuted        = errors.New("user is not muted")
+	ErrInvalidDuration = errors.New("duration must be 1h, 24h, 7d or 30d, or empty to mute until removed")
+	ErrInvalidKeyword  = errors.New("keyword must be 1-100 characters, or a valid #hashtag")
+	ErrTooManyKeywords = errors.New("you can mute at most 100 keywords")
+	ErrKeywordNotFound = errors.New("muted keyword not found")
+)
+
+// MuteService defines the business logic for muting accounts and keywords. Muted users
+// are not told; their content just stops showing up for the muter.
+type MuteService interface {
+	// Accounts
+	MuteUser(ctx context.Context, muterID, mutedID string, req *MuteUserRequest) error
+	UnmuteUser(ctx context.Context, muterID, mutedID string) error
+	GetMutedUsers(ctx context.Context, muterID string, page, limit int) (*MutedUserListResponse, error)
+
+	// Keywords and hashtags
+	MuteKeyword(ctx context.Context, userID string, req *MuteKeywordRequest) (*KeywordMuteResponse, error)
+	GetMutedKeywords(ctx context.Context, userID string) (*KeywordMuteListResponse, error)
+	UnmuteKeyword(ctx context.Context, userID, keywordID string) error
+}
+
+// muteService implements MuteService
+type muteService struct {
+	muteRepo MuteRepository
+	userRepo auth.UserRepository
+}
+
+// NewService creates a new mute service
+func NewService(muteRepo MuteRepository, userRepo auth.UserRepository) MuteService {
+	return &muteService{
+		muteRepo: muteRepo,
+		userRepo: userRepo,
+	}
+}
+
+// MuteUser mutes an account for a duration, or until unmuted. Muting again changes the duration.
+func (s *muteService) MuteUser(ctx context.Context, muterID, mutedID string, req *MuteUserRequest) error {
+	if muterID == mutedID {
+		return ErrCannotMuteSelf
+	}
+
+	expiresAt, err := parseDuration(req.Duration)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, mutedID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil || !user.IsActive {
+		return ErrUserNotFound
+	}
+
+	if err := s.muteRepo.MuteUser(ctx, muterID, mutedID, expiresAt); err != nil {
+		return fmt.Errorf("failed to mute user: %w", err)
+	}
+	return nil
+}
+
+// UnmuteUser removes a mute
+func (s *muteService) UnmuteUser(ctx context.Context, muterID, mutedID string) error {
+	removed, err := s.muteRepo.UnmuteUser(ctx, muterID, mutedID)
+	if err != nil {
+		return fmt.Errorf("failed to unmute user: %w", err)
+	}
+	if !removed {
+		return ErrNotMuted
+	}
+	return nil
+}
+
+// GetMutedUsers retrieves the accounts a user muted, most recent first
+func (s *muteService) GetMutedUsers(ctx context.Context, muterID string, page, limit int) (*MutedUserListResponse, error) {
+	// Validate pagination
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 20
+	}
+
+	offset := (page - 1) * limit
+
+	mutes, err := s.muteRepo.GetMutedUsers(ctx, muterID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get muted users: %w", err)
+	}
+
+	users := make([]*MutedUserResponse, 0, len(mutes))
+	for _, m := range mutes {
+		users = append(users, &MutedUserResponse{
+			UserID:    m.MutedID,
+			Username:  m.Username,
+			FirstName: m.FirstName,
+			AvatarURL: m.AvatarURL,
+			ExpiresAt: formatTime(m.ExpiresAt),
+			MutedAt:   m.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	// Calculate total pages (simplified)
+	totalPages := 1
+	if len(users) == limit {
+		totalPages = page + 1
+	}
+
+	return &MutedUserListResponse{
+		Users: users,
+		Pagination: PaginationInfo{
+			Page:       page,
+			TotalPages: totalPages,
+			TotalItems: len(users),
+			Limit:      limit,
+		},
+	}, nil
+}
+
+// MuteKeyword mutes a word, phrase or #hashtag for a duration, or until unmuted. Keywords
+// match whole words, case-insensitively; muting a word also mutes it as a hashtag.
+func (s *muteService) MuteKeyword(ctx context.Context, userID string, req *MuteKeywordRequest) (*KeywordMuteResponse, error) {
+	keyword, pattern, ok := normalizeKeyword(req.Keyword)
+	if !ok {
+		return nil, ErrInvalidKeyword
+	}
+
+	expiresAt, err := parseDuration(req.Duration)
+	if err != nil {
+		return nil, err
+	}
+
+	// Muting a keyword again only changes its duration, so it doesn't count against the limit
+	existing, err := s.muteRepo.GetKeywords(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get muted keywords: %w", err)
+	}
+	if len(existing) >= maxKeywordMutes && !hasKeyword(existing, keyword) {
+		return nil, ErrTooManyKeywords
+	}
+
+	mute := &KeywordMute{
+		UserID:    userID,
+		Keyword:   keyword,
+		Pattern:   pattern,
+		ExpiresAt: expiresAt,
+	}
+	if err := s.muteRepo.AddKeyword(ctx, mute); err != nil {
+		return nil, fmt.Errorf("failed to mute keyword: %w", err)
+	}
+
+	return toKeywordMuteResponse(mute), nil
+}
+
+// GetMutedKeywords retrieves a user's muted keywords, most recent first
+func (s *muteService) GetMutedKeywords(ctx context.Context, userID string) (*KeywordMuteListResponse, error) {
+	mutes, err := s.muteRepo.GetKeywords(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get muted keywords: %w", err)
+	}
+
+	keywords := make([]*KeywordMuteResponse, 0, len(mutes))
+	for _, m := range mutes {
+		keywords = append(keywords, toKeywordMuteResponse(m))
+	}
+
+	return &KeywordMuteListResponse{Keywords: keywords}, nil
+}
+
+// UnmuteKeyword removes one of the user's keyword mutes
+func (s *muteService) UnmuteKeyword(ctx context.Context, userID, keywordID string) error {
+	removed, err := s.muteRepo.DeleteKeyword(ctx, keywordID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to unmute keyword: %w", err)
+	}
+	if !removed {
+		return ErrKeywordNotFound
+	}
+	return nil
+}
+
+// Helper functions
+
+// parseDuration returns when a mute of the given duration ends (nil for no end)
+func parseDuration(duration string) (*time.Time, error) {
+	if duration == "" {
+		return nil, nil
+	}
+	d, ok := muteDurations[duration]
+	if !ok {
+		return nil, ErrInvalidDuration
+	}
+	expiresAt := time.Now().Add(d)
+	return &expiresAt, nil
+}
+
+// normalizeKeyword returns the stored form of a keyword ("Season  Finale" -> "season finale",
+// "#GoLang" -> "#golang") and a Postgres regular expression matching it as a whole word
+func normalizeKeyword(input string) (keyword, pattern string, ok bool) {
+	const (
+		wordStart = `(^|[^[:alnum:]_])`
+		wordEnd   = `($|[^[:alnum:]_])`
+	)
+
+	input = strings.TrimSpace(input)
+	if strings.HasPrefix(input, "#") {
+		tag, ok := entities.NormalizeHashtag(input)
+		if !ok {
+			return "", "", false
+		}
+		return "#" + tag, wordStart + "#" + tag + wordEnd, true
+	}
+
+	words := strings.Fields(strings.ToLower(input))
+	keyword = strings.Join(words, " ")
+	if keyword == "" || utf8.RuneCountInString(keyword) > maxKeywordLength {
+		return "", "", false
+	}
+
+	for i, word := range words {
+		words[i] = regexp.QuoteMeta(word)
+	}
+	return keyword, wordStart + strings.Join(words, `[[:space:]]+`) + wordEnd, true
+}
+
+// hasKeyword reports whether keyword is among the mutes
+func hasKeyword(mutes []*KeywordMute, keyword string) bool {
+	for _, m := range mutes {
+		if m.Keyword == keyword {
+			return true
+		}
+	}
+	return false
+}
+
+// toKeywordMuteResponse converts a keyword mute to its response DTO
+func toKeywordMuteResponse(m *KeywordMute) *KeywordMuteResponse {
+	return &KeywordMuteResponse{
+		ID:        m.ID,
+		Keyword:   m.Keyword,
+		ExpiresAt: formatTime(m.ExpiresAt),
+		CreatedAt: m.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// formatTime formats an optional timestamp as RFC3339
+func formatTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format(time.RFC3339)
+	return &formatted
+}
@@ -0,0 +1,153 @@
+package mute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"mockhu-app-backend/internal/pkg/testdb"
+)
+
+// matchTests are texts checked against the pattern of each keyword
+var matchTests = []struct {
+	keyword string
+	text    string
+	want    bool
+}{
+	{"spoiler", "No SPOILERS please", false},
+	{"spoiler", "Spoiler: he lives", true},
+	{"spoiler", "(spoiler)", true},
+	{"spoiler", "a_spoiler_b", false},
+	{"season finale", "the season   finale was great", true},
+	{"season finale", "the season-finale was great", false},
+	{"c++", "learning C++ today", true},
+	{"c++", "learning c today", false},
+	{"#GoLang", "we love #golang!", true},
+	{"#GoLang", "we love golang", false},
+	{"#GoLang", "we love #golang_tips", false},
+}
+
+func TestNormalizeKeyword(t *testing.T) {
+	tests := []struct {
+		input  string
+		want   string
+		wantOK bool
+	}{
+		{"  Season   Finale ", "season finale", true},
+		{"#GoLang", "#golang", true},
+		{"C++", "c++", true},
+		{"", "", false},
+		{"   ", "", false},
+		{"#", "", false},
+		{"#not-a-tag", "", false},
+		{strings.Repeat("x", maxKeywordLength+1), "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, _, ok := normalizeKeyword(tt.input)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("normalizeKeyword(%q) = %q, %v; want %q, %v", tt.input, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+// TestKeywordPatterns checks the patterns with Go's regexp, which agrees with Postgres for
+// ASCII text; TestKeywordPatternsInPostgres runs the same cases against the database
+func TestKeywordPatterns(t *testing.T) {
+	for _, tt := range matchTests {
+		t.Run(tt.keyword+"/"+tt.text, func(t *testing.T) {
+			_, pattern, ok := normalizeKeyword(tt.keyword)
+			if !ok {
+				t.Fatalf("normalizeKeyword(%q) failed", tt.keyword)
+			}
+			if got := regexp.MustCompile("(?i)" + pattern).MatchString(tt.text); got != tt.want {
+				t.Errorf("pattern %s on %q = %v, want %v", pattern, tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeywordPatternsInPostgres(t *testing.T) {
+	pool := testdb.Pool(t)
+
+	for _, tt := range matchTests {
+		t.Run(tt.keyword+"/"+tt.text, func(t *testing.T) {
+			_, pattern, _ := normalizeKeyword(tt.keyword)
+
+			var got bool
+			if err := pool.QueryRow(context.Background(), `SELECT $1::text ~* $2`, tt.text, pattern).Scan(&got); err != nil {
+				t.Fatalf("match: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("pattern %s on %q = %v, want %v", pattern, tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeMuteRepo keeps keyword mutes in memory
+type fakeMuteRepo struct {
+	MuteRepository
+
+	keywords []*KeywordMute
+}
+
+func (r *fakeMuteRepo) GetKeywords(ctx context.Context, userID string) ([]*KeywordMute, error) {
+	return r.keywords, nil
+}
+
+func (r *fakeMuteRepo) AddKeyword(ctx context.Context, mute *KeywordMute) error {
+	for _, m := range r.keywords {
+		if m.Keyword == mute.Keyword {
+			m.ExpiresAt = mute.ExpiresAt
+			return nil
+		}
+	}
+	r.keywords = append(r.keywords, mute)
+	return nil
+}
+
+func TestMuteKeyword(t *testing.T) {
+	repo := &fakeMuteRepo{}
+	s := NewService(repo, nil)
+	ctx := context.Background()
+
+	if _, err := s.MuteKeyword(ctx, "user", &MuteKeywordRequest{Keyword: "x", Duration: "2h"}); !errors.Is(err, ErrInvalidDuration) {
+		t.Errorf("MuteKeyword(2h): err = %v, want ErrInvalidDuration", err)
+	}
+	if _, err := s.MuteKeyword(ctx, "user", &MuteKeywordRequest{Keyword: " "}); !errors.Is(err, ErrInvalidKeyword) {
+		t.Errorf("MuteKeyword(blank): err = %v, want ErrInvalidKeyword", err)
+	}
+
+	resp, err := s.MuteKeyword(ctx, "user", &MuteKeywordRequest{Keyword: "Spoiler", Duration: "24h"})
+	if err != nil {
+		t.Fatalf("MuteKeyword: %v", err)
+	}
+	if resp.Keyword != "spoiler" || resp.ExpiresAt == nil {
+		t.Errorf("MuteKeyword = %+v, want spoiler for 24h", resp)
+	}
+	if until := time.Until(*repo.keywords[0].ExpiresAt); until < 23*time.Hour || until > 24*time.Hour {
+		t.Errorf("mute ends in %v, want 24h", until)
+	}
+
+	for i := len(repo.keywords); i < maxKeywordMutes; i++ {
+		repo.keywords = append(repo.keywords, &KeywordMute{Keyword: fmt.Sprintf("word%d", i)})
+	}
+	if _, err := s.MuteKeyword(ctx, "user", &MuteKeywordRequest{Keyword: "one more"}); !errors.Is(err, ErrTooManyKeywords) {
+		t.Errorf("MuteKeyword over the limit: err = %v, want ErrTooManyKeywords", err)
+	}
+
+	// Changing the duration of an existing mute is allowed at the limit
+	if _, err := s.MuteKeyword(ctx, "user", &MuteKeywordRequest{Keyword: "SPOILER"}); err != nil {
+		t.Errorf("MuteKeyword again at the limit: %v", err)
+	}
+	if repo.keywords[0].ExpiresAt != nil {
+		t.Errorf("mute still ends at %v, want until removed", repo.keywords[0].ExpiresAt)
+	}
+}
@@ -0,0 +1,36 @@
+package notification
+
+// NotificationResponse is the response DTO for a notification
+type NotificationResponse struct {
+	ID        string     `json:"id"`
+	Type      string     `json:"type"`
+	Actor     *ActorInfo `json:"actor"` // Nil when the actor is anonymous
+	PostID    *string    `json:"post_id,omitempty"`
+	CommentID *string    `json:"comment_id,omitempty"`
+	ReportID  *string    `json:"report_id,omitempty"`
+	IsRead    bool       `json:"is_read"`
+	CreatedAt string     `json:"created_at"`
+}
+
+// ActorInfo contains information about the user who triggered a notification
+type ActorInfo struct {
+	ID        string `json:"id"`
+	Username  string `json:"username"`
+	FirstName string `json:"first_name"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+// PaginationInfo contains pagination metadata
+type PaginationInfo struct {
+	Page       int `json:"page"`
+	TotalPages int `json:"total_pages"`
+	TotalItems int `json:"total_items"`
+	Limit      int `json:"limit"`
+}
+
+// NotificationListResponse is the response for listing notifications
+type NotificationListResponse struct {
+	Notifications []*NotificationResponse `json:"notifications"`
+	UnreadCount   int                     `json:"unread_count"`
+	Pagination    PaginationInfo          `json:"pagination"`
+}
@@ -0,0 +1,97 @@
+package notification
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles HTTP requests for notification operations
+type Handler struct {
+	service NotificationService
+}
+
+// NewHandler creates a new notification handler
+func NewHandler(service NotificationService) *Handler {
+	return &Handler{service: service}
+}
+
+// GetNotifications handles GET /v1/notifications
+func (h *Handler) GetNotifications(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	// Parse pagination
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	response, err := h.service.GetNotifications(c.Context(), currentUserID, page, limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get notifications",
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// MarkAsRead handles PUT /v1/notifications/:notificationId/read
+func (h *Handler) MarkAsRead(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	notificationID := c.Params("notificationId")
+	if _, err := uuid.Parse(notificationID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid notification ID",
+		})
+	}
+
+	err := h.service.MarkAsRead(c.Context(), notificationID, currentUserID)
+	if err != nil {
+		if err == ErrNotificationNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "notification not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to mark notification as read",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "notification marked as read",
+	})
+}
+
+// MarkAllAsRead handles PUT /v1/notifications/read-all
+func (h *Handler) MarkAllAsRead(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	if err := h.service.MarkAllAsRead(c.Context(), currentUserID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to mark notifications as read",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "all notifications marked as read",
+	})
+}
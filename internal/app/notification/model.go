@@ -0,0 +1,24 @@
+package notification
+
+import "time"
+
+// Notification types
+const (
+	TypeMention           = "mention"
+	TypeReportResolved    = "report_resolved"    // A report the user filed was reviewed
+	TypeModerationWarning = "moderation_warning" // A moderator warned the user about their content
+)
+
+// Notification represents an in-app notification
+type Notification struct {
+	ID        string     `json:"id"`
+	UserID    string     `json:"user_id"`  // Recipient
+	ActorID   *string    `json:"actor_id"` // Nil when the actor is anonymous
+	Type      string     `json:"type"`
+	PostID    *string    `json:"post_id,omitempty"`
+	CommentID *string    `json:"comment_id,omitempty"`
+	ReportID  *string    `json:"report_id,omitempty"`
+	IsRead    bool       `json:"is_read"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
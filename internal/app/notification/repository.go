@@ -0,0 +1,21 @@
+package notification
+
+import "context"
+
+// NotificationRepository defines the interface for notification data operations
+type NotificationRepository interface {
+	// Create stores a notification
+	Create(ctx context.Context, notification *Notification) error
+
+	// GetByUserID retrieves a user's notifications, newest first
+	GetByUserID(ctx context.Context, userID string, limit, offset int) ([]*Notification, error)
+
+	// GetUnreadCount returns the number of unread notifications for a user
+	GetUnreadCount(ctx context.Context, userID string) (int, error)
+
+	// MarkAsRead marks one of the user's notifications as read
+	MarkAsRead(ctx context.Context, notificationID, userID string) error
+
+	// MarkAllAsRead marks all of the user's notifications as read
+	MarkAllAsRead(ctx context.Context, userID string) error
+}
@@ -0,0 +1,129 @@
+package notification
+
+import (
+	"context"
+
+	"mockhu-app-backend/internal/app/mute"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresNotificationRepository implements NotificationRepository for PostgreSQL
+type PostgresNotificationRepository struct {
+	pool *pgxpool.Pool
+}
+
+// notMuted is a SQL predicate that hides notifications from accounts the recipient ($1)
+// muted, and about text written by others that contains a muted keyword
+var notMuted = mute.UserNotMuted("notifications.actor_id", "$1") + `
+		AND ` + mute.TextNotMuted(`COALESCE(
+			(SELECT mc.content FROM post_comments mc WHERE mc.id = notifications.comment_id),
+			(SELECT mp.content FROM posts mp WHERE mp.id = notifications.post_id AND mp.user_id <> notifications.user_id)
+		)`, "$1")
+
+// NewPostgresNotificationRepository creates a new PostgreSQL notification repository
+func NewPostgresNotificationRepository(pool *pgxpool.Pool) *PostgresNotificationRepository {
+	return &PostgresNotificationRepository{pool: pool}
+}
+
+// Create stores a notification
+func (r *PostgresNotificationRepository) Create(ctx context.Context, notification *Notification) error {
+	query := `
+		INSERT INTO notifications (user_id, actor_id, type, post_id, comment_id, report_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, is_read, created_at
+	`
+
+	return r.pool.QueryRow(ctx, query,
+		notification.UserID,
+		notification.ActorID,
+		notification.Type,
+		notification.PostID,
+		notification.CommentID,
+		notification.ReportID,
+	).Scan(&notification.ID, &notification.IsRead, &notification.CreatedAt)
+}
+
+// GetByUserID retrieves a user's notifications, newest first, without muted ones
+func (r *PostgresNotificationRepository) GetByUserID(ctx context.Context, userID string, limit, offset int) ([]*Notification, error) {
+	query := `
+		SELECT id, user_id, actor_id, type, post_id, comment_id, report_id, is_read, read_at, created_at
+		FROM notifications
+		WHERE user_id = $1
+		AND ` + notMuted + `
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notifications []*Notification
+
+	for rows.Next() {
+		n := &Notification{}
+		err := rows.Scan(
+			&n.ID,
+			&n.UserID,
+			&n.ActorID,
+			&n.Type,
+			&n.PostID,
+			&n.CommentID,
+			&n.ReportID,
+			&n.IsRead,
+			&n.ReadAt,
+			&n.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, n)
+	}
+
+	return notifications, rows.Err()
+}
+
+// GetUnreadCount returns the number of unread notifications for a user, without muted ones
+func (r *PostgresNotificationRepository) GetUnreadCount(ctx context.Context, userID string) (int, error) {
+	query := `SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND is_read = false AND ` + notMuted
+
+	var count int
+	err := r.pool.QueryRow(ctx, query, userID).Scan(&count)
+	return count, err
+}
+
+// MarkAsRead marks one of the user's notifications as read
+func (r *PostgresNotificationRepository) MarkAsRead(ctx context.Context, notificationID, userID string) error {
+	query := `
+		UPDATE notifications
+		SET is_read = true, read_at = COALESCE(read_at, NOW())
+		WHERE id = $1 AND user_id = $2
+	`
+
+	result, err := r.pool.Exec(ctx, query, notificationID, userID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// MarkAllAsRead marks all of the user's notifications as read
+func (r *PostgresNotificationRepository) MarkAllAsRead(ctx context.Context, userID string) error {
+	query := `
+		UPDATE notifications
+		SET is_read = true, read_at = NOW()
+		WHERE user_id = $1 AND is_read = false
+	`
+
+	_, err := r.pool.Exec(ctx, query, userID)
+	return err
+}
@@ -0,0 +1,19 @@
+package notification
+
+import (
+	"mockhu-app-backend/internal/pkg/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterRoutes registers all notification routes
+func RegisterRoutes(app *fiber.App, handler *Handler) {
+	v1 := app.Group("/v1")
+
+	// All notification routes require authentication
+	auth := middleware.AuthMiddleware()
+
+	v1.Get("/notifications", auth, handler.GetNotifications)
+	v1.Put("/notifications/read-all", auth, handler.MarkAllAsRead) // Register before /:notificationId
+	v1.Put("/notifications/:notificationId/read", auth, handler.MarkAsRead)
+}
@@ -0,0 +1,162 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"mockhu-app-backend/internal/app/auth"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Errors
+var (
+	ErrNotificationNotFound = errors.New("notification not found")
+)
+
+// NotificationService defines the business logic for notification operations
+type NotificationService interface {
+	Notify(ctx context.Context, notification *Notification) error
+	GetNotifications(ctx context.Context, userID string, page, limit int) (*NotificationListResponse, error)
+	MarkAsRead(ctx context.Context, notificationID, userID string) error
+	MarkAllAsRead(ctx context.Context, userID string) error
+}
+
+// notificationService implements NotificationService
+type notificationService struct {
+	notificationRepo NotificationRepository
+	userRepo         auth.UserRepository
+}
+
+// NewService creates a new notification service
+func NewService(notificationRepo NotificationRepository, userRepo auth.UserRepository) NotificationService {
+	return &notificationService{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+	}
+}
+
+// Notify stores a notification for its recipient
+func (s *notificationService) Notify(ctx context.Context, notification *Notification) error {
+	if err := s.notificationRepo.Create(ctx, notification); err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+	return nil
+}
+
+// GetNotifications retrieves a user's notifications, newest first
+func (s *notificationService) GetNotifications(ctx context.Context, userID string, page, limit int) (*NotificationListResponse, error) {
+	// Validate pagination
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 20
+	}
+
+	offset := (page - 1) * limit
+
+	notifications, err := s.notificationRepo.GetByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+
+	unreadCount, err := s.notificationRepo.GetUnreadCount(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unread count: %w", err)
+	}
+
+	// Load every actor with one query
+	users := auth.NewUserLoader(s.userRepo)
+	actorIDs := make([]string, 0, len(notifications))
+	for _, n := range notifications {
+		if n.ActorID != nil {
+			actorIDs = append(actorIDs, *n.ActorID)
+		}
+	}
+	if err := users.Prime(ctx, actorIDs); err != nil {
+		return nil, fmt.Errorf("failed to get notification actors: %w", err)
+	}
+
+	responses := make([]*NotificationResponse, 0, len(notifications))
+	for _, n := range notifications {
+		response := &NotificationResponse{
+			ID:        n.ID,
+			Type:      n.Type,
+			PostID:    n.PostID,
+			CommentID: n.CommentID,
+			ReportID:  n.ReportID,
+			IsRead:    n.IsRead,
+			CreatedAt: n.CreatedAt.Format(time.RFC3339),
+		}
+
+		if n.ActorID != nil {
+			actor, err := getActorInfo(ctx, users, *n.ActorID)
+			if err != nil {
+				continue // Skip notifications from deleted users
+			}
+			response.Actor = actor
+		}
+
+		responses = append(responses, response)
+	}
+
+	// Calculate total pages (simplified)
+	totalPages := 1
+	if len(notifications) == limit {
+		totalPages = page + 1
+	}
+
+	return &NotificationListResponse{
+		Notifications: responses,
+		UnreadCount:   unreadCount,
+		Pagination: PaginationInfo{
+			Page:       page,
+			TotalPages: totalPages,
+			TotalItems: len(responses),
+			Limit:      limit,
+		},
+	}, nil
+}
+
+// MarkAsRead marks one of the user's notifications as read
+func (s *notificationService) MarkAsRead(ctx context.Context, notificationID, userID string) error {
+	err := s.notificationRepo.MarkAsRead(ctx, notificationID, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNotificationNotFound
+		}
+		return fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+	return nil
+}
+
+// MarkAllAsRead marks all of the user's notifications as read
+func (s *notificationService) MarkAllAsRead(ctx context.Context, userID string) error {
+	if err := s.notificationRepo.MarkAllAsRead(ctx, userID); err != nil {
+		return fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+	return nil
+}
+
+// Helper functions
+
+// getActorInfo retrieves information about the user who triggered a notification
+func getActorInfo(ctx context.Context, users *auth.UserLoader, userID string) (*ActorInfo, error) {
+	user, ok, err := users.Load(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("user not found")
+	}
+
+	return &ActorInfo{
+		ID:        user.ID,
+		Username:  user.Username,
+		FirstName: user.FirstName,
+		AvatarURL: user.AvatarURL,
+	}, nil
+}
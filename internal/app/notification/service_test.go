@@ -0,0 +1,87 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"mockhu-app-backend/internal/app/auth"
+)
+
+// fakeNotificationRepo returns a fixed list of notifications
+type fakeNotificationRepo struct {
+	NotificationRepository
+
+	notifications []*Notification
+}
+
+func (r *fakeNotificationRepo) GetByUserID(ctx context.Context, userID string, limit, offset int) ([]*Notification, error) {
+	return r.notifications, nil
+}
+
+func (r *fakeNotificationRepo) GetUnreadCount(ctx context.Context, userID string) (int, error) {
+	return len(r.notifications), nil
+}
+
+// fakeUserRepo knows every user except those in deleted, and counts its lookups
+type fakeUserRepo struct {
+	auth.UserRepository
+
+	deleted map[string]bool
+	lookups int
+}
+
+func (r *fakeUserRepo) FindByIDs(ctx context.Context, ids []string) ([]*auth.User, error) {
+	r.lookups++
+	users := make([]*auth.User, 0, len(ids))
+	for _, id := range ids {
+		if !r.deleted[id] {
+			users = append(users, &auth.User{ID: id, Username: "user_" + id})
+		}
+	}
+	return users, nil
+}
+
+func TestGetNotificationsLoadsActorsOnce(t *testing.T) {
+	repo := &fakeNotificationRepo{}
+	for i := 0; i < 6; i++ {
+		actorID := fmt.Sprintf("actor-%d", i%3)
+		repo.notifications = append(repo.notifications, &Notification{
+			ID:        fmt.Sprintf("n%d", i),
+			ActorID:   &actorID,
+			Type:      TypeMention,
+			CreatedAt: time.Now(),
+		})
+	}
+	// Anonymous actors are left out
+	repo.notifications = append(repo.notifications, &Notification{ID: "anonymous", Type: TypeMention, CreatedAt: time.Now()})
+
+	users := &fakeUserRepo{deleted: map[string]bool{"actor-2": true}}
+	s := NewService(repo, users)
+
+	resp, err := s.GetNotifications(context.Background(), "user", 1, 20)
+	if err != nil {
+		t.Fatalf("GetNotifications: %v", err)
+	}
+
+	if users.lookups != 1 {
+		t.Errorf("looked up users %d times, want once", users.lookups)
+	}
+
+	// Notifications from deleted users are skipped
+	if len(resp.Notifications) != 5 {
+		t.Fatalf("got %d notifications, want 5", len(resp.Notifications))
+	}
+	for _, n := range resp.Notifications {
+		if n.ID == "anonymous" {
+			if n.Actor != nil {
+				t.Errorf("anonymous notification has actor %+v", n.Actor)
+			}
+			continue
+		}
+		if n.Actor == nil || n.Actor.Username != "user_"+n.Actor.ID || n.Actor.ID == "actor-2" {
+			t.Errorf("notification %s: actor %+v", n.ID, n.Actor)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package poll
+
+import "time"
+
+// CreatePollRequest describes the poll of a new poll post
+type CreatePollRequest struct {
+	Options               []string   `json:"options" validate:"min=2,max=6"`
+	AllowsMultiple        bool       `json:"allows_multiple"`
+	PublicVotes           bool       `json:"public_votes"`
+	HideResultsUntilVoted bool       `json:"hide_results_until_voted"`
+	ClosesAt              *time.Time `json:"closes_at,omitempty"`
+}
+
+// VoteRequest is the request DTO for voting in a poll
+type VoteRequest struct {
+	OptionIDs []string `json:"option_ids" validate:"required,min=1"`
+}
+
+// PollResponse is the poll as seen by a viewer
+type PollResponse struct {
+	Options        []*OptionResponse `json:"options"`
+	AllowsMultiple bool              `json:"allows_multiple"`
+	PublicVotes    bool              `json:"public_votes"`
+	ClosesAt       *string           `json:"closes_at,omitempty"`
+	IsClosed       bool              `json:"is_closed"`
+	HasVoted       bool              `json:"has_voted"`
+	MyVotes        []string          `json:"my_votes"`       // Option IDs the viewer voted for
+	ResultsHidden  bool              `json:"results_hidden"` // Tallies withheld until the viewer votes
+	TotalVoters    *int              `json:"total_voters,omitempty"`
+}
+
+// OptionResponse is a poll option with its tally (nil while results are hidden)
+type OptionResponse struct {
+	ID        string   `json:"id"`
+	Text      string   `json:"text"`
+	VoteCount *int     `json:"vote_count,omitempty"`
+	VoterIDs  []string `json:"voter_ids,omitempty"` // Recent voters, public polls only
+}
@@ -0,0 +1,35 @@
+package poll
+
+import "time"
+
+// Poll limits
+const (
+	MinOptions         = 2
+	MaxOptions         = 6
+	MaxOptionLength    = 100
+	MaxDuration        = 30 * 24 * time.Hour // Polls close at most 30 days out
+	maxVotersPerOption = 10                  // Voters listed per option for public polls
+)
+
+// Poll represents the poll attached to a poll post
+type Poll struct {
+	PostID                string     `json:"post_id"`
+	AllowsMultiple        bool       `json:"allows_multiple"`
+	PublicVotes           bool       `json:"public_votes"`
+	HideResultsUntilVoted bool       `json:"hide_results_until_voted"`
+	ClosesAt              *time.Time `json:"closes_at,omitempty"`
+	CreatedAt             time.Time  `json:"created_at"`
+	Options               []*Option  `json:"options"`
+}
+
+// Option is a choice in a poll
+type Option struct {
+	ID       string `json:"id"`
+	Position int    `json:"position"`
+	Text     string `json:"text"`
+}
+
+// IsClosed reports whether voting has ended
+func (p *Poll) IsClosed(now time.Time) bool {
+	return p.ClosesAt != nil && !now.Before(*p.ClosesAt)
+}
@@ -0,0 +1,28 @@
+package poll
+
+import "context"
+
+// PollRepository defines the interface for poll data operations
+type PollRepository interface {
+	// Create stores a poll and its options, filling in option IDs
+	Create(ctx context.Context, poll *Poll) error
+
+	// GetByPostID retrieves a poll with its options (nil if the post has no poll)
+	GetByPostID(ctx context.Context, postID string) (*Poll, error)
+
+	// GetByPostIDs retrieves the polls of many posts with their options, by post ID
+	GetByPostIDs(ctx context.Context, postIDs []string) (map[string]*Poll, error)
+
+	// GetTalliesAmong returns, by post ID, vote counts per option ID and the number of
+	// distinct voters
+	GetTalliesAmong(ctx context.Context, postIDs []string) (map[string]map[string]int, map[string]int, error)
+
+	// GetUserVotesAmong returns, by post ID, the option IDs a user voted for
+	GetUserVotesAmong(ctx context.Context, postIDs []string, userID string) (map[string][]string, error)
+
+	// GetRecentVotersAmong returns, by post ID, up to limit recent voter IDs per option ID
+	GetRecentVotersAmong(ctx context.Context, postIDs []string, limit int) (map[string]map[string][]string, error)
+
+	// Vote records a user's votes; returns ErrAlreadyVoted if the user has voted in the poll
+	Vote(ctx context.Context, postID, userID string, optionIDs []string) error
+}
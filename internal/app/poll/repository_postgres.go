@@ -0,0 +1,321 @@
+package poll
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresPollRepository implements PollRepository for PostgreSQL
+type PostgresPollRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresPollRepository creates a new PostgreSQL poll repository
+func NewPostgresPollRepository(pool *pgxpool.Pool) *PostgresPollRepository {
+	return &PostgresPollRepository{pool: pool}
+}
+
+// Create stores a poll and its options in a single transaction
+func (r *PostgresPollRepository) Create(ctx context.Context, poll *Poll) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO polls (post_id, allows_multiple, public_votes, hide_results_until_voted, closes_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at
+	`, poll.PostID, poll.AllowsMultiple, poll.PublicVotes, poll.HideResultsUntilVoted, poll.ClosesAt).
+		Scan(&poll.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	for _, option := range poll.Options {
+		err := tx.QueryRow(ctx, `
+			INSERT INTO poll_options (post_id, position, text)
+			VALUES ($1, $2, $3)
+			RETURNING id
+		`, poll.PostID, option.Position, option.Text).Scan(&option.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetByPostID retrieves a poll with its options
+func (r *PostgresPollRepository) GetByPostID(ctx context.Context, postID string) (*Poll, error) {
+	poll := &Poll{}
+
+	err := r.pool.QueryRow(ctx, `
+		SELECT post_id, allows_multiple, public_votes, hide_results_until_voted, closes_at, created_at
+		FROM polls
+		WHERE post_id = $1
+	`, postID).Scan(
+		&poll.PostID,
+		&poll.AllowsMultiple,
+		&poll.PublicVotes,
+		&poll.HideResultsUntilVoted,
+		&poll.ClosesAt,
+		&poll.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, position, text
+		FROM poll_options
+		WHERE post_id = $1
+		ORDER BY position
+	`, postID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		option := &Option{}
+		if err := rows.Scan(&option.ID, &option.Position, &option.Text); err != nil {
+			return nil, err
+		}
+		poll.Options = append(poll.Options, option)
+	}
+
+	return poll, rows.Err()
+}
+
+// GetByPostIDs retrieves the polls of many posts with their options, by post ID
+func (r *PostgresPollRepository) GetByPostIDs(ctx context.Context, postIDs []string) (map[string]*Poll, error) {
+	polls := make(map[string]*Poll, len(postIDs))
+	if len(postIDs) == 0 {
+		return polls, nil
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT post_id, allows_multiple, public_votes, hide_results_until_voted, closes_at, created_at
+		FROM polls
+		WHERE post_id = ANY($1::uuid[])
+	`, postIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		poll := &Poll{}
+		err := rows.Scan(
+			&poll.PostID,
+			&poll.AllowsMultiple,
+			&poll.PublicVotes,
+			&poll.HideResultsUntilVoted,
+			&poll.ClosesAt,
+			&poll.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		polls[poll.PostID] = poll
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	optionRows, err := r.pool.Query(ctx, `
+		SELECT post_id, id, position, text
+		FROM poll_options
+		WHERE post_id = ANY($1::uuid[])
+		ORDER BY post_id, position
+	`, postIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer optionRows.Close()
+
+	for optionRows.Next() {
+		var postID string
+		option := &Option{}
+		if err := optionRows.Scan(&postID, &option.ID, &option.Position, &option.Text); err != nil {
+			return nil, err
+		}
+		if poll, ok := polls[postID]; ok {
+			poll.Options = append(poll.Options, option)
+		}
+	}
+
+	return polls, optionRows.Err()
+}
+
+// GetTalliesAmong returns, by post ID, vote counts per option ID and the number of
+// distinct voters
+func (r *PostgresPollRepository) GetTalliesAmong(ctx context.Context, postIDs []string) (map[string]map[string]int, map[string]int, error) {
+	tallies := make(map[string]map[string]int, len(postIDs))
+	voters := make(map[string]int, len(postIDs))
+	if len(postIDs) == 0 {
+		return tallies, voters, nil
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT post_id, option_id, COUNT(*)
+		FROM poll_votes
+		WHERE post_id = ANY($1::uuid[])
+		GROUP BY post_id, option_id
+	`, postIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var postID, optionID string
+		var count int
+		if err := rows.Scan(&postID, &optionID, &count); err != nil {
+			return nil, nil, err
+		}
+		if tallies[postID] == nil {
+			tallies[postID] = make(map[string]int)
+		}
+		tallies[postID][optionID] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	voterRows, err := r.pool.Query(ctx, `
+		SELECT post_id, COUNT(DISTINCT user_id)
+		FROM poll_votes
+		WHERE post_id = ANY($1::uuid[])
+		GROUP BY post_id
+	`, postIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer voterRows.Close()
+
+	for voterRows.Next() {
+		var postID string
+		var count int
+		if err := voterRows.Scan(&postID, &count); err != nil {
+			return nil, nil, err
+		}
+		voters[postID] = count
+	}
+
+	return tallies, voters, voterRows.Err()
+}
+
+// GetUserVotesAmong returns, by post ID, the option IDs a user voted for
+func (r *PostgresPollRepository) GetUserVotesAmong(ctx context.Context, postIDs []string, userID string) (map[string][]string, error) {
+	votes := make(map[string][]string, len(postIDs))
+	if len(postIDs) == 0 {
+		return votes, nil
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT post_id, option_id FROM poll_votes
+		WHERE post_id = ANY($1::uuid[]) AND user_id = $2
+	`, postIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var postID, optionID string
+		if err := rows.Scan(&postID, &optionID); err != nil {
+			return nil, err
+		}
+		votes[postID] = append(votes[postID], optionID)
+	}
+
+	return votes, rows.Err()
+}
+
+// GetRecentVotersAmong returns, by post ID, up to limit recent voter IDs per option ID
+func (r *PostgresPollRepository) GetRecentVotersAmong(ctx context.Context, postIDs []string, limit int) (map[string]map[string][]string, error) {
+	voters := make(map[string]map[string][]string, len(postIDs))
+	if len(postIDs) == 0 {
+		return voters, nil
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT post_id, option_id, user_id
+		FROM (
+			SELECT post_id, option_id, user_id, created_at,
+			       ROW_NUMBER() OVER (PARTITION BY option_id ORDER BY created_at DESC) AS rn
+			FROM poll_votes
+			WHERE post_id = ANY($1::uuid[])
+		) v
+		WHERE rn <= $2
+		ORDER BY post_id, option_id, created_at DESC
+	`, postIDs, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var postID, optionID, userID string
+		if err := rows.Scan(&postID, &optionID, &userID); err != nil {
+			return nil, err
+		}
+		if voters[postID] == nil {
+			voters[postID] = make(map[string][]string)
+		}
+		voters[postID][optionID] = append(voters[postID][optionID], userID)
+	}
+
+	return voters, rows.Err()
+}
+
+// Vote records a user's votes. The poll row is locked so two concurrent requests from
+// the same user cannot both pass the already-voted check.
+func (r *PostgresPollRepository) Vote(ctx context.Context, postID, userID string, optionIDs []string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var locked string
+	err = tx.QueryRow(ctx, `SELECT post_id FROM polls WHERE post_id = $1 FOR UPDATE`, postID).Scan(&locked)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrPollNotFound
+		}
+		return err
+	}
+
+	var voted bool
+	err = tx.QueryRow(ctx, `
+		SELECT EXISTS(SELECT 1 FROM poll_votes WHERE post_id = $1 AND user_id = $2)
+	`, postID, userID).Scan(&voted)
+	if err != nil {
+		return err
+	}
+	if voted {
+		return ErrAlreadyVoted
+	}
+
+	for _, optionID := range optionIDs {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO poll_votes (post_id, option_id, user_id)
+			VALUES ($1, $2, $3)
+		`, postID, optionID, userID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,87 @@
+package poll
+
+import (
+	"context"
+	"testing"
+
+	"mockhu-app-backend/internal/pkg/testdb"
+)
+
+func TestBatchReads(t *testing.T) {
+	pool := testdb.Pool(t)
+	repo := NewPostgresPollRepository(pool)
+	ctx := context.Background()
+
+	authorID := testdb.CreateUser(t, pool)
+	voterID := testdb.CreateUser(t, pool)
+	otherID := testdb.CreateUser(t, pool)
+
+	var postIDs []string
+	polls := make([]*Poll, 0, 2)
+	for i := 0; i < 2; i++ {
+		var postID string
+		err := pool.QueryRow(ctx, `
+			INSERT INTO posts (user_id, content, post_type) VALUES ($1, 'poll', 'poll') RETURNING id
+		`, authorID).Scan(&postID)
+		if err != nil {
+			t.Fatalf("insert post: %v", err)
+		}
+
+		poll := &Poll{PostID: postID, PublicVotes: true, Options: []*Option{
+			{Position: 0, Text: "Yes"},
+			{Position: 1, Text: "No"},
+		}}
+		if err := repo.Create(ctx, poll); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		postIDs = append(postIDs, postID)
+		polls = append(polls, poll)
+	}
+
+	// Both users vote yes on the first poll; only the other user votes on the second
+	for _, vote := range []struct {
+		poll   *Poll
+		userID string
+		option int
+	}{
+		{polls[0], voterID, 0},
+		{polls[0], otherID, 0},
+		{polls[1], otherID, 1},
+	} {
+		if err := repo.Vote(ctx, vote.poll.PostID, vote.userID, []string{vote.poll.Options[vote.option].ID}); err != nil {
+			t.Fatalf("Vote: %v", err)
+		}
+	}
+
+	got, err := repo.GetByPostIDs(ctx, postIDs)
+	if err != nil {
+		t.Fatalf("GetByPostIDs: %v", err)
+	}
+	if len(got) != 2 || len(got[postIDs[0]].Options) != 2 || got[postIDs[0]].Options[0].Text != "Yes" {
+		t.Fatalf("GetByPostIDs = %+v, want both polls with their options in order", got)
+	}
+
+	tallies, voters, err := repo.GetTalliesAmong(ctx, postIDs)
+	if err != nil {
+		t.Fatalf("GetTalliesAmong: %v", err)
+	}
+	if tallies[postIDs[0]][polls[0].Options[0].ID] != 2 || voters[postIDs[0]] != 2 || voters[postIDs[1]] != 1 {
+		t.Errorf("tallies = %v, voters = %v", tallies, voters)
+	}
+
+	votes, err := repo.GetUserVotesAmong(ctx, postIDs, voterID)
+	if err != nil {
+		t.Fatalf("GetUserVotesAmong: %v", err)
+	}
+	if len(votes) != 1 || len(votes[postIDs[0]]) != 1 || votes[postIDs[0]][0] != polls[0].Options[0].ID {
+		t.Errorf("votes = %v, want the yes vote on the first poll", votes)
+	}
+
+	recent, err := repo.GetRecentVotersAmong(ctx, postIDs, 1)
+	if err != nil {
+		t.Fatalf("GetRecentVotersAmong: %v", err)
+	}
+	if len(recent[postIDs[0]][polls[0].Options[0].ID]) != 1 || len(recent[postIDs[1]][polls[1].Options[1].ID]) != 1 {
+		t.Errorf("recent voters = %v, want one voter per voted option", recent)
+	}
+}
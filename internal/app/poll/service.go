@@ -0,0 +1,281 @@
+package poll
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Errors
+var (
+	ErrPollNotFound   = errors.New("poll not found")
+	ErrInvalidPoll    = errors.New("invalid poll")
+	ErrInvalidOptions = errors.New("invalid poll options")
+	ErrAlreadyVoted   = errors.New("already voted in this poll")
+	ErrPollClosed     = errors.New("poll is closed")
+)
+
+// PollService defines the business logic for polls
+type PollService interface {
+	// Validate checks a poll before its post is created
+	Validate(req *CreatePollRequest) error
+
+	// Create stores the poll of a poll post
+	Create(ctx context.Context, postID string, req *CreatePollRequest) (*Poll, error)
+
+	// Vote records a user's choice; single-choice polls take exactly one option
+	Vote(ctx context.Context, postID, userID string, optionIDs []string) error
+
+	// GetPollResponse returns the poll as seen by viewerID (empty for logged-out viewers),
+	// or nil if the post has no poll
+	GetPollResponse(ctx context.Context, postID, authorID, viewerID string) (*PollResponse, error)
+
+	// GetPollResponses returns the polls of many posts as seen by viewerID, by post ID,
+	// loading each kind of data with one query. authors maps post IDs to their authors;
+	// posts without a poll are left out of the result.
+	GetPollResponses(ctx context.Context, authors map[string]string, viewerID string) (map[string]*PollResponse, error)
+}
+
+// pollService implements PollService
+type pollService struct {
+	pollRepo PollRepository
+}
+
+// NewService creates a new poll service
+func NewService(pollRepo PollRepository) PollService {
+	return &pollService{
+		pollRepo: pollRepo,
+	}
+}
+
+// Validate checks a poll before its post is created
+func (s *pollService) Validate(req *CreatePollRequest) error {
+	if req == nil {
+		return fmt.Errorf("%w: poll is required", ErrInvalidPoll)
+	}
+
+	if len(req.Options) < MinOptions || len(req.Options) > MaxOptions {
+		return fmt.Errorf("%w: polls need %d to %d options", ErrInvalidPoll, MinOptions, MaxOptions)
+	}
+
+	seen := make(map[string]bool, len(req.Options))
+	for _, option := range req.Options {
+		text := strings.TrimSpace(option)
+		if text == "" || utf8.RuneCountInString(text) > MaxOptionLength {
+			return fmt.Errorf("%w: options must be 1 to %d characters", ErrInvalidPoll, MaxOptionLength)
+		}
+
+		key := strings.ToLower(text)
+		if seen[key] {
+			return fmt.Errorf("%w: duplicate option %q", ErrInvalidPoll, text)
+		}
+		seen[key] = true
+	}
+
+	if req.ClosesAt != nil {
+		now := time.Now()
+		if !req.ClosesAt.After(now) {
+			return fmt.Errorf("%w: closing time must be in the future", ErrInvalidPoll)
+		}
+		if req.ClosesAt.After(now.Add(MaxDuration)) {
+			return fmt.Errorf("%w: polls can run for at most 30 days", ErrInvalidPoll)
+		}
+	}
+
+	return nil
+}
+
+// Create stores the poll of a poll post
+func (s *pollService) Create(ctx context.Context, postID string, req *CreatePollRequest) (*Poll, error) {
+	if err := s.Validate(req); err != nil {
+		return nil, err
+	}
+
+	poll := &Poll{
+		PostID:                postID,
+		AllowsMultiple:        req.AllowsMultiple,
+		PublicVotes:           req.PublicVotes,
+		HideResultsUntilVoted: req.HideResultsUntilVoted,
+		ClosesAt:              req.ClosesAt,
+		Options:               make([]*Option, 0, len(req.Options)),
+	}
+	for i, text := range req.Options {
+		poll.Options = append(poll.Options, &Option{
+			Position: i,
+			Text:     strings.TrimSpace(text),
+		})
+	}
+
+	if err := s.pollRepo.Create(ctx, poll); err != nil {
+		return nil, fmt.Errorf("failed to create poll: %w", err)
+	}
+
+	return poll, nil
+}
+
+// Vote records a user's choice; single-choice polls take exactly one option
+func (s *pollService) Vote(ctx context.Context, postID, userID string, optionIDs []string) error {
+	poll, err := s.pollRepo.GetByPostID(ctx, postID)
+	if err != nil {
+		return fmt.Errorf("failed to get poll: %w", err)
+	}
+	if poll == nil {
+		return ErrPollNotFound
+	}
+
+	if poll.IsClosed(time.Now()) {
+		return ErrPollClosed
+	}
+
+	if len(optionIDs) == 0 || (!poll.AllowsMultiple && len(optionIDs) > 1) {
+		return ErrInvalidOptions
+	}
+
+	// Every option must belong to this poll, and each may be chosen once
+	valid := make(map[string]bool, len(poll.Options))
+	for _, option := range poll.Options {
+		valid[option.ID] = true
+	}
+	chosen := make(map[string]bool, len(optionIDs))
+	for _, id := range optionIDs {
+		if !valid[id] || chosen[id] {
+			return ErrInvalidOptions
+		}
+		chosen[id] = true
+	}
+
+	if err := s.pollRepo.Vote(ctx, postID, userID, optionIDs); err != nil {
+		if errors.Is(err, ErrAlreadyVoted) || errors.Is(err, ErrPollNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to save vote: %w", err)
+	}
+
+	return nil
+}
+
+// GetPollResponse returns the poll as seen by viewerID, or nil if the post has no poll
+func (s *pollService) GetPollResponse(ctx context.Context, postID, authorID, viewerID string) (*PollResponse, error) {
+	responses, err := s.GetPollResponses(ctx, map[string]string{postID: authorID}, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	return responses[postID], nil
+}
+
+// GetPollResponses returns the polls of many posts as seen by viewerID, by post ID
+func (s *pollService) GetPollResponses(ctx context.Context, authors map[string]string, viewerID string) (map[string]*PollResponse, error) {
+	responses := make(map[string]*PollResponse, len(authors))
+	if len(authors) == 0 {
+		return responses, nil
+	}
+
+	postIDs := make([]string, 0, len(authors))
+	for postID := range authors {
+		postIDs = append(postIDs, postID)
+	}
+
+	polls, err := s.pollRepo.GetByPostIDs(ctx, postIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(polls) == 0 {
+		return responses, nil
+	}
+
+	pollIDs := make([]string, 0, len(polls))
+	for postID := range polls {
+		pollIDs = append(pollIDs, postID)
+	}
+
+	myVotes := map[string][]string{}
+	if viewerID != "" {
+		myVotes, err = s.pollRepo.GetUserVotesAmong(ctx, pollIDs, viewerID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Build the responses, noting which polls show results and which list voters
+	now := time.Now()
+	shown := make([]string, 0, len(polls))
+	public := make([]string, 0, len(polls))
+	for postID, poll := range polls {
+		response := newPollResponse(poll, authors[postID], viewerID, myVotes[postID], now)
+		responses[postID] = response
+
+		if !response.ResultsHidden {
+			shown = append(shown, postID)
+			if poll.PublicVotes {
+				public = append(public, postID)
+			}
+		}
+	}
+	if len(shown) == 0 {
+		return responses, nil
+	}
+
+	// Tallies are counted on read so they are always current
+	tallies, totalVoters, err := s.pollRepo.GetTalliesAmong(ctx, shown)
+	if err != nil {
+		return nil, err
+	}
+
+	voters := map[string]map[string][]string{}
+	if len(public) > 0 {
+		voters, err = s.pollRepo.GetRecentVotersAmong(ctx, public, maxVotersPerOption)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, postID := range shown {
+		response := responses[postID]
+		total := totalVoters[postID]
+		response.TotalVoters = &total
+
+		for _, option := range response.Options {
+			count := tallies[postID][option.ID]
+			option.VoteCount = &count
+			option.VoterIDs = voters[postID][option.ID]
+		}
+	}
+
+	return responses, nil
+}
+
+// newPollResponse builds a poll's response without its results
+func newPollResponse(poll *Poll, authorID, viewerID string, myVotes []string, now time.Time) *PollResponse {
+	if myVotes == nil {
+		myVotes = []string{}
+	}
+
+	isClosed := poll.IsClosed(now)
+	hasVoted := len(myVotes) > 0
+
+	response := &PollResponse{
+		Options:        make([]*OptionResponse, 0, len(poll.Options)),
+		AllowsMultiple: poll.AllowsMultiple,
+		PublicVotes:    poll.PublicVotes,
+		IsClosed:       isClosed,
+		HasVoted:       hasVoted,
+		MyVotes:        myVotes,
+		ResultsHidden:  poll.HideResultsUntilVoted && !hasVoted && !isClosed && viewerID != authorID,
+	}
+	if poll.ClosesAt != nil {
+		closesAt := poll.ClosesAt.Format(time.RFC3339)
+		response.ClosesAt = &closesAt
+	}
+
+	for _, option := range poll.Options {
+		response.Options = append(response.Options, &OptionResponse{
+			ID:   option.ID,
+			Text: option.Text,
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,307 @@
+package poll
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakePollRepo keeps one poll per post and records votes in memory
+type fakePollRepo struct {
+	PollRepository
+
+	polls map[string]*Poll
+	votes map[string]map[string][]string // post ID -> user ID -> option IDs
+
+	queries      int      // Batch reads made
+	voterLookups []string // Posts whose voters were read
+}
+
+func newFakePollRepo() *fakePollRepo {
+	return &fakePollRepo{
+		polls: make(map[string]*Poll),
+		votes: make(map[string]map[string][]string),
+	}
+}
+
+func (r *fakePollRepo) GetByPostID(ctx context.Context, postID string) (*Poll, error) {
+	return r.polls[postID], nil
+}
+
+func (r *fakePollRepo) GetByPostIDs(ctx context.Context, postIDs []string) (map[string]*Poll, error) {
+	r.queries++
+	polls := make(map[string]*Poll)
+	for _, id := range postIDs {
+		if poll, ok := r.polls[id]; ok {
+			polls[id] = poll
+		}
+	}
+	return polls, nil
+}
+
+func (r *fakePollRepo) GetUserVotesAmong(ctx context.Context, postIDs []string, userID string) (map[string][]string, error) {
+	r.queries++
+	votes := make(map[string][]string)
+	for _, id := range postIDs {
+		if optionIDs, ok := r.votes[id][userID]; ok {
+			votes[id] = optionIDs
+		}
+	}
+	return votes, nil
+}
+
+func (r *fakePollRepo) GetTalliesAmong(ctx context.Context, postIDs []string) (map[string]map[string]int, map[string]int, error) {
+	r.queries++
+	tallies := make(map[string]map[string]int)
+	voters := make(map[string]int)
+	for _, id := range postIDs {
+		tallies[id] = make(map[string]int)
+		for _, optionIDs := range r.votes[id] {
+			for _, optionID := range optionIDs {
+				tallies[id][optionID]++
+			}
+		}
+		voters[id] = len(r.votes[id])
+	}
+	return tallies, voters, nil
+}
+
+func (r *fakePollRepo) GetRecentVotersAmong(ctx context.Context, postIDs []string, limit int) (map[string]map[string][]string, error) {
+	r.queries++
+	r.voterLookups = append(r.voterLookups, postIDs...)
+	voters := make(map[string]map[string][]string)
+	for _, id := range postIDs {
+		voters[id] = make(map[string][]string)
+		for userID, optionIDs := range r.votes[id] {
+			for _, optionID := range optionIDs {
+				voters[id][optionID] = append(voters[id][optionID], userID)
+			}
+		}
+	}
+	return voters, nil
+}
+
+func (r *fakePollRepo) Vote(ctx context.Context, postID, userID string, optionIDs []string) error {
+	if _, ok := r.votes[postID][userID]; ok {
+		return ErrAlreadyVoted
+	}
+	if r.votes[postID] == nil {
+		r.votes[postID] = make(map[string][]string)
+	}
+	r.votes[postID][userID] = optionIDs
+	return nil
+}
+
+// addPoll stores a poll with options "a", "b" and "c" on post
+func (r *fakePollRepo) addPoll(post string, configure func(p *Poll)) {
+	poll := &Poll{
+		PostID: post,
+		Options: []*Option{
+			{ID: "a", Position: 0, Text: "A"},
+			{ID: "b", Position: 1, Text: "B"},
+			{ID: "c", Position: 2, Text: "C"},
+		},
+	}
+	if configure != nil {
+		configure(poll)
+	}
+	r.polls[post] = poll
+}
+
+func TestValidate(t *testing.T) {
+	s := NewService(newFakePollRepo())
+	past := time.Now().Add(-time.Hour)
+	tomorrow := time.Now().Add(24 * time.Hour)
+	tooLate := time.Now().Add(MaxDuration + time.Hour)
+
+	tests := []struct {
+		name    string
+		req     *CreatePollRequest
+		wantErr bool
+	}{
+		{"valid", &CreatePollRequest{Options: []string{"Yes", "No"}}, false},
+		{"closes tomorrow", &CreatePollRequest{Options: []string{"Yes", "No"}, ClosesAt: &tomorrow}, false},
+		{"missing", nil, true},
+		{"one option", &CreatePollRequest{Options: []string{"Yes"}}, true},
+		{"too many options", &CreatePollRequest{Options: []string{"1", "2", "3", "4", "5", "6", "7"}}, true},
+		{"blank option", &CreatePollRequest{Options: []string{"Yes", "  "}}, true},
+		{"long option", &CreatePollRequest{Options: []string{"Yes", strings.Repeat("x", MaxOptionLength+1)}}, true},
+		{"duplicate option", &CreatePollRequest{Options: []string{"Yes", " yes "}}, true},
+		{"closes in the past", &CreatePollRequest{Options: []string{"Yes", "No"}, ClosesAt: &past}, true},
+		{"closes too late", &CreatePollRequest{Options: []string{"Yes", "No"}, ClosesAt: &tooLate}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.Validate(tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate: err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidPoll) {
+				t.Errorf("Validate: err = %v, want ErrInvalidPoll", err)
+			}
+		})
+	}
+}
+
+func TestVote(t *testing.T) {
+	closed := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name      string
+		configure func(p *Poll)
+		postID    string
+		optionIDs []string
+		wantErr   error
+	}{
+		{"single choice", nil, "post", []string{"a"}, nil},
+		{"multiple choice", func(p *Poll) { p.AllowsMultiple = true }, "post", []string{"a", "c"}, nil},
+		{"no poll", nil, "other", []string{"a"}, ErrPollNotFound},
+		{"closed", func(p *Poll) { p.ClosesAt = &closed }, "post", []string{"a"}, ErrPollClosed},
+		{"no options", nil, "post", nil, ErrInvalidOptions},
+		{"two options on single choice", nil, "post", []string{"a", "b"}, ErrInvalidOptions},
+		{"unknown option", nil, "post", []string{"z"}, ErrInvalidOptions},
+		{"repeated option", func(p *Poll) { p.AllowsMultiple = true }, "post", []string{"a", "a"}, ErrInvalidOptions},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakePollRepo()
+			repo.addPoll("post", tt.configure)
+			s := NewService(repo)
+
+			err := s.Vote(context.Background(), tt.postID, "voter", tt.optionIDs)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Vote: err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil && len(repo.votes) != 0 {
+				t.Errorf("Vote saved %v after an error", repo.votes)
+			}
+		})
+	}
+}
+
+func TestVoteTwice(t *testing.T) {
+	repo := newFakePollRepo()
+	repo.addPoll("post", nil)
+	s := NewService(repo)
+	ctx := context.Background()
+
+	if err := s.Vote(ctx, "post", "voter", []string{"a"}); err != nil {
+		t.Fatalf("first vote: %v", err)
+	}
+	if err := s.Vote(ctx, "post", "voter", []string{"b"}); !errors.Is(err, ErrAlreadyVoted) {
+		t.Errorf("second vote: err = %v, want ErrAlreadyVoted", err)
+	}
+}
+
+func TestGetPollResponseHiddenResults(t *testing.T) {
+	repo := newFakePollRepo()
+	repo.addPoll("post", func(p *Poll) { p.HideResultsUntilVoted = true })
+	repo.votes["post"] = map[string][]string{"voter": {"a"}}
+	s := NewService(repo)
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		viewerID   string
+		wantHidden bool
+	}{
+		{"not voted", "viewer", true},
+		{"logged out", "", true},
+		{"voted", "voter", false},
+		{"author", "author", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.GetPollResponse(ctx, "post", "author", tt.viewerID)
+			if err != nil {
+				t.Fatalf("GetPollResponse: %v", err)
+			}
+			if resp.ResultsHidden != tt.wantHidden {
+				t.Fatalf("ResultsHidden = %v, want %v", resp.ResultsHidden, tt.wantHidden)
+			}
+			if tt.wantHidden && (resp.TotalVoters != nil || resp.Options[0].VoteCount != nil) {
+				t.Errorf("hidden results leak tallies: %+v", resp)
+			}
+			if !tt.wantHidden && (resp.TotalVoters == nil || *resp.TotalVoters != 1 || *resp.Options[0].VoteCount != 1) {
+				t.Errorf("results = %+v, want one vote for a", resp)
+			}
+		})
+	}
+}
+
+func TestGetPollResponseClosedShowsResults(t *testing.T) {
+	closed := time.Now().Add(-time.Minute)
+	repo := newFakePollRepo()
+	repo.addPoll("post", func(p *Poll) {
+		p.HideResultsUntilVoted = true
+		p.ClosesAt = &closed
+	})
+	s := NewService(repo)
+
+	resp, err := s.GetPollResponse(context.Background(), "post", "author", "viewer")
+	if err != nil {
+		t.Fatalf("GetPollResponse: %v", err)
+	}
+	if !resp.IsClosed || resp.ResultsHidden {
+		t.Errorf("IsClosed = %v, ResultsHidden = %v; want closed with results shown", resp.IsClosed, resp.ResultsHidden)
+	}
+}
+
+func TestGetPollResponsesBatches(t *testing.T) {
+	repo := newFakePollRepo()
+	repo.addPoll("open", nil)
+	repo.addPoll("public", func(p *Poll) { p.PublicVotes = true })
+	repo.addPoll("hidden", func(p *Poll) {
+		p.PublicVotes = true
+		p.HideResultsUntilVoted = true
+	})
+	repo.votes["open"] = map[string][]string{"viewer": {"b"}, "other": {"b"}}
+	repo.votes["public"] = map[string][]string{"other": {"a"}}
+	repo.votes["hidden"] = map[string][]string{"other": {"c"}}
+	s := NewService(repo)
+
+	authors := map[string]string{"open": "author", "public": "author", "hidden": "author", "text-post": "author"}
+	responses, err := s.GetPollResponses(context.Background(), authors, "viewer")
+	if err != nil {
+		t.Fatalf("GetPollResponses: %v", err)
+	}
+
+	// One query per kind of data, however many polls there are
+	if repo.queries != 4 {
+		t.Errorf("made %d queries, want 4", repo.queries)
+	}
+	if len(responses) != 3 || responses["text-post"] != nil {
+		t.Fatalf("responses for %d posts, want the 3 polls", len(responses))
+	}
+
+	open := responses["open"]
+	if !open.HasVoted || len(open.MyVotes) != 1 || open.MyVotes[0] != "b" {
+		t.Errorf("open poll votes = %v, want the viewer's vote for b", open.MyVotes)
+	}
+	if *open.TotalVoters != 2 || *open.Options[1].VoteCount != 2 || open.Options[1].VoterIDs != nil {
+		t.Errorf("open poll = %+v, want 2 votes for b and no voter list", open)
+	}
+
+	public := responses["public"]
+	if public.HasVoted || len(public.MyVotes) != 0 || *public.Options[0].VoteCount != 1 {
+		t.Errorf("public poll = %+v, want one vote for a", public)
+	}
+	if voters := public.Options[0].VoterIDs; len(voters) != 1 || voters[0] != "other" {
+		t.Errorf("public poll voters = %v, want [other]", voters)
+	}
+
+	// Voters of a poll whose results are hidden are not even loaded
+	if !responses["hidden"].ResultsHidden {
+		t.Error("hidden poll shows results before the viewer voted")
+	}
+	for _, id := range repo.voterLookups {
+		if id == "hidden" {
+			t.Error("loaded the voters of a poll whose results are hidden")
+		}
+	}
+}
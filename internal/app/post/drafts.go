@@ -0,0 +1,388 @@
+package post
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"mockhu-app-backend/internal/app/anonymity"
+	"mockhu-app-backend/internal/app/media"
+	"mockhu-app-backend/internal/app/moderation"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Scheduled publishing tuning
+const (
+	maxScheduleAhead         = 365 * 24 * time.Hour // Posts can be scheduled at most a year out
+	publishBatchSize         = 100                  // Scheduled posts published per job run
+	ScheduledPublishInterval = time.Minute          // How often due scheduled posts are published
+)
+
+// CreateDraft saves a new draft, or a scheduled post if PublishAt is set
+func (s *postService) CreateDraft(ctx context.Context, userID string, req *DraftRequest) (*DraftResponse, error) {
+	if err := validateDraft(req); err != nil {
+		return nil, err
+	}
+
+	if req.IsAnonymous {
+		if err := s.anonymityService.CheckCanPost(ctx, userID, anonymity.ContentPost); err != nil {
+			return nil, err
+		}
+	}
+
+	interestIDs, err := s.resolveInterests(ctx, req.Interests)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rejected text can't be saved even as a draft; flags are queued on publish
+	if _, err := s.moderationService.Check(ctx, moderation.ContentPost, userID, req.Content); err != nil {
+		return nil, err
+	}
+
+	// Only images uploaded by the author can be attached
+	attachments, err := s.mediaService.PrepareForPost(ctx, userID, "", req.Media)
+	if err != nil {
+		return nil, mediaError(err)
+	}
+
+	post := &Post{
+		UserID:      userID,
+		Content:     req.Content,
+		Images:      imageURLs(attachments),
+		IsAnonymous: req.IsAnonymous,
+		PostType:    TypeText,
+		Status:      draftStatus(req),
+		PublishAt:   req.PublishAt,
+		IsActive:    true,
+	}
+
+	if err := s.postRepo.Create(ctx, post); err != nil {
+		return nil, fmt.Errorf("failed to create draft: %w", err)
+	}
+
+	if err := s.mediaService.AttachToPost(ctx, post.ID, attachments); err != nil {
+		_ = s.postRepo.Delete(ctx, post.ID)
+		return nil, err
+	}
+
+	s.tagInterests(ctx, post.ID, interestIDs)
+
+	return s.toDraftResponse(ctx, post), nil
+}
+
+// GetDrafts retrieves the user's drafts and scheduled posts
+func (s *postService) GetDrafts(ctx context.Context, userID string, page, limit int) (*DraftsResponse, error) {
+	// Validate pagination
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 20
+	}
+
+	offset := (page - 1) * limit
+
+	posts, err := s.postRepo.GetDrafts(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get drafts: %w", err)
+	}
+
+	drafts := s.toDraftsResponse(ctx, posts)
+
+	// Calculate total pages (simplified)
+	totalPages := 1
+	if len(drafts) == limit {
+		totalPages = page + 1
+	}
+
+	return &DraftsResponse{
+		Drafts: drafts,
+		Pagination: PaginationInfo{
+			Page:       page,
+			TotalPages: totalPages,
+			TotalItems: len(drafts),
+			Limit:      limit,
+		},
+	}, nil
+}
+
+// GetDraft retrieves one of the user's drafts
+func (s *postService) GetDraft(ctx context.Context, postID, userID string) (*DraftResponse, error) {
+	post, err := s.getDraft(ctx, postID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toDraftResponse(ctx, post), nil
+}
+
+// UpdateDraft edits a draft; setting or clearing PublishAt schedules or unschedules it
+func (s *postService) UpdateDraft(ctx context.Context, postID, userID string, req *DraftRequest) (*DraftResponse, error) {
+	if err := validateDraft(req); err != nil {
+		return nil, err
+	}
+
+	post, err := s.getDraft(ctx, postID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.IsAnonymous {
+		if err := s.anonymityService.CheckCanPost(ctx, userID, anonymity.ContentPost); err != nil {
+			return nil, err
+		}
+	}
+
+	interestIDs, err := s.resolveInterests(ctx, req.Interests)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.moderationService.Check(ctx, moderation.ContentPost, userID, req.Content); err != nil {
+		return nil, err
+	}
+
+	// Only images uploaded by the author can be attached
+	attachments, err := s.mediaService.PrepareForPost(ctx, userID, postID, req.Media)
+	if err != nil {
+		return nil, mediaError(err)
+	}
+
+	post.Content = req.Content
+	post.Images = imageURLs(attachments)
+	post.IsAnonymous = req.IsAnonymous
+	post.Status = draftStatus(req)
+	post.PublishAt = req.PublishAt
+
+	if err := s.postRepo.UpdateDraft(ctx, post); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrDraftNotFound // Published by the scheduler in the meantime
+		}
+		return nil, fmt.Errorf("failed to update draft: %w", err)
+	}
+
+	if err := s.mediaService.AttachToPost(ctx, post.ID, attachments); err != nil {
+		return nil, err
+	}
+
+	s.tagInterests(ctx, post.ID, interestIDs)
+
+	return s.toDraftResponse(ctx, post), nil
+}
+
+// DeleteDraft deletes one of the user's drafts (soft delete)
+func (s *postService) DeleteDraft(ctx context.Context, postID, userID string) error {
+	if _, err := s.getDraft(ctx, postID, userID); err != nil {
+		return err
+	}
+
+	if err := s.postRepo.Delete(ctx, postID); err != nil {
+		return fmt.Errorf("failed to delete draft: %w", err)
+	}
+
+	return nil
+}
+
+// PublishDraft publishes one of the user's drafts immediately
+func (s *postService) PublishDraft(ctx context.Context, postID, userID string) (*PostResponse, error) {
+	post, err := s.getDraft(ctx, postID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The anonymous posting limit applies when the post goes out
+	if post.IsAnonymous {
+		if err := s.anonymityService.CheckCanPost(ctx, userID, anonymity.ContentPost); err != nil {
+			return nil, err
+		}
+	}
+
+	// Checked again in case the rules changed since the draft was saved
+	moderated, err := s.moderationService.Check(ctx, moderation.ContentPost, userID, post.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.postRepo.PublishDraft(ctx, post); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrDraftNotFound
+		}
+		return nil, fmt.Errorf("failed to publish draft: %w", err)
+	}
+
+	// Hashtags and mentions are indexed on publish, so drafts never notify anyone
+	s.indexContent(ctx, post)
+	s.queueForReview(ctx, moderated, post.ID)
+	s.publishToTimelines(ctx, post)
+
+	responses, err := s.convertPostsToResponse(ctx, []*Post{post}, userID)
+	if err != nil || len(responses) == 0 {
+		return nil, fmt.Errorf("failed to build post response: %w", err)
+	}
+
+	return responses[0], nil
+}
+
+// PublishScheduled publishes scheduled posts that are due; run periodically by a background job
+func (s *postService) PublishScheduled(ctx context.Context) error {
+	for {
+		posts, err := s.postRepo.PublishDue(ctx, publishBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to publish scheduled posts: %w", err)
+		}
+
+		for _, post := range posts {
+			s.afterScheduledPublish(ctx, post)
+		}
+
+		if len(posts) > 0 {
+			log.Printf("🗓️ Published %d scheduled posts", len(posts))
+		}
+
+		if len(posts) < publishBatchSize {
+			return s.publishScheduledAnonymous(ctx)
+		}
+	}
+}
+
+// publishScheduledAnonymous publishes due anonymous posts one by one. As in PublishDraft, the
+// anonymous posting limit applies when the post goes out; posts whose author may not post
+// anonymously right now go back to their drafts.
+func (s *postService) publishScheduledAnonymous(ctx context.Context) error {
+	for {
+		posts, err := s.postRepo.GetDueAnonymous(ctx, publishBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to get scheduled anonymous posts: %w", err)
+		}
+
+		for _, post := range posts {
+			denied := s.anonymityService.CheckCanPost(ctx, post.UserID, anonymity.ContentPost)
+			if denied != nil && !errors.Is(denied, anonymity.ErrAnonymousRevoked) && !errors.Is(denied, anonymity.ErrAnonymousRateLimited) {
+				return denied
+			}
+
+			if denied != nil {
+				err = s.postRepo.ReturnToDraft(ctx, post)
+			} else {
+				err = s.postRepo.PublishDraft(ctx, post)
+			}
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue // Edited or published by a concurrent run
+			}
+			if err != nil {
+				return fmt.Errorf("failed to publish scheduled post: %w", err)
+			}
+
+			if denied != nil {
+				log.Printf("🗓️ Returned scheduled anonymous post %s to drafts: %v", post.ID, denied)
+				continue
+			}
+			s.afterScheduledPublish(ctx, post)
+		}
+
+		if len(posts) < publishBatchSize {
+			return nil
+		}
+	}
+}
+
+// afterScheduledPublish indexes, moderates and fans out a scheduled post once it is published
+func (s *postService) afterScheduledPublish(ctx context.Context, post *Post) {
+	s.indexContent(ctx, post)
+	if err := s.moderationService.CheckPublished(ctx, moderation.ContentPost, post.ID, post.UserID, post.Content); err != nil {
+		log.Printf("⚠️ Failed to moderate scheduled post %s: %v", post.ID, err)
+	}
+	s.publishToTimelines(ctx, post)
+}
+
+// getDraft retrieves a draft owned by userID
+func (s *postService) getDraft(ctx context.Context, postID, userID string) (*Post, error) {
+	post, err := s.postRepo.GetDraftByID(ctx, postID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get draft: %w", err)
+	}
+	if post == nil {
+		return nil, ErrDraftNotFound
+	}
+	return post, nil
+}
+
+// toDraftResponse converts a draft to its response DTO
+func (s *postService) toDraftResponse(ctx context.Context, post *Post) *DraftResponse {
+	return s.toDraftsResponse(ctx, []*Post{post})[0]
+}
+
+// toDraftsResponse converts drafts to their response DTOs, loading their images and
+// interests with one query each
+func (s *postService) toDraftsResponse(ctx context.Context, posts []*Post) []*DraftResponse {
+	postIDs := make([]string, 0, len(posts))
+	for _, post := range posts {
+		postIDs = append(postIDs, post.ID)
+	}
+
+	postMedia, err := s.mediaService.GetPostsMedia(ctx, postIDs)
+	if err != nil {
+		log.Printf("⚠️ Failed to get draft media: %v", err)
+	}
+	interests, err := s.postRepo.GetPostsInterests(ctx, postIDs)
+	if err != nil {
+		log.Printf("⚠️ Failed to get draft interests: %v", err)
+	}
+
+	responses := make([]*DraftResponse, 0, len(posts))
+	for _, post := range posts {
+		items := postMedia[post.ID]
+		if items == nil {
+			items = []*media.PostMediaResponse{}
+		}
+
+		response := &DraftResponse{
+			ID:          post.ID,
+			Content:     post.Content,
+			Media:       items,
+			Interests:   toInterestTags(interests[post.ID]),
+			IsAnonymous: post.IsAnonymous,
+			Status:      post.Status,
+			CreatedAt:   post.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:   post.UpdatedAt.Format(time.RFC3339),
+		}
+		if post.PublishAt != nil {
+			publishAt := post.PublishAt.Format(time.RFC3339)
+			response.PublishAt = &publishAt
+		}
+		responses = append(responses, response)
+	}
+	return responses
+}
+
+// validateDraft checks a draft's content, images and publish time
+func validateDraft(req *DraftRequest) error {
+	if len(req.Content) < 1 || len(req.Content) > 5000 {
+		return ErrInvalidContent
+	}
+
+	if len(req.Media) > 10 {
+		return ErrTooManyImages
+	}
+
+	if req.PublishAt != nil {
+		now := time.Now()
+		if !req.PublishAt.After(now) || req.PublishAt.After(now.Add(maxScheduleAhead)) {
+			return ErrInvalidPublishAt
+		}
+	}
+
+	return nil
+}
+
+// draftStatus returns the status a draft request saves
+func draftStatus(req *DraftRequest) string {
+	if req.PublishAt != nil {
+		return StatusScheduled
+	}
+	return StatusDraft
+}
@@ -0,0 +1,131 @@
+package post
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPublishScheduled(t *testing.T) {
+	repo := newFakePostRepo()
+	s := newTestService(repo)
+
+	// More due posts than one batch, so the job has to loop
+	due := publishBatchSize + 5
+	for i := 0; i < due; i++ {
+		publishAt := time.Now().Add(-time.Duration(i+1) * time.Minute)
+		repo.add(&Post{
+			ID:        fmt.Sprintf("due-%d", i),
+			UserID:    "author",
+			Content:   "scheduled #news",
+			Status:    StatusScheduled,
+			PublishAt: &publishAt,
+		})
+	}
+	later := time.Now().Add(time.Hour)
+	repo.add(&Post{ID: "later", UserID: "author", Content: "not yet", Status: StatusScheduled, PublishAt: &later})
+	repo.add(&Post{ID: "draft", UserID: "author", Content: "draft", Status: StatusDraft})
+
+	if err := s.PublishScheduled(context.Background()); err != nil {
+		t.Fatalf("PublishScheduled: %v", err)
+	}
+
+	for id, post := range repo.posts {
+		wantPublished := strings.HasPrefix(id, "due-")
+		if (post.Status == StatusPublished) != wantPublished {
+			t.Errorf("post %s: status %s, want published %v", id, post.Status, wantPublished)
+		}
+		if wantPublished && post.PublishAt != nil {
+			t.Errorf("post %s: publish_at %v, want cleared", id, post.PublishAt)
+		}
+	}
+
+	// Each published post is indexed, moderated and fanned out once
+	timelines := s.timelineService.(*fakeTimelineService)
+	moderation := s.moderationService.(*fakeModerationService)
+	hashtags := s.hashtagRepo.(*fakeHashtagRepo)
+	if len(timelines.published) != due {
+		t.Errorf("published to timelines %d times, want %d", len(timelines.published), due)
+	}
+	if len(moderation.checked) != due {
+		t.Errorf("moderated %d posts, want %d", len(moderation.checked), due)
+	}
+	if tags := hashtags.synced["due-0"]; len(tags) != 1 || tags[0] != "news" {
+		t.Errorf("hashtags of due-0 = %v, want [news]", tags)
+	}
+
+	// A second run has nothing left to do
+	if err := s.PublishScheduled(context.Background()); err != nil {
+		t.Fatalf("PublishScheduled again: %v", err)
+	}
+	if len(timelines.published) != due {
+		t.Errorf("second run published %d more posts", len(timelines.published)-due)
+	}
+}
+
+func TestPublishScheduledAnonymous(t *testing.T) {
+	repo := newFakePostRepo()
+	s := newTestService(repo)
+	s.anonymityService = &fakeAnonymityService{revoked: map[string]bool{"revoked": true}}
+
+	past := time.Now().Add(-time.Minute)
+	for _, author := range []string{"allowed", "revoked"} {
+		publishAt := past
+		repo.add(&Post{
+			ID:          author,
+			UserID:      author,
+			Content:     "anonymous",
+			IsAnonymous: true,
+			Status:      StatusScheduled,
+			PublishAt:   &publishAt,
+		})
+	}
+
+	if err := s.PublishScheduled(context.Background()); err != nil {
+		t.Fatalf("PublishScheduled: %v", err)
+	}
+
+	if post := repo.posts["allowed"]; post.Status != StatusPublished {
+		t.Errorf("allowed author's post: status %s, want published", post.Status)
+	}
+
+	// Revoked after scheduling, so the post goes back to the author's drafts instead
+	if post := repo.posts["revoked"]; post.Status != StatusDraft || post.PublishAt != nil {
+		t.Errorf("revoked author's post: status %s publish_at %v, want an unscheduled draft", post.Status, post.PublishAt)
+	}
+
+	timelines := s.timelineService.(*fakeTimelineService)
+	if len(timelines.published) != 1 || timelines.published[0] != "allowed" {
+		t.Errorf("published to timelines %v, want [allowed]", timelines.published)
+	}
+}
+
+func TestValidateDraft(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	soon := time.Now().Add(time.Hour)
+	tooFar := time.Now().Add(maxScheduleAhead + time.Hour)
+
+	tests := []struct {
+		name    string
+		req     *DraftRequest
+		wantErr error
+	}{
+		{"draft", &DraftRequest{Content: "hello"}, nil},
+		{"scheduled", &DraftRequest{Content: "hello", PublishAt: &soon}, nil},
+		{"empty", &DraftRequest{Content: ""}, ErrInvalidContent},
+		{"too long", &DraftRequest{Content: strings.Repeat("x", 5001)}, ErrInvalidContent},
+		{"in the past", &DraftRequest{Content: "hello", PublishAt: &past}, ErrInvalidPublishAt},
+		{"too far ahead", &DraftRequest{Content: "hello", PublishAt: &tooFar}, ErrInvalidPublishAt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateDraft(tt.req); !errors.Is(err, tt.wantErr) {
+				t.Errorf("validateDraft: err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
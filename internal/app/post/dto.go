@@ -1,28 +1,78 @@
 package post
 
+import (
+	"time"
+
+	"mockhu-app-backend/internal/app/linkpreview"
+	"mockhu-app-backend/internal/app/media"
+	"mockhu-app-backend/internal/app/mention"
+	"mockhu-app-backend/internal/app/poll"
+)
+
 // CreatePostRequest is the request DTO for creating a new post
 type CreatePostRequest struct {
-	Content     string   `json:"content" validate:"required,min=1,max=5000"`
-	Images      []string `json:"images" validate:"max=10"`
-	IsAnonymous bool     `json:"is_anonymous"`
+	Content      string                  `json:"content" validate:"required,min=1,max=5000"`
+	Media        []media.PostMediaInput  `json:"media" validate:"max=10"` // Images uploaded via POST /v1/media
+	IsAnonymous  bool                    `json:"is_anonymous"`
+	Type         string                  `json:"type"`                       // "text" (default), "poll" or "quote"
+	Poll         *poll.CreatePollRequest `json:"poll,omitempty"`             // Required for poll posts; Content is the question
+	QuotedPostID *string                 `json:"quoted_post_id,omitempty"`   // Required for quote posts
+	Interests    []string                `json:"interests" validate:"max=3"` // Interest slugs the post is about
+}
+
+// UpdatePostRequest is the request DTO for editing a post
+type UpdatePostRequest struct {
+	Content   string                 `json:"content" validate:"required,min=1,max=5000"`
+	Media     []media.PostMediaInput `json:"media" validate:"max=10"`
+	Interests []string               `json:"interests" validate:"max=3"` // Replaces the interest tags
+}
+
+// DraftRequest is the request DTO for creating or editing a draft. Drafts are text posts;
+// setting PublishAt schedules the draft, leaving it empty keeps (or turns) it back into a draft.
+type DraftRequest struct {
+	Content     string                 `json:"content" validate:"required,min=1,max=5000"`
+	Media       []media.PostMediaInput `json:"media" validate:"max=10"`
+	IsAnonymous bool                   `json:"is_anonymous"`
+	PublishAt   *time.Time             `json:"publish_at,omitempty"`
+	Interests   []string               `json:"interests" validate:"max=3"`
 }
 
 // PostResponse is the response DTO for a post with enriched data
 type PostResponse struct {
-	ID        string       `json:"id"`
-	Author    AuthorInfo   `json:"author"`
-	Content   string       `json:"content"`
-	Images    []string     `json:"images"`
-	Reactions ReactionInfo `json:"reactions"`
-	CreatedAt string       `json:"created_at"`
+	ID          string                       `json:"id"`
+	Author      AuthorInfo                   `json:"author"`
+	Type        string                       `json:"type"`
+	Content     string                       `json:"content"`
+	Images      []string                     `json:"images"` // Large image URLs, kept for older clients
+	Media       []*media.PostMediaResponse   `json:"media"`
+	Hashtags    []string                     `json:"hashtags"`
+	Interests   []*InterestTag               `json:"interests"`
+	Mentions    []mention.Entity             `json:"mentions"`
+	Poll        *poll.PollResponse           `json:"poll,omitempty"`
+	QuotedPost  *PostResponse                `json:"quoted_post,omitempty"`  // Original of a repost or quote; omitted once it is no longer visible
+	LinkPreview *linkpreview.PreviewResponse `json:"link_preview,omitempty"` // Preview of the first link, once fetched
+	ViewCount   int                          `json:"view_count"`
+	Reactions   ReactionInfo                 `json:"reactions"`
+	IsSavedByMe bool                         `json:"is_saved_by_me"`
+	IsPinned    bool                         `json:"is_pinned"` // Pinned to the author's profile
+	CreatedAt   string                       `json:"created_at"`
 }
 
-// AuthorInfo contains author information for a post
+// AuthorInfo contains author information for a post. For anonymous posts only FirstName
+// is set, to the author's pseudonym in the thread (e.g. "Anonymous Owl").
 type AuthorInfo struct {
-	ID        string `json:"id"`
-	Username  string `json:"username"`
-	FirstName string `json:"first_name"`
-	AvatarURL string `json:"avatar_url"`
+	ID          string `json:"id"`
+	Username    string `json:"username"`
+	FirstName   string `json:"first_name"`
+	AvatarURL   string `json:"avatar_url"`
+	IsAnonymous bool   `json:"is_anonymous,omitempty"`
+}
+
+// InterestTag is an interest a post is tagged with
+type InterestTag struct {
+	Slug     string `json:"slug"`
+	Name     string `json:"name"`
+	Inferred bool   `json:"inferred,omitempty"` // From a hashtag rather than picked by the author
 }
 
 // ReactionInfo contains reaction information for a post
@@ -43,9 +93,65 @@ type PaginationInfo struct {
 // FeedResponse is the response for feed endpoint
 type FeedResponse struct {
 	Posts      []*PostResponse `json:"posts"`
+	Mode       string          `json:"mode,omitempty"`
 	Pagination PaginationInfo  `json:"pagination"`
 }
 
+// DraftResponse is the response DTO for a draft or scheduled post
+type DraftResponse struct {
+	ID          string                     `json:"id"`
+	Content     string                     `json:"content"`
+	Media       []*media.PostMediaResponse `json:"media"`
+	Interests   []*InterestTag             `json:"interests"`
+	IsAnonymous bool                       `json:"is_anonymous"`
+	Status      string                     `json:"status"` // draft or scheduled
+	PublishAt   *string                    `json:"publish_at,omitempty"`
+	CreatedAt   string                     `json:"created_at"`
+	UpdatedAt   string                     `json:"updated_at"`
+}
+
+// DraftsResponse is the response for listing a user's drafts
+type DraftsResponse struct {
+	Drafts     []*DraftResponse `json:"drafts"`
+	Pagination PaginationInfo   `json:"pagination"`
+}
+
+// ReorderPinsRequest is the request DTO for reordering pinned posts
+type ReorderPinsRequest struct {
+	PostIDs []string `json:"post_ids" validate:"max=3"`
+}
+
+// PinsResponse lists a user's pinned posts in display order
+type PinsResponse struct {
+	PostIDs []string `json:"pinned_post_ids"`
+}
+
+// FeedbackResponse is the response for hiding a post from the user's feeds
+type FeedbackResponse struct {
+	PostID string `json:"post_id"` // The original post when a repost was hidden
+	Reason string `json:"reason"`  // hidden or not_interested
+}
+
+// HiddenPostResponse is a post the user hid, with when and why
+type HiddenPostResponse struct {
+	Post     *PostResponse `json:"post"`
+	Reason   string        `json:"reason"` // hidden or not_interested
+	HiddenAt string        `json:"hidden_at"`
+}
+
+// HiddenPostsResponse is the response for listing a user's hidden posts
+type HiddenPostsResponse struct {
+	Posts      []*HiddenPostResponse `json:"posts"`
+	Pagination PaginationInfo        `json:"pagination"`
+}
+
+// PostViewsResponse is the response for a post's view statistics
+type PostViewsResponse struct {
+	PostID        string `json:"post_id"`
+	ViewCount     int    `json:"view_count"`     // Total counted views, deduplicated per viewer per 30 minutes
+	UniqueViewers int    `json:"unique_viewers"` // Distinct logged-in users who saw the post
+}
+
 // ReactionResponse is the response for toggling a reaction
 type ReactionResponse struct {
 	PostID      string `json:"post_id"`
@@ -0,0 +1,35 @@
+package post
+
+import (
+	"time"
+
+	"mockhu-app-backend/internal/app/interest"
+)
+
+// Trending score tuning
+const (
+	trendingWindow        = 72 * time.Hour // Engagement older than this no longer counts
+	trendingHalfLifeHours = 12.0           // Hours for an interaction's weight to halve
+)
+
+// ExploreFilter narrows the explore feed
+type ExploreFilter struct {
+	Category      string // Category (e.g. technology) of an interest the post is tagged with, empty for all
+	InstitutionID string // Author institution, empty for all
+}
+
+// isValidCategory reports whether category is one of the known interest categories
+func isValidCategory(category string) bool {
+	switch category {
+	case interest.CategoryTechnology,
+		interest.CategoryArts,
+		interest.CategorySports,
+		interest.CategoryEntertainment,
+		interest.CategoryLifestyle,
+		interest.CategoryBusiness,
+		interest.CategoryEducation,
+		interest.CategorySocial:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package post
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"mockhu-app-backend/internal/pkg/jwt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestExploreEndpoint(t *testing.T) {
+	const institutionID = "3f2b8c1e-5a6d-4e7f-8a9b-0c1d2e3f4a5b"
+
+	tests := []struct {
+		name       string
+		query      string
+		userID     string
+		wantStatus int
+		wantFilter ExploreFilter
+		wantOffset int
+		wantPosts  int
+	}{
+		{name: "logged out", wantStatus: fiber.StatusOK, wantPosts: 1},
+		{name: "logged in", userID: "viewer", wantStatus: fiber.StatusOK, wantPosts: 1},
+		{name: "author sees own hidden post", userID: "hidden", wantStatus: fiber.StatusOK, wantPosts: 2},
+		{
+			name:       "filters",
+			query:      "?category=technology&institution_id=" + institutionID,
+			wantStatus: fiber.StatusOK,
+			wantFilter: ExploreFilter{Category: "technology", InstitutionID: institutionID},
+			wantPosts:  1,
+		},
+		{name: "second page", query: "?page=2&limit=10", wantStatus: fiber.StatusOK, wantOffset: 10},
+		{name: "limit out of range", query: "?page=2&limit=500", wantStatus: fiber.StatusOK, wantOffset: 20},
+		{name: "unknown category", query: "?category=astrology", wantStatus: fiber.StatusBadRequest},
+		{name: "invalid institution", query: "?institution_id=nope", wantStatus: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakePostRepo()
+			repo.add(&Post{ID: "visible", UserID: "author", Content: "trending"})
+			repo.add(&Post{ID: "hidden", UserID: "hidden", Content: "from a hidden author"})
+			repo.hiddenAuthors["hidden"] = true
+
+			app := fiber.New()
+			RegisterRoutes(app, NewHandler(newTestService(repo)))
+
+			req := httptest.NewRequest("GET", "/v1/explore"+tt.query, nil)
+			if tt.userID != "" {
+				token, err := jwt.GenerateAccessToken(tt.userID, tt.userID+"@example.com", tt.userID)
+				if err != nil {
+					t.Fatalf("GenerateAccessToken: %v", err)
+				}
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("request: %v", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+
+			if tt.wantStatus != fiber.StatusOK {
+				if repo.trendingCalls != 0 {
+					t.Error("invalid request reached the repository")
+				}
+				return
+			}
+
+			if repo.trendingViewer != tt.userID || repo.trendingFilter != tt.wantFilter || repo.trendingOffset != tt.wantOffset {
+				t.Errorf("GetTrending(viewer %q, %+v, offset %d), want (viewer %q, %+v, offset %d)",
+					repo.trendingViewer, repo.trendingFilter, repo.trendingOffset, tt.userID, tt.wantFilter, tt.wantOffset)
+			}
+
+			var feed FeedResponse
+			if err := json.NewDecoder(resp.Body).Decode(&feed); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if len(feed.Posts) != tt.wantPosts {
+				t.Errorf("got %d posts, want %d", len(feed.Posts), tt.wantPosts)
+			}
+		})
+	}
+}
@@ -0,0 +1,549 @@
+package post
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"mockhu-app-backend/internal/app/anonymity"
+	"mockhu-app-backend/internal/app/auth"
+	"mockhu-app-backend/internal/app/hashtag"
+	"mockhu-app-backend/internal/app/interest"
+	"mockhu-app-backend/internal/app/linkpreview"
+	"mockhu-app-backend/internal/app/media"
+	"mockhu-app-backend/internal/app/mention"
+	"mockhu-app-backend/internal/app/moderation"
+	"mockhu-app-backend/internal/app/poll"
+	"mockhu-app-backend/internal/app/timeline"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Test doubles for the post service. Each embeds the interface it fakes, so calling a
+// method a test didn't expect panics instead of silently passing.
+
+// fakePostRepo keeps posts in memory. Posts of authors in hiddenAuthors are invisible to
+// every viewer but the author.
+type fakePostRepo struct {
+	PostRepository
+
+	mu            sync.Mutex
+	posts         map[string]*Post
+	hiddenAuthors map[string]bool
+	pins          map[string][]string // user ID -> pinned post IDs in order
+	savedViews    []*PostView
+	saveViewsErr  error
+	nextID        int
+
+	// Arguments of the last GetTrending call
+	trendingViewer string
+	trendingFilter ExploreFilter
+	trendingOffset int
+	trendingCalls  int
+}
+
+func newFakePostRepo() *fakePostRepo {
+	return &fakePostRepo{
+		posts:         make(map[string]*Post),
+		hiddenAuthors: make(map[string]bool),
+		pins:          make(map[string][]string),
+	}
+}
+
+// add stores a published post and returns it
+func (r *fakePostRepo) add(post *Post) *Post {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if post.PostType == "" {
+		post.PostType = TypeText
+	}
+	if post.Status == "" {
+		post.Status = StatusPublished
+	}
+	if post.CreatedAt.IsZero() {
+		post.CreatedAt = time.Now()
+	}
+	post.IsActive = true
+	r.posts[post.ID] = post
+	return post
+}
+
+func (r *fakePostRepo) visibleTo(post *Post, viewerID string) bool {
+	return post.IsActive && post.Status == StatusPublished &&
+		(!r.hiddenAuthors[post.UserID] || post.UserID == viewerID)
+}
+
+func (r *fakePostRepo) Create(ctx context.Context, post *Post) error {
+	r.mu.Lock()
+	r.nextID++
+	post.ID = fmt.Sprintf("created-%d", r.nextID)
+	r.mu.Unlock()
+	r.add(post)
+	return nil
+}
+
+func (r *fakePostRepo) GetByID(ctx context.Context, id string) (*Post, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	post, ok := r.posts[id]
+	if !ok || !post.IsActive || post.Status != StatusPublished {
+		return nil, nil
+	}
+	return post, nil
+}
+
+func (r *fakePostRepo) GetByUserID(ctx context.Context, userID, viewerID string, limit, offset int) ([]*Post, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var posts []*Post
+	for _, post := range r.posts {
+		if post.UserID != userID || !r.visibleTo(post, viewerID) {
+			continue
+		}
+		if post.IsAnonymous && post.UserID != viewerID {
+			continue
+		}
+		posts = append(posts, post)
+	}
+	sort.Slice(posts, func(i, j int) bool { return posts[i].CreatedAt.After(posts[j].CreatedAt) })
+	return posts, nil
+}
+
+func (r *fakePostRepo) IsVisibleTo(ctx context.Context, postID, viewerID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	post, ok := r.posts[postID]
+	return ok && r.visibleTo(post, viewerID), nil
+}
+
+func (r *fakePostRepo) GetVisibleByIDs(ctx context.Context, ids []string, viewerID string) ([]*Post, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var posts []*Post
+	for _, id := range ids {
+		if post, ok := r.posts[id]; ok && r.visibleTo(post, viewerID) {
+			copied := *post
+			posts = append(posts, &copied)
+		}
+	}
+	return posts, nil
+}
+
+func (r *fakePostRepo) GetRepost(ctx context.Context, userID, originalID string) (*Post, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, post := range r.posts {
+		if post.UserID == userID && post.PostType == TypeRepost && post.IsActive &&
+			post.QuotedPostID != nil && *post.QuotedPostID == originalID {
+			return post, nil
+		}
+	}
+	return nil, nil
+}
+
+// Delete soft deletes a post, leaving it in the map
+func (r *fakePostRepo) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	post, ok := r.posts[id]
+	if !ok || !post.IsActive {
+		return pgx.ErrNoRows
+	}
+	post.IsActive = false
+	return nil
+}
+
+func (r *fakePostRepo) DeleteRepost(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	post, ok := r.posts[id]
+	if !ok || post.PostType != TypeRepost {
+		return pgx.ErrNoRows
+	}
+	delete(r.posts, id)
+	return nil
+}
+
+// GetTrending returns a page of the visible posts, newest first, and records its arguments
+func (r *fakePostRepo) GetTrending(ctx context.Context, viewerID string, filter ExploreFilter, limit, offset int) ([]*Post, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.trendingViewer, r.trendingFilter, r.trendingOffset = viewerID, filter, offset
+	r.trendingCalls++
+
+	var posts []*Post
+	for _, post := range r.posts {
+		if r.visibleTo(post, viewerID) {
+			posts = append(posts, post)
+		}
+	}
+	sort.Slice(posts, func(i, j int) bool { return posts[i].CreatedAt.After(posts[j].CreatedAt) })
+	if offset >= len(posts) {
+		return nil, nil
+	}
+	posts = posts[offset:]
+	if len(posts) > limit {
+		posts = posts[:limit]
+	}
+	return posts, nil
+}
+
+// PublishDue publishes scheduled non-anonymous posts whose publish time has passed, oldest first
+func (r *fakePostRepo) PublishDue(ctx context.Context, limit int) ([]*Post, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	due := r.due(false, limit)
+	for _, post := range due {
+		publish(post)
+	}
+	return due, nil
+}
+
+func (r *fakePostRepo) GetDueAnonymous(ctx context.Context, limit int) ([]*Post, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var posts []*Post
+	for _, post := range r.due(true, limit) {
+		copied := *post
+		posts = append(posts, &copied)
+	}
+	return posts, nil
+}
+
+func (r *fakePostRepo) PublishDraft(ctx context.Context, post *Post) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.posts[post.ID]
+	if !ok || stored.Status == StatusPublished {
+		return pgx.ErrNoRows
+	}
+	publish(stored)
+	*post = *stored
+	return nil
+}
+
+func (r *fakePostRepo) ReturnToDraft(ctx context.Context, post *Post) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.posts[post.ID]
+	if !ok || stored.Status != StatusScheduled {
+		return pgx.ErrNoRows
+	}
+	stored.Status = StatusDraft
+	stored.PublishAt = nil
+	*post = *stored
+	return nil
+}
+
+// due returns the scheduled posts whose publish time has passed, oldest first
+func (r *fakePostRepo) due(anonymous bool, limit int) []*Post {
+	var due []*Post
+	for _, post := range r.posts {
+		if post.Status == StatusScheduled && post.IsAnonymous == anonymous && !post.PublishAt.After(time.Now()) {
+			due = append(due, post)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].PublishAt.Before(*due[j].PublishAt) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due
+}
+
+func publish(post *Post) {
+	post.Status = StatusPublished
+	post.PublishAt = nil
+	post.CreatedAt = time.Now()
+}
+
+func (r *fakePostRepo) SyncInferredInterests(ctx context.Context, postID string) error {
+	return nil
+}
+
+func (r *fakePostRepo) SaveViews(ctx context.Context, views []*PostView) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.saveViewsErr != nil {
+		return r.saveViewsErr
+	}
+	r.savedViews = append(r.savedViews, views...)
+	return nil
+}
+
+func (r *fakePostRepo) GetPostsInterests(ctx context.Context, postIDs []string) (map[string][]*PostInterest, error) {
+	return map[string][]*PostInterest{}, nil
+}
+
+func (r *fakePostRepo) PinPost(ctx context.Context, userID, postID string, maxPins int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range r.pins[userID] {
+		if id == postID {
+			return ErrAlreadyPinned
+		}
+	}
+	if len(r.pins[userID]) >= maxPins {
+		return ErrTooManyPins
+	}
+	r.pins[userID] = append(r.pins[userID], postID)
+	return nil
+}
+
+func (r *fakePostRepo) UnpinPost(ctx context.Context, userID, postID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, id := range r.pins[userID] {
+		if id == postID {
+			r.pins[userID] = append(r.pins[userID][:i:i], r.pins[userID][i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakePostRepo) GetPinnedPostIDs(ctx context.Context, userID string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string{}, r.pins[userID]...), nil
+}
+
+func (r *fakePostRepo) ReorderPins(ctx context.Context, userID string, postIDs []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pins[userID] = append([]string{}, postIDs...)
+	return nil
+}
+
+func (r *fakePostRepo) GetPinnedAmong(ctx context.Context, postIDs []string) (map[string]bool, error) {
+	return map[string]bool{}, nil
+}
+
+func (r *fakePostRepo) GetReactionCounts(ctx context.Context, postIDs []string) (map[string]int, error) {
+	return map[string]int{}, nil
+}
+
+func (r *fakePostRepo) GetRecentReactions(ctx context.Context, postIDs []string, limit int) ([]*Reaction, error) {
+	return nil, nil
+}
+
+func (r *fakePostRepo) GetReactedAmong(ctx context.Context, postIDs []string, userID string) (map[string]bool, error) {
+	return map[string]bool{}, nil
+}
+
+func (r *fakePostRepo) GetSavedAmong(ctx context.Context, postIDs []string, userID string) (map[string]bool, error) {
+	return map[string]bool{}, nil
+}
+
+// fakeUserRepo knows every user ID it is asked for
+type fakeUserRepo struct {
+	auth.UserRepository
+}
+
+func (r *fakeUserRepo) FindByIDs(ctx context.Context, ids []string) ([]*auth.User, error) {
+	users := make([]*auth.User, 0, len(ids))
+	for _, id := range ids {
+		users = append(users, &auth.User{ID: id, Username: "user_" + id, FirstName: "User " + id})
+	}
+	return users, nil
+}
+
+// fakeHashtagRepo records the hashtags synced for each post
+type fakeHashtagRepo struct {
+	hashtag.HashtagRepository
+
+	mu     sync.Mutex
+	synced map[string][]string
+}
+
+func (r *fakeHashtagRepo) SyncPostHashtags(ctx context.Context, postID string, tags []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.synced == nil {
+		r.synced = make(map[string][]string)
+	}
+	r.synced[postID] = tags
+	return nil
+}
+
+// fakeInterestRepo knows a fixed set of interests, keyed by slug
+type fakeInterestRepo struct {
+	interest.InterestRepository
+}
+
+var fakeInterests = map[string]string{"technology": "interest-tech", "music": "interest-music", "sports": "interest-sports", "art": "interest-art"}
+
+func (r *fakeInterestRepo) FindBySlugs(ctx context.Context, slugs []string) ([]interest.Interest, error) {
+	var found []interest.Interest
+	for _, slug := range slugs {
+		if id, ok := fakeInterests[slug]; ok {
+			found = append(found, interest.Interest{ID: id, Slug: slug})
+		}
+	}
+	return found, nil
+}
+
+type fakeMediaService struct {
+	media.MediaService
+}
+
+func (s *fakeMediaService) GetPostsMedia(ctx context.Context, postIDs []string) (map[string][]*media.PostMediaResponse, error) {
+	return map[string][]*media.PostMediaResponse{}, nil
+}
+
+type fakeMentionService struct {
+	mention.MentionService
+}
+
+func (s *fakeMentionService) GetPostsEntities(ctx context.Context, contents map[string]string) map[string][]mention.Entity {
+	return map[string][]mention.Entity{}
+}
+
+func (s *fakeMentionService) SyncPostMentions(ctx context.Context, postID, authorID, content string, isAnonymous bool) error {
+	return nil
+}
+
+type fakeLinkPreviewService struct {
+	linkpreview.LinkPreviewService
+}
+
+func (s *fakeLinkPreviewService) Enqueue(ctx context.Context, text string) error {
+	return nil
+}
+
+func (s *fakeLinkPreviewService) GetPreviews(ctx context.Context, texts map[string]string) map[string]*linkpreview.PreviewResponse {
+	return map[string]*linkpreview.PreviewResponse{}
+}
+
+// fakePollService gives every poll post an empty poll and records each batch it loads and
+// each vote
+type fakePollService struct {
+	poll.PollService
+
+	mu      sync.Mutex
+	batches []map[string]string
+	votes   []string // "postID/userID"
+}
+
+func (s *fakePollService) Vote(ctx context.Context, postID, userID string, optionIDs []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.votes = append(s.votes, postID+"/"+userID)
+	return nil
+}
+
+func (s *fakePollService) GetPollResponses(ctx context.Context, authors map[string]string, viewerID string) (map[string]*poll.PollResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batches = append(s.batches, authors)
+
+	responses := make(map[string]*poll.PollResponse, len(authors))
+	for postID := range authors {
+		responses[postID] = &poll.PollResponse{Options: []*poll.OptionResponse{}, MyVotes: []string{}}
+	}
+	return responses, nil
+}
+
+// fakeModerationService records the published content it was asked to check
+type fakeModerationService struct {
+	moderation.ModerationService
+
+	mu      sync.Mutex
+	checked []string
+}
+
+func (s *fakeModerationService) CheckPublished(ctx context.Context, contentType, contentID, authorID, text string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checked = append(s.checked, contentID)
+	return nil
+}
+
+// fakeAnonymityService lets everyone post anonymously except users in revoked, and records
+// each batch of pseudonyms looked up
+type fakeAnonymityService struct {
+	anonymity.AnonymityService
+
+	revoked map[string]bool
+
+	mu      sync.Mutex
+	batches [][]anonymity.ThreadKey
+}
+
+func (s *fakeAnonymityService) CheckCanPost(ctx context.Context, userID, contentType string) error {
+	if s.revoked[userID] {
+		return anonymity.ErrAnonymousRevoked
+	}
+	return nil
+}
+
+func (s *fakeAnonymityService) Pseudonyms(ctx context.Context, keys []anonymity.ThreadKey) map[anonymity.ThreadKey]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batches = append(s.batches, keys)
+
+	pseudonyms := make(map[anonymity.ThreadKey]string, len(keys))
+	for _, key := range keys {
+		pseudonyms[key] = "Anonymous Owl"
+	}
+	return pseudonyms
+}
+
+// fakeTimelineService records the posts published to and removed from timelines
+type fakeTimelineService struct {
+	timeline.TimelineService
+
+	mu        sync.Mutex
+	published []string
+	removed   []string
+}
+
+func (s *fakeTimelineService) Publish(ctx context.Context, postID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.published = append(s.published, postID)
+	return nil
+}
+
+func (s *fakeTimelineService) Remove(ctx context.Context, postID, authorID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removed = append(s.removed, postID)
+	return nil
+}
+
+// newTestService creates a post service on repo with fakes for its other dependencies
+func newTestService(repo *fakePostRepo) *postService {
+	return &postService{
+		postRepo:           repo,
+		userRepo:           &fakeUserRepo{},
+		hashtagRepo:        &fakeHashtagRepo{},
+		interestRepo:       &fakeInterestRepo{},
+		mediaService:       &fakeMediaService{},
+		pollService:        &fakePollService{},
+		mentionService:     &fakeMentionService{},
+		linkPreviewService: &fakeLinkPreviewService{},
+		moderationService:  &fakeModerationService{},
+		anonymityService:   &fakeAnonymityService{},
+		timelineService:    &fakeTimelineService{},
+		views:              NewViewTracker(repo),
+		scorer:             NewWeightedScorer("test", DefaultWeights()),
+	}
+}
@@ -0,0 +1,108 @@
+package post
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// HidePost hides a post from the user's feeds. With FeedbackNotInterested the ranked feed
+// also down-ranks posts sharing its hashtags and interests. Hiding a repost hides the
+// original, along with every other repost of it. Hiding again changes the reason.
+func (s *postService) HidePost(ctx context.Context, postID, userID, kind string) (*FeedbackResponse, error) {
+	post, err := s.getQuotable(ctx, postID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if post.UserID == userID {
+		return nil, ErrHideOwnPost
+	}
+
+	if err := s.postRepo.SaveFeedback(ctx, userID, post.ID, kind); err != nil {
+		return nil, fmt.Errorf("failed to hide post: %w", err)
+	}
+
+	return &FeedbackResponse{PostID: post.ID, Reason: kind}, nil
+}
+
+// UnhidePost undoes HidePost, for either reason
+func (s *postService) UnhidePost(ctx context.Context, postID, userID string) error {
+	originalID := postID
+	post, err := s.postRepo.GetByID(ctx, postID)
+	if err != nil {
+		return fmt.Errorf("failed to get post: %w", err)
+	}
+	if post != nil && post.PostType == TypeRepost && post.QuotedPostID != nil {
+		originalID = *post.QuotedPostID
+	}
+
+	removed, err := s.postRepo.DeleteFeedback(ctx, userID, originalID)
+	if err != nil {
+		return fmt.Errorf("failed to unhide post: %w", err)
+	}
+	if !removed {
+		return ErrNotHidden
+	}
+
+	return nil
+}
+
+// GetHiddenPosts lists the posts the user hid, most recently hidden first
+func (s *postService) GetHiddenPosts(ctx context.Context, userID string, page, limit int) (*HiddenPostsResponse, error) {
+	// Validate pagination
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 20
+	}
+
+	offset := (page - 1) * limit
+
+	hidden, err := s.postRepo.GetHiddenPosts(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hidden posts: %w", err)
+	}
+
+	posts := make([]*Post, 0, len(hidden))
+	for _, h := range hidden {
+		posts = append(posts, h.Post)
+	}
+	postResponses, err := s.convertPostsToResponse(ctx, posts, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert posts: %w", err)
+	}
+	byID := make(map[string]*PostResponse, len(postResponses))
+	for _, post := range postResponses {
+		byID[post.ID] = post
+	}
+
+	responses := make([]*HiddenPostResponse, 0, len(hidden))
+	for _, h := range hidden {
+		post, ok := byID[h.Post.ID]
+		if !ok {
+			continue
+		}
+		responses = append(responses, &HiddenPostResponse{
+			Post:     post,
+			Reason:   h.Kind,
+			HiddenAt: h.HiddenAt.Format(time.RFC3339),
+		})
+	}
+
+	// Calculate total pages (simplified)
+	totalPages := 1
+	if len(hidden) == limit {
+		totalPages = page + 1
+	}
+
+	return &HiddenPostsResponse{
+		Posts: responses,
+		Pagination: PaginationInfo{
+			Page:       page,
+			TotalPages: totalPages,
+			TotalItems: len(responses),
+			Limit:      limit,
+		},
+	}, nil
+}
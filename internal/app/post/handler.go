@@ -1,9 +1,15 @@
 package post
 
 import (
+	"errors"
 	"strconv"
 
+	"mockhu-app-backend/internal/app/anonymity"
+	"mockhu-app-backend/internal/app/moderation"
+	"mockhu-app-backend/internal/app/poll"
+
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
 // Handler handles HTTP requests for post operations
@@ -47,7 +53,7 @@ func (h *Handler) CreatePost(c *fiber.Ctx) error {
 		})
 	}
 
-	if len(req.Images) > 10 {
+	if len(req.Media) > 10 {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": "too many images (max 10)",
 		})
@@ -56,6 +62,23 @@ func (h *Handler) CreatePost(c *fiber.Ctx) error {
 	// Create post
 	post, err := h.service.CreatePost(c.Context(), currentUserID, &req)
 	if err != nil {
+		var rejection *moderation.RejectionError
+		if errors.As(err, &rejection) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"error":      rejection.Error(),
+				"moderation": rejection,
+			})
+		}
+		if err == anonymity.ErrAnonymousRevoked {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if err == anonymity.ErrAnonymousRateLimited {
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		if err == ErrInvalidContent {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 				"error": "invalid content",
@@ -66,6 +89,31 @@ func (h *Handler) CreatePost(c *fiber.Ctx) error {
 				"error": "too many images",
 			})
 		}
+		if errors.Is(err, ErrInvalidMedia) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if err == ErrTooManyInterests || err == ErrInvalidInterest {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if err == ErrInvalidPostType {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "type must be text, poll or quote; only poll posts can include a poll and only quote posts can quote a post",
+			})
+		}
+		if err == ErrPostNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "quoted post not found",
+			})
+		}
+		if errors.Is(err, poll.ErrInvalidPoll) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "failed to create post",
 		})
@@ -74,6 +122,85 @@ func (h *Handler) CreatePost(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusCreated).JSON(post)
 }
 
+// UpdatePost handles PUT /v1/posts/:postId
+func (h *Handler) UpdatePost(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	// Get post ID from URL
+	postID := c.Params("postId")
+	if postID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "post ID is required",
+		})
+	}
+
+	// Parse request body
+	var req UpdatePostRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	// Update post
+	post, err := h.service.UpdatePost(c.Context(), postID, currentUserID, &req)
+	if err != nil {
+		var rejection *moderation.RejectionError
+		if errors.As(err, &rejection) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"error":      rejection.Error(),
+				"moderation": rejection,
+			})
+		}
+		if err == ErrInvalidContent {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "content must be between 1 and 5000 characters",
+			})
+		}
+		if err == ErrTooManyImages {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "too many images (max 10)",
+			})
+		}
+		if errors.Is(err, ErrInvalidMedia) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if err == ErrTooManyInterests || err == ErrInvalidInterest {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if err == ErrPostNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "post not found",
+			})
+		}
+		if err == ErrUnauthorized {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "unauthorized to edit this post",
+			})
+		}
+		if err == ErrInvalidPostType {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "reposts cannot be edited",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to update post",
+		})
+	}
+
+	return c.JSON(post)
+}
+
 // GetPost handles GET /v1/posts/:postId
 func (h *Handler) GetPost(c *fiber.Ctx) error {
 	// Get post ID from URL
@@ -172,6 +299,45 @@ func (h *Handler) DeletePost(c *fiber.Ctx) error {
 	})
 }
 
+// GetPostViews handles GET /v1/posts/:postId/views (author only)
+func (h *Handler) GetPostViews(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	// Get post ID from URL
+	postID := c.Params("postId")
+	if postID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "post ID is required",
+		})
+	}
+
+	// Get view stats
+	response, err := h.service.GetPostViews(c.Context(), postID, currentUserID)
+	if err != nil {
+		if err == ErrPostNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "post not found",
+			})
+		}
+		if err == ErrUnauthorized {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "only the author can see post views",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get post views",
+		})
+	}
+
+	return c.JSON(response)
+}
+
 // ToggleReaction handles POST /v1/posts/:postId/reactions
 func (h *Handler) ToggleReaction(c *fiber.Ctx) error {
 	// Get current user ID from JWT
@@ -206,7 +372,70 @@ func (h *Handler) ToggleReaction(c *fiber.Ctx) error {
 	return c.JSON(response)
 }
 
-// GetFeed handles GET /v1/feed
+// VotePoll handles POST /v1/posts/:postId/votes
+func (h *Handler) VotePoll(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	// Get post ID from URL
+	postID := c.Params("postId")
+	if postID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "post ID is required",
+		})
+	}
+
+	// Parse request body
+	var req poll.VoteRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	if len(req.OptionIDs) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "option_ids is required",
+		})
+	}
+
+	// Vote
+	post, err := h.service.VotePoll(c.Context(), postID, currentUserID, req.OptionIDs)
+	if err != nil {
+		if err == ErrPostNotFound || err == poll.ErrPollNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "poll not found",
+			})
+		}
+		if err == poll.ErrInvalidOptions {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid options (single-choice polls take exactly one option)",
+			})
+		}
+		if err == poll.ErrAlreadyVoted {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "already voted in this poll",
+			})
+		}
+		if err == poll.ErrPollClosed {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "poll is closed",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to vote",
+		})
+	}
+
+	return c.JSON(post)
+}
+
+// GetFeed handles GET /v1/feed?mode=latest|ranked
 func (h *Handler) GetFeed(c *fiber.Ctx) error {
 	// Get current user ID from JWT
 	currentUserID, ok := c.Locals("user_id").(string)
@@ -216,13 +445,19 @@ func (h *Handler) GetFeed(c *fiber.Ctx) error {
 		})
 	}
 
-	// Parse pagination
+	// Parse pagination and feed mode
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	mode := c.Query("mode", FeedModeLatest)
 
 	// Get feed
-	response, err := h.service.GetFeed(c.Context(), currentUserID, page, limit)
+	response, err := h.service.GetFeed(c.Context(), currentUserID, mode, page, limit)
 	if err != nil {
+		if err == ErrInvalidFeedMode {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid feed mode. Must be 'latest' or 'ranked'",
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "failed to get feed",
 		})
@@ -231,3 +466,633 @@ func (h *Handler) GetFeed(c *fiber.Ctx) error {
 	return c.JSON(response)
 }
 
+
+// GetExplore handles GET /v1/explore (auth optional)
+func (h *Handler) GetExplore(c *fiber.Ctx) error {
+	// Get current user ID (optional, anonymous browsing allowed)
+	currentUserID, _ := c.Locals("user_id").(string)
+
+	// Parse pagination and filters
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	filter := ExploreFilter{
+		Category:      c.Query("category"),
+		InstitutionID: c.Query("institution_id"),
+	}
+
+	if filter.InstitutionID != "" {
+		if _, err := uuid.Parse(filter.InstitutionID); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid institution ID",
+			})
+		}
+	}
+
+	// Get explore feed
+	response, err := h.service.GetExplore(c.Context(), currentUserID, filter, page, limit)
+	if err != nil {
+		if err == ErrInvalidCategory {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid interest category",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get explore feed",
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// GetHashtagPosts handles GET /v1/hashtags/:tag/posts (auth optional)
+func (h *Handler) GetHashtagPosts(c *fiber.Ctx) error {
+	// Get current user ID (optional, anonymous browsing allowed)
+	currentUserID, _ := c.Locals("user_id").(string)
+
+	// Parse pagination
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	// Get tagged posts
+	response, err := h.service.GetHashtagPosts(c.Context(), c.Params("tag"), currentUserID, page, limit)
+	if err != nil {
+		if err == ErrInvalidHashtag {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid hashtag",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get hashtag posts",
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// GetInterestPosts handles GET /v1/interests/:slug/posts (auth optional)
+func (h *Handler) GetInterestPosts(c *fiber.Ctx) error {
+	// Get current user ID (optional, anonymous browsing allowed)
+	currentUserID, _ := c.Locals("user_id").(string)
+
+	// Parse pagination
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	// Get tagged posts
+	response, err := h.service.GetInterestPosts(c.Context(), c.Params("slug"), currentUserID, page, limit)
+	if err != nil {
+		if err == ErrInterestNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "interest not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get interest posts",
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// CreateDraft handles POST /v1/users/me/drafts
+func (h *Handler) CreateDraft(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	// Parse request body
+	var req DraftRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	// Create draft
+	draft, err := h.service.CreateDraft(c.Context(), currentUserID, &req)
+	if err != nil {
+		var rejection *moderation.RejectionError
+		if errors.As(err, &rejection) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"error":      rejection.Error(),
+				"moderation": rejection,
+			})
+		}
+		if err == anonymity.ErrAnonymousRevoked {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if err == anonymity.ErrAnonymousRateLimited {
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if err == ErrInvalidContent {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "content must be between 1 and 5000 characters",
+			})
+		}
+		if err == ErrTooManyImages {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "too many images (max 10)",
+			})
+		}
+		if err == ErrInvalidPublishAt {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if errors.Is(err, ErrInvalidMedia) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if err == ErrTooManyInterests || err == ErrInvalidInterest {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to create draft",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(draft)
+}
+
+// GetDrafts handles GET /v1/users/me/drafts
+func (h *Handler) GetDrafts(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	// Parse pagination
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	response, err := h.service.GetDrafts(c.Context(), currentUserID, page, limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get drafts",
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// GetDraft handles GET /v1/users/me/drafts/:postId
+func (h *Handler) GetDraft(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	postID := c.Params("postId")
+	if _, err := uuid.Parse(postID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid draft ID",
+		})
+	}
+
+	draft, err := h.service.GetDraft(c.Context(), postID, currentUserID)
+	if err != nil {
+		if err == ErrDraftNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "draft not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get draft",
+		})
+	}
+
+	return c.JSON(draft)
+}
+
+// UpdateDraft handles PUT /v1/users/me/drafts/:postId
+func (h *Handler) UpdateDraft(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	postID := c.Params("postId")
+	if _, err := uuid.Parse(postID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid draft ID",
+		})
+	}
+
+	// Parse request body
+	var req DraftRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	// Update draft
+	draft, err := h.service.UpdateDraft(c.Context(), postID, currentUserID, &req)
+	if err != nil {
+		var rejection *moderation.RejectionError
+		if errors.As(err, &rejection) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"error":      rejection.Error(),
+				"moderation": rejection,
+			})
+		}
+		if err == anonymity.ErrAnonymousRevoked {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if err == anonymity.ErrAnonymousRateLimited {
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if err == ErrDraftNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "draft not found",
+			})
+		}
+		if err == ErrInvalidContent {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "content must be between 1 and 5000 characters",
+			})
+		}
+		if err == ErrTooManyImages {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "too many images (max 10)",
+			})
+		}
+		if err == ErrInvalidPublishAt {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if errors.Is(err, ErrInvalidMedia) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if err == ErrTooManyInterests || err == ErrInvalidInterest {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to update draft",
+		})
+	}
+
+	return c.JSON(draft)
+}
+
+// DeleteDraft handles DELETE /v1/users/me/drafts/:postId
+func (h *Handler) DeleteDraft(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	postID := c.Params("postId")
+	if _, err := uuid.Parse(postID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid draft ID",
+		})
+	}
+
+	err := h.service.DeleteDraft(c.Context(), postID, currentUserID)
+	if err != nil {
+		if err == ErrDraftNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "draft not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to delete draft",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "draft deleted successfully",
+	})
+}
+
+// PublishDraft handles POST /v1/users/me/drafts/:postId/publish
+func (h *Handler) PublishDraft(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	postID := c.Params("postId")
+	if _, err := uuid.Parse(postID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid draft ID",
+		})
+	}
+
+	post, err := h.service.PublishDraft(c.Context(), postID, currentUserID)
+	if err != nil {
+		var rejection *moderation.RejectionError
+		if errors.As(err, &rejection) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"error":      rejection.Error(),
+				"moderation": rejection,
+			})
+		}
+		if err == anonymity.ErrAnonymousRevoked {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if err == anonymity.ErrAnonymousRateLimited {
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if err == ErrDraftNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "draft not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to publish draft",
+		})
+	}
+
+	return c.JSON(post)
+}
+
+// GetSavedPosts handles GET /v1/users/me/saved?collection_id=
+func (h *Handler) GetSavedPosts(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	// Optional collection filter
+	collectionID := c.Query("collection_id")
+	if collectionID != "" {
+		if _, err := uuid.Parse(collectionID); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid collection ID",
+			})
+		}
+	}
+
+	// Parse pagination
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	response, err := h.service.GetSavedPosts(c.Context(), currentUserID, collectionID, page, limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get saved posts",
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// PinPost handles POST /v1/posts/:postId/pin
+func (h *Handler) PinPost(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	postID := c.Params("postId")
+	if _, err := uuid.Parse(postID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid post ID",
+		})
+	}
+
+	response, err := h.service.PinPost(c.Context(), postID, currentUserID)
+	if err != nil {
+		if err == ErrPostNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "post not found",
+			})
+		}
+		if err == ErrUnauthorized {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "you can only pin your own posts",
+			})
+		}
+		if err == ErrPinAnonymous {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if err == ErrAlreadyPinned || err == ErrTooManyPins {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to pin post",
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// UnpinPost handles DELETE /v1/posts/:postId/pin
+func (h *Handler) UnpinPost(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	postID := c.Params("postId")
+	if _, err := uuid.Parse(postID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid post ID",
+		})
+	}
+
+	response, err := h.service.UnpinPost(c.Context(), postID, currentUserID)
+	if err != nil {
+		if err == ErrNotPinned {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "post is not pinned",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to unpin post",
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// ReorderPins handles PUT /v1/users/me/pins
+func (h *Handler) ReorderPins(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	// Parse request body
+	var req ReorderPinsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	response, err := h.service.ReorderPins(c.Context(), currentUserID, req.PostIDs)
+	if err != nil {
+		if err == ErrInvalidPinOrder {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to reorder pins",
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// HidePost handles POST /v1/posts/:postId/hide
+func (h *Handler) HidePost(c *fiber.Ctx) error {
+	return h.hidePost(c, FeedbackHidden)
+}
+
+// MarkNotInterested handles POST /v1/posts/:postId/not-interested
+func (h *Handler) MarkNotInterested(c *fiber.Ctx) error {
+	return h.hidePost(c, FeedbackNotInterested)
+}
+
+// hidePost hides a post from the current user's feeds with the given feedback kind
+func (h *Handler) hidePost(c *fiber.Ctx, kind string) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	postID := c.Params("postId")
+	if _, err := uuid.Parse(postID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid post ID",
+		})
+	}
+
+	response, err := h.service.HidePost(c.Context(), postID, currentUserID, kind)
+	if err != nil {
+		if err == ErrPostNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "post not found",
+			})
+		}
+		if err == ErrHideOwnPost {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to hide post",
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// UnhidePost handles DELETE /v1/posts/:postId/hide and DELETE /v1/posts/:postId/not-interested
+func (h *Handler) UnhidePost(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	postID := c.Params("postId")
+	if _, err := uuid.Parse(postID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid post ID",
+		})
+	}
+
+	if err := h.service.UnhidePost(c.Context(), postID, currentUserID); err != nil {
+		if err == ErrNotHidden {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to unhide post",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "post unhidden successfully",
+	})
+}
+
+// GetHiddenPosts handles GET /v1/users/me/hidden-posts
+func (h *Handler) GetHiddenPosts(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	// Parse pagination
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	response, err := h.service.GetHiddenPosts(c.Context(), currentUserID, page, limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get hidden posts",
+		})
+	}
+
+	return c.JSON(response)
+}
@@ -0,0 +1,134 @@
+package post
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// maxPostInterests is how many interests an author can tag a post with
+const maxPostInterests = 3
+
+// GetInterestPosts retrieves the posts tagged with an interest, newest first
+func (s *postService) GetInterestPosts(ctx context.Context, slug, currentUserID string, page, limit int) (*FeedResponse, error) {
+	interests, err := s.interestRepo.FindBySlugs(ctx, []string{strings.ToLower(strings.TrimSpace(slug))})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get interest: %w", err)
+	}
+	if len(interests) == 0 {
+		return nil, ErrInterestNotFound
+	}
+
+	// Validate pagination
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 20
+	}
+
+	offset := (page - 1) * limit
+
+	// Get tagged posts
+	posts, err := s.postRepo.GetByInterest(ctx, currentUserID, interests[0].ID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get interest posts: %w", err)
+	}
+
+	// Convert to response
+	postResponses, err := s.convertPostsToResponse(ctx, posts, currentUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert posts: %w", err)
+	}
+
+	// Count impressions of the posts shown
+	s.views.RecordPosts(shownPosts(posts, postResponses), currentUserID)
+
+	// Calculate total pages (simplified)
+	totalPages := 1
+	if len(postResponses) == limit {
+		totalPages = page + 1
+	}
+
+	return &FeedResponse{
+		Posts: postResponses,
+		Pagination: PaginationInfo{
+			Page:       page,
+			TotalPages: totalPages,
+			TotalItems: len(postResponses),
+			Limit:      limit,
+		},
+	}, nil
+}
+
+// Helper functions
+
+// resolveInterests validates the interest slugs an author picked and returns their IDs.
+// Slugs are matched case-insensitively and duplicates are ignored.
+func (s *postService) resolveInterests(ctx context.Context, slugs []string) ([]string, error) {
+	seen := make(map[string]bool, len(slugs))
+	normalized := make([]string, 0, len(slugs))
+	for _, slug := range slugs {
+		slug = strings.ToLower(strings.TrimSpace(slug))
+		if slug == "" {
+			return nil, ErrInvalidInterest
+		}
+		if !seen[slug] {
+			seen[slug] = true
+			normalized = append(normalized, slug)
+		}
+	}
+
+	if len(normalized) > maxPostInterests {
+		return nil, ErrTooManyInterests
+	}
+	if len(normalized) == 0 {
+		return nil, nil
+	}
+
+	interests, err := s.interestRepo.FindBySlugs(ctx, normalized)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get interests: %w", err)
+	}
+	if len(interests) != len(normalized) {
+		return nil, ErrInvalidInterest
+	}
+
+	ids := make([]string, 0, len(interests))
+	for _, interest := range interests {
+		ids = append(ids, interest.ID)
+	}
+	return ids, nil
+}
+
+// tagInterests replaces the interests the author tagged a post with
+func (s *postService) tagInterests(ctx context.Context, postID string, interestIDs []string) {
+	if err := s.postRepo.SetPostInterests(ctx, postID, interestIDs); err != nil {
+		log.Printf("⚠️ Failed to tag interests for post %s: %v", postID, err)
+	}
+}
+
+// getPostInterests returns the interests a post is tagged with, by the author or inferred
+// from its hashtags
+func (s *postService) getPostInterests(ctx context.Context, postID string) []*InterestTag {
+	interests, err := s.postRepo.GetPostInterests(ctx, postID)
+	if err != nil {
+		log.Printf("⚠️ Failed to get interests for post %s: %v", postID, err)
+		return []*InterestTag{}
+	}
+	return toInterestTags(interests)
+}
+
+// toInterestTags converts the interests a post is tagged with to their response
+func toInterestTags(interests []*PostInterest) []*InterestTag {
+	tags := make([]*InterestTag, 0, len(interests))
+	for _, interest := range interests {
+		tags = append(tags, &InterestTag{
+			Slug:     interest.Slug,
+			Name:     interest.Name,
+			Inferred: interest.Source == InterestSourceHashtag,
+		})
+	}
+	return tags
+}
@@ -0,0 +1,75 @@
+package post
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestResolveInterests(t *testing.T) {
+	tests := []struct {
+		name    string
+		slugs   []string
+		wantIDs []string
+		wantErr error
+	}{
+		{name: "none"},
+		{name: "known", slugs: []string{"technology", "music"}, wantIDs: []string{"interest-tech", "interest-music"}},
+		{name: "case and spacing", slugs: []string{" Technology "}, wantIDs: []string{"interest-tech"}},
+		{name: "duplicates count once", slugs: []string{"art", "ART", "music", "sports"}, wantIDs: []string{"interest-art", "interest-music", "interest-sports"}},
+		{name: "unknown", slugs: []string{"technology", "astrology"}, wantErr: ErrInvalidInterest},
+		{name: "empty slug", slugs: []string{""}, wantErr: ErrInvalidInterest},
+		{name: "too many", slugs: []string{"technology", "music", "sports", "art"}, wantErr: ErrTooManyInterests},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(newFakePostRepo())
+
+			ids, err := s.resolveInterests(context.Background(), tt.slugs)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("resolveInterests: err = %v, want %v", err, tt.wantErr)
+			}
+			if len(ids) != 0 || len(tt.wantIDs) != 0 {
+				if !reflect.DeepEqual(ids, tt.wantIDs) {
+					t.Errorf("resolveInterests = %v, want %v", ids, tt.wantIDs)
+				}
+			}
+		})
+	}
+}
+
+func TestCreatePostRejectsInvalidInterests(t *testing.T) {
+	tests := []struct {
+		name    string
+		slugs   []string
+		wantErr error
+	}{
+		{"unknown", []string{"astrology"}, ErrInvalidInterest},
+		{"too many", []string{"technology", "music", "sports", "art"}, ErrTooManyInterests},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakePostRepo()
+			s := newTestService(repo)
+
+			_, err := s.CreatePost(context.Background(), "author", &CreatePostRequest{Content: "tagged", Interests: tt.slugs})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CreatePost: err = %v, want %v", err, tt.wantErr)
+			}
+			if len(repo.posts) != 0 {
+				t.Error("CreatePost stored the post")
+			}
+		})
+	}
+}
+
+func TestGetInterestPostsUnknownInterest(t *testing.T) {
+	s := newTestService(newFakePostRepo())
+
+	if _, err := s.GetInterestPosts(context.Background(), "astrology", "viewer", 1, 20); !errors.Is(err, ErrInterestNotFound) {
+		t.Errorf("GetInterestPosts: err = %v, want ErrInterestNotFound", err)
+	}
+}
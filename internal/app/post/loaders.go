@@ -0,0 +1,231 @@
+package post
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"mockhu-app-backend/internal/app/anonymity"
+	"mockhu-app-backend/internal/app/auth"
+	"mockhu-app-backend/internal/app/linkpreview"
+	"mockhu-app-backend/internal/app/media"
+	"mockhu-app-backend/internal/app/mention"
+	"mockhu-app-backend/internal/app/poll"
+	"mockhu-app-backend/internal/pkg/entities"
+)
+
+// recentReactorsLimit is how many of the latest reactors are shown on a post
+const recentReactorsLimit = 5
+
+// responseLoaders holds the data shown on the responses for a page of posts, loaded with one
+// query per kind of data for the whole page instead of one per post. Create one per call.
+type responseLoaders struct {
+	viewerID string
+
+	users          *auth.UserLoader
+	pseudonyms     *anonymity.PseudonymLoader
+	originals      map[string]*Post // Visible originals of reposts and quotes, by ID
+	media          map[string][]*media.PostMediaResponse
+	interests      map[string][]*PostInterest
+	mentions       map[string][]mention.Entity
+	previews       map[string]*linkpreview.PreviewResponse
+	polls          map[string]*poll.PollResponse
+	reactionCounts map[string]int
+	reacted        map[string]bool
+	recentReactors map[string][]string // User IDs, newest first
+	saved          map[string]bool
+	pinned         map[string]bool
+}
+
+// loadResponseData loads everything needed to build the responses for posts and the posts
+// they embed. Failing to load optional data only leaves it out of the responses; failing to
+// load authors is an error.
+func (s *postService) loadResponseData(ctx context.Context, posts []*Post, viewerID string) (*responseLoaders, error) {
+	l := &responseLoaders{
+		viewerID:   viewerID,
+		users:      auth.NewUserLoader(s.userRepo),
+		pseudonyms: anonymity.NewPseudonymLoader(s.anonymityService),
+		originals:  make(map[string]*Post),
+	}
+
+	// Load the originals first so their data is loaded along with the page's
+	quotedIDs := make([]string, 0)
+	for _, post := range posts {
+		if post.QuotedPostID != nil {
+			quotedIDs = append(quotedIDs, *post.QuotedPostID)
+		}
+	}
+	if len(quotedIDs) > 0 {
+		originals, err := s.postRepo.GetVisibleByIDs(ctx, quotedIDs, viewerID)
+		if err != nil {
+			log.Printf("⚠️ Failed to get quoted posts: %v", err)
+		}
+		for _, original := range originals {
+			// Only one level is embedded: a quoted quote post comes without its own original
+			original.QuotedPostID = nil
+			l.originals[original.ID] = original
+		}
+	}
+
+	all := make([]*Post, 0, len(posts)+len(l.originals))
+	all = append(all, posts...)
+	for _, original := range l.originals {
+		all = append(all, original)
+	}
+
+	postIDs := make([]string, 0, len(all))
+	contents := make(map[string]string, len(all))
+	authorIDs := make([]string, 0, len(all))
+	threads := make([]anonymity.ThreadKey, 0)
+	pollAuthors := make(map[string]string)
+	for _, post := range all {
+		postIDs = append(postIDs, post.ID)
+		contents[post.ID] = post.Content
+		if post.IsAnonymous {
+			threads = append(threads, anonymity.ThreadKey{PostID: post.ID, UserID: post.UserID})
+		} else {
+			authorIDs = append(authorIDs, post.UserID)
+		}
+		if post.PostType == TypePoll {
+			pollAuthors[post.ID] = post.UserID
+		}
+	}
+
+	var err error
+
+	if l.media, err = s.mediaService.GetPostsMedia(ctx, postIDs); err != nil {
+		log.Printf("⚠️ Failed to get post media: %v", err)
+	}
+	if l.interests, err = s.postRepo.GetPostsInterests(ctx, postIDs); err != nil {
+		log.Printf("⚠️ Failed to get post interests: %v", err)
+	}
+	l.mentions = s.mentionService.GetPostsEntities(ctx, contents)
+	l.previews = s.linkPreviewService.GetPreviews(ctx, contents)
+	if l.polls, err = s.pollService.GetPollResponses(ctx, pollAuthors, viewerID); err != nil {
+		log.Printf("⚠️ Failed to get polls: %v", err)
+	}
+	if l.pinned, err = s.postRepo.GetPinnedAmong(ctx, postIDs); err != nil {
+		log.Printf("⚠️ Failed to get pinned posts: %v", err)
+	}
+
+	// Reactions
+	if l.reactionCounts, err = s.postRepo.GetReactionCounts(ctx, postIDs); err != nil {
+		log.Printf("⚠️ Failed to get reaction counts: %v", err)
+	}
+	reactions, err := s.postRepo.GetRecentReactions(ctx, postIDs, recentReactorsLimit)
+	if err != nil {
+		log.Printf("⚠️ Failed to get recent reactions: %v", err)
+	}
+	l.recentReactors = make(map[string][]string)
+	for _, reaction := range reactions {
+		l.recentReactors[reaction.PostID] = append(l.recentReactors[reaction.PostID], reaction.UserID)
+		authorIDs = append(authorIDs, reaction.UserID)
+	}
+
+	// The viewer's own state (nothing for logged-out viewers)
+	if viewerID != "" {
+		if l.reacted, err = s.postRepo.GetReactedAmong(ctx, postIDs, viewerID); err != nil {
+			log.Printf("⚠️ Failed to check reactions of user %s: %v", viewerID, err)
+		}
+		if l.saved, err = s.postRepo.GetSavedAmong(ctx, postIDs, viewerID); err != nil {
+			log.Printf("⚠️ Failed to check saved posts of user %s: %v", viewerID, err)
+		}
+	}
+
+	// Authors and reactors together, then the pseudonyms of anonymous authors
+	if err := l.users.Prime(ctx, authorIDs); err != nil {
+		return nil, err
+	}
+	if err := l.pseudonyms.Prime(ctx, threads); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// buildResponse builds the response for a post from loaded data, or nil if the post can't
+// be shown: its author is gone, or it is a repost whose original was deleted or is hidden
+// from the viewer
+func (s *postService) buildResponse(ctx context.Context, l *responseLoaders, post *Post) *PostResponse {
+	// Get author info
+	author, err := s.getPostAuthor(ctx, l.users, l.pseudonyms, post)
+	if err != nil {
+		return nil
+	}
+
+	var quotedPost *PostResponse
+	if post.QuotedPostID != nil {
+		if original, ok := l.originals[*post.QuotedPostID]; ok {
+			quotedPost = s.buildResponse(ctx, l, original)
+		}
+	}
+	if post.PostType == TypeRepost && quotedPost == nil {
+		return nil
+	}
+
+	postMedia := l.media[post.ID]
+	if postMedia == nil {
+		postMedia = []*media.PostMediaResponse{}
+	}
+
+	mentions := l.mentions[post.ID]
+	if mentions == nil {
+		mentions = []mention.Entity{}
+	}
+
+	return &PostResponse{
+		ID:          post.ID,
+		Author:      *author,
+		Type:        post.PostType,
+		Content:     post.Content,
+		Images:      post.Images,
+		Media:       postMedia,
+		Hashtags:    entities.HashtagValues(post.Content),
+		Interests:   toInterestTags(l.interests[post.ID]),
+		Mentions:    mentions,
+		Poll:        l.polls[post.ID],
+		QuotedPost:  quotedPost,
+		LinkPreview: l.previews[post.ID],
+		ViewCount:   post.ViewCount,
+		Reactions:   l.reactionInfo(ctx, post.ID),
+		IsSavedByMe: l.saved[post.ID],
+		IsPinned:    l.pinned[post.ID],
+		CreatedAt:   post.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// reactionInfo returns the loaded reaction information for a post
+func (l *responseLoaders) reactionInfo(ctx context.Context, postID string) ReactionInfo {
+	recentUsers := make([]AuthorInfo, 0, len(l.recentReactors[postID]))
+	for _, userID := range l.recentReactors[postID] {
+		author, err := getAuthorInfo(ctx, l.users, userID)
+		if err == nil {
+			recentUsers = append(recentUsers, *author)
+		}
+	}
+
+	return ReactionInfo{
+		FireCount:   l.reactionCounts[postID],
+		IsFiredByMe: l.reacted[postID],
+		RecentUsers: recentUsers,
+	}
+}
+
+// getAuthorInfo retrieves author information for a post
+func getAuthorInfo(ctx context.Context, users *auth.UserLoader, userID string) (*AuthorInfo, error) {
+	user, ok, err := users.Load(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("user not found")
+	}
+
+	return &AuthorInfo{
+		ID:        user.ID,
+		Username:  user.Username,
+		FirstName: user.FirstName,
+		AvatarURL: user.AvatarURL,
+	}, nil
+}
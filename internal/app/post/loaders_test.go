@@ -0,0 +1,66 @@
+package post
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestConvertPostsLoadsPollsOnce(t *testing.T) {
+	repo := newFakePostRepo()
+	s := newTestService(repo)
+
+	var posts []*Post
+	for i := 0; i < 5; i++ {
+		postType := TypeText
+		if i%2 == 0 {
+			postType = TypePoll
+		}
+		posts = append(posts, repo.add(&Post{ID: fmt.Sprintf("p%d", i), UserID: "author", Content: "post", PostType: postType}))
+	}
+
+	responses, err := s.convertPostsToResponse(context.Background(), posts, "viewer")
+	if err != nil {
+		t.Fatalf("convertPostsToResponse: %v", err)
+	}
+
+	polls := s.pollService.(*fakePollService)
+	if len(polls.batches) != 1 {
+		t.Fatalf("loaded polls %d times, want once", len(polls.batches))
+	}
+	if len(polls.batches[0]) != 3 {
+		t.Errorf("loaded polls of %v, want the 3 poll posts", polls.batches[0])
+	}
+
+	for _, response := range responses {
+		if (response.Poll != nil) != (response.Type == TypePoll) {
+			t.Errorf("post %s (%s): poll %v", response.ID, response.Type, response.Poll)
+		}
+	}
+}
+
+func TestConvertPostsLoadsPseudonymsOnce(t *testing.T) {
+	repo := newFakePostRepo()
+	s := newTestService(repo)
+
+	var posts []*Post
+	for i := 0; i < 5; i++ {
+		posts = append(posts, repo.add(&Post{ID: fmt.Sprintf("p%d", i), UserID: fmt.Sprintf("author-%d", i), Content: "post", IsAnonymous: i > 0}))
+	}
+
+	responses, err := s.convertPostsToResponse(context.Background(), posts, "viewer")
+	if err != nil {
+		t.Fatalf("convertPostsToResponse: %v", err)
+	}
+
+	anonymity := s.anonymityService.(*fakeAnonymityService)
+	if len(anonymity.batches) != 1 || len(anonymity.batches[0]) != 4 {
+		t.Fatalf("looked up pseudonyms in batches %v, want the 4 anonymous posts at once", anonymity.batches)
+	}
+
+	for _, response := range responses {
+		if response.Author.IsAnonymous && (response.Author.FirstName != "Anonymous Owl" || response.Author.ID != "") {
+			t.Errorf("post %s: author %+v, want only the pseudonym", response.ID, response.Author)
+		}
+	}
+}
@@ -2,17 +2,48 @@ package post
 
 import "time"
 
+// Post types
+const (
+	TypeText   = "text"
+	TypePoll   = "poll"
+	TypeRepost = "repost" // Shares another post to the author's followers, no content of its own
+	TypeQuote  = "quote"  // Shares another post with the author's commentary
+)
+
+// Post statuses; only published posts are shown outside the author's drafts
+const (
+	StatusDraft     = "draft"
+	StatusScheduled = "scheduled"
+	StatusPublished = "published"
+)
+
+// Feed feedback kinds; both hide a post from the user's feeds
+const (
+	FeedbackHidden        = "hidden"
+	FeedbackNotInterested = "not_interested" // Also down-ranks posts on the same topics
+)
+
+// Interest tag sources
+const (
+	InterestSourceAuthor  = "author"  // Picked by the author, max 3
+	InterestSourceHashtag = "hashtag" // Inferred from a hashtag linked to the interest
+)
+
 // Post represents a user's post
 type Post struct {
-	ID          string    `json:"id"`
-	UserID      string    `json:"user_id"`
-	Content     string    `json:"content"`
-	Images      []string  `json:"images"`
-	IsAnonymous bool      `json:"is_anonymous"`
-	IsActive    bool      `json:"is_active"`
-	ViewCount   int       `json:"view_count"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID           string     `json:"id"`
+	UserID       string     `json:"user_id"`
+	Content      string     `json:"content"`
+	Images       []string   `json:"images"`
+	IsAnonymous  bool       `json:"is_anonymous"`
+	PostType     string     `json:"post_type"`                // TypeText, TypePoll, TypeRepost or TypeQuote
+	QuotedPostID *string    `json:"quoted_post_id,omitempty"` // Original post of a repost or quote
+	Status       string     `json:"status"`                   // StatusDraft, StatusScheduled or StatusPublished
+	PublishAt    *time.Time `json:"publish_at,omitempty"`
+	IsActive     bool       `json:"is_active"`
+	ViewCount    int        `json:"view_count"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
 }
 
 // Reaction represents a user's reaction to a post
@@ -24,3 +55,17 @@ type Reaction struct {
 	CreatedAt    time.Time `json:"created_at"`
 }
 
+// HiddenPost is a post the user hid from their feeds
+type HiddenPost struct {
+	Post     *Post
+	Kind     string // FeedbackHidden or FeedbackNotInterested
+	HiddenAt time.Time
+}
+
+// PostInterest is an interest a post is tagged with
+type PostInterest struct {
+	InterestID string
+	Slug       string
+	Name       string
+	Source     string // InterestSourceAuthor or InterestSourceHashtag
+}
@@ -0,0 +1,86 @@
+package post
+
+import (
+	"context"
+	"fmt"
+)
+
+// maxPinnedPosts is how many posts a user can pin to their profile
+const maxPinnedPosts = 3
+
+// PinPost pins one of the user's posts to the top of their profile, after existing pins
+func (s *postService) PinPost(ctx context.Context, postID, userID string) (*PinsResponse, error) {
+	post, err := s.postRepo.GetByID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+	if post == nil {
+		return nil, ErrPostNotFound
+	}
+	if post.UserID != userID {
+		return nil, ErrUnauthorized
+	}
+	if post.IsAnonymous {
+		return nil, ErrPinAnonymous // Would tie the post to the author's profile
+	}
+
+	if err := s.postRepo.PinPost(ctx, userID, postID, maxPinnedPosts); err != nil {
+		if err == ErrAlreadyPinned || err == ErrTooManyPins {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to pin post: %w", err)
+	}
+
+	return s.getPins(ctx, userID)
+}
+
+// UnpinPost removes a post from the user's pins
+func (s *postService) UnpinPost(ctx context.Context, postID, userID string) (*PinsResponse, error) {
+	removed, err := s.postRepo.UnpinPost(ctx, userID, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpin post: %w", err)
+	}
+	if !removed {
+		return nil, ErrNotPinned
+	}
+
+	return s.getPins(ctx, userID)
+}
+
+// ReorderPins changes the order of the user's pins; postIDs must list every pinned post once
+func (s *postService) ReorderPins(ctx context.Context, userID string, postIDs []string) (*PinsResponse, error) {
+	current, err := s.postRepo.GetPinnedPostIDs(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pins: %w", err)
+	}
+
+	if len(postIDs) != len(current) {
+		return nil, ErrInvalidPinOrder
+	}
+	pinned := make(map[string]bool, len(current))
+	for _, id := range current {
+		pinned[id] = true
+	}
+	for _, id := range postIDs {
+		if !pinned[id] {
+			return nil, ErrInvalidPinOrder
+		}
+		delete(pinned, id) // Each post may appear only once
+	}
+
+	if err := s.postRepo.ReorderPins(ctx, userID, postIDs); err != nil {
+		return nil, fmt.Errorf("failed to reorder pins: %w", err)
+	}
+
+	return s.getPins(ctx, userID)
+}
+
+// getPins returns the user's pinned post IDs in order
+func (s *postService) getPins(ctx context.Context, userID string) (*PinsResponse, error) {
+	postIDs, err := s.postRepo.GetPinnedPostIDs(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pins: %w", err)
+	}
+
+	return &PinsResponse{PostIDs: postIDs}, nil
+}
@@ -0,0 +1,92 @@
+package post
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestPinPost(t *testing.T) {
+	repo := newFakePostRepo()
+	s := newTestService(repo)
+	ctx := context.Background()
+
+	for i := 0; i < maxPinnedPosts+1; i++ {
+		repo.add(&Post{ID: fmt.Sprintf("p%d", i), UserID: "author", Content: "post"})
+	}
+	repo.add(&Post{ID: "theirs", UserID: "someone-else", Content: "post"})
+	repo.add(&Post{ID: "anon", UserID: "author", Content: "post", IsAnonymous: true})
+
+	for i := 0; i < maxPinnedPosts; i++ {
+		if _, err := s.PinPost(ctx, fmt.Sprintf("p%d", i), "author"); err != nil {
+			t.Fatalf("PinPost(p%d): %v", i, err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		postID  string
+		wantErr error
+	}{
+		{"over the limit", fmt.Sprintf("p%d", maxPinnedPosts), ErrTooManyPins},
+		{"already pinned", "p0", ErrAlreadyPinned},
+		{"someone else's post", "theirs", ErrUnauthorized},
+		{"anonymous post", "anon", ErrPinAnonymous},
+		{"missing post", "missing", ErrPostNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.PinPost(ctx, tt.postID, "author"); !errors.Is(err, tt.wantErr) {
+				t.Errorf("PinPost(%s): err = %v, want %v", tt.postID, err, tt.wantErr)
+			}
+		})
+	}
+
+	// Unpinning frees a slot
+	resp, err := s.UnpinPost(ctx, "p1", "author")
+	if err != nil {
+		t.Fatalf("UnpinPost: %v", err)
+	}
+	if want := []string{"p0", "p2"}; !reflect.DeepEqual(resp.PostIDs, want) {
+		t.Errorf("pins after unpin = %v, want %v", resp.PostIDs, want)
+	}
+	if _, err := s.PinPost(ctx, fmt.Sprintf("p%d", maxPinnedPosts), "author"); err != nil {
+		t.Errorf("PinPost after unpin: %v", err)
+	}
+	if _, err := s.UnpinPost(ctx, "p1", "author"); !errors.Is(err, ErrNotPinned) {
+		t.Errorf("UnpinPost twice: err = %v, want ErrNotPinned", err)
+	}
+}
+
+func TestReorderPins(t *testing.T) {
+	repo := newFakePostRepo()
+	s := newTestService(repo)
+	ctx := context.Background()
+	repo.pins["author"] = []string{"a", "b", "c"}
+
+	tests := []struct {
+		name    string
+		order   []string
+		wantErr error
+	}{
+		{"missing a pin", []string{"c", "a"}, ErrInvalidPinOrder},
+		{"repeated pin", []string{"c", "a", "a"}, ErrInvalidPinOrder},
+		{"unpinned post", []string{"c", "a", "d"}, ErrInvalidPinOrder},
+		{"valid", []string{"c", "a", "b"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.ReorderPins(ctx, "author", tt.order)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ReorderPins(%v): err = %v, want %v", tt.order, err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(resp.PostIDs, tt.order) {
+				t.Errorf("pins = %v, want %v", resp.PostIDs, tt.order)
+			}
+		})
+	}
+}
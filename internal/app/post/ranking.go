@@ -0,0 +1,229 @@
+package post
+
+import (
+	"hash/fnv"
+	"math"
+	"time"
+)
+
+// Feed modes supported by GET /v1/feed
+const (
+	FeedModeLatest = "latest"
+	FeedModeRanked = "ranked"
+)
+
+// Candidate sources for the ranked feed
+const (
+	SourceFollow           = "follow"
+	SourceFollowedInterest = "followed_interest" // Tagged with an interest the viewer follows
+	SourceInterest         = "interest"
+	SourceInstitution      = "institution"
+	SourcePopular          = "popular"
+)
+
+// Ranked feed tuning
+const (
+	rankedFeedWindow   = 7 * 24 * time.Hour // Only rank posts from the last week
+	rankedFeedPoolSize = 500                // Max candidates pulled per request
+	maxPostsPerAuthor  = 3                  // Posts per author before the rest are pushed down
+	authorSpacing      = 2                  // Min distance between two posts by the same author
+)
+
+// FeedCandidate is a post considered for the ranked feed along with its ranking signals
+type FeedCandidate struct {
+	Post            *Post
+	Source          string  // Why the post was picked (follow, followed_interest, interest, institution, popular)
+	ReactionCount   int     // Total reactions on the post
+	CommentCount    int     // Active comments on the post
+	ShareCount      int     // Shares of the post
+	AuthorAffinity  int     // Viewer's recent reactions/comments on this author's posts
+	InterestOverlap int     // Interests shared by viewer and author
+	Score           float64 // Filled in by a Scorer
+
+	// Hashtags and interests on the post matching topics the viewer recently marked
+	// "not interested"
+	NotInterestedOverlap int
+}
+
+// Scorer assigns a ranking score to a feed candidate.
+// Implementations must be safe for concurrent use.
+type Scorer interface {
+	Name() string
+	Score(viewerID string, candidate *FeedCandidate, now time.Time) float64
+}
+
+// ScoringWeights configures a WeightedScorer
+type ScoringWeights struct {
+	Reaction            float64 // Weight per log-scaled reaction count
+	Comment             float64 // Weight per log-scaled comment count
+	Share               float64 // Weight per log-scaled share count
+	Affinity            float64 // Weight per log-scaled past interaction with the author
+	InterestOverlap     float64 // Weight per shared interest with the author
+	FollowBoost         float64 // Flat boost for posts from followed users
+	InterestFollowBoost float64 // Flat boost for posts tagged with a followed interest
+	InstitutionBoost    float64 // Flat boost for posts from the same institution
+	NotInterested       float64 // Exponential penalty per hashtag or interest on a "not interested" topic
+	HalfLifeHours       float64 // Hours for the recency multiplier to halve
+}
+
+// DefaultWeights returns the baseline weighting used in production
+func DefaultWeights() ScoringWeights {
+	return ScoringWeights{
+		Reaction:            1.0,
+		Comment:             2.0,
+		Share:               3.0,
+		Affinity:            1.5,
+		InterestOverlap:     0.5,
+		FollowBoost:         2.0,
+		InterestFollowBoost: 1.5,
+		InstitutionBoost:    1.0,
+		NotInterested:       0.7,
+		HalfLifeHours:       24,
+	}
+}
+
+// WeightedScorer scores candidates as a weighted sum of signals multiplied by recency decay
+type WeightedScorer struct {
+	name    string
+	weights ScoringWeights
+}
+
+// NewWeightedScorer creates a weighted scorer identified by name (used for A/B reporting)
+func NewWeightedScorer(name string, weights ScoringWeights) *WeightedScorer {
+	if weights.HalfLifeHours <= 0 {
+		weights.HalfLifeHours = DefaultWeights().HalfLifeHours
+	}
+	return &WeightedScorer{name: name, weights: weights}
+}
+
+// Name returns the scorer name
+func (s *WeightedScorer) Name() string {
+	return s.name
+}
+
+// Score computes the candidate score
+func (s *WeightedScorer) Score(viewerID string, c *FeedCandidate, now time.Time) float64 {
+	w := s.weights
+
+	// Log scaling keeps a single viral post from drowning everything else
+	signal := 1.0 +
+		w.Reaction*math.Log1p(float64(c.ReactionCount)) +
+		w.Comment*math.Log1p(float64(c.CommentCount)) +
+		w.Share*math.Log1p(float64(c.ShareCount)) +
+		w.Affinity*math.Log1p(float64(c.AuthorAffinity)) +
+		w.InterestOverlap*float64(c.InterestOverlap)
+
+	switch c.Source {
+	case SourceFollow:
+		signal += w.FollowBoost
+	case SourceFollowedInterest:
+		signal += w.InterestFollowBoost
+	case SourceInstitution:
+		signal += w.InstitutionBoost
+	}
+
+	// Topics the viewer said they are not interested in are pushed down, not removed
+	signal *= math.Exp(-w.NotInterested * float64(c.NotInterestedOverlap))
+
+	// Exponential recency decay
+	ageHours := now.Sub(c.Post.CreatedAt).Hours()
+	if ageHours < 0 {
+		ageHours = 0
+	}
+	decay := math.Exp(-ageHours * math.Ln2 / w.HalfLifeHours)
+
+	return signal * decay
+}
+
+// BucketedScorer splits viewers deterministically across scorer variants for A/B tests
+type BucketedScorer struct {
+	variants []Scorer
+}
+
+// NewBucketedScorer creates a scorer that assigns each viewer to one of the variants
+func NewBucketedScorer(variants ...Scorer) *BucketedScorer {
+	return &BucketedScorer{variants: variants}
+}
+
+// Name returns the scorer name
+func (s *BucketedScorer) Name() string {
+	return "bucketed"
+}
+
+// Variant returns the scorer assigned to a viewer
+func (s *BucketedScorer) Variant(viewerID string) Scorer {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(viewerID))
+	return s.variants[h.Sum32()%uint32(len(s.variants))]
+}
+
+// Score delegates to the viewer's variant
+func (s *BucketedScorer) Score(viewerID string, c *FeedCandidate, now time.Time) float64 {
+	return s.Variant(viewerID).Score(viewerID, c, now)
+}
+
+// collapseReposts keeps only the highest-scored entry for each post among score-sorted
+// candidates, so a post reposted by several followed users (or posted by one and reposted
+// by another) appears once
+func collapseReposts(ranked []*FeedCandidate) []*FeedCandidate {
+	seen := make(map[string]bool, len(ranked))
+	result := make([]*FeedCandidate, 0, len(ranked))
+
+	for _, c := range ranked {
+		key := c.Post.ID
+		if c.Post.PostType == TypeRepost && c.Post.QuotedPostID != nil {
+			key = *c.Post.QuotedPostID
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, c)
+	}
+
+	return result
+}
+
+// applyDiversity reorders score-sorted candidates so one author cannot flood the feed.
+// Each author keeps at most maxPerAuthor posts in the main ranking (the rest move to the
+// tail), and two posts by the same author are kept at least minGap positions apart
+// whenever another author is available to fill the slot.
+func applyDiversity(ranked []*FeedCandidate, maxPerAuthor, minGap int) []*FeedCandidate {
+	perAuthor := make(map[string]int)
+	pending := make([]*FeedCandidate, 0, len(ranked))
+	overflow := make([]*FeedCandidate, 0)
+
+	for _, c := range ranked {
+		if perAuthor[c.Post.UserID] >= maxPerAuthor {
+			overflow = append(overflow, c)
+			continue
+		}
+		perAuthor[c.Post.UserID]++
+		pending = append(pending, c)
+	}
+
+	result := make([]*FeedCandidate, 0, len(ranked))
+	for len(pending) > 0 {
+		picked := 0
+		for i, c := range pending {
+			if !authorInTail(result, c.Post.UserID, minGap) {
+				picked = i
+				break
+			}
+		}
+		result = append(result, pending[picked])
+		pending = append(pending[:picked], pending[picked+1:]...)
+	}
+
+	return append(result, overflow...)
+}
+
+// authorInTail reports whether userID authored one of the last n picked candidates
+func authorInTail(picked []*FeedCandidate, userID string, n int) bool {
+	for i := len(picked) - 1; i >= 0 && i >= len(picked)-n; i-- {
+		if picked[i].Post.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,194 @@
+package post
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func candidate(id, userID string, age time.Duration, now time.Time) *FeedCandidate {
+	return &FeedCandidate{Post: &Post{ID: id, UserID: userID, PostType: TypeText, CreatedAt: now.Add(-age)}}
+}
+
+func TestWeightedScorerSignals(t *testing.T) {
+	now := time.Now()
+	scorer := NewWeightedScorer("test", DefaultWeights())
+
+	base := scorer.Score("viewer", candidate("p", "a", 0, now), now)
+	if base != 1 {
+		t.Fatalf("score without signals = %v, want 1", base)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(c *FeedCandidate)
+	}{
+		{"reactions", func(c *FeedCandidate) { c.ReactionCount = 10 }},
+		{"comments", func(c *FeedCandidate) { c.CommentCount = 10 }},
+		{"shares", func(c *FeedCandidate) { c.ShareCount = 10 }},
+		{"affinity", func(c *FeedCandidate) { c.AuthorAffinity = 5 }},
+		{"interest overlap", func(c *FeedCandidate) { c.InterestOverlap = 2 }},
+		{"followed author", func(c *FeedCandidate) { c.Source = SourceFollow }},
+		{"followed interest", func(c *FeedCandidate) { c.Source = SourceFollowedInterest }},
+		{"institution", func(c *FeedCandidate) { c.Source = SourceInstitution }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := candidate("p", "a", 0, now)
+			tt.modify(c)
+			if got := scorer.Score("viewer", c, now); got <= base {
+				t.Errorf("score = %v, want more than %v", got, base)
+			}
+		})
+	}
+}
+
+func TestWeightedScorerRecencyDecay(t *testing.T) {
+	now := time.Now()
+	scorer := NewWeightedScorer("test", DefaultWeights())
+
+	fresh := scorer.Score("viewer", candidate("p", "a", 0, now), now)
+	dayOld := scorer.Score("viewer", candidate("p", "a", 24*time.Hour, now), now)
+	if math.Abs(dayOld-fresh/2) > 1e-9 {
+		t.Errorf("score after one half-life = %v, want %v", dayOld, fresh/2)
+	}
+
+	// Clock skew must not boost posts from the future
+	future := scorer.Score("viewer", candidate("p", "a", -time.Hour, now), now)
+	if future != fresh {
+		t.Errorf("future post score = %v, want %v", future, fresh)
+	}
+}
+
+func TestWeightedScorerNotInterested(t *testing.T) {
+	now := time.Now()
+	scorer := NewWeightedScorer("test", DefaultWeights())
+
+	c := candidate("p", "a", 0, now)
+	c.ReactionCount = 10
+	before := scorer.Score("viewer", c, now)
+
+	c.NotInterestedOverlap = 1
+	once := scorer.Score("viewer", c, now)
+	c.NotInterestedOverlap = 2
+	twice := scorer.Score("viewer", c, now)
+
+	if !(before > once && once > twice && twice > 0) {
+		t.Errorf("scores = %v, %v, %v; want decreasing and positive", before, once, twice)
+	}
+}
+
+func TestWeightedScorerDefaultsHalfLife(t *testing.T) {
+	scorer := NewWeightedScorer("test", ScoringWeights{})
+	if scorer.weights.HalfLifeHours != DefaultWeights().HalfLifeHours {
+		t.Errorf("half-life = %v, want default %v", scorer.weights.HalfLifeHours, DefaultWeights().HalfLifeHours)
+	}
+}
+
+func TestBucketedScorerIsDeterministic(t *testing.T) {
+	a := NewWeightedScorer("a", DefaultWeights())
+	b := NewWeightedScorer("b", DefaultWeights())
+	scorer := NewBucketedScorer(a, b)
+
+	seen := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		viewerID := fmt.Sprintf("viewer-%d", i)
+		variant := scorer.Variant(viewerID)
+		if scorer.Variant(viewerID) != variant {
+			t.Fatalf("viewer %s switched variants", viewerID)
+		}
+		seen[variant.Name()] = true
+	}
+	if !seen["a"] || !seen["b"] {
+		t.Errorf("variants used = %v, want both", seen)
+	}
+}
+
+func TestCollapseReposts(t *testing.T) {
+	now := time.Now()
+	originalID := "original"
+
+	repost := func(id, userID string) *FeedCandidate {
+		c := candidate(id, userID, 0, now)
+		c.Post.PostType = TypeRepost
+		c.Post.QuotedPostID = &originalID
+		return c
+	}
+
+	ranked := []*FeedCandidate{
+		repost("r1", "b"),
+		candidate(originalID, "a", 0, now),
+		repost("r2", "c"),
+		candidate("other", "d", 0, now),
+	}
+
+	got := collapseReposts(ranked)
+	if ids := candidateIDs(got); fmt.Sprint(ids) != "[r1 other]" {
+		t.Errorf("collapsed = %v, want [r1 other]", ids)
+	}
+}
+
+func TestApplyDiversity(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name         string
+		authors      []string
+		maxPerAuthor int
+		minGap       int
+		want         string
+	}{
+		{
+			name:         "spaces out one author",
+			authors:      []string{"a", "a", "b", "c"},
+			maxPerAuthor: 3,
+			minGap:       2,
+			want:         "[a b c a]",
+		},
+		{
+			name:         "moves extra posts to the tail",
+			authors:      []string{"a", "a", "a", "b"},
+			maxPerAuthor: 1,
+			minGap:       1,
+			want:         "[a b a a]",
+		},
+		{
+			name:         "keeps order when only one author is left",
+			authors:      []string{"a", "a", "a"},
+			maxPerAuthor: 3,
+			minGap:       2,
+			want:         "[a a a]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranked := make([]*FeedCandidate, len(tt.authors))
+			for i, author := range tt.authors {
+				ranked[i] = candidate(fmt.Sprintf("p%d", i), author, 0, now)
+			}
+
+			got := applyDiversity(ranked, tt.maxPerAuthor, tt.minGap)
+			authors := make([]string, len(got))
+			for i, c := range got {
+				authors[i] = c.Post.UserID
+			}
+			if fmt.Sprint(authors) != tt.want {
+				t.Errorf("authors = %v, want %s", authors, tt.want)
+			}
+			if len(got) != len(ranked) {
+				t.Errorf("got %d candidates, want %d", len(got), len(ranked))
+			}
+		})
+	}
+}
+
+func candidateIDs(candidates []*FeedCandidate) []string {
+	ids := make([]string, len(candidates))
+	for i, c := range candidates {
+		ids[i] = c.Post.ID
+	}
+	return ids
+}
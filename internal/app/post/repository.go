@@ -1,18 +1,76 @@
 package post
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // PostRepository defines the interface for post data operations
 type PostRepository interface {
 	// Post CRUD operations
 	Create(ctx context.Context, post *Post) error
 	GetByID(ctx context.Context, id string) (*Post, error)
-	GetByUserID(ctx context.Context, userID string, limit, offset int) ([]*Post, error)
+	GetByUserID(ctx context.Context, userID, viewerID string, limit, offset int) ([]*Post, error)
 	Update(ctx context.Context, post *Post) error
 	Delete(ctx context.Context, id string) error
 
+	// Draft operations (drafts and scheduled posts are never returned by the queries above)
+	GetDraftByID(ctx context.Context, id, userID string) (*Post, error)
+	GetDrafts(ctx context.Context, userID string, limit, offset int) ([]*Post, error)
+	UpdateDraft(ctx context.Context, post *Post) error
+	PublishDraft(ctx context.Context, post *Post) error
+	PublishDue(ctx context.Context, limit int) ([]*Post, error)
+	GetDueAnonymous(ctx context.Context, limit int) ([]*Post, error)
+	ReturnToDraft(ctx context.Context, post *Post) error
+
 	// Feed operations
 	GetFeed(ctx context.Context, userID string, limit, offset int) ([]*Post, error)
+	GetFeedPosts(ctx context.Context, userID string, ids []string) ([]*Post, error)
+	GetFeedCandidates(ctx context.Context, userID string, window time.Duration, limit int) ([]*FeedCandidate, error)
+
+	// Repost operations
+	GetRepost(ctx context.Context, userID, originalID string) (*Post, error)
+	DeleteRepost(ctx context.Context, id string) error
+
+	// Visibility
+	IsVisibleTo(ctx context.Context, postID, viewerID string) (bool, error)
+	GetVisibleByIDs(ctx context.Context, ids []string, viewerID string) ([]*Post, error)
+
+	// Saved post operations
+	GetSaved(ctx context.Context, userID, collectionID string, limit, offset int) ([]*Post, error)
+	HasUserSaved(ctx context.Context, postID, userID string) (bool, error)
+	GetSavedAmong(ctx context.Context, postIDs []string, userID string) (map[string]bool, error)
+
+	// Pin operations
+	PinPost(ctx context.Context, userID, postID string, maxPins int) error
+	UnpinPost(ctx context.Context, userID, postID string) (bool, error)
+	GetPinnedPostIDs(ctx context.Context, userID string) ([]string, error)
+	ReorderPins(ctx context.Context, userID string, postIDs []string) error
+	IsPinned(ctx context.Context, postID string) (bool, error)
+	GetPinnedAmong(ctx context.Context, postIDs []string) (map[string]bool, error)
+
+	// Feed feedback operations
+	SaveFeedback(ctx context.Context, userID, postID, kind string) error
+	DeleteFeedback(ctx context.Context, userID, postID string) (bool, error)
+	GetHiddenPosts(ctx context.Context, userID string, limit, offset int) ([]*HiddenPost, error)
+
+	// View operations
+	SaveViews(ctx context.Context, views []*PostView) error
+	GetUniqueViewerCount(ctx context.Context, postID string) (int, error)
+
+	// Hashtag operations
+	GetByHashtag(ctx context.Context, viewerID, tag string, limit, offset int) ([]*Post, error)
+
+	// Interest operations
+	SetPostInterests(ctx context.Context, postID string, interestIDs []string) error
+	SyncInferredInterests(ctx context.Context, postID string) error
+	GetPostInterests(ctx context.Context, postID string) ([]*PostInterest, error)
+	GetPostsInterests(ctx context.Context, postIDs []string) (map[string][]*PostInterest, error)
+	GetByInterest(ctx context.Context, viewerID, interestID string, limit, offset int) ([]*Post, error)
+
+	// Explore operations
+	GetTrending(ctx context.Context, viewerID string, filter ExploreFilter, limit, offset int) ([]*Post, error)
+	RefreshTrendingScores(ctx context.Context, window time.Duration, halfLifeHours float64) (int64, error)
 
 	// Reaction operations
 	AddReaction(ctx context.Context, reaction *Reaction) error
@@ -20,5 +78,8 @@ type PostRepository interface {
 	GetReactions(ctx context.Context, postID string, limit, offset int) ([]*Reaction, error)
 	GetReactionCount(ctx context.Context, postID string) (int, error)
 	HasUserReacted(ctx context.Context, postID, userID string) (bool, error)
+	GetReactionCounts(ctx context.Context, postIDs []string) (map[string]int, error)
+	GetReactedAmong(ctx context.Context, postIDs []string, userID string) (map[string]bool, error)
+	GetRecentReactions(ctx context.Context, postIDs []string, limit int) ([]*Reaction, error)
 }
 
@@ -3,6 +3,11 @@ package post
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
+
+	"mockhu-app-backend/internal/app/counter"
+	"mockhu-app-backend/internal/app/mute"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -19,8 +24,8 @@ func NewPostgresPostRepository(pool *pgxpool.Pool) *PostgresPostRepository {
 
 func (r *PostgresPostRepository) Create(ctx context.Context, post *Post) error {
 	query := `
-		INSERT INTO posts (user_id, content, images, is_anonymous)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO posts (user_id, content, images, is_anonymous, post_type, quoted_post_id, status, publish_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id, is_active, view_count, created_at, updated_at
 	`
 
@@ -28,19 +33,43 @@ func (r *PostgresPostRepository) Create(ctx context.Context, post *Post) error {
 		post.Images = []string{}
 	}
 
-	err := r.pool.QueryRow(ctx, query, post.UserID, post.Content, post.Images, post.IsAnonymous).
+	if post.PostType == "" {
+		post.PostType = TypeText
+	}
+
+	if post.Status == "" {
+		post.Status = StatusPublished
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, query, post.UserID, post.Content, post.Images, post.IsAnonymous, post.PostType, post.QuotedPostID, post.Status, post.PublishAt).
 		Scan(&post.ID, &post.IsActive, &post.ViewCount, &post.CreatedAt, &post.UpdatedAt)
+	if err != nil {
+		return err
+	}
 
-	return err
+	// Reposts aren't counted as the user's own posts
+	if post.Status == StatusPublished && post.PostType != TypeRepost {
+		if err := counter.Add(ctx, tx, counter.UserPosts, post.UserID, 1); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
 }
 
 // GetByID retrieves a single post by its ID
 func (r *PostgresPostRepository) GetByID(ctx context.Context, id string) (*Post, error) {
 	query := `
-		SELECT id, user_id, content, images, is_anonymous, is_active, 
+		SELECT id, user_id, content, images, is_anonymous, post_type, quoted_post_id, status, publish_at, is_active, 
 		       view_count, created_at, updated_at
 		FROM posts
-		WHERE id = $1 AND is_active = true
+		WHERE id = $1 AND is_active = true AND status = 'published'
 	`
 
 	post := &Post{}
@@ -52,6 +81,10 @@ func (r *PostgresPostRepository) GetByID(ctx context.Context, id string) (*Post,
 		&post.Content,
 		&images,
 		&post.IsAnonymous,
+		&post.PostType,
+		&post.QuotedPostID,
+		&post.Status,
+		&post.PublishAt,
 		&post.IsActive,
 		&post.ViewCount,
 		&post.CreatedAt,
@@ -69,18 +102,26 @@ func (r *PostgresPostRepository) GetByID(ctx context.Context, id string) (*Post,
 	return post, nil
 }
 
-// GetByUserID retrieves all active posts by a specific user
-func (r *PostgresPostRepository) GetByUserID(ctx context.Context, userID string, limit, offset int) ([]*Post, error) {
+// GetByUserID retrieves all active posts by a specific user that the viewer may see and
+// hasn't hidden, pinned posts first in pin order. Anonymous posts are only included on the
+// user's own profile.
+func (r *PostgresPostRepository) GetByUserID(ctx context.Context, userID, viewerID string, limit, offset int) ([]*Post, error) {
 	query := `
-		SELECT id, user_id, content, images, is_anonymous, is_active,
-		       view_count, created_at, updated_at
-		FROM posts
-		WHERE user_id = $1 AND is_active = true
-		ORDER BY created_at DESC
+		SELECT p.id, p.user_id, p.content, p.images, p.is_anonymous, p.post_type, p.quoted_post_id, p.status, p.publish_at, p.is_active,
+		       p.view_count, p.created_at, p.updated_at
+		FROM posts p
+		JOIN users u ON u.id = p.user_id
+		LEFT JOIN post_pins pp ON pp.post_id = p.id AND pp.user_id = p.user_id
+		WHERE p.user_id = $1 AND p.is_active = true AND p.status = 'published'
+		  AND (p.is_anonymous = false OR p.user_id = NULLIF($4::text, '')::uuid)
+		  AND ` + visibleToViewer("$4") + `
+		  AND ` + originalVisibleToViewer("p", "$4") + `
+		  AND ` + notHiddenByViewer("p", "$4") + `
+		ORDER BY pp.position ASC NULLS LAST, p.created_at DESC
 		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := r.pool.Query(ctx, query, userID, limit, offset)
+	rows, err := r.pool.Query(ctx, query, userID, limit, offset, viewerID)
 	if err != nil {
 		return nil, err
 	}
@@ -98,6 +139,10 @@ func (r *PostgresPostRepository) GetByUserID(ctx context.Context, userID string,
 			&post.Content,
 			&images,
 			&post.IsAnonymous,
+			&post.PostType,
+			&post.QuotedPostID,
+			&post.Status,
+			&post.PublishAt,
 			&post.IsActive,
 			&post.ViewCount,
 			&post.CreatedAt,
@@ -133,38 +178,82 @@ func (r *PostgresPostRepository) Update(ctx context.Context, post *Post) error {
 	return err
 }
 
-// Delete performs a soft delete on a post
+// Delete performs a soft delete on a post, moving it to the author's trash
 func (r *PostgresPostRepository) Delete(ctx context.Context, id string) error {
 	query := `
 		UPDATE posts
-		SET is_active = false, updated_at = NOW()
+		SET is_active = false, deleted_at = NOW(), updated_at = NOW()
 		WHERE id = $1 AND is_active = true
+		RETURNING user_id, status, post_type
 	`
 
-	result, err := r.pool.Exec(ctx, query, id)
+	tx, err := r.pool.Begin(ctx)
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback(ctx)
 
-	rowsAffected := result.RowsAffected()
-	if rowsAffected == 0 {
-		return pgx.ErrNoRows
+	var userID, status, postType string
+	if err := tx.QueryRow(ctx, query, id).Scan(&userID, &status, &postType); err != nil {
+		return err // pgx.ErrNoRows if the post is not active
 	}
 
-	return nil
+	if status == StatusPublished && postType != TypeRepost {
+		if err := counter.Add(ctx, tx, counter.UserPosts, userID, -1); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
 }
 
-// GetFeed retrieves posts from users that the current user follows
-func (r *PostgresPostRepository) GetFeed(ctx context.Context, userID string, limit, offset int) ([]*Post, error) {
+// GetDraftByID retrieves a draft or scheduled post owned by userID
+func (r *PostgresPostRepository) GetDraftByID(ctx context.Context, id, userID string) (*Post, error) {
 	query := `
-		SELECT p.id, p.user_id, p.content, p.images, p.is_anonymous, p.is_active,
-		       p.view_count, p.created_at, p.updated_at
-		FROM posts p
-		WHERE p.user_id IN (
-			SELECT following_id FROM user_follows WHERE follower_id = $1
-		)
-		AND p.is_active = true
-		ORDER BY p.created_at DESC
+		SELECT id, user_id, content, images, is_anonymous, post_type, quoted_post_id, status, publish_at, is_active,
+		       view_count, created_at, updated_at
+		FROM posts
+		WHERE id = $1 AND user_id = $2 AND is_active = true AND status <> 'published'
+	`
+
+	post := &Post{}
+	var images []string
+
+	err := r.pool.QueryRow(ctx, query, id, userID).Scan(
+		&post.ID,
+		&post.UserID,
+		&post.Content,
+		&images,
+		&post.IsAnonymous,
+		&post.PostType,
+		&post.QuotedPostID,
+		&post.Status,
+		&post.PublishAt,
+		&post.IsActive,
+		&post.ViewCount,
+		&post.CreatedAt,
+		&post.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	post.Images = images
+	return post, nil
+}
+
+// GetDrafts retrieves a user's drafts and scheduled posts, most recently edited first
+func (r *PostgresPostRepository) GetDrafts(ctx context.Context, userID string, limit, offset int) ([]*Post, error) {
+	query := `
+		SELECT id, user_id, content, images, is_anonymous, post_type, quoted_post_id, status, publish_at, is_active,
+		       view_count, created_at, updated_at
+		FROM posts
+		WHERE user_id = $1 AND is_active = true AND status <> 'published'
+		ORDER BY updated_at DESC
 		LIMIT $2 OFFSET $3
 	`
 
@@ -186,6 +275,10 @@ func (r *PostgresPostRepository) GetFeed(ctx context.Context, userID string, lim
 			&post.Content,
 			&images,
 			&post.IsAnonymous,
+			&post.PostType,
+			&post.QuotedPostID,
+			&post.Status,
+			&post.PublishAt,
 			&post.IsActive,
 			&post.ViewCount,
 			&post.CreatedAt,
@@ -202,103 +295,1620 @@ func (r *PostgresPostRepository) GetFeed(ctx context.Context, userID string, lim
 	return posts, rows.Err()
 }
 
-// AddReaction adds a fire reaction to a post
-func (r *PostgresPostRepository) AddReaction(ctx context.Context, reaction *Reaction) error {
+// UpdateDraft modifies a draft or scheduled post, including its status and publish time.
+// Returns pgx.ErrNoRows if the post has been published in the meantime.
+func (r *PostgresPostRepository) UpdateDraft(ctx context.Context, post *Post) error {
 	query := `
-		INSERT INTO post_reactions (post_id, user_id, reaction_type)
-		VALUES ($1, $2, $3)
-		ON CONFLICT (post_id, user_id) DO NOTHING
-		RETURNING id, created_at
+		UPDATE posts
+		SET content = $1, images = $2, is_anonymous = $3, status = $4, publish_at = $5, updated_at = NOW()
+		WHERE id = $6 AND user_id = $7 AND is_active = true AND status <> 'published'
+		RETURNING updated_at
 	`
 
-	err := r.pool.QueryRow(ctx, query, reaction.PostID, reaction.UserID, reaction.ReactionType).
-		Scan(&reaction.ID, &reaction.CreatedAt)
+	if post.Images == nil {
+		post.Images = []string{}
+	}
+
+	err := r.pool.QueryRow(ctx, query, post.Content, post.Images, post.IsAnonymous, post.Status, post.PublishAt, post.ID, post.UserID).
+		Scan(&post.UpdatedAt)
+
+	return err
+}
+
+// PublishDraft publishes a draft or scheduled post now. created_at is reset to the publish
+// time so the post is ordered and ranked as new. Returns pgx.ErrNoRows if the post has
+// already been published.
+func (r *PostgresPostRepository) PublishDraft(ctx context.Context, post *Post) error {
+	query := `
+		UPDATE posts
+		SET status = 'published', publish_at = NULL, created_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND is_active = true AND status <> 'published'
+		RETURNING status, created_at, updated_at
+	`
 
+	tx, err := r.pool.Begin(ctx)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil // ON CONFLICT triggered, reaction already exists
-		}
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, query, post.ID, post.UserID).
+		Scan(&post.Status, &post.CreatedAt, &post.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if err := counter.Add(ctx, tx, counter.UserPosts, post.UserID, 1); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
 		return err
 	}
 
+	post.PublishAt = nil
 	return nil
 }
 
-// RemoveReaction removes a user's reaction from a post
-func (r *PostgresPostRepository) RemoveReaction(ctx context.Context, postID, userID string) error {
+// PublishDue publishes up to limit scheduled posts whose publish time has passed and returns
+// them. Rows locked by a concurrent run are skipped, so each post is published exactly once.
+// Anonymous posts are left for GetDueAnonymous, since each is checked against the anonymous
+// posting limit first.
+func (r *PostgresPostRepository) PublishDue(ctx context.Context, limit int) ([]*Post, error) {
 	query := `
-		DELETE FROM post_reactions
-		WHERE post_id = $1 AND user_id = $2
+		UPDATE posts
+		SET status = 'published', publish_at = NULL, created_at = NOW(), updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM posts
+			WHERE status = 'scheduled' AND is_active = true AND publish_at <= NOW() AND is_anonymous = false
+			ORDER BY publish_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, user_id, content, images, is_anonymous, post_type, quoted_post_id, status, publish_at, is_active,
+		          view_count, created_at, updated_at
 	`
 
-	result, err := r.pool.Exec(ctx, query, postID, userID)
+	tx, err := r.pool.Begin(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer tx.Rollback(ctx)
 
-	rowsAffected := result.RowsAffected()
-	if rowsAffected == 0 {
-		return pgx.ErrNoRows
+	rows, err := tx.Query(ctx, query, limit)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	return nil
+	var posts []*Post
+
+	for rows.Next() {
+		post := &Post{}
+		var images []string
+
+		err := rows.Scan(
+			&post.ID,
+			&post.UserID,
+			&post.Content,
+			&images,
+			&post.IsAnonymous,
+			&post.PostType,
+			&post.QuotedPostID,
+			&post.Status,
+			&post.PublishAt,
+			&post.IsActive,
+			&post.ViewCount,
+			&post.CreatedAt,
+			&post.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		post.Images = images
+		posts = append(posts, post)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for _, post := range posts {
+		if err := counter.Add(ctx, tx, counter.UserPosts, post.UserID, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return posts, nil
 }
 
-// GetReactions retrieves all reactions for a post
-func (r *PostgresPostRepository) GetReactions(ctx context.Context, postID string, limit, offset int) ([]*Reaction, error) {
+// GetDueAnonymous retrieves up to limit scheduled anonymous posts whose publish time has
+// passed, oldest first
+func (r *PostgresPostRepository) GetDueAnonymous(ctx context.Context, limit int) ([]*Post, error) {
 	query := `
-		SELECT id, post_id, user_id, reaction_type, created_at
-		FROM post_reactions
-		WHERE post_id = $1
-		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3
+		SELECT id, user_id, content, images, is_anonymous, post_type, quoted_post_id, status, publish_at, is_active,
+		       view_count, created_at, updated_at
+		FROM posts
+		WHERE status = 'scheduled' AND is_active = true AND publish_at <= NOW() AND is_anonymous = true
+		ORDER BY publish_at
+		LIMIT $1
 	`
 
-	rows, err := r.pool.Query(ctx, query, postID, limit, offset)
+	rows, err := r.pool.Query(ctx, query, limit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var reactions []*Reaction
+	var posts []*Post
 
 	for rows.Next() {
-		reaction := &Reaction{}
+		post := &Post{}
+		var images []string
+
 		err := rows.Scan(
-			&reaction.ID,
-			&reaction.PostID,
-			&reaction.UserID,
-			&reaction.ReactionType,
-			&reaction.CreatedAt,
+			&post.ID,
+			&post.UserID,
+			&post.Content,
+			&images,
+			&post.IsAnonymous,
+			&post.PostType,
+			&post.QuotedPostID,
+			&post.Status,
+			&post.PublishAt,
+			&post.IsActive,
+			&post.ViewCount,
+			&post.CreatedAt,
+			&post.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
 		}
-		reactions = append(reactions, reaction)
+
+		post.Images = images
+		posts = append(posts, post)
 	}
 
-	return reactions, rows.Err()
+	return posts, rows.Err()
 }
 
-// GetReactionCount returns the total number of reactions for a post
-func (r *PostgresPostRepository) GetReactionCount(ctx context.Context, postID string) (int, error) {
-	query := `SELECT COUNT(*) FROM post_reactions WHERE post_id = $1`
+// ReturnToDraft turns a scheduled post back into an unscheduled draft. Returns pgx.ErrNoRows
+// if the post is no longer scheduled.
+func (r *PostgresPostRepository) ReturnToDraft(ctx context.Context, post *Post) error {
+	query := `
+		UPDATE posts
+		SET status = 'draft', publish_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND is_active = true AND status = 'scheduled'
+		RETURNING status, updated_at
+	`
 
-	var count int
-	err := r.pool.QueryRow(ctx, query, postID).Scan(&count)
-	return count, err
+	if err := r.pool.QueryRow(ctx, query, post.ID).Scan(&post.Status, &post.UpdatedAt); err != nil {
+		return err
+	}
+
+	post.PublishAt = nil
+	return nil
 }
 
-// HasUserReacted checks if a specific user has reacted to a post
-func (r *PostgresPostRepository) HasUserReacted(ctx context.Context, postID, userID string) (bool, error) {
+// GetFeed retrieves posts and reposts from users that the current user follows, plus posts
+// tagged with interests the user follows. When the same post was posted or reposted by
+// several followed users only the newest entry is kept, and reposts of posts the viewer may
+// not see are skipped, as are muted and hidden posts.
+func (r *PostgresPostRepository) GetFeed(ctx context.Context, userID string, limit, offset int) ([]*Post, error) {
 	query := `
-		SELECT EXISTS(
-			SELECT 1 FROM post_reactions
-			WHERE post_id = $1 AND user_id = $2
+		SELECT p.id, p.user_id, p.content, p.images, p.is_anonymous, p.post_type, p.quoted_post_id, p.status, p.publish_at, p.is_active,
+		       p.view_count, p.created_at, p.updated_at
+		FROM (
+			SELECT DISTINCT ON (COALESCE(CASE WHEN fp.post_type = 'repost' THEN fp.quoted_post_id END, fp.id)) fp.*
+			FROM posts fp
+			WHERE ` + inHomeFeed("fp", "$1") + `
+			ORDER BY COALESCE(CASE WHEN fp.post_type = 'repost' THEN fp.quoted_post_id END, fp.id), fp.created_at DESC
+		) p
+		ORDER BY p.created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var posts []*Post
+
+	for rows.Next() {
+		post := &Post{}
+		var images []string
+
+		err := rows.Scan(
+			&post.ID,
+			&post.UserID,
+			&post.Content,
+			&images,
+			&post.IsAnonymous,
+			&post.PostType,
+			&post.QuotedPostID,
+			&post.Status,
+			&post.PublishAt,
+			&post.IsActive,
+			&post.ViewCount,
+			&post.CreatedAt,
+			&post.UpdatedAt,
 		)
+		if err != nil {
+			return nil, err
+		}
+
+		post.Images = images
+		posts = append(posts, post)
+	}
+
+	return posts, rows.Err()
+}
+
+// GetFeedPosts retrieves the posts among ids that belong in the user's home feed, newest
+// first, with the same filtering and repost collapsing as GetFeed
+func (r *PostgresPostRepository) GetFeedPosts(ctx context.Context, userID string, ids []string) ([]*Post, error) {
+	query := `
+		SELECT p.id, p.user_id, p.content, p.images, p.is_anonymous, p.post_type, p.quoted_post_id, p.status, p.publish_at, p.is_active,
+		       p.view_count, p.created_at, p.updated_at
+		FROM (
+			SELECT DISTINCT ON (COALESCE(CASE WHEN fp.post_type = 'repost' THEN fp.quoted_post_id END, fp.id)) fp.*
+			FROM posts fp
+			WHERE fp.id = ANY($2::uuid[])
+			AND ` + inHomeFeed("fp", "$1") + `
+			ORDER BY COALESCE(CASE WHEN fp.post_type = 'repost' THEN fp.quoted_post_id END, fp.id), fp.created_at DESC
+		) p
+		ORDER BY p.created_at DESC, p.id DESC
 	`
 
-	var exists bool
-	err := r.pool.QueryRow(ctx, query, postID, userID).Scan(&exists)
-	return exists, err
+	rows, err := r.pool.Query(ctx, query, userID, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var posts []*Post
+
+	for rows.Next() {
+		post := &Post{}
+		var images []string
+
+		err := rows.Scan(
+			&post.ID,
+			&post.UserID,
+			&post.Content,
+			&images,
+			&post.IsAnonymous,
+			&post.PostType,
+			&post.QuotedPostID,
+			&post.Status,
+			&post.PublishAt,
+			&post.IsActive,
+			&post.ViewCount,
+			&post.CreatedAt,
+			&post.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		post.Images = images
+		posts = append(posts, post)
+	}
+
+	return posts, rows.Err()
+}
+
+// GetFeedCandidates gathers posts for the ranked feed from several sources (followed users,
+// followed interests, users with shared interests, the viewer's institution and globally
+// popular posts) together with the engagement and affinity signals needed to score them.
+// Reposts only come from followed users; reposts and quotes are skipped when the original
+// is hidden from the viewer. Muted posts and posts the viewer hid are left out; topics the
+// viewer marked "not interested" are counted so the scorer can down-rank them and don't
+// count toward shared interests.
+func (r *PostgresPostRepository) GetFeedCandidates(ctx context.Context, userID string, window time.Duration, limit int) ([]*FeedCandidate, error) {
+	query := `
+		WITH disliked AS (
+			SELECT ph.hashtag_id, h.interest_id
+			FROM post_feedback pf
+			JOIN post_hashtags ph ON ph.post_id = pf.post_id
+			JOIN hashtags h ON h.id = ph.hashtag_id
+			WHERE pf.user_id = $1 AND pf.kind = 'not_interested'
+			  AND pf.created_at > NOW() - INTERVAL '90 days'
+
+			UNION
+
+			SELECT NULL, pi.interest_id
+			FROM post_feedback pf
+			JOIN post_interests pi ON pi.post_id = pf.post_id
+			WHERE pf.user_id = $1 AND pf.kind = 'not_interested'
+			  AND pf.created_at > NOW() - INTERVAL '90 days'
+		),
+		interest_peers AS (
+			SELECT ui2.user_id, COUNT(*) AS overlap
+			FROM user_interests ui1
+			JOIN user_interests ui2 ON ui2.interest_id = ui1.interest_id AND ui2.user_id <> ui1.user_id
+			WHERE ui1.user_id = $1
+			  AND ui1.interest_id NOT IN (SELECT interest_id FROM disliked WHERE interest_id IS NOT NULL)
+			GROUP BY ui2.user_id
+		),
+		recent AS (
+			SELECT id, user_id, post_type, is_anonymous FROM posts
+			WHERE is_active = true
+			  AND status = 'published'
+			  AND user_id <> $1
+			  AND created_at > NOW() - $2 * INTERVAL '1 second'
+		),
+		candidates AS (
+			SELECT rp.id, 'follow' AS source, 1 AS priority
+			FROM recent rp
+			JOIN user_follows f ON f.following_id = rp.user_id AND f.follower_id = $1
+			WHERE rp.is_anonymous = false
+
+			UNION ALL
+
+			SELECT DISTINCT rp.id, 'followed_interest', 2
+			FROM recent rp
+			JOIN post_interests pi ON pi.post_id = rp.id
+			JOIN interest_follows inf ON inf.interest_id = pi.interest_id AND inf.user_id = $1
+			WHERE rp.post_type <> 'repost' AND rp.is_anonymous = false
+
+			UNION ALL
+
+			SELECT rp.id, 'interest', 3
+			FROM recent rp
+			JOIN interest_peers ip ON ip.user_id = rp.user_id
+			WHERE rp.post_type <> 'repost' AND rp.is_anonymous = false
+
+			UNION ALL
+
+			SELECT rp.id, 'institution', 4
+			FROM recent rp
+			JOIN users au ON au.id = rp.user_id
+			JOIN users me ON me.id = $1
+			WHERE me.institution_id IS NOT NULL AND au.institution_id = me.institution_id
+			  AND rp.post_type <> 'repost' AND rp.is_anonymous = false
+
+			UNION ALL
+
+			(
+				SELECT rp.id, 'popular', 5
+				FROM recent rp
+				LEFT JOIN post_reactions pr ON pr.post_id = rp.id
+				WHERE rp.post_type <> 'repost' AND rp.is_anonymous = false
+				GROUP BY rp.id
+				ORDER BY COUNT(pr.id) DESC
+				LIMIT 100
+			)
+		),
+		sourced AS (
+			SELECT DISTINCT ON (id) id, source
+			FROM candidates
+			ORDER BY id, priority
+		)
+		SELECT p.id, p.user_id, p.content, p.images, p.is_anonymous, p.post_type, p.quoted_post_id, p.status, p.publish_at, p.is_active,
+		       p.view_count, p.created_at, p.updated_at,
+		       s.source,
+		       p.reaction_count, p.comment_count, p.share_count,
+		       (SELECT COUNT(*) FROM post_reactions r
+		          JOIN posts ap ON ap.id = r.post_id
+		          WHERE r.user_id = $1 AND ap.user_id = p.user_id
+		            AND r.created_at > NOW() - INTERVAL '30 days')
+		       + (SELECT COUNT(*) FROM post_comments c
+		          JOIN posts ap ON ap.id = c.post_id
+		          WHERE c.user_id = $1 AND ap.user_id = p.user_id
+		            AND c.created_at > NOW() - INTERVAL '30 days'),
+		       COALESCE(ip.overlap, 0),
+		       (SELECT COUNT(*) FROM post_hashtags cph
+		          JOIN hashtags ch ON ch.id = cph.hashtag_id
+		          WHERE cph.post_id = COALESCE(CASE WHEN p.post_type = 'repost' THEN p.quoted_post_id END, p.id)
+		            AND (cph.hashtag_id IN (SELECT hashtag_id FROM disliked WHERE hashtag_id IS NOT NULL)
+		                 OR ch.interest_id IN (SELECT interest_id FROM disliked WHERE interest_id IS NOT NULL)))
+		       + (SELECT COUNT(*) FROM post_interests cpi
+		          WHERE cpi.post_id = COALESCE(CASE WHEN p.post_type = 'repost' THEN p.quoted_post_id END, p.id)
+		            AND cpi.source = 'author'
+		            AND cpi.interest_id IN (SELECT interest_id FROM disliked WHERE interest_id IS NOT NULL))
+		FROM sourced s
+		JOIN posts p ON p.id = s.id
+		JOIN users u ON u.id = p.user_id
+		LEFT JOIN interest_peers ip ON ip.user_id = p.user_id
+		WHERE ` + visibleToViewer("$1") + `
+		AND ` + originalVisibleToViewer("p", "$1") + `
+		AND ` + mute.PostNotMuted("p", "$1") + `
+		AND ` + notHiddenByViewer("p", "$1") + `
+		ORDER BY p.created_at DESC
+		LIMIT $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID, int64(window.Seconds()), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candidates []*FeedCandidate
+
+	for rows.Next() {
+		post := &Post{}
+		candidate := &FeedCandidate{Post: post}
+		var images []string
+
+		err := rows.Scan(
+			&post.ID,
+			&post.UserID,
+			&post.Content,
+			&images,
+			&post.IsAnonymous,
+			&post.PostType,
+			&post.QuotedPostID,
+			&post.Status,
+			&post.PublishAt,
+			&post.IsActive,
+			&post.ViewCount,
+			&post.CreatedAt,
+			&post.UpdatedAt,
+			&candidate.Source,
+			&candidate.ReactionCount,
+			&candidate.CommentCount,
+			&candidate.ShareCount,
+			&candidate.AuthorAffinity,
+			&candidate.InterestOverlap,
+			&candidate.NotInterestedOverlap,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		post.Images = images
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, rows.Err()
+}
+
+// SaveViews adds a batch of counted views to the posts' view counts and hourly metrics and
+// records the viewers, in a single transaction
+func (r *PostgresPostRepository) SaveViews(ctx context.Context, views []*PostView) error {
+	postIDs := make([]string, 0, len(views))
+	viewerIDs := make([]string, 0, len(views))
+	counts := make([]int32, 0, len(views))
+	for _, v := range views {
+		postIDs = append(postIDs, v.PostID)
+		viewerIDs = append(viewerIDs, v.ViewerID)
+		counts = append(counts, int32(v.Views))
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// One UPDATE per post per flush instead of one per view
+	_, err = tx.Exec(ctx, `
+		UPDATE posts p
+		SET view_count = COALESCE(p.view_count, 0) + v.views
+		FROM (
+			SELECT post_id, SUM(views) AS views
+			FROM unnest($1::uuid[], $2::int[]) AS t(post_id, views)
+			GROUP BY post_id
+		) v
+		WHERE p.id = v.post_id
+	`, postIDs, counts)
+	if err != nil {
+		return err
+	}
+
+	// Hourly rollups for author insights; must run before post_views is updated so first-time
+	// viewers can be told apart
+	_, err = tx.Exec(ctx, `
+		INSERT INTO post_metrics_hourly (post_id, bucket, metric, dimension, value)
+		SELECT v.post_id, date_trunc('hour', NOW()), m.metric, '', SUM(m.value)
+		FROM unnest($1::uuid[], $2::uuid[], $3::int[]) AS v(post_id, viewer_id, views)
+		JOIN posts p ON p.id = v.post_id
+		JOIN users u ON u.id = v.viewer_id
+		CROSS JOIN LATERAL (
+			SELECT EXISTS (
+				SELECT 1 FROM user_follows f
+				WHERE f.follower_id = v.viewer_id AND f.following_id = p.user_id
+			) AS is_follower,
+			NOT EXISTS (
+				SELECT 1 FROM post_views pv
+				WHERE pv.post_id = v.post_id AND pv.viewer_id = v.viewer_id
+			) AS is_new
+		) s
+		CROSS JOIN LATERAL (VALUES
+			('views', v.views),
+			('follower_views', CASE WHEN s.is_follower THEN v.views ELSE 0 END),
+			('viewers', CASE WHEN s.is_new THEN 1 ELSE 0 END),
+			('follower_viewers', CASE WHEN s.is_new AND s.is_follower THEN 1 ELSE 0 END)
+		) m(metric, value)
+		WHERE m.value > 0
+		GROUP BY v.post_id, m.metric
+		ON CONFLICT (post_id, bucket, metric, dimension) DO UPDATE
+		SET value = post_metrics_hourly.value + EXCLUDED.value
+	`, postIDs, viewerIDs, counts)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO post_views (post_id, viewer_id, view_count)
+		SELECT v.post_id, v.viewer_id, v.views
+		FROM unnest($1::uuid[], $2::uuid[], $3::int[]) AS v(post_id, viewer_id, views)
+		WHERE EXISTS (SELECT 1 FROM posts p WHERE p.id = v.post_id)
+		  AND EXISTS (SELECT 1 FROM users u WHERE u.id = v.viewer_id)
+		ON CONFLICT (post_id, viewer_id) DO UPDATE
+		SET view_count = post_views.view_count + EXCLUDED.view_count,
+		    last_viewed_at = NOW()
+	`, postIDs, viewerIDs, counts)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetRepost retrieves a user's active repost of a post (nil if they haven't reposted it)
+func (r *PostgresPostRepository) GetRepost(ctx context.Context, userID, originalID string) (*Post, error) {
+	query := `
+		SELECT id, user_id, content, images, is_anonymous, post_type, quoted_post_id, status, publish_at, is_active,
+		       view_count, created_at, updated_at
+		FROM posts
+		WHERE user_id = $1 AND quoted_post_id = $2 AND post_type = 'repost' AND is_active = true
+	`
+
+	post := &Post{}
+	var images []string
+
+	err := r.pool.QueryRow(ctx, query, userID, originalID).Scan(
+		&post.ID,
+		&post.UserID,
+		&post.Content,
+		&images,
+		&post.IsAnonymous,
+		&post.PostType,
+		&post.QuotedPostID,
+		&post.Status,
+		&post.PublishAt,
+		&post.IsActive,
+		&post.ViewCount,
+		&post.CreatedAt,
+		&post.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	post.Images = images
+	return post, nil
+}
+
+// DeleteRepost permanently deletes a repost. A repost has nothing of its own to keep, so
+// unlike Delete the row is removed rather than soft deleted.
+func (r *PostgresPostRepository) DeleteRepost(ctx context.Context, id string) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM posts WHERE id = $1 AND post_type = 'repost'`, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// IsVisibleTo checks that a post is published and the viewer may see it
+func (r *PostgresPostRepository) IsVisibleTo(ctx context.Context, postID, viewerID string) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM posts p
+			JOIN users u ON u.id = p.user_id
+			WHERE p.id = $1 AND p.is_active = true AND p.status = 'published'
+			AND ` + visibleToViewer("$2") + `
+		)
+	`
+
+	var visible bool
+	err := r.pool.QueryRow(ctx, query, postID, viewerID).Scan(&visible)
+	return visible, err
+}
+
+// GetVisibleByIDs retrieves published posts by ID that the viewer may see, in no particular
+// order. Missing, deleted and hidden posts are left out.
+func (r *PostgresPostRepository) GetVisibleByIDs(ctx context.Context, ids []string, viewerID string) ([]*Post, error) {
+	query := `
+		SELECT p.id, p.user_id, p.content, p.images, p.is_anonymous, p.post_type, p.quoted_post_id, p.status, p.publish_at, p.is_active,
+		       p.view_count, p.created_at, p.updated_at
+		FROM posts p
+		JOIN users u ON u.id = p.user_id
+		WHERE p.id = ANY($1::uuid[]) AND p.is_active = true AND p.status = 'published'
+		AND ` + visibleToViewer("$2") + `
+	`
+
+	rows, err := r.pool.Query(ctx, query, ids, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var posts []*Post
+
+	for rows.Next() {
+		post := &Post{}
+		var images []string
+
+		err := rows.Scan(
+			&post.ID,
+			&post.UserID,
+			&post.Content,
+			&images,
+			&post.IsAnonymous,
+			&post.PostType,
+			&post.QuotedPostID,
+			&post.Status,
+			&post.PublishAt,
+			&post.IsActive,
+			&post.ViewCount,
+			&post.CreatedAt,
+			&post.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		post.Images = images
+		posts = append(posts, post)
+	}
+
+	return posts, rows.Err()
+}
+
+// GetSaved retrieves a user's saved posts, most recently saved first, optionally limited to
+// one collection. Posts that were deleted or that the user can no longer see are skipped.
+func (r *PostgresPostRepository) GetSaved(ctx context.Context, userID, collectionID string, limit, offset int) ([]*Post, error) {
+	query := `
+		SELECT p.id, p.user_id, p.content, p.images, p.is_anonymous, p.post_type, p.quoted_post_id, p.status, p.publish_at, p.is_active,
+		       p.view_count, p.created_at, p.updated_at
+		FROM bookmarks b
+		JOIN posts p ON p.id = b.post_id AND p.is_active = true AND p.status = 'published'
+		JOIN users u ON u.id = p.user_id
+		WHERE b.user_id = $1
+		AND ($2::text = '' OR b.collection_id = NULLIF($2::text, '')::uuid)
+		AND ` + visibleToViewer("$1") + `
+		ORDER BY b.created_at DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID, collectionID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var posts []*Post
+
+	for rows.Next() {
+		post := &Post{}
+		var images []string
+
+		err := rows.Scan(
+			&post.ID,
+			&post.UserID,
+			&post.Content,
+			&images,
+			&post.IsAnonymous,
+			&post.PostType,
+			&post.QuotedPostID,
+			&post.Status,
+			&post.PublishAt,
+			&post.IsActive,
+			&post.ViewCount,
+			&post.CreatedAt,
+			&post.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		post.Images = images
+		posts = append(posts, post)
+	}
+
+	return posts, rows.Err()
+}
+
+// HasUserSaved checks if a user has saved a post
+func (r *PostgresPostRepository) HasUserSaved(ctx context.Context, postID, userID string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM bookmarks WHERE post_id = $1 AND user_id = $2)`
+
+	var exists bool
+	err := r.pool.QueryRow(ctx, query, postID, userID).Scan(&exists)
+	return exists, err
+}
+
+// GetSavedAmong returns which of postIDs the user has saved
+func (r *PostgresPostRepository) GetSavedAmong(ctx context.Context, postIDs []string, userID string) (map[string]bool, error) {
+	query := `SELECT DISTINCT post_id FROM bookmarks WHERE post_id = ANY($1::uuid[]) AND user_id = $2`
+
+	return r.queryIDSet(ctx, query, postIDs, userID)
+}
+
+// GetUniqueViewerCount returns the number of distinct logged-in users who viewed a post
+func (r *PostgresPostRepository) GetUniqueViewerCount(ctx context.Context, postID string) (int, error) {
+	query := `SELECT COUNT(*) FROM post_views WHERE post_id = $1`
+
+	var count int
+	err := r.pool.QueryRow(ctx, query, postID).Scan(&count)
+	return count, err
+}
+
+// GetByHashtag retrieves posts tagged with a hashtag, newest first, hiding posts the
+// viewer may not see or hid. An empty viewerID is treated as a logged-out visitor.
+func (r *PostgresPostRepository) GetByHashtag(ctx context.Context, viewerID, tag string, limit, offset int) ([]*Post, error) {
+	query := `
+		SELECT p.id, p.user_id, p.content, p.images, p.is_anonymous, p.post_type, p.quoted_post_id, p.status, p.publish_at, p.is_active,
+		       p.view_count, p.created_at, p.updated_at
+		FROM hashtags h
+		JOIN post_hashtags ph ON ph.hashtag_id = h.id
+		JOIN posts p ON p.id = ph.post_id AND p.is_active = true AND p.status = 'published'
+		JOIN users u ON u.id = p.user_id
+		WHERE h.tag = $2
+		AND ` + visibleToViewer("$1") + `
+		AND ` + originalVisibleToViewer("p", "$1") + `
+		AND ` + notHiddenByViewer("p", "$1") + `
+		ORDER BY p.created_at DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.pool.Query(ctx, query, viewerID, tag, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var posts []*Post
+
+	for rows.Next() {
+		post := &Post{}
+		var images []string
+
+		err := rows.Scan(
+			&post.ID,
+			&post.UserID,
+			&post.Content,
+			&images,
+			&post.IsAnonymous,
+			&post.PostType,
+			&post.QuotedPostID,
+			&post.Status,
+			&post.PublishAt,
+			&post.IsActive,
+			&post.ViewCount,
+			&post.CreatedAt,
+			&post.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		post.Images = images
+		posts = append(posts, post)
+	}
+
+	return posts, rows.Err()
+}
+
+// SetPostInterests replaces the interests the author tagged a post with. An interest that was
+// inferred from a hashtag becomes an author tag when picked.
+func (r *PostgresPostRepository) SetPostInterests(ctx context.Context, postID string, interestIDs []string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM post_interests WHERE post_id = $1 AND source = 'author'`, postID); err != nil {
+		return err
+	}
+
+	if len(interestIDs) > 0 {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO post_interests (post_id, interest_id, source)
+			SELECT $1, unnest($2::uuid[]), 'author'
+			ON CONFLICT (post_id, interest_id) DO UPDATE SET source = 'author'
+		`, postID, interestIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// SyncInferredInterests re-derives the interests of a post from its hashtags, keeping the
+// author's own tags
+func (r *PostgresPostRepository) SyncInferredInterests(ctx context.Context, postID string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM post_interests WHERE post_id = $1 AND source = 'hashtag'`, postID); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO post_interests (post_id, interest_id, source)
+		SELECT DISTINCT ph.post_id, h.interest_id, 'hashtag'
+		FROM post_hashtags ph
+		JOIN hashtags h ON h.id = ph.hashtag_id
+		WHERE ph.post_id = $1 AND h.interest_id IS NOT NULL
+		ON CONFLICT (post_id, interest_id) DO NOTHING
+	`, postID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetPostInterests retrieves the interests a post is tagged with, author tags first
+func (r *PostgresPostRepository) GetPostInterests(ctx context.Context, postID string) ([]*PostInterest, error) {
+	query := `
+		SELECT i.id, i.slug, i.name, pi.source
+		FROM post_interests pi
+		JOIN interests i ON i.id = pi.interest_id
+		WHERE pi.post_id = $1
+		ORDER BY pi.source = 'author' DESC, i.name
+	`
+
+	rows, err := r.pool.Query(ctx, query, postID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var interests []*PostInterest
+
+	for rows.Next() {
+		interest := &PostInterest{}
+		if err := rows.Scan(&interest.InterestID, &interest.Slug, &interest.Name, &interest.Source); err != nil {
+			return nil, err
+		}
+		interests = append(interests, interest)
+	}
+
+	return interests, rows.Err()
+}
+
+// GetPostsInterests retrieves the interests many posts are tagged with, keyed by post ID,
+// each post's author tags first
+func (r *PostgresPostRepository) GetPostsInterests(ctx context.Context, postIDs []string) (map[string][]*PostInterest, error) {
+	query := `
+		SELECT pi.post_id, i.id, i.slug, i.name, pi.source
+		FROM post_interests pi
+		JOIN interests i ON i.id = pi.interest_id
+		WHERE pi.post_id = ANY($1::uuid[])
+		ORDER BY pi.post_id, pi.source = 'author' DESC, i.name
+	`
+
+	rows, err := r.pool.Query(ctx, query, postIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	interests := make(map[string][]*PostInterest)
+
+	for rows.Next() {
+		var postID string
+		interest := &PostInterest{}
+		if err := rows.Scan(&postID, &interest.InterestID, &interest.Slug, &interest.Name, &interest.Source); err != nil {
+			return nil, err
+		}
+		interests[postID] = append(interests[postID], interest)
+	}
+
+	return interests, rows.Err()
+}
+
+// GetByInterest retrieves posts tagged with an interest, newest first, hiding posts the
+// viewer may not see, muted or hid. Like the interest posts in the home feed, anonymous
+// posts are left out. An empty viewerID is treated as a logged-out visitor.
+func (r *PostgresPostRepository) GetByInterest(ctx context.Context, viewerID, interestID string, limit, offset int) ([]*Post, error) {
+	query := `
+		SELECT p.id, p.user_id, p.content, p.images, p.is_anonymous, p.post_type, p.quoted_post_id, p.status, p.publish_at, p.is_active,
+		       p.view_count, p.created_at, p.updated_at
+		FROM post_interests pi
+		JOIN posts p ON p.id = pi.post_id AND p.is_active = true AND p.status = 'published'
+		JOIN users u ON u.id = p.user_id
+		WHERE pi.interest_id = $2
+		AND p.is_anonymous = false
+		AND ` + visibleToViewer("$1") + `
+		AND ` + originalVisibleToViewer("p", "$1") + `
+		AND ` + mute.PostNotMuted("p", "$1") + `
+		AND ` + notHiddenByViewer("p", "$1") + `
+		ORDER BY p.created_at DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.pool.Query(ctx, query, viewerID, interestID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var posts []*Post
+
+	for rows.Next() {
+		post := &Post{}
+		var images []string
+
+		err := rows.Scan(
+			&post.ID,
+			&post.UserID,
+			&post.Content,
+			&images,
+			&post.IsAnonymous,
+			&post.PostType,
+			&post.QuotedPostID,
+			&post.Status,
+			&post.PublishAt,
+			&post.IsActive,
+			&post.ViewCount,
+			&post.CreatedAt,
+			&post.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		post.Images = images
+		posts = append(posts, post)
+	}
+
+	return posts, rows.Err()
+}
+
+// GetTrending retrieves posts ordered by their precomputed trending score, hiding posts the
+// viewer may not see, muted or hidden. The category filter matches the interests a post is
+// tagged with. An empty viewerID is treated as a logged-out visitor.
+func (r *PostgresPostRepository) GetTrending(ctx context.Context, viewerID string, filter ExploreFilter, limit, offset int) ([]*Post, error) {
+	query := `
+		SELECT p.id, p.user_id, p.content, p.images, p.is_anonymous, p.post_type, p.quoted_post_id, p.status, p.publish_at, p.is_active,
+		       p.view_count, p.created_at, p.updated_at
+		FROM post_trending_scores t
+		JOIN posts p ON p.id = t.post_id AND p.is_active = true AND p.status = 'published' AND p.post_type <> 'repost'
+		JOIN users u ON u.id = p.user_id
+		WHERE ` + visibleToViewer("$1") + `
+		AND ` + originalVisibleToViewer("p", "$1") + `
+		AND ` + mute.PostNotMuted("p", "$1") + `
+		AND ` + notHiddenByViewer("p", "$1") + `
+		AND ($2::text = '' OR EXISTS (
+			SELECT 1 FROM post_interests pi
+			JOIN interests i ON i.id = pi.interest_id
+			WHERE pi.post_id = p.id AND i.category = $2::text
+		))
+		AND ($3::text = '' OR u.institution_id = NULLIF($3::text, '')::uuid)
+		ORDER BY t.score DESC, p.created_at DESC
+		LIMIT $4 OFFSET $5
+	`
+
+	rows, err := r.pool.Query(ctx, query, viewerID, filter.Category, filter.InstitutionID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var posts []*Post
+
+	for rows.Next() {
+		post := &Post{}
+		var images []string
+
+		err := rows.Scan(
+			&post.ID,
+			&post.UserID,
+			&post.Content,
+			&images,
+			&post.IsAnonymous,
+			&post.PostType,
+			&post.QuotedPostID,
+			&post.Status,
+			&post.PublishAt,
+			&post.IsActive,
+			&post.ViewCount,
+			&post.CreatedAt,
+			&post.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		post.Images = images
+		posts = append(posts, post)
+	}
+
+	return posts, rows.Err()
+}
+
+// RefreshTrendingScores recomputes trending scores from reactions, comments and shares within
+// the window. Each interaction's weight decays exponentially with its age, so posts that are
+// getting attention right now outrank posts that were popular days ago. Returns the number of
+// scored posts.
+func (r *PostgresPostRepository) RefreshTrendingScores(ctx context.Context, window time.Duration, halfLifeHours float64) (int64, error) {
+	query := `
+		INSERT INTO post_trending_scores (post_id, score, computed_at)
+		SELECT e.post_id,
+		       SUM(e.weight * EXP(-LN(2) * EXTRACT(EPOCH FROM (NOW() - e.created_at)) / 3600 / $2))::double precision,
+		       NOW()
+		FROM (
+			SELECT post_id, 1.0 AS weight, created_at FROM post_reactions
+			WHERE created_at > NOW() - $1 * INTERVAL '1 second'
+			UNION ALL
+			SELECT post_id, 2.0, created_at FROM post_comments
+			WHERE is_active = true AND created_at > NOW() - $1 * INTERVAL '1 second'
+			UNION ALL
+			SELECT post_id, 3.0, created_at FROM post_shares
+			WHERE created_at > NOW() - $1 * INTERVAL '1 second'
+		) e
+		JOIN posts p ON p.id = e.post_id AND p.is_active = true AND p.status = 'published'
+		GROUP BY e.post_id
+	`
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Replace the whole table atomically; readers keep seeing the old scores until commit
+	if _, err := tx.Exec(ctx, `DELETE FROM post_trending_scores`); err != nil {
+		return 0, err
+	}
+
+	result, err := tx.Exec(ctx, query, int64(window.Seconds()), halfLifeHours)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// AddReaction adds a fire reaction to a post
+func (r *PostgresPostRepository) AddReaction(ctx context.Context, reaction *Reaction) error {
+	query := `
+		INSERT INTO post_reactions (post_id, user_id, reaction_type)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (post_id, user_id) DO NOTHING
+		RETURNING id, created_at
+	`
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, query, reaction.PostID, reaction.UserID, reaction.ReactionType).
+		Scan(&reaction.ID, &reaction.CreatedAt)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil // ON CONFLICT triggered, reaction already exists
+		}
+		return err
+	}
+
+	if err := counter.Add(ctx, tx, counter.PostReactions, reaction.PostID, 1); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// RemoveReaction removes a user's reaction from a post
+func (r *PostgresPostRepository) RemoveReaction(ctx context.Context, postID, userID string) error {
+	query := `
+		DELETE FROM post_reactions
+		WHERE post_id = $1 AND user_id = $2
+	`
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, query, postID, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected := result.RowsAffected()
+	if rowsAffected == 0 {
+		return pgx.ErrNoRows
+	}
+
+	if err := counter.Add(ctx, tx, counter.PostReactions, postID, -1); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetReactions retrieves all reactions for a post
+func (r *PostgresPostRepository) GetReactions(ctx context.Context, postID string, limit, offset int) ([]*Reaction, error) {
+	query := `
+		SELECT id, post_id, user_id, reaction_type, created_at
+		FROM post_reactions
+		WHERE post_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, postID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reactions []*Reaction
+
+	for rows.Next() {
+		reaction := &Reaction{}
+		err := rows.Scan(
+			&reaction.ID,
+			&reaction.PostID,
+			&reaction.UserID,
+			&reaction.ReactionType,
+			&reaction.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		reactions = append(reactions, reaction)
+	}
+
+	return reactions, rows.Err()
+}
+
+// GetReactionCount returns the total number of reactions for a post
+func (r *PostgresPostRepository) GetReactionCount(ctx context.Context, postID string) (int, error) {
+	query := `SELECT COALESCE((SELECT reaction_count FROM posts WHERE id = $1), 0)`
+
+	var count int
+	err := r.pool.QueryRow(ctx, query, postID).Scan(&count)
+	return count, err
+}
+
+// HasUserReacted checks if a specific user has reacted to a post
+func (r *PostgresPostRepository) HasUserReacted(ctx context.Context, postID, userID string) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM post_reactions
+			WHERE post_id = $1 AND user_id = $2
+		)
+	`
+
+	var exists bool
+	err := r.pool.QueryRow(ctx, query, postID, userID).Scan(&exists)
+	return exists, err
+}
+
+// GetReactionCounts returns the number of reactions to each of many posts. Posts without
+// reactions are left out.
+func (r *PostgresPostRepository) GetReactionCounts(ctx context.Context, postIDs []string) (map[string]int, error) {
+	query := `
+		SELECT id, reaction_count
+		FROM posts
+		WHERE id = ANY($1::uuid[]) AND reaction_count > 0
+	`
+
+	rows, err := r.pool.Query(ctx, query, postIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var postID string
+		var count int
+		if err := rows.Scan(&postID, &count); err != nil {
+			return nil, err
+		}
+		counts[postID] = count
+	}
+
+	return counts, rows.Err()
+}
+
+// GetReactedAmong returns which of postIDs the user has reacted to
+func (r *PostgresPostRepository) GetReactedAmong(ctx context.Context, postIDs []string, userID string) (map[string]bool, error) {
+	query := `SELECT post_id FROM post_reactions WHERE post_id = ANY($1::uuid[]) AND user_id = $2`
+
+	return r.queryIDSet(ctx, query, postIDs, userID)
+}
+
+// GetRecentReactions retrieves the latest reactions to each of many posts, up to limit per
+// post, newest first
+func (r *PostgresPostRepository) GetRecentReactions(ctx context.Context, postIDs []string, limit int) ([]*Reaction, error) {
+	query := `
+		SELECT id, post_id, user_id, reaction_type, created_at
+		FROM (
+			SELECT id, post_id, user_id, reaction_type, created_at,
+			       ROW_NUMBER() OVER (PARTITION BY post_id ORDER BY created_at DESC) AS rn
+			FROM post_reactions
+			WHERE post_id = ANY($1::uuid[])
+		) ranked
+		WHERE rn <= $2
+		ORDER BY post_id, created_at DESC
+	`
+
+	rows, err := r.pool.Query(ctx, query, postIDs, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reactions []*Reaction
+
+	for rows.Next() {
+		reaction := &Reaction{}
+		err := rows.Scan(
+			&reaction.ID,
+			&reaction.PostID,
+			&reaction.UserID,
+			&reaction.ReactionType,
+			&reaction.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		reactions = append(reactions, reaction)
+	}
+
+	return reactions, rows.Err()
+}
+
+// PinPost pins a post to its author's profile after the existing pins. The user's row is
+// locked so concurrent requests can't exceed maxPins. Returns ErrAlreadyPinned or ErrTooManyPins.
+func (r *PostgresPostRepository) PinPost(ctx context.Context, userID, postID string, maxPins int) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT 1 FROM users WHERE id = $1 FOR UPDATE`, userID); err != nil {
+		return err
+	}
+
+	var count int
+	var pinned bool
+	err = tx.QueryRow(ctx, `
+		SELECT COUNT(*), COALESCE(BOOL_OR(post_id = $2), false)
+		FROM post_pins
+		WHERE user_id = $1
+	`, userID, postID).Scan(&count, &pinned)
+	if err != nil {
+		return err
+	}
+	if pinned {
+		return ErrAlreadyPinned
+	}
+	if count >= maxPins {
+		return ErrTooManyPins
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO post_pins (user_id, post_id, position)
+		VALUES ($1, $2, $3)
+	`, userID, postID, count)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// UnpinPost removes a pin and closes the gap in the remaining positions; returns false if
+// the post wasn't pinned
+func (r *PostgresPostRepository) UnpinPost(ctx context.Context, userID, postID string) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `DELETE FROM post_pins WHERE user_id = $1 AND post_id = $2`, userID, postID)
+	if err != nil {
+		return false, err
+	}
+	if result.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE post_pins pp
+		SET position = ordered.rn - 1
+		FROM (
+			SELECT post_id, ROW_NUMBER() OVER (ORDER BY position) AS rn
+			FROM post_pins
+			WHERE user_id = $1
+		) ordered
+		WHERE pp.post_id = ordered.post_id
+	`, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit(ctx)
+}
+
+// GetPinnedPostIDs returns a user's pinned post IDs in pin order
+func (r *PostgresPostRepository) GetPinnedPostIDs(ctx context.Context, userID string) ([]string, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT post_id FROM post_pins
+		WHERE user_id = $1
+		ORDER BY position
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	postIDs := []string{}
+	for rows.Next() {
+		var postID string
+		if err := rows.Scan(&postID); err != nil {
+			return nil, err
+		}
+		postIDs = append(postIDs, postID)
+	}
+
+	return postIDs, rows.Err()
+}
+
+// ReorderPins sets the pin order to the order of postIDs, which must be the user's pinned posts
+func (r *PostgresPostRepository) ReorderPins(ctx context.Context, userID string, postIDs []string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for i, postID := range postIDs {
+		_, err := tx.Exec(ctx, `
+			UPDATE post_pins SET position = $1
+			WHERE user_id = $2 AND post_id = $3
+		`, i, userID, postID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// IsPinned checks if a post is pinned to its author's profile
+func (r *PostgresPostRepository) IsPinned(ctx context.Context, postID string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM post_pins WHERE post_id = $1)`
+
+	var exists bool
+	err := r.pool.QueryRow(ctx, query, postID).Scan(&exists)
+	return exists, err
+}
+
+// GetPinnedAmong returns which of postIDs are pinned to their author's profile
+func (r *PostgresPostRepository) GetPinnedAmong(ctx context.Context, postIDs []string) (map[string]bool, error) {
+	query := `SELECT post_id FROM post_pins WHERE post_id = ANY($1::uuid[])`
+
+	return r.queryIDSet(ctx, query, postIDs)
+}
+
+// SaveFeedback hides a post from a user's feeds, replacing any earlier reason
+func (r *PostgresPostRepository) SaveFeedback(ctx context.Context, userID, postID, kind string) error {
+	query := `
+		INSERT INTO post_feedback (user_id, post_id, kind)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, post_id) DO UPDATE SET kind = EXCLUDED.kind, created_at = NOW()
+	`
+
+	_, err := r.pool.Exec(ctx, query, userID, postID, kind)
+	return err
+}
+
+// DeleteFeedback unhides a post; returns false if it wasn't hidden
+func (r *PostgresPostRepository) DeleteFeedback(ctx context.Context, userID, postID string) (bool, error) {
+	result, err := r.pool.Exec(ctx, `DELETE FROM post_feedback WHERE user_id = $1 AND post_id = $2`, userID, postID)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// GetHiddenPosts retrieves the posts a user hid, most recently hidden first. Posts that were
+// deleted or that the user can no longer see are skipped.
+func (r *PostgresPostRepository) GetHiddenPosts(ctx context.Context, userID string, limit, offset int) ([]*HiddenPost, error) {
+	query := `
+		SELECT p.id, p.user_id, p.content, p.images, p.is_anonymous, p.post_type, p.quoted_post_id, p.status, p.publish_at, p.is_active,
+		       p.view_count, p.created_at, p.updated_at,
+		       pf.kind, pf.created_at
+		FROM post_feedback pf
+		JOIN posts p ON p.id = pf.post_id AND p.is_active = true AND p.status = 'published'
+		JOIN users u ON u.id = p.user_id
+		WHERE pf.user_id = $1
+		AND ` + visibleToViewer("$1") + `
+		ORDER BY pf.created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hidden []*HiddenPost
+
+	for rows.Next() {
+		post := &Post{}
+		h := &HiddenPost{Post: post}
+		var images []string
+
+		err := rows.Scan(
+			&post.ID,
+			&post.UserID,
+			&post.Content,
+			&images,
+			&post.IsAnonymous,
+			&post.PostType,
+			&post.QuotedPostID,
+			&post.Status,
+			&post.PublishAt,
+			&post.IsActive,
+			&post.ViewCount,
+			&post.CreatedAt,
+			&post.UpdatedAt,
+			&h.Kind,
+			&h.HiddenAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		post.Images = images
+		hidden = append(hidden, h)
+	}
+
+	return hidden, rows.Err()
+}
+
+// queryIDSet runs a query selecting one ID column and returns the IDs as a set
+func (r *PostgresPostRepository) queryIDSet(ctx context.Context, query string, args ...interface{}) (map[string]bool, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make(map[string]bool)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids[id] = true
+	}
+
+	return ids, rows.Err()
+}
+
+// visibleToViewer returns a SQL predicate restricting posts to those whose author (aliased
+// as u) the viewer bound to param may see: the author is active, neither user has blocked
+// the other, and the author's who_can_see_posts setting allows it. An empty viewer ID is
+// treated as a logged-out visitor and only sees public posts.
+func visibleToViewer(param string) string {
+	return authorVisibleToViewer("u", param)
+}
+
+// authorVisibleToViewer is visibleToViewer for an author joined under another alias, e.g.
+// the original author of a repost
+func authorVisibleToViewer(alias, param string) string {
+	viewer := fmt.Sprintf("NULLIF(%s::text, '')::uuid", param)
+
+	return fmt.Sprintf(`%[2]s.is_active = true
+		AND (
+			%[2]s.who_can_see_posts = 'everyone'
+			OR %[2]s.id = %[1]s
+			OR (%[2]s.who_can_see_posts = 'followers' AND EXISTS (
+				SELECT 1 FROM user_follows vf
+				WHERE vf.follower_id = %[1]s AND vf.following_id = %[2]s.id
+			))
+		)
+		AND NOT EXISTS (
+			SELECT 1 FROM blocked_users vb
+			WHERE (vb.blocker_id = %[1]s AND vb.blocked_id = %[2]s.id)
+			   OR (vb.blocker_id = %[2]s.id AND vb.blocked_id = %[1]s)
+		)`, viewer, alias)
+}
+
+// inHomeFeed returns a SQL predicate that is true if the post aliased as postAlias belongs
+// in the home feed of the user bound to param: a published, non-anonymous post or repost by
+// a followed user, or a post tagged with a followed interest by another user the viewer may
+// see, that isn't muted or hidden and whose original (for a repost or quote) is visible
+func inHomeFeed(postAlias, param string) string {
+	return fmt.Sprintf(`(
+				%[1]s.user_id IN (
+					SELECT following_id FROM user_follows WHERE follower_id = %[2]s
+				)
+				OR (
+					%[1]s.user_id <> %[2]s
+					AND %[1]s.id IN (
+						SELECT pi.post_id FROM post_interests pi
+						JOIN interest_follows inf ON inf.interest_id = pi.interest_id
+						WHERE inf.user_id = %[2]s
+					)
+					AND EXISTS (SELECT 1 FROM users iu WHERE iu.id = %[1]s.user_id AND %[3]s)
+				)
+			)
+			AND %[1]s.is_active = true AND %[1]s.status = 'published'
+			AND %[1]s.is_anonymous = false
+			AND %[4]s
+			AND %[5]s
+			AND %[6]s`, postAlias, param,
+		authorVisibleToViewer("iu", param),
+		originalVisibleToViewer(postAlias, param),
+		mute.PostNotMuted(postAlias, param),
+		notHiddenByViewer(postAlias, param))
+}
+
+// originalVisibleToViewer returns a SQL predicate that is true unless the post aliased as
+// postAlias shares an original the viewer may not see. A repost or quote of a post whose
+// author is hidden from the viewer (blocked, deactivated or private) is left out; so is a
+// repost of a deleted post, while a quote keeps its own content and stays.
+func originalVisibleToViewer(postAlias, param string) string {
+	return fmt.Sprintf(`(CASE %[1]s.post_type
+		WHEN 'repost' THEN EXISTS (
+			SELECT 1 FROM posts op
+			JOIN users ou ON ou.id = op.user_id
+			WHERE op.id = %[1]s.quoted_post_id
+			AND op.is_active = true AND op.status = 'published'
+			AND %[2]s
+		)
+		WHEN 'quote' THEN NOT EXISTS (
+			SELECT 1 FROM posts op
+			JOIN users ou ON ou.id = op.user_id
+			WHERE op.id = %[1]s.quoted_post_id
+			AND NOT (%[2]s)
+		)
+		ELSE true
+	END)`, postAlias, authorVisibleToViewer("ou", param))
+}
+
+// notHiddenByViewer returns a SQL predicate that is true unless the viewer bound to param
+// hid the post aliased as postAlias (or, for a repost, its original) from their feeds
+func notHiddenByViewer(postAlias, param string) string {
+	return fmt.Sprintf(`NOT EXISTS (
+			SELECT 1 FROM post_feedback pf
+			WHERE pf.user_id = NULLIF(%[2]s::text, '')::uuid
+			AND pf.post_id = COALESCE(CASE WHEN %[1]s.post_type = 'repost' THEN %[1]s.quoted_post_id END, %[1]s.id)
+		)`, postAlias, param)
 }
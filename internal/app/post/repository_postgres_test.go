@@ -0,0 +1,811 @@
+package post
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"mockhu-app-backend/internal/pkg/testdb"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestCreateAndRepost(t *testing.T) {
+	pool := testdb.Pool(t)
+	repo := NewPostgresPostRepository(pool)
+	ctx := context.Background()
+
+	authorID := testdb.CreateUser(t, pool)
+	reposterID := testdb.CreateUser(t, pool)
+
+	original := &Post{UserID: authorID, Content: "original post", IsActive: true}
+	if err := repo.Create(ctx, original); err != nil {
+		t.Fatalf("Create original: %v", err)
+	}
+	if original.ID == "" || original.Status != StatusPublished {
+		t.Fatalf("Create original: got id %q status %q", original.ID, original.Status)
+	}
+
+	// Reposts have no content of their own
+	repost := &Post{UserID: reposterID, PostType: TypeRepost, QuotedPostID: &original.ID, IsActive: true}
+	if err := repo.Create(ctx, repost); err != nil {
+		t.Fatalf("Create repost: %v", err)
+	}
+
+	got, err := repo.GetRepost(ctx, reposterID, original.ID)
+	if err != nil {
+		t.Fatalf("GetRepost: %v", err)
+	}
+	if got == nil || got.ID != repost.ID {
+		t.Fatalf("GetRepost: got %+v, want repost %s", got, repost.ID)
+	}
+	if got.QuotedPostID == nil || *got.QuotedPostID != original.ID {
+		t.Errorf("GetRepost: quoted post %v, want %s", got.QuotedPostID, original.ID)
+	}
+
+	// Only the original counts towards its author's posts
+	for userID, want := range map[string]int{authorID: 1, reposterID: 0} {
+		var count int
+		if err := pool.QueryRow(ctx, `SELECT post_count FROM users WHERE id = $1`, userID).Scan(&count); err != nil {
+			t.Fatalf("get post count: %v", err)
+		}
+		if count != want {
+			t.Errorf("post count of %s = %d, want %d", userID, count, want)
+		}
+	}
+
+	// A text post still needs content
+	empty := &Post{UserID: authorID, Content: "", IsActive: true}
+	if err := repo.Create(ctx, empty); err == nil {
+		t.Error("Create empty text post: want error, got nil")
+	}
+
+	// Undoing a repost removes the row; only reposts can be removed this way
+	if err := repo.DeleteRepost(ctx, original.ID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("DeleteRepost(original): err = %v, want pgx.ErrNoRows", err)
+	}
+	if err := repo.DeleteRepost(ctx, repost.ID); err != nil {
+		t.Fatalf("DeleteRepost: %v", err)
+	}
+	var exists bool
+	if err := pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM posts WHERE id = $1)`, repost.ID).Scan(&exists); err != nil {
+		t.Fatalf("check repost: %v", err)
+	}
+	if exists {
+		t.Error("DeleteRepost left the repost row behind")
+	}
+}
+
+func TestFeedsHideQuotesOfHiddenOriginals(t *testing.T) {
+	pool := testdb.Pool(t)
+	repo := NewPostgresPostRepository(pool)
+	ctx := context.Background()
+
+	blockerID := testdb.CreateUser(t, pool)
+	authorID := testdb.CreateUser(t, pool)
+	quoterID := testdb.CreateUser(t, pool)
+	viewerID := testdb.CreateUser(t, pool)
+
+	if _, err := pool.Exec(ctx, `INSERT INTO blocked_users (blocker_id, blocked_id) VALUES ($1, $2)`, blockerID, viewerID); err != nil {
+		t.Fatalf("block: %v", err)
+	}
+	if _, err := pool.Exec(ctx, `INSERT INTO user_follows (follower_id, following_id) VALUES ($1, $2)`, viewerID, quoterID); err != nil {
+		t.Fatalf("follow: %v", err)
+	}
+
+	blockedOriginal := &Post{UserID: blockerID, Content: "by someone who blocked the viewer", IsActive: true}
+	deletedOriginal := &Post{UserID: authorID, Content: "deleted later", IsActive: true}
+	for _, post := range []*Post{blockedOriginal, deletedOriginal} {
+		if err := repo.Create(ctx, post); err != nil {
+			t.Fatalf("Create original: %v", err)
+		}
+	}
+
+	quoteOfBlocked := &Post{UserID: quoterID, Content: "look at this", PostType: TypeQuote, QuotedPostID: &blockedOriginal.ID, IsActive: true}
+	repostOfBlocked := &Post{UserID: quoterID, PostType: TypeRepost, QuotedPostID: &blockedOriginal.ID, IsActive: true}
+	quoteOfDeleted := &Post{UserID: quoterID, Content: "and this", PostType: TypeQuote, QuotedPostID: &deletedOriginal.ID, IsActive: true}
+	repostOfDeleted := &Post{UserID: quoterID, PostType: TypeRepost, QuotedPostID: &deletedOriginal.ID, IsActive: true}
+	for _, post := range []*Post{quoteOfBlocked, repostOfBlocked, quoteOfDeleted, repostOfDeleted} {
+		if err := repo.Create(ctx, post); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+	if err := repo.Delete(ctx, deletedOriginal.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	// Reposts and quotes of a hidden author's post are both left out; a quote of a deleted
+	// post keeps its own content, a repost of it has nothing left to show
+	want := []string{quoteOfDeleted.ID}
+
+	feeds := []struct {
+		name string
+		get  func() ([]*Post, error)
+	}{
+		{"profile", func() ([]*Post, error) { return repo.GetByUserID(ctx, quoterID, viewerID, 20, 0) }},
+		{"home", func() ([]*Post, error) { return repo.GetFeed(ctx, viewerID, 20, 0) }},
+	}
+
+	for _, feed := range feeds {
+		t.Run(feed.name, func(t *testing.T) {
+			posts, err := feed.get()
+			if err != nil {
+				t.Fatalf("get feed: %v", err)
+			}
+			var got []string
+			for _, post := range posts {
+				if post.UserID == quoterID {
+					got = append(got, post.ID)
+				}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("quoter's posts in the feed = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestHomeFeedHidesQuotesOfMutedAuthors(t *testing.T) {
+	pool := testdb.Pool(t)
+	repo := NewPostgresPostRepository(pool)
+	ctx := context.Background()
+
+	mutedID := testdb.CreateUser(t, pool)
+	quoterID := testdb.CreateUser(t, pool)
+	viewerID := testdb.CreateUser(t, pool)
+
+	if _, err := pool.Exec(ctx, `INSERT INTO user_mutes (muter_id, muted_id) VALUES ($1, $2)`, viewerID, mutedID); err != nil {
+		t.Fatalf("mute: %v", err)
+	}
+	if _, err := pool.Exec(ctx, `INSERT INTO user_follows (follower_id, following_id) VALUES ($1, $2)`, viewerID, quoterID); err != nil {
+		t.Fatalf("follow: %v", err)
+	}
+
+	original := &Post{UserID: mutedID, Content: "by a muted user", IsActive: true}
+	if err := repo.Create(ctx, original); err != nil {
+		t.Fatalf("Create original: %v", err)
+	}
+	quote := &Post{UserID: quoterID, Content: "look at this", PostType: TypeQuote, QuotedPostID: &original.ID, IsActive: true}
+	own := &Post{UserID: quoterID, Content: "my own post", IsActive: true}
+	for _, post := range []*Post{quote, own} {
+		if err := repo.Create(ctx, post); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	posts, err := repo.GetFeed(ctx, viewerID, 20, 0)
+	if err != nil {
+		t.Fatalf("GetFeed: %v", err)
+	}
+	if len(posts) != 1 || posts[0].ID != own.ID {
+		t.Errorf("home feed = %d posts, want only the quoter's own post", len(posts))
+	}
+}
+
+func TestGetByUserIDAnonymousPosts(t *testing.T) {
+	pool := testdb.Pool(t)
+	repo := NewPostgresPostRepository(pool)
+	ctx := context.Background()
+
+	authorID := testdb.CreateUser(t, pool)
+	viewerID := testdb.CreateUser(t, pool)
+
+	for _, post := range []*Post{
+		{UserID: authorID, Content: "signed post", IsActive: true},
+		{UserID: authorID, Content: "anonymous post", IsAnonymous: true, IsActive: true},
+	} {
+		if err := repo.Create(ctx, post); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		viewerID  string
+		wantPosts int
+	}{
+		{"author", authorID, 2},
+		{"other user", viewerID, 1},
+		{"logged out", "", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			posts, err := repo.GetByUserID(ctx, authorID, tt.viewerID, 20, 0)
+			if err != nil {
+				t.Fatalf("GetByUserID: %v", err)
+			}
+			if len(posts) != tt.wantPosts {
+				t.Errorf("got %d posts, want %d", len(posts), tt.wantPosts)
+			}
+		})
+	}
+}
+
+func TestGetFeedCandidatesSkipsAnonymousPosts(t *testing.T) {
+	pool := testdb.Pool(t)
+	repo := NewPostgresPostRepository(pool)
+	ctx := context.Background()
+
+	authorID := testdb.CreateUser(t, pool)
+	viewerID := testdb.CreateUser(t, pool)
+
+	signed := &Post{UserID: authorID, Content: "signed post", IsActive: true}
+	anonymous := &Post{UserID: authorID, Content: "anonymous post", IsAnonymous: true, IsActive: true}
+	for _, post := range []*Post{signed, anonymous} {
+		if err := repo.Create(ctx, post); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		// Enough reactions to make both popular
+		for i := 0; i < 3; i++ {
+			_, err := pool.Exec(ctx, `INSERT INTO post_reactions (post_id, user_id) VALUES ($1, $2)`, post.ID, testdb.CreateUser(t, pool))
+			if err != nil {
+				t.Fatalf("insert reaction: %v", err)
+			}
+		}
+	}
+
+	candidates, err := repo.GetFeedCandidates(ctx, viewerID, time.Minute, 1000)
+	if err != nil {
+		t.Fatalf("GetFeedCandidates: %v", err)
+	}
+
+	sources := make(map[string]string)
+	for _, c := range candidates {
+		sources[c.Post.ID] = c.Source
+	}
+	if sources[signed.ID] != SourcePopular {
+		t.Errorf("signed post source = %q, want popular", sources[signed.ID])
+	}
+	if source, ok := sources[anonymous.ID]; ok {
+		t.Errorf("anonymous post is a candidate from %q", source)
+	}
+}
+
+func TestPublishDue(t *testing.T) {
+	pool := testdb.Pool(t)
+	repo := NewPostgresPostRepository(pool)
+	ctx := context.Background()
+
+	authorID := testdb.CreateUser(t, pool)
+
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+	due := &Post{UserID: authorID, Content: "due", Status: StatusScheduled, PublishAt: &past, IsActive: true}
+	later := &Post{UserID: authorID, Content: "later", Status: StatusScheduled, PublishAt: &future, IsActive: true}
+	for _, post := range []*Post{due, later} {
+		if err := repo.Create(ctx, post); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	published, err := repo.PublishDue(ctx, 1000)
+	if err != nil {
+		t.Fatalf("PublishDue: %v", err)
+	}
+
+	found := false
+	for _, post := range published {
+		if post.ID == later.ID {
+			t.Error("PublishDue published a post scheduled for later")
+		}
+		if post.ID == due.ID {
+			found = true
+			if post.Status != StatusPublished || post.PublishAt != nil {
+				t.Errorf("published post: status %q publish_at %v", post.Status, post.PublishAt)
+			}
+		}
+	}
+	if !found {
+		t.Fatal("PublishDue did not publish the due post")
+	}
+
+	if got, err := repo.GetByID(ctx, due.ID); err != nil || got == nil {
+		t.Errorf("GetByID(due) = %v, %v; want the published post", got, err)
+	}
+	if got, err := repo.GetByID(ctx, later.ID); err != nil || got != nil {
+		t.Errorf("GetByID(later) = %v, %v; want nil until it is published", got, err)
+	}
+
+	// Already published posts are not published again
+	again, err := repo.PublishDue(ctx, 1000)
+	if err != nil {
+		t.Fatalf("PublishDue again: %v", err)
+	}
+	for _, post := range again {
+		if post.ID == due.ID {
+			t.Error("PublishDue published the same post twice")
+		}
+	}
+}
+
+func TestScheduledAnonymousPosts(t *testing.T) {
+	pool := testdb.Pool(t)
+	repo := NewPostgresPostRepository(pool)
+	ctx := context.Background()
+
+	authorID := testdb.CreateUser(t, pool)
+
+	past := time.Now().Add(-time.Minute)
+	anonymous := &Post{UserID: authorID, Content: "anonymous", IsAnonymous: true, Status: StatusScheduled, PublishAt: &past, IsActive: true}
+	if err := repo.Create(ctx, anonymous); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	// Left for the scheduler to check against the anonymous posting limit
+	published, err := repo.PublishDue(ctx, 1000)
+	if err != nil {
+		t.Fatalf("PublishDue: %v", err)
+	}
+	for _, post := range published {
+		if post.ID == anonymous.ID {
+			t.Fatal("PublishDue published an anonymous post")
+		}
+	}
+
+	due, err := repo.GetDueAnonymous(ctx, 1000)
+	if err != nil {
+		t.Fatalf("GetDueAnonymous: %v", err)
+	}
+	found := false
+	for _, post := range due {
+		found = found || post.ID == anonymous.ID
+	}
+	if !found {
+		t.Fatal("GetDueAnonymous did not return the due anonymous post")
+	}
+
+	if err := repo.ReturnToDraft(ctx, anonymous); err != nil {
+		t.Fatalf("ReturnToDraft: %v", err)
+	}
+	draft, err := repo.GetDraftByID(ctx, anonymous.ID, authorID)
+	if err != nil || draft == nil || draft.Status != StatusDraft || draft.PublishAt != nil {
+		t.Fatalf("GetDraftByID = %+v, %v; want an unscheduled draft", draft, err)
+	}
+
+	// Only scheduled posts can be returned to drafts
+	if err := repo.ReturnToDraft(ctx, anonymous); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("ReturnToDraft of a draft: err = %v, want pgx.ErrNoRows", err)
+	}
+}
+
+func TestPinLimitAndPositions(t *testing.T) {
+	pool := testdb.Pool(t)
+	repo := NewPostgresPostRepository(pool)
+	ctx := context.Background()
+
+	authorID := testdb.CreateUser(t, pool)
+
+	var postIDs []string
+	for i := 0; i < 4; i++ {
+		post := &Post{UserID: authorID, Content: "post", IsActive: true}
+		if err := repo.Create(ctx, post); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		postIDs = append(postIDs, post.ID)
+	}
+
+	for _, id := range postIDs[:3] {
+		if err := repo.PinPost(ctx, authorID, id, 3); err != nil {
+			t.Fatalf("PinPost: %v", err)
+		}
+	}
+	if err := repo.PinPost(ctx, authorID, postIDs[3], 3); !errors.Is(err, ErrTooManyPins) {
+		t.Errorf("PinPost over the limit: err = %v, want ErrTooManyPins", err)
+	}
+	if err := repo.PinPost(ctx, authorID, postIDs[0], 3); !errors.Is(err, ErrAlreadyPinned) {
+		t.Errorf("PinPost twice: err = %v, want ErrAlreadyPinned", err)
+	}
+
+	// Unpinning closes the gap, so the next pin goes last
+	if removed, err := repo.UnpinPost(ctx, authorID, postIDs[0]); err != nil || !removed {
+		t.Fatalf("UnpinPost = %v, %v; want removed", removed, err)
+	}
+	if err := repo.PinPost(ctx, authorID, postIDs[3], 3); err != nil {
+		t.Fatalf("PinPost after unpin: %v", err)
+	}
+
+	pinned, err := repo.GetPinnedPostIDs(ctx, authorID)
+	if err != nil {
+		t.Fatalf("GetPinnedPostIDs: %v", err)
+	}
+	if want := []string{postIDs[1], postIDs[2], postIDs[3]}; !reflect.DeepEqual(pinned, want) {
+		t.Errorf("pins = %v, want %v", pinned, want)
+	}
+}
+
+func TestGetTrendingFiltersByPostInterests(t *testing.T) {
+	pool := testdb.Pool(t)
+	repo := NewPostgresPostRepository(pool)
+	ctx := context.Background()
+
+	var interestID string
+	if err := pool.QueryRow(ctx, `SELECT id FROM interests WHERE category = 'technology' LIMIT 1`).Scan(&interestID); err != nil {
+		t.Fatalf("get interest: %v", err)
+	}
+
+	// The author is into technology, but only the tagged post is about it
+	authorID := testdb.CreateUser(t, pool)
+	if _, err := pool.Exec(ctx, `INSERT INTO user_interests (user_id, interest_id) VALUES ($1, $2)`, authorID, interestID); err != nil {
+		t.Fatalf("add user interest: %v", err)
+	}
+
+	tagged := &Post{UserID: authorID, Content: "about technology", IsActive: true}
+	untagged := &Post{UserID: authorID, Content: "about lunch", IsActive: true}
+	for _, post := range []*Post{tagged, untagged} {
+		if err := repo.Create(ctx, post); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if _, err := pool.Exec(ctx, `INSERT INTO post_trending_scores (post_id, score) VALUES ($1, 1)`, post.ID); err != nil {
+			t.Fatalf("insert score: %v", err)
+		}
+	}
+	if err := repo.SetPostInterests(ctx, tagged.ID, []string{interestID}); err != nil {
+		t.Fatalf("SetPostInterests: %v", err)
+	}
+
+	posts, err := repo.GetTrending(ctx, "", ExploreFilter{Category: "technology"}, 1000, 0)
+	if err != nil {
+		t.Fatalf("GetTrending: %v", err)
+	}
+
+	found := make(map[string]bool)
+	for _, post := range posts {
+		found[post.ID] = true
+	}
+	if !found[tagged.ID] {
+		t.Error("post tagged with a technology interest is missing")
+	}
+	if found[untagged.ID] {
+		t.Error("untagged post by an author interested in technology is listed")
+	}
+}
+
+func TestRefreshTrendingScores(t *testing.T) {
+	pool := testdb.Pool(t)
+	repo := NewPostgresPostRepository(pool)
+	ctx := context.Background()
+
+	authorID := testdb.CreateUser(t, pool)
+	fanID := testdb.CreateUser(t, pool)
+
+	// Each post gets one reaction, at a different age
+	ages := map[string]time.Duration{
+		"fresh":   0,
+		"day old": 24 * time.Hour,
+		"too old": 100 * time.Hour,
+		"deleted": 0,
+	}
+	posts := make(map[string]*Post)
+	for name, age := range ages {
+		post := &Post{UserID: authorID, Content: name, IsActive: true}
+		if err := repo.Create(ctx, post); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		_, err := pool.Exec(ctx, `INSERT INTO post_reactions (post_id, user_id, created_at) VALUES ($1, $2, NOW() - $3 * INTERVAL '1 second')`,
+			post.ID, fanID, int64(age.Seconds()))
+		if err != nil {
+			t.Fatalf("insert reaction: %v", err)
+		}
+		posts[name] = post
+	}
+	if err := repo.Delete(ctx, posts["deleted"].ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	if _, err := repo.RefreshTrendingScores(ctx, 72*time.Hour, 12); err != nil {
+		t.Fatalf("RefreshTrendingScores: %v", err)
+	}
+
+	scores := make(map[string]float64)
+	for name, post := range posts {
+		var score float64
+		err := pool.QueryRow(ctx, `SELECT score FROM post_trending_scores WHERE post_id = $1`, post.ID).Scan(&score)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("get score: %v", err)
+		}
+		scores[name] = score
+	}
+
+	// Engagement outside the window and deleted posts aren't scored
+	if _, ok := scores["too old"]; ok {
+		t.Error("post with only old engagement was scored")
+	}
+	if _, ok := scores["deleted"]; ok {
+		t.Error("deleted post was scored")
+	}
+
+	// Two half-lives later a reaction counts for a quarter
+	if fresh := scores["fresh"]; fresh < 0.99 || fresh > 1.01 {
+		t.Errorf("fresh score = %v, want about 1", fresh)
+	}
+	if dayOld := scores["day old"]; dayOld < 0.24 || dayOld > 0.26 {
+		t.Errorf("day old score = %v, want about 0.25", dayOld)
+	}
+}
+
+func TestHiddenPostsDropOutOfFeeds(t *testing.T) {
+	pool := testdb.Pool(t)
+	repo := NewPostgresPostRepository(pool)
+	ctx := context.Background()
+
+	authorID := testdb.CreateUser(t, pool)
+	reposterID := testdb.CreateUser(t, pool)
+	viewerID := testdb.CreateUser(t, pool)
+	otherID := testdb.CreateUser(t, pool)
+
+	_, err := pool.Exec(ctx, `INSERT INTO user_follows (follower_id, following_id) VALUES ($1, $2), ($1, $3), ($4, $2)`,
+		viewerID, authorID, reposterID, otherID)
+	if err != nil {
+		t.Fatalf("follow: %v", err)
+	}
+
+	hidden := &Post{UserID: authorID, Content: "not for me", IsActive: true}
+	kept := &Post{UserID: authorID, Content: "fine", IsActive: true}
+	for _, post := range []*Post{hidden, kept} {
+		if err := repo.Create(ctx, post); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if _, err := pool.Exec(ctx, `INSERT INTO post_trending_scores (post_id, score) VALUES ($1, 1)`, post.ID); err != nil {
+			t.Fatalf("insert score: %v", err)
+		}
+	}
+	// Hiding a post hides reposts of it too
+	repost := &Post{UserID: reposterID, PostType: TypeRepost, QuotedPostID: &hidden.ID, IsActive: true}
+	if err := repo.Create(ctx, repost); err != nil {
+		t.Fatalf("Create repost: %v", err)
+	}
+
+	if err := repo.SaveFeedback(ctx, viewerID, hidden.ID, FeedbackHidden); err != nil {
+		t.Fatalf("SaveFeedback: %v", err)
+	}
+
+	feeds := []struct {
+		name string
+		get  func(viewerID string) ([]*Post, error)
+	}{
+		{"home", func(viewerID string) ([]*Post, error) { return repo.GetFeed(ctx, viewerID, 20, 0) }},
+		{"ranked home", func(viewerID string) ([]*Post, error) {
+			candidates, err := repo.GetFeedCandidates(ctx, viewerID, time.Minute, 1000)
+			posts := make([]*Post, 0, len(candidates))
+			for _, c := range candidates {
+				posts = append(posts, c.Post)
+			}
+			return posts, err
+		}},
+		{"explore", func(viewerID string) ([]*Post, error) {
+			return repo.GetTrending(ctx, viewerID, ExploreFilter{}, 1000, 0)
+		}},
+		{"profile", func(viewerID string) ([]*Post, error) { return repo.GetByUserID(ctx, authorID, viewerID, 20, 0) }},
+	}
+
+	// shown reports whether the hidden and kept posts appear in a feed, directly or reposted
+	shown := func(posts []*Post) (hiddenShown, keptShown bool) {
+		for _, post := range posts {
+			switch {
+			case post.ID == hidden.ID || (post.QuotedPostID != nil && *post.QuotedPostID == hidden.ID):
+				hiddenShown = true
+			case post.ID == kept.ID:
+				keptShown = true
+			}
+		}
+		return hiddenShown, keptShown
+	}
+
+	for _, feed := range feeds {
+		t.Run(feed.name, func(t *testing.T) {
+			posts, err := feed.get(viewerID)
+			if err != nil {
+				t.Fatalf("get feed: %v", err)
+			}
+			if hiddenShown, keptShown := shown(posts); hiddenShown || !keptShown {
+				t.Errorf("hidden post shown = %v, other post shown = %v; want only the other post", hiddenShown, keptShown)
+			}
+
+			// Hiding is the viewer's own
+			posts, err = feed.get(otherID)
+			if err != nil {
+				t.Fatalf("get feed for another user: %v", err)
+			}
+			if hiddenShown, _ := shown(posts); !hiddenShown {
+				t.Error("post hidden by the viewer is missing for another user")
+			}
+		})
+	}
+
+	// Unhiding brings it back
+	if removed, err := repo.DeleteFeedback(ctx, viewerID, hidden.ID); err != nil || !removed {
+		t.Fatalf("DeleteFeedback = %v, %v", removed, err)
+	}
+	posts, err := repo.GetFeed(ctx, viewerID, 20, 0)
+	if err != nil {
+		t.Fatalf("GetFeed: %v", err)
+	}
+	if hiddenShown, _ := shown(posts); !hiddenShown {
+		t.Error("unhidden post is still missing from the home feed")
+	}
+}
+
+func TestNotInterestedDownRanksTopics(t *testing.T) {
+	pool := testdb.Pool(t)
+	repo := NewPostgresPostRepository(pool)
+	ctx := context.Background()
+
+	authorID := testdb.CreateUser(t, pool)
+	viewerID := testdb.CreateUser(t, pool)
+
+	if _, err := pool.Exec(ctx, `INSERT INTO user_follows (follower_id, following_id) VALUES ($1, $2)`, viewerID, authorID); err != nil {
+		t.Fatalf("follow: %v", err)
+	}
+
+	var hashtagID string
+	tag := "topic" + uuid.NewString()[:8]
+	if err := pool.QueryRow(ctx, `INSERT INTO hashtags (tag) VALUES ($1) RETURNING id`, tag).Scan(&hashtagID); err != nil {
+		t.Fatalf("insert hashtag: %v", err)
+	}
+	t.Cleanup(func() {
+		_, _ = pool.Exec(context.Background(), `DELETE FROM hashtags WHERE id = $1`, hashtagID)
+	})
+
+	// The post on the same topic is the newest, so it would rank first without feedback
+	disliked := &Post{UserID: authorID, Content: "#" + tag + " again", IsActive: true}
+	other := &Post{UserID: authorID, Content: "something else", IsActive: true}
+	sameTopic := &Post{UserID: authorID, Content: "more #" + tag, IsActive: true}
+	for _, post := range []*Post{disliked, other, sameTopic} {
+		if err := repo.Create(ctx, post); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+	for _, post := range []*Post{disliked, sameTopic} {
+		if _, err := pool.Exec(ctx, `INSERT INTO post_hashtags (post_id, hashtag_id) VALUES ($1, $2)`, post.ID, hashtagID); err != nil {
+			t.Fatalf("tag post: %v", err)
+		}
+	}
+
+	if err := repo.SaveFeedback(ctx, viewerID, disliked.ID, FeedbackNotInterested); err != nil {
+		t.Fatalf("SaveFeedback: %v", err)
+	}
+
+	candidates, err := repo.GetFeedCandidates(ctx, viewerID, time.Minute, 1000)
+	if err != nil {
+		t.Fatalf("GetFeedCandidates: %v", err)
+	}
+	byID := make(map[string]*FeedCandidate)
+	for _, c := range candidates {
+		byID[c.Post.ID] = c
+	}
+
+	if byID[disliked.ID] != nil {
+		t.Error("post marked not interested is still a candidate")
+	}
+	if byID[sameTopic.ID] == nil || byID[other.ID] == nil {
+		t.Fatalf("candidates = %v, want both remaining posts", byID)
+	}
+	if got := byID[sameTopic.ID].NotInterestedOverlap; got != 1 {
+		t.Errorf("same topic overlap = %d, want 1", got)
+	}
+	if got := byID[other.ID].NotInterestedOverlap; got != 0 {
+		t.Errorf("other post overlap = %d, want 0", got)
+	}
+
+	scorer := NewWeightedScorer("test", DefaultWeights())
+	now := time.Now()
+	if same, otherScore := scorer.Score(viewerID, byID[sameTopic.ID], now), scorer.Score(viewerID, byID[other.ID], now); same >= otherScore {
+		t.Errorf("same topic score %v, other post %v; want the same topic ranked lower", same, otherScore)
+	}
+}
+
+func TestGetByInterestFiltersPosts(t *testing.T) {
+	pool := testdb.Pool(t)
+	repo := NewPostgresPostRepository(pool)
+	ctx := context.Background()
+
+	var interestID string
+	if err := pool.QueryRow(ctx, `SELECT id FROM interests LIMIT 1`).Scan(&interestID); err != nil {
+		t.Fatalf("get interest: %v", err)
+	}
+
+	viewerID := testdb.CreateUser(t, pool)
+	authorID := testdb.CreateUser(t, pool)
+	blockerID := testdb.CreateUser(t, pool)
+	mutedID := testdb.CreateUser(t, pool)
+	privateID := testdb.CreateUser(t, pool)
+	deactivatedID := testdb.CreateUser(t, pool)
+
+	for _, sql := range []string{
+		`INSERT INTO blocked_users (blocker_id, blocked_id) VALUES ($2, $1)`,
+		`INSERT INTO user_mutes (muter_id, muted_id) VALUES ($1, $3)`,
+		`INSERT INTO muted_keywords (user_id, keyword, pattern) VALUES ($1, 'spoilers', '(^|[^[:alnum:]_])spoilers($|[^[:alnum:]_])')`,
+	} {
+		if _, err := pool.Exec(ctx, sql, viewerID, blockerID, mutedID); err != nil {
+			t.Fatalf("exec %q: %v", sql, err)
+		}
+	}
+	if _, err := pool.Exec(ctx, `UPDATE users SET who_can_see_posts = 'followers' WHERE id = $1`, privateID); err != nil {
+		t.Fatalf("make private: %v", err)
+	}
+	if _, err := pool.Exec(ctx, `UPDATE users SET is_active = false WHERE id = $1`, deactivatedID); err != nil {
+		t.Fatalf("deactivate: %v", err)
+	}
+
+	// tagged creates a post tagged with the interest
+	tagged := func(userID, content string, anonymous bool) string {
+		t.Helper()
+
+		post := &Post{UserID: userID, Content: content, IsAnonymous: anonymous, IsActive: true}
+		if err := repo.Create(ctx, post); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if err := repo.SetPostInterests(ctx, post.ID, []string{interestID}); err != nil {
+			t.Fatalf("SetPostInterests: %v", err)
+		}
+		return post.ID
+	}
+
+	visible := tagged(authorID, "on topic", false)
+	blocked := tagged(blockerID, "by someone who blocked the viewer", false)
+	muted := tagged(mutedID, "by a muted user", false)
+	keyword := tagged(authorID, "spoilers ahead", false)
+	hidden := tagged(authorID, "hidden by the viewer", false)
+	private := tagged(privateID, "followers only", false)
+	deactivated := tagged(deactivatedID, "by a deactivated user", false)
+	anonymous := tagged(authorID, "anonymous", true)
+
+	if err := repo.SaveFeedback(ctx, viewerID, hidden, FeedbackHidden); err != nil {
+		t.Fatalf("SaveFeedback: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		viewerID string
+		want     []string
+		notWant  []string
+	}{
+		{
+			name:     "viewer",
+			viewerID: viewerID,
+			want:     []string{visible},
+			notWant:  []string{blocked, muted, keyword, hidden, private, deactivated, anonymous},
+		},
+		{
+			name:    "logged out",
+			want:    []string{visible, blocked, muted, keyword, hidden},
+			notWant: []string{private, deactivated, anonymous},
+		},
+		{
+			name:     "anonymous author",
+			viewerID: authorID,
+			want:     []string{visible, keyword, hidden},
+			notWant:  []string{anonymous},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			posts, err := repo.GetByInterest(ctx, tt.viewerID, interestID, 1000, 0)
+			if err != nil {
+				t.Fatalf("GetByInterest: %v", err)
+			}
+			found := make(map[string]bool)
+			for _, post := range posts {
+				found[post.ID] = true
+			}
+			for _, id := range tt.want {
+				if !found[id] {
+					t.Errorf("post %s is missing", id)
+				}
+			}
+			for _, id := range tt.notWant {
+				if found[id] {
+					t.Errorf("post %s is listed", id)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package post
+
+import (
+	"context"
+	"fmt"
+)
+
+// Repost shares a post the user can see into their followers' feeds. Reposting a repost
+// reposts its original.
+func (s *postService) Repost(ctx context.Context, postID, userID string) (*PostResponse, error) {
+	original, err := s.getQuotable(ctx, postID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.postRepo.GetRepost(ctx, userID, original.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check repost: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrAlreadyReposted
+	}
+
+	repost := &Post{
+		UserID:       userID,
+		PostType:     TypeRepost,
+		QuotedPostID: &original.ID,
+		IsActive:     true,
+	}
+
+	if err := s.postRepo.Create(ctx, repost); err != nil {
+		return nil, fmt.Errorf("failed to create repost: %w", err)
+	}
+
+	s.publishToTimelines(ctx, repost)
+
+	responses, err := s.convertPostsToResponse(ctx, []*Post{repost}, userID)
+	if err != nil || len(responses) == 0 {
+		return nil, fmt.Errorf("failed to build post response: %w", err)
+	}
+
+	return responses[0], nil
+}
+
+// Unrepost removes the user's repost of a post (or of the original, if postID is a repost)
+func (s *postService) Unrepost(ctx context.Context, postID, userID string) error {
+	originalID := postID
+	post, err := s.postRepo.GetByID(ctx, postID)
+	if err != nil {
+		return fmt.Errorf("failed to get post: %w", err)
+	}
+	if post != nil && post.PostType == TypeRepost && post.QuotedPostID != nil {
+		originalID = *post.QuotedPostID
+	}
+
+	repost, err := s.postRepo.GetRepost(ctx, userID, originalID)
+	if err != nil {
+		return fmt.Errorf("failed to get repost: %w", err)
+	}
+	if repost == nil {
+		return ErrNotReposted
+	}
+
+	if err := s.postRepo.DeleteRepost(ctx, repost.ID); err != nil {
+		return fmt.Errorf("failed to delete repost: %w", err)
+	}
+
+	s.removeFromTimelines(ctx, repost)
+
+	return nil
+}
+
+// getQuotable returns the post that reposting or quoting postID refers to: the post itself,
+// or the original of a repost. The user must be able to see it.
+func (s *postService) getQuotable(ctx context.Context, postID, userID string) (*Post, error) {
+	post, err := s.postRepo.GetByID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+	if post != nil && post.PostType == TypeRepost && post.QuotedPostID != nil {
+		post, err = s.postRepo.GetByID(ctx, *post.QuotedPostID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get post: %w", err)
+		}
+	}
+	if post == nil {
+		return nil, ErrPostNotFound
+	}
+
+	visible, err := s.postRepo.IsVisibleTo(ctx, post.ID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check post visibility: %w", err)
+	}
+	if !visible {
+		return nil, ErrPostNotFound
+	}
+
+	return post, nil
+}
+
+// getQuotedPost returns the original embedded in a repost or quote as seen by viewerID, or
+// nil if there is none or it has been deleted or hidden from the viewer. Only one level is
+// embedded: a quoted quote post comes without its own original.
+func (s *postService) getQuotedPost(ctx context.Context, post *Post, viewerID string) *PostResponse {
+	if post.QuotedPostID == nil {
+		return nil
+	}
+
+	original, err := s.postRepo.GetByID(ctx, *post.QuotedPostID)
+	if err != nil || original == nil {
+		return nil
+	}
+
+	visible, err := s.postRepo.IsVisibleTo(ctx, original.ID, viewerID)
+	if err != nil || !visible {
+		return nil
+	}
+
+	original.QuotedPostID = nil
+	responses, err := s.convertPostsToResponse(ctx, []*Post{original}, viewerID)
+	if err != nil || len(responses) == 0 {
+		return nil
+	}
+
+	return responses[0]
+}
@@ -0,0 +1,54 @@
+package post
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestUndoRepostDeletesIt(t *testing.T) {
+	tests := []struct {
+		name string
+		undo func(s *postService, repost *PostResponse) error
+	}{
+		{"unrepost the original", func(s *postService, repost *PostResponse) error {
+			return s.Unrepost(context.Background(), "original", "reposter")
+		}},
+		{"unrepost the repost", func(s *postService, repost *PostResponse) error {
+			return s.Unrepost(context.Background(), repost.ID, "reposter")
+		}},
+		{"delete the repost", func(s *postService, repost *PostResponse) error {
+			return s.DeletePost(context.Background(), repost.ID, "reposter")
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakePostRepo()
+			repo.add(&Post{ID: "original", UserID: "author", Content: "worth sharing"})
+			s := newTestService(repo)
+			ctx := context.Background()
+
+			repost, err := s.Repost(ctx, "original", "reposter")
+			if err != nil {
+				t.Fatalf("Repost: %v", err)
+			}
+			if err := tt.undo(s, repost); err != nil {
+				t.Fatalf("undo: %v", err)
+			}
+
+			// Nothing is left behind, not even a soft deleted row
+			if _, ok := repo.posts[repost.ID]; ok {
+				t.Errorf("repost %s is still stored", repost.ID)
+			}
+			if err := s.Unrepost(ctx, "original", "reposter"); !errors.Is(err, ErrNotReposted) {
+				t.Errorf("Unrepost again: err = %v, want ErrNotReposted", err)
+			}
+
+			// The post can be reposted again
+			if _, err := s.Repost(ctx, "original", "reposter"); err != nil {
+				t.Errorf("Repost again: %v", err)
+			}
+		})
+	}
+}
@@ -10,9 +10,35 @@ import (
 func RegisterRoutes(app *fiber.App, handler *Handler) {
 	v1 := app.Group("/v1")
 
-	// Public routes (no auth required)
+	// Public routes (auth optional, so logged-in views are counted)
 	posts := v1.Group("/posts")
-	posts.Get("/:postId", handler.GetPost)
+	posts.Get("/:postId", middleware.OptionalAuthMiddleware(), handler.GetPost)
+
+	// Edit post (per-route auth so it doesn't apply to the public routes above)
+	v1.Put("/posts/:postId", middleware.AuthMiddleware(), handler.UpdatePost)
+
+	// View stats (author only)
+	v1.Get("/posts/:postId/views", middleware.AuthMiddleware(), handler.GetPostViews)
+
+	// Vote in a poll post
+	v1.Post("/posts/:postId/votes", middleware.AuthMiddleware(), handler.VotePoll)
+
+	// Pin to profile (author only, max 3)
+	v1.Post("/posts/:postId/pin", middleware.AuthMiddleware(), handler.PinPost)
+	v1.Delete("/posts/:postId/pin", middleware.AuthMiddleware(), handler.UnpinPost)
+
+	// Hide from feeds, optionally as "not interested"; DELETE on either undoes both
+	v1.Post("/posts/:postId/hide", middleware.AuthMiddleware(), handler.HidePost)
+	v1.Delete("/posts/:postId/hide", middleware.AuthMiddleware(), handler.UnhidePost)
+	v1.Post("/posts/:postId/not-interested", middleware.AuthMiddleware(), handler.MarkNotInterested)
+	v1.Delete("/posts/:postId/not-interested", middleware.AuthMiddleware(), handler.UnhidePost)
+
+	// Hashtag and interest pages (auth optional)
+	v1.Get("/hashtags/:tag/posts", middleware.OptionalAuthMiddleware(), handler.GetHashtagPosts)
+	v1.Get("/interests/:slug/posts", middleware.OptionalAuthMiddleware(), handler.GetInterestPosts)
+
+	// Explore feed (auth optional, anonymous browsing allowed)
+	v1.Get("/explore", middleware.OptionalAuthMiddleware(), handler.GetExplore)
 
 	// Protected routes (auth required)
 	protected := v1.Group("/v1/posts", middleware.AuthMiddleware())
@@ -21,7 +47,25 @@ func RegisterRoutes(app *fiber.App, handler *Handler) {
 	protected.Post("/posts/:postId/reactions", handler.ToggleReaction)
 	protected.Get("/feed", handler.GetFeed)
 
+	// Drafts and scheduled posts (owner only)
+	auth := middleware.AuthMiddleware()
+	v1.Get("/users/me/drafts", auth, handler.GetDrafts)
+	v1.Post("/users/me/drafts", auth, handler.CreateDraft)
+	v1.Get("/users/me/drafts/:postId", auth, handler.GetDraft)
+	v1.Put("/users/me/drafts/:postId", auth, handler.UpdateDraft)
+	v1.Delete("/users/me/drafts/:postId", auth, handler.DeleteDraft)
+	v1.Post("/users/me/drafts/:postId/publish", auth, handler.PublishDraft)
+
+	// Pin order (owner only)
+	v1.Put("/users/me/pins", auth, handler.ReorderPins)
+
+	// Saved posts (owner only)
+	v1.Get("/users/me/saved", auth, handler.GetSavedPosts)
+
+	// Posts hidden from feeds (owner only)
+	v1.Get("/users/me/hidden-posts", auth, handler.GetHiddenPosts)
+
 	// User posts (public, but auth optional for reaction info)
 	users := v1.Group("/users")
-	users.Get("/:userId/posts", handler.GetUserPosts)
+	users.Get("/:userId/posts", middleware.OptionalAuthMiddleware(), handler.GetUserPosts)
 }
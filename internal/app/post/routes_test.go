@@ -0,0 +1,74 @@
+package post
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"mockhu-app-backend/internal/pkg/jwt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// getUserPosts requests a profile feed through the registered routes, as userID if set
+func getUserPosts(t *testing.T, app *fiber.App, profileID, userID string) *FeedResponse {
+	t.Helper()
+
+	req := httptest.NewRequest("GET", "/v1/users/"+profileID+"/posts", nil)
+	if userID != "" {
+		token, err := jwt.GenerateAccessToken(userID, userID+"@example.com", userID)
+		if err != nil {
+			t.Fatalf("GenerateAccessToken: %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+
+	var feed FeedResponse
+	if err := json.NewDecoder(resp.Body).Decode(&feed); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	return &feed
+}
+
+func TestUserPostsShowOwnAnonymousPostsToAuthor(t *testing.T) {
+	repo := newFakePostRepo()
+	repo.add(&Post{ID: "signed", UserID: "author", Content: "signed post"})
+	repo.add(&Post{ID: "anon", UserID: "author", Content: "anonymous post", IsAnonymous: true})
+
+	app := fiber.New()
+	RegisterRoutes(app, NewHandler(newTestService(repo)))
+
+	tests := []struct {
+		name      string
+		userID    string
+		wantPosts int
+	}{
+		{"author", "author", 2},
+		{"other user", "someone-else", 1},
+		{"logged out", "", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feed := getUserPosts(t, app, "author", tt.userID)
+			if len(feed.Posts) != tt.wantPosts {
+				t.Fatalf("got %d posts, want %d", len(feed.Posts), tt.wantPosts)
+			}
+
+			for _, post := range feed.Posts {
+				if post.ID == "anon" && (!post.Author.IsAnonymous || post.Author.ID != "") {
+					t.Errorf("anonymous post shows author %+v, want only the pseudonym", post.Author)
+				}
+			}
+		})
+	}
+}
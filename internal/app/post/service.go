@@ -4,9 +4,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
+	"sort"
+	"strings"
 	"time"
 
+	"mockhu-app-backend/internal/app/anonymity"
 	"mockhu-app-backend/internal/app/auth"
+	"mockhu-app-backend/internal/app/hashtag"
+	"mockhu-app-backend/internal/app/interest"
+	"mockhu-app-backend/internal/app/linkpreview"
+	"mockhu-app-backend/internal/app/media"
+	"mockhu-app-backend/internal/app/mention"
+	"mockhu-app-backend/internal/app/moderation"
+	"mockhu-app-backend/internal/app/poll"
+	"mockhu-app-backend/internal/app/timeline"
+	"mockhu-app-backend/internal/pkg/entities"
 )
 
 // Errors
@@ -16,29 +29,101 @@ var (
 	ErrInvalidContent    = errors.New("invalid content")
 	ErrTooManyImages     = errors.New("too many images (max 10)")
 	ErrPostAlreadyExists = errors.New("post already exists")
+	ErrInvalidFeedMode   = errors.New("invalid feed mode")
+	ErrInvalidCategory   = errors.New("invalid interest category")
+	ErrInvalidHashtag    = errors.New("invalid hashtag")
+	ErrInvalidMedia      = errors.New("invalid media")
+	ErrInvalidPostType   = errors.New("invalid post type")
+	ErrDraftNotFound     = errors.New("draft not found")
+	ErrInvalidPublishAt  = errors.New("publish time must be in the future and within a year")
+	ErrAlreadyPinned     = errors.New("post is already pinned")
+	ErrNotPinned         = errors.New("post is not pinned")
+	ErrTooManyPins       = errors.New("too many pinned posts (max 3)")
+	ErrInvalidPinOrder   = errors.New("pin order must list each pinned post once")
+	ErrAlreadyReposted   = errors.New("post already reposted")
+	ErrNotReposted       = errors.New("post is not reposted")
+	ErrPinAnonymous      = errors.New("anonymous posts cannot be pinned")
+	ErrHideOwnPost       = errors.New("you cannot hide your own post")
+	ErrNotHidden         = errors.New("post is not hidden")
+	ErrTooManyInterests  = errors.New("too many interests (max 3)")
+	ErrInvalidInterest   = errors.New("unknown interest")
+	ErrInterestNotFound  = errors.New("interest not found")
 )
 
 // PostService defines the business logic for post operations
 type PostService interface {
 	CreatePost(ctx context.Context, userID string, req *CreatePostRequest) (*PostResponse, error)
+	UpdatePost(ctx context.Context, postID, userID string, req *UpdatePostRequest) (*PostResponse, error)
 	GetPost(ctx context.Context, postID, currentUserID string) (*PostResponse, error)
 	GetUserPosts(ctx context.Context, userID, currentUserID string, page, limit int) (*FeedResponse, error)
 	DeletePost(ctx context.Context, postID, userID string) error
+	GetPostViews(ctx context.Context, postID, userID string) (*PostViewsResponse, error)
+	VotePoll(ctx context.Context, postID, userID string, optionIDs []string) (*PostResponse, error)
 	ToggleReaction(ctx context.Context, postID, userID string) (*ReactionResponse, error)
-	GetFeed(ctx context.Context, userID string, page, limit int) (*FeedResponse, error)
+	GetFeed(ctx context.Context, userID, mode string, page, limit int) (*FeedResponse, error)
+	GetExplore(ctx context.Context, currentUserID string, filter ExploreFilter, page, limit int) (*FeedResponse, error)
+	GetHashtagPosts(ctx context.Context, tag, currentUserID string, page, limit int) (*FeedResponse, error)
+	GetInterestPosts(ctx context.Context, slug, currentUserID string, page, limit int) (*FeedResponse, error)
+	GetSavedPosts(ctx context.Context, userID, collectionID string, page, limit int) (*FeedResponse, error)
+	RefreshTrending(ctx context.Context) error
+
+	// Reposts (quote posts are created with CreatePost)
+	Repost(ctx context.Context, postID, userID string) (*PostResponse, error)
+	Unrepost(ctx context.Context, postID, userID string) error
+
+	// Pinned posts
+	PinPost(ctx context.Context, postID, userID string) (*PinsResponse, error)
+	UnpinPost(ctx context.Context, postID, userID string) (*PinsResponse, error)
+	ReorderPins(ctx context.Context, userID string, postIDs []string) (*PinsResponse, error)
+
+	// Feed feedback (hide a post, or mark it "not interested")
+	HidePost(ctx context.Context, postID, userID, kind string) (*FeedbackResponse, error)
+	UnhidePost(ctx context.Context, postID, userID string) error
+	GetHiddenPosts(ctx context.Context, userID string, page, limit int) (*HiddenPostsResponse, error)
+
+	// Drafts and scheduled posts
+	CreateDraft(ctx context.Context, userID string, req *DraftRequest) (*DraftResponse, error)
+	GetDrafts(ctx context.Context, userID string, page, limit int) (*DraftsResponse, error)
+	GetDraft(ctx context.Context, postID, userID string) (*DraftResponse, error)
+	UpdateDraft(ctx context.Context, postID, userID string, req *DraftRequest) (*DraftResponse, error)
+	DeleteDraft(ctx context.Context, postID, userID string) error
+	PublishDraft(ctx context.Context, postID, userID string) (*PostResponse, error)
+	PublishScheduled(ctx context.Context) error
 }
 
 // postService implements PostService
 type postService struct {
-	postRepo PostRepository
-	userRepo auth.UserRepository
+	postRepo           PostRepository
+	userRepo           auth.UserRepository
+	hashtagRepo        hashtag.HashtagRepository
+	interestRepo       interest.InterestRepository
+	mentionService     mention.MentionService
+	mediaService       media.MediaService
+	pollService        poll.PollService
+	linkPreviewService linkpreview.LinkPreviewService
+	moderationService  moderation.ModerationService
+	anonymityService   anonymity.AnonymityService
+	timelineService    timeline.TimelineService
+	views              *ViewTracker
+	scorer             Scorer
 }
 
 // NewService creates a new post service
-func NewService(postRepo PostRepository, userRepo auth.UserRepository) PostService {
+func NewService(postRepo PostRepository, userRepo auth.UserRepository, hashtagRepo hashtag.HashtagRepository, interestRepo interest.InterestRepository, mentionService mention.MentionService, mediaService media.MediaService, pollService poll.PollService, linkPreviewService linkpreview.LinkPreviewService, moderationService moderation.ModerationService, anonymityService anonymity.AnonymityService, timelineService timeline.TimelineService, views *ViewTracker, scorer Scorer) PostService {
 	return &postService{
-		postRepo: postRepo,
-		userRepo: userRepo,
+		postRepo:           postRepo,
+		userRepo:           userRepo,
+		hashtagRepo:        hashtagRepo,
+		interestRepo:       interestRepo,
+		mentionService:     mentionService,
+		mediaService:       mediaService,
+		pollService:        pollService,
+		linkPreviewService: linkPreviewService,
+		moderationService:  moderationService,
+		anonymityService:   anonymityService,
+		timelineService:    timelineService,
+		views:              views,
+		scorer:             scorer,
 	}
 }
 
@@ -50,37 +135,129 @@ func (s *postService) CreatePost(ctx context.Context, userID string, req *Create
 	}
 
 	// Validate images
-	if len(req.Images) > 10 {
+	if len(req.Media) > 10 {
 		return nil, ErrTooManyImages
 	}
 
+	// Validate post type; poll posts carry a poll, other posts must not
+	postType := req.Type
+	if postType == "" {
+		postType = TypeText
+	}
+	switch postType {
+	case TypeText:
+		if req.Poll != nil || req.QuotedPostID != nil {
+			return nil, ErrInvalidPostType
+		}
+	case TypePoll:
+		if req.QuotedPostID != nil {
+			return nil, ErrInvalidPostType
+		}
+		if err := s.pollService.Validate(req.Poll); err != nil {
+			return nil, err
+		}
+	case TypeQuote:
+		if req.Poll != nil || req.QuotedPostID == nil {
+			return nil, ErrInvalidPostType
+		}
+	default:
+		return nil, ErrInvalidPostType // Plain reposts go through Repost
+	}
+
+	// Quote posts quote the original of a repost, and only posts the author can see
+	var quotedPostID *string
+	if postType == TypeQuote {
+		original, err := s.getQuotable(ctx, *req.QuotedPostID, userID)
+		if err != nil {
+			return nil, err
+		}
+		quotedPostID = &original.ID
+	}
+
+	// Interest tags must be known interests
+	interestIDs, err := s.resolveInterests(ctx, req.Interests)
+	if err != nil {
+		return nil, err
+	}
+
+	// Anonymous posting can be rate limited or revoked
+	if req.IsAnonymous {
+		if err := s.anonymityService.CheckCanPost(ctx, userID, anonymity.ContentPost); err != nil {
+			return nil, err
+		}
+	}
+
+	// Moderate the text (including poll options) before anything is saved
+	moderated, err := s.moderationService.Check(ctx, moderation.ContentPost, userID, moderationText(req.Content, req.Poll))
+	if err != nil {
+		return nil, err
+	}
+
+	// Only images uploaded by the author can be attached
+	attachments, err := s.mediaService.PrepareForPost(ctx, userID, "", req.Media)
+	if err != nil {
+		return nil, mediaError(err)
+	}
+
 	// Create post
 	post := &Post{
-		UserID:      userID,
-		Content:     req.Content,
-		Images:      req.Images,
-		IsAnonymous: req.IsAnonymous,
-		IsActive:    true,
-		ViewCount:   0,
+		UserID:       userID,
+		Content:      req.Content,
+		Images:       imageURLs(attachments),
+		IsAnonymous:  req.IsAnonymous,
+		PostType:     postType,
+		QuotedPostID: quotedPostID,
+		IsActive:     true,
+		ViewCount:    0,
 	}
 
-	err := s.postRepo.Create(ctx, post)
+	err = s.postRepo.Create(ctx, post)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create post: %w", err)
 	}
 
+	// Link images; don't leave a post without the images it was created with
+	if err := s.mediaService.AttachToPost(ctx, post.ID, attachments); err != nil {
+		_ = s.postRepo.Delete(ctx, post.ID)
+		return nil, err
+	}
+
+	// Likewise, a poll post can't exist without its poll
+	if post.PostType == TypePoll {
+		if _, err := s.pollService.Create(ctx, post.ID, req.Poll); err != nil {
+			_ = s.postRepo.Delete(ctx, post.ID)
+			return nil, err
+		}
+	}
+
+	// Tag interests, then index hashtags and mentions and fan out to followers (the post
+	// is already saved, so failures are only logged)
+	s.tagInterests(ctx, post.ID, interestIDs)
+	s.indexContent(ctx, post)
+	s.queueForReview(ctx, moderated, post.ID)
+	s.publishToTimelines(ctx, post)
+
 	// Get author info
-	author, err := s.getAuthorInfo(ctx, userID)
+	author, err := s.getPostAuthor(ctx, auth.NewUserLoader(s.userRepo), anonymity.NewPseudonymLoader(s.anonymityService), post)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get author info: %w", err)
 	}
 
 	// Build response
 	response := &PostResponse{
-		ID:      post.ID,
-		Author:  *author,
-		Content: post.Content,
-		Images:  post.Images,
+		ID:          post.ID,
+		Author:      *author,
+		Type:        post.PostType,
+		Content:     post.Content,
+		Images:      post.Images,
+		Media:       s.getPostMedia(ctx, post.ID),
+		Hashtags:    entities.HashtagValues(post.Content),
+		Interests:   s.getPostInterests(ctx, post.ID),
+		Mentions:    s.mentionService.GetPostEntities(ctx, post.ID, post.Content),
+		Poll:        s.getPoll(ctx, post, userID),
+		QuotedPost:  s.getQuotedPost(ctx, post, userID),
+		LinkPreview: s.linkPreviewService.GetPreview(ctx, post.Content),
+		ViewCount:   post.ViewCount,
 		Reactions: ReactionInfo{
 			FireCount:   0,
 			IsFiredByMe: false,
@@ -92,6 +269,75 @@ func (s *postService) CreatePost(ctx context.Context, userID string, req *Create
 	return response, nil
 }
 
+// UpdatePost edits the content and images of a post owned by userID
+func (s *postService) UpdatePost(ctx context.Context, postID, userID string, req *UpdatePostRequest) (*PostResponse, error) {
+	// Validate content
+	if len(req.Content) < 1 || len(req.Content) > 5000 {
+		return nil, ErrInvalidContent
+	}
+
+	// Validate images
+	if len(req.Media) > 10 {
+		return nil, ErrTooManyImages
+	}
+
+	// Get post to verify ownership
+	post, err := s.postRepo.GetByID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+	if post == nil {
+		return nil, ErrPostNotFound
+	}
+	if post.UserID != userID {
+		return nil, ErrUnauthorized
+	}
+	if post.PostType == TypeRepost {
+		return nil, ErrInvalidPostType // Reposts have no content to edit
+	}
+
+	interestIDs, err := s.resolveInterests(ctx, req.Interests)
+	if err != nil {
+		return nil, err
+	}
+
+	moderated, err := s.moderationService.Check(ctx, moderation.ContentPost, userID, req.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only images uploaded by the author can be attached
+	attachments, err := s.mediaService.PrepareForPost(ctx, userID, postID, req.Media)
+	if err != nil {
+		return nil, mediaError(err)
+	}
+
+	// Update post
+	post.Content = req.Content
+	post.Images = imageURLs(attachments)
+
+	err = s.postRepo.Update(ctx, post)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update post: %w", err)
+	}
+
+	if err := s.mediaService.AttachToPost(ctx, post.ID, attachments); err != nil {
+		return nil, err
+	}
+
+	// Re-tag interests and re-index hashtags and mentions for the new content
+	s.tagInterests(ctx, post.ID, interestIDs)
+	s.indexContent(ctx, post)
+	s.queueForReview(ctx, moderated, post.ID)
+
+	responses, err := s.convertPostsToResponse(ctx, []*Post{post}, userID)
+	if err != nil || len(responses) == 0 {
+		return nil, fmt.Errorf("failed to build post response: %w", err)
+	}
+
+	return responses[0], nil
+}
+
 // GetPost retrieves a single post by ID
 func (s *postService) GetPost(ctx context.Context, postID, currentUserID string) (*PostResponse, error) {
 	// Get post
@@ -103,35 +349,29 @@ func (s *postService) GetPost(ctx context.Context, postID, currentUserID string)
 		return nil, ErrPostNotFound
 	}
 
-	// Increment view count (async, don't wait)
-	go func() {
-		// In production, you might want to batch these updates
-		_ = s.postRepo.Update(ctx, post)
-	}()
-
-	// Get author info
-	author, err := s.getAuthorInfo(ctx, post.UserID)
+	// The author's privacy settings and blocks apply to single posts too
+	visible, err := s.postRepo.IsVisibleTo(ctx, post.ID, currentUserID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get author info: %w", err)
+		return nil, fmt.Errorf("failed to check post visibility: %w", err)
+	}
+	if !visible {
+		return nil, ErrPostNotFound
 	}
 
-	// Get reaction info
-	reactionInfo, err := s.getReactionInfo(ctx, postID, currentUserID)
+	// A repost is only shown together with its original
+	responses, err := s.convertPostsToResponse(ctx, []*Post{post}, currentUserID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get reaction info: %w", err)
+		return nil, fmt.Errorf("failed to convert post: %w", err)
 	}
-
-	// Build response
-	response := &PostResponse{
-		ID:        post.ID,
-		Author:    *author,
-		Content:   post.Content,
-		Images:    post.Images,
-		Reactions: *reactionInfo,
-		CreatedAt: post.CreatedAt.Format(time.RFC3339),
+	if len(responses) == 0 {
+		return nil, ErrPostNotFound
 	}
 
-	return response, nil
+	// Count the view only once the post is known to be shown (buffered, written by the
+	// view flush job)
+	s.views.Record(post.ID, post.UserID, currentUserID)
+
+	return responses[0], nil
 }
 
 // GetUserPosts retrieves all posts by a specific user
@@ -146,8 +386,9 @@ func (s *postService) GetUserPosts(ctx context.Context, userID, currentUserID st
 
 	offset := (page - 1) * limit
 
-	// Get posts
-	posts, err := s.postRepo.GetByUserID(ctx, userID, limit, offset)
+	// Get the posts the viewer may see; anonymous posts only show on the author's own view
+	// of their profile
+	posts, err := s.postRepo.GetByUserID(ctx, userID, currentUserID, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get posts: %w", err)
 	}
@@ -158,6 +399,9 @@ func (s *postService) GetUserPosts(ctx context.Context, userID, currentUserID st
 		return nil, fmt.Errorf("failed to convert posts: %w", err)
 	}
 
+	// Count impressions of the posts shown
+	s.views.RecordPosts(shownPosts(posts, postResponses), currentUserID)
+
 	// Calculate total pages (simplified - in production, get total count)
 	totalPages := 1
 	if len(postResponses) == limit {
@@ -191,15 +435,87 @@ func (s *postService) DeletePost(ctx context.Context, postID, userID string) err
 		return ErrUnauthorized
 	}
 
-	// Delete post
-	err = s.postRepo.Delete(ctx, postID)
+	// Delete post; a repost is undone instead
+	if post.PostType == TypeRepost {
+		err = s.postRepo.DeleteRepost(ctx, postID)
+	} else {
+		err = s.postRepo.Delete(ctx, postID)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to delete post: %w", err)
 	}
 
+	// Deleted posts don't keep their pin slot
+	if _, err := s.postRepo.UnpinPost(ctx, userID, postID); err != nil {
+		log.Printf("⚠️ Failed to unpin deleted post %s: %v", postID, err)
+	}
+
+	s.removeFromTimelines(ctx, post)
+
 	return nil
 }
 
+// GetPostViews retrieves view statistics for a post; only the author may see them
+func (s *postService) GetPostViews(ctx context.Context, postID, userID string) (*PostViewsResponse, error) {
+	post, err := s.postRepo.GetByID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+	if post == nil {
+		return nil, ErrPostNotFound
+	}
+
+	// Check ownership
+	if post.UserID != userID {
+		return nil, ErrUnauthorized
+	}
+
+	uniqueViewers, err := s.postRepo.GetUniqueViewerCount(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unique viewers: %w", err)
+	}
+
+	return &PostViewsResponse{
+		PostID:        post.ID,
+		ViewCount:     post.ViewCount,
+		UniqueViewers: uniqueViewers,
+	}, nil
+}
+
+// VotePoll records a vote in a poll post and returns the post with updated tallies
+func (s *postService) VotePoll(ctx context.Context, postID, userID string, optionIDs []string) (*PostResponse, error) {
+	post, err := s.postRepo.GetByID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+	if post == nil {
+		return nil, ErrPostNotFound
+	}
+	if post.PostType != TypePoll {
+		return nil, poll.ErrPollNotFound
+	}
+
+	// Only viewers who can see the poll can vote on it and see the tallies
+	visible, err := s.postRepo.IsVisibleTo(ctx, post.ID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check post visibility: %w", err)
+	}
+	if !visible {
+		return nil, ErrPostNotFound
+	}
+
+	if err := s.pollService.Vote(ctx, postID, userID, optionIDs); err != nil {
+		return nil, err
+	}
+
+	responses, err := s.convertPostsToResponse(ctx, []*Post{post}, userID)
+	if err != nil || len(responses) == 0 {
+		return nil, fmt.Errorf("failed to build post response: %w", err)
+	}
+
+	return responses[0], nil
+}
+
 // ToggleReaction toggles a fire reaction on a post
 func (s *postService) ToggleReaction(ctx context.Context, postID, userID string) (*ReactionResponse, error) {
 	// Check if post exists
@@ -252,8 +568,16 @@ func (s *postService) ToggleReaction(ctx context.Context, postID, userID string)
 	}, nil
 }
 
-// GetFeed retrieves posts from users that the current user follows
-func (s *postService) GetFeed(ctx context.Context, userID string, page, limit int) (*FeedResponse, error) {
+// GetFeed retrieves the home feed, either reverse-chronological from the precomputed home
+// timeline (latest) or scored by the ranking pipeline (ranked)
+func (s *postService) GetFeed(ctx context.Context, userID, mode string, page, limit int) (*FeedResponse, error) {
+	if mode == "" {
+		mode = FeedModeLatest
+	}
+	if mode != FeedModeLatest && mode != FeedModeRanked {
+		return nil, ErrInvalidFeedMode
+	}
+
 	// Validate pagination
 	if page < 1 {
 		page = 1
@@ -265,7 +589,13 @@ func (s *postService) GetFeed(ctx context.Context, userID string, page, limit in
 	offset := (page - 1) * limit
 
 	// Get feed posts
-	posts, err := s.postRepo.GetFeed(ctx, userID, limit, offset)
+	var posts []*Post
+	var err error
+	if mode == FeedModeRanked {
+		posts, err = s.getRankedFeed(ctx, userID, limit, offset)
+	} else {
+		posts, err = s.getTimelineFeed(ctx, userID, limit, offset)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get feed: %w", err)
 	}
@@ -276,6 +606,9 @@ func (s *postService) GetFeed(ctx context.Context, userID string, page, limit in
 		return nil, fmt.Errorf("failed to convert posts: %w", err)
 	}
 
+	// Count impressions of the posts shown
+	s.views.RecordPosts(shownPosts(posts, postResponses), userID)
+
 	// Calculate total pages (simplified)
 	totalPages := 1
 	if len(postResponses) == limit {
@@ -284,6 +617,7 @@ func (s *postService) GetFeed(ctx context.Context, userID string, page, limit in
 
 	return &FeedResponse{
 		Posts: postResponses,
+		Mode:  mode,
 		Pagination: PaginationInfo{
 			Page:       page,
 			TotalPages: totalPages,
@@ -293,94 +627,293 @@ func (s *postService) GetFeed(ctx context.Context, userID string, page, limit in
 	}, nil
 }
 
-// Helper methods
+// GetExplore retrieves trending posts for discovery; works for logged-out users too
+func (s *postService) GetExplore(ctx context.Context, currentUserID string, filter ExploreFilter, page, limit int) (*FeedResponse, error) {
+	if filter.Category != "" && !isValidCategory(filter.Category) {
+		return nil, ErrInvalidCategory
+	}
+
+	// Validate pagination
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 20
+	}
+
+	offset := (page - 1) * limit
 
-// getAuthorInfo retrieves author information for a post
-func (s *postService) getAuthorInfo(ctx context.Context, userID string) (*AuthorInfo, error) {
-	user, err := s.userRepo.FindByID(ctx, userID)
+	// Get trending posts
+	posts, err := s.postRepo.GetTrending(ctx, currentUserID, filter, limit, offset)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to get trending posts: %w", err)
 	}
-	if user == nil {
-		return nil, errors.New("user not found")
+
+	// Convert to response
+	postResponses, err := s.convertPostsToResponse(ctx, posts, currentUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert posts: %w", err)
 	}
 
-	return &AuthorInfo{
-		ID:        user.ID,
-		Username:  user.Username,
-		FirstName: user.FirstName,
-		AvatarURL: user.AvatarURL,
+	// Count impressions of the posts shown
+	s.views.RecordPosts(shownPosts(posts, postResponses), currentUserID)
+
+	// Calculate total pages (simplified)
+	totalPages := 1
+	if len(postResponses) == limit {
+		totalPages = page + 1
+	}
+
+	return &FeedResponse{
+		Posts: postResponses,
+		Pagination: PaginationInfo{
+			Page:       page,
+			TotalPages: totalPages,
+			TotalItems: len(postResponses),
+			Limit:      limit,
+		},
 	}, nil
 }
 
-// getReactionInfo retrieves reaction information for a post
-func (s *postService) getReactionInfo(ctx context.Context, postID, currentUserID string) (*ReactionInfo, error) {
-	// Get reaction count
-	count, err := s.postRepo.GetReactionCount(ctx, postID)
+// GetHashtagPosts retrieves the posts tagged with a hashtag, newest first
+func (s *postService) GetHashtagPosts(ctx context.Context, tag, currentUserID string, page, limit int) (*FeedResponse, error) {
+	normalized, ok := entities.NormalizeHashtag(tag)
+	if !ok {
+		return nil, ErrInvalidHashtag
+	}
+
+	// Validate pagination
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 20
+	}
+
+	offset := (page - 1) * limit
+
+	// Get tagged posts
+	posts, err := s.postRepo.GetByHashtag(ctx, currentUserID, normalized, limit, offset)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to get hashtag posts: %w", err)
 	}
 
-	// Check if current user reacted
-	isFiredByMe := false
-	if currentUserID != "" {
-		hasReacted, err := s.postRepo.HasUserReacted(ctx, postID, currentUserID)
-		if err == nil {
-			isFiredByMe = hasReacted
-		}
+	// Convert to response
+	postResponses, err := s.convertPostsToResponse(ctx, posts, currentUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert posts: %w", err)
+	}
+
+	// Count impressions of the posts shown
+	s.views.RecordPosts(shownPosts(posts, postResponses), currentUserID)
+
+	// Calculate total pages (simplified)
+	totalPages := 1
+	if len(postResponses) == limit {
+		totalPages = page + 1
+	}
+
+	return &FeedResponse{
+		Posts: postResponses,
+		Pagination: PaginationInfo{
+			Page:       page,
+			TotalPages: totalPages,
+			TotalItems: len(postResponses),
+			Limit:      limit,
+		},
+	}, nil
+}
+
+// GetSavedPosts retrieves the user's saved posts, optionally from one collection
+func (s *postService) GetSavedPosts(ctx context.Context, userID, collectionID string, page, limit int) (*FeedResponse, error) {
+	// Validate pagination
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 20
 	}
 
-	// Get recent users who reacted (limit 5)
-	reactions, err := s.postRepo.GetReactions(ctx, postID, 5, 0)
+	offset := (page - 1) * limit
+
+	posts, err := s.postRepo.GetSaved(ctx, userID, collectionID, limit, offset)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to get saved posts: %w", err)
 	}
 
-	// Convert to author info
-	recentUsers := make([]AuthorInfo, 0, len(reactions))
-	for _, reaction := range reactions {
-		author, err := s.getAuthorInfo(ctx, reaction.UserID)
-		if err == nil && author != nil {
-			recentUsers = append(recentUsers, *author)
-		}
+	postResponses, err := s.convertPostsToResponse(ctx, posts, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert posts: %w", err)
 	}
 
-	return &ReactionInfo{
-		FireCount:   count,
-		IsFiredByMe: isFiredByMe,
-		RecentUsers: recentUsers,
+	// Calculate total pages (simplified)
+	totalPages := 1
+	if len(postResponses) == limit {
+		totalPages = page + 1
+	}
+
+	return &FeedResponse{
+		Posts: postResponses,
+		Pagination: PaginationInfo{
+			Page:       page,
+			TotalPages: totalPages,
+			TotalItems: len(postResponses),
+			Limit:      limit,
+		},
 	}, nil
 }
 
-// convertPostsToResponse converts a slice of posts to post responses
-func (s *postService) convertPostsToResponse(ctx context.Context, posts []*Post, currentUserID string) ([]*PostResponse, error) {
-	responses := make([]*PostResponse, 0, len(posts))
+// RefreshTrending recomputes trending scores; run periodically by a background job
+func (s *postService) RefreshTrending(ctx context.Context) error {
+	count, err := s.postRepo.RefreshTrendingScores(ctx, trendingWindow, trendingHalfLifeHours)
+	if err != nil {
+		return fmt.Errorf("failed to refresh trending scores: %w", err)
+	}
 
-	for _, post := range posts {
-		// Get author info
-		author, err := s.getAuthorInfo(ctx, post.UserID)
-		if err != nil {
-			continue // Skip posts with invalid authors
-		}
+	log.Printf("🔥 Trending scores refreshed for %d posts", count)
+	return nil
+}
+
+// Helper methods
+
+// getPostMedia retrieves the images attached to a post, empty if they can't be loaded
+func (s *postService) getPostMedia(ctx context.Context, postID string) []*media.PostMediaResponse {
+	items, err := s.mediaService.GetPostMedia(ctx, postID)
+	if err != nil {
+		return []*media.PostMediaResponse{}
+	}
+	return items
+}
+
+// imageURLs returns the large variant URL of each attachment, stored in posts.images
+func imageURLs(attachments []*media.PostMedia) []string {
+	urls := make([]string, 0, len(attachments))
+	for _, a := range attachments {
+		urls = append(urls, media.LargeURL(a.MediaID))
+	}
+	return urls
+}
+
+// mediaError maps media validation errors to ErrInvalidMedia, keeping the reason
+func mediaError(err error) error {
+	switch {
+	case errors.Is(err, media.ErrInvalidMedia),
+		errors.Is(err, media.ErrMediaInUse),
+		errors.Is(err, media.ErrDuplicateMedia),
+		errors.Is(err, media.ErrAltTextTooLong):
+		return fmt.Errorf("%w: %v", ErrInvalidMedia, err)
+	}
+	return err
+}
+
+// getPoll returns a poll post's poll as seen by viewerID, nil for other posts
+func (s *postService) getPoll(ctx context.Context, post *Post, viewerID string) *poll.PollResponse {
+	if post.PostType != TypePoll {
+		return nil
+	}
+	response, err := s.pollService.GetPollResponse(ctx, post.ID, post.UserID, viewerID)
+	if err != nil {
+		log.Printf("⚠️ Failed to get poll for post %s: %v", post.ID, err)
+		return nil
+	}
+	return response
+}
 
-		// Get reaction info
-		reactionInfo, err := s.getReactionInfo(ctx, post.ID, currentUserID)
+// indexContent indexes the hashtags (and the interests they imply) and mentions in a post's
+// content and queues its link preview
+func (s *postService) indexContent(ctx context.Context, post *Post) {
+	if err := s.hashtagRepo.SyncPostHashtags(ctx, post.ID, entities.HashtagValues(post.Content)); err != nil {
+		log.Printf("⚠️ Failed to sync hashtags for post %s: %v", post.ID, err)
+	}
+	if err := s.postRepo.SyncInferredInterests(ctx, post.ID); err != nil {
+		log.Printf("⚠️ Failed to sync inferred interests for post %s: %v", post.ID, err)
+	}
+	if err := s.mentionService.SyncPostMentions(ctx, post.ID, post.UserID, post.Content, post.IsAnonymous); err != nil {
+		log.Printf("⚠️ Failed to sync mentions for post %s: %v", post.ID, err)
+	}
+	if err := s.linkPreviewService.Enqueue(ctx, post.Content); err != nil {
+		log.Printf("⚠️ Failed to queue link preview for post %s: %v", post.ID, err)
+	}
+}
+
+// queueForReview queues a saved post for moderator review if its text was flagged
+func (s *postService) queueForReview(ctx context.Context, moderated *moderation.Result, postID string) {
+	if err := s.moderationService.QueueForReview(ctx, moderated, postID); err != nil {
+		log.Printf("⚠️ Failed to queue post %s for review: %v", postID, err)
+	}
+}
+
+// moderationText is the user-written text of a post: its content and any poll options
+func moderationText(content string, pollReq *poll.CreatePollRequest) string {
+	if pollReq == nil {
+		return content
+	}
+	return strings.Join(append([]string{content}, pollReq.Options...), "\n")
+}
+
+// getRankedFeed scores feed candidates, applies diversity rules and returns the requested page
+func (s *postService) getRankedFeed(ctx context.Context, userID string, limit, offset int) ([]*Post, error) {
+	candidates, err := s.postRepo.GetFeedCandidates(ctx, userID, rankedFeedWindow, rankedFeedPoolSize)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, c := range candidates {
+		c.Score = s.scorer.Score(userID, c, now)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+
+	ranked := applyDiversity(collapseReposts(candidates), maxPostsPerAuthor, authorSpacing)
+
+	if offset >= len(ranked) {
+		return []*Post{}, nil
+	}
+	end := offset + limit
+	if end > len(ranked) {
+		end = len(ranked)
+	}
+
+	posts := make([]*Post, 0, end-offset)
+	for _, c := range ranked[offset:end] {
+		posts = append(posts, c.Post)
+	}
+
+	return posts, nil
+}
+
+// getPostAuthor returns the author shown on a post. Anonymous posts show the author's
+// pseudonym in the thread and never their account.
+func (s *postService) getPostAuthor(ctx context.Context, users *auth.UserLoader, pseudonyms *anonymity.PseudonymLoader, post *Post) (*AuthorInfo, error) {
+	if post.IsAnonymous {
+		pseudonym, _, err := pseudonyms.Load(ctx, anonymity.ThreadKey{PostID: post.ID, UserID: post.UserID})
 		if err != nil {
-			reactionInfo = &ReactionInfo{
-				FireCount:   0,
-				IsFiredByMe: false,
-				RecentUsers: []AuthorInfo{},
-			}
+			return nil, err
 		}
+		return &AuthorInfo{
+			FirstName:   pseudonym,
+			IsAnonymous: true,
+		}, nil
+	}
+	return getAuthorInfo(ctx, users, post.UserID)
+}
 
-		responses = append(responses, &PostResponse{
-			ID:        post.ID,
-			Author:    *author,
-			Content:   post.Content,
-			Images:    post.Images,
-			Reactions: *reactionInfo,
-			CreatedAt: post.CreatedAt.Format(time.RFC3339),
-		})
+// convertPostsToResponse converts a slice of posts to post responses, loading the data
+// they show with a fixed number of queries. Posts that can't be shown are skipped.
+func (s *postService) convertPostsToResponse(ctx context.Context, posts []*Post, currentUserID string) ([]*PostResponse, error) {
+	loaders, err := s.loadResponseData(ctx, posts, currentUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*PostResponse, 0, len(posts))
+	for _, post := range posts {
+		if response := s.buildResponse(ctx, loaders, post); response != nil {
+			responses = append(responses, response)
+		}
 	}
 
 	return responses, nil
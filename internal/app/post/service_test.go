@@ -0,0 +1,30 @@
+package post
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestVotePollChecksVisibility(t *testing.T) {
+	repo := newFakePostRepo()
+	repo.add(&Post{ID: "poll", UserID: "author", Content: "which?", PostType: TypePoll})
+	repo.hiddenAuthors["author"] = true // Blocked the voter, or deactivated
+	s := newTestService(repo)
+	polls := s.pollService.(*fakePollService)
+
+	if _, err := s.VotePoll(context.Background(), "poll", "voter", []string{"a"}); !errors.Is(err, ErrPostNotFound) {
+		t.Errorf("VotePoll on a hidden poll: err = %v, want ErrPostNotFound", err)
+	}
+	if len(polls.votes) != 0 {
+		t.Errorf("recorded votes %v on a hidden poll", polls.votes)
+	}
+
+	// The author can still vote on their own poll
+	if _, err := s.VotePoll(context.Background(), "poll", "author", []string{"a"}); err != nil {
+		t.Fatalf("VotePoll by the author: %v", err)
+	}
+	if len(polls.votes) != 1 {
+		t.Errorf("recorded votes %v, want the author's", polls.votes)
+	}
+}
@@ -0,0 +1,70 @@
+package post
+
+import (
+	"context"
+	"log"
+
+	"mockhu-app-backend/internal/app/timeline"
+)
+
+// getTimelineFeed returns a page of the latest feed from the precomputed home timeline.
+// Timeline entries are only candidates: they are loaded with the same filters as GetFeed,
+// so entries that were deleted, muted or hidden since are dropped. More entries are read
+// while filtering leaves the page short. Pages past the stored timeline, or a timeline
+// store outage, fall back to querying the feed directly.
+func (s *postService) getTimelineFeed(ctx context.Context, userID string, limit, offset int) ([]*Post, error) {
+	want := offset + limit
+	if want > timeline.MaxLength {
+		return s.postRepo.GetFeed(ctx, userID, limit, offset)
+	}
+
+	n := want * 2
+	for {
+		if n > timeline.MaxLength {
+			n = timeline.MaxLength
+		}
+
+		entries, err := s.timelineService.GetHome(ctx, userID, n)
+		if err != nil {
+			log.Printf("⚠️ Failed to read timeline of user %s, querying feed: %v", userID, err)
+			return s.postRepo.GetFeed(ctx, userID, limit, offset)
+		}
+
+		ids := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			ids = append(ids, entry.PostID)
+		}
+
+		posts, err := s.postRepo.GetFeedPosts(ctx, userID, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(posts) >= want || len(entries) < n || n == timeline.MaxLength {
+			if offset >= len(posts) {
+				return []*Post{}, nil
+			}
+			if want > len(posts) {
+				want = len(posts)
+			}
+			return posts[offset:want], nil
+		}
+
+		n *= 2
+	}
+}
+
+// publishToTimelines pushes a newly published post to followers' timelines; the post is
+// already saved, so failures are only logged
+func (s *postService) publishToTimelines(ctx context.Context, post *Post) {
+	if err := s.timelineService.Publish(ctx, post.ID); err != nil {
+		log.Printf("⚠️ Failed to fan out post %s: %v", post.ID, err)
+	}
+}
+
+// removeFromTimelines removes a deleted post from followers' timelines
+func (s *postService) removeFromTimelines(ctx context.Context, post *Post) {
+	if err := s.timelineService.Remove(ctx, post.ID, post.UserID); err != nil {
+		log.Printf("⚠️ Failed to remove post %s from timelines: %v", post.ID, err)
+	}
+}
@@ -0,0 +1,131 @@
+package post
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// View tracking tuning
+const (
+	viewDedupeWindow  = 30 * time.Minute // Repeat views by the same viewer within this window count once
+	ViewFlushInterval = 30 * time.Second // How often buffered views are written to the database
+)
+
+// PostView is a counted view of a post by a logged-in viewer
+type PostView struct {
+	PostID   string
+	ViewerID string
+	Views    int // Counted views since the last flush
+}
+
+// viewKey identifies a viewer of a post
+type viewKey struct {
+	postID   string
+	viewerID string
+}
+
+// ViewTracker counts post views in memory and writes them in batches, so reading a post
+// doesn't UPDATE its row on every request. Views are deduplicated per viewer within
+// viewDedupeWindow; authors viewing their own posts and logged-out visitors are not counted.
+// Deduplication is per process, so with several instances a viewer may be counted once per
+// instance within a window.
+type ViewTracker struct {
+	postRepo PostRepository
+	window   time.Duration
+
+	mu      sync.Mutex
+	seen    map[viewKey]time.Time // Last counted view per viewer, for deduplication
+	pending map[viewKey]int       // Counted views not yet flushed
+}
+
+// NewViewTracker creates a view tracker that flushes to postRepo
+func NewViewTracker(postRepo PostRepository) *ViewTracker {
+	return &ViewTracker{
+		postRepo: postRepo,
+		window:   viewDedupeWindow,
+		seen:     make(map[viewKey]time.Time),
+		pending:  make(map[viewKey]int),
+	}
+}
+
+// Record counts a view of a post unless the same viewer was counted within the window
+func (t *ViewTracker) Record(postID, authorID, viewerID string) {
+	if viewerID == "" || viewerID == authorID {
+		return
+	}
+
+	key := viewKey{postID: postID, viewerID: viewerID}
+	now := time.Now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if last, ok := t.seen[key]; ok && now.Sub(last) < t.window {
+		return
+	}
+	t.seen[key] = now
+	t.pending[key]++
+}
+
+// RecordPosts counts an impression of each post, e.g. when a feed page is served
+func (t *ViewTracker) RecordPosts(posts []*Post, viewerID string) {
+	for _, post := range posts {
+		t.Record(post.ID, post.UserID, viewerID)
+	}
+}
+
+// shownPosts returns the posts that made it into responses, so impressions are only counted
+// for posts the viewer was actually shown
+func shownPosts(posts []*Post, responses []*PostResponse) []*Post {
+	shown := make(map[string]bool, len(responses))
+	for _, response := range responses {
+		shown[response.ID] = true
+	}
+
+	result := make([]*Post, 0, len(responses))
+	for _, post := range posts {
+		if shown[post.ID] {
+			result = append(result, post)
+		}
+	}
+	return result
+}
+
+// Flush writes buffered views to the database; run periodically by a background job.
+// If the write fails the views are kept and retried on the next flush.
+func (t *ViewTracker) Flush(ctx context.Context) error {
+	t.mu.Lock()
+	batch := t.pending
+	t.pending = make(map[viewKey]int)
+
+	// Forget viewers whose window has passed so the map doesn't grow forever
+	now := time.Now()
+	for key, last := range t.seen {
+		if now.Sub(last) >= t.window {
+			delete(t.seen, key)
+		}
+	}
+	t.mu.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	views := make([]*PostView, 0, len(batch))
+	for key, count := range batch {
+		views = append(views, &PostView{PostID: key.postID, ViewerID: key.viewerID, Views: count})
+	}
+
+	if err := t.postRepo.SaveViews(ctx, views); err != nil {
+		t.mu.Lock()
+		for key, count := range batch {
+			t.pending[key] += count
+		}
+		t.mu.Unlock()
+		return fmt.Errorf("failed to save %d post views: %w", len(views), err)
+	}
+
+	return nil
+}
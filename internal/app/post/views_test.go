@@ -0,0 +1,132 @@
+package post
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// pendingViews returns the views a tracker has counted but not flushed
+func pendingViews(t *ViewTracker) map[viewKey]int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	pending := make(map[viewKey]int, len(t.pending))
+	for key, count := range t.pending {
+		pending[key] = count
+	}
+	return pending
+}
+
+func TestViewTrackerRecord(t *testing.T) {
+	tracker := NewViewTracker(newFakePostRepo())
+
+	tracker.Record("p1", "author", "viewer")
+	tracker.Record("p1", "author", "viewer") // Within the window: counted once
+	tracker.Record("p1", "author", "author") // Authors don't count
+	tracker.Record("p1", "author", "")       // Nor do logged-out visitors
+	tracker.Record("p1", "author", "other")
+	tracker.Record("p2", "author", "viewer")
+
+	want := map[viewKey]int{
+		{postID: "p1", viewerID: "viewer"}: 1,
+		{postID: "p1", viewerID: "other"}:  1,
+		{postID: "p2", viewerID: "viewer"}: 1,
+	}
+	got := pendingViews(tracker)
+	if len(got) != len(want) {
+		t.Fatalf("pending = %v, want %v", got, want)
+	}
+	for key, count := range want {
+		if got[key] != count {
+			t.Errorf("pending[%v] = %d, want %d", key, got[key], count)
+		}
+	}
+}
+
+func TestViewTrackerCountsAgainAfterWindow(t *testing.T) {
+	tracker := NewViewTracker(newFakePostRepo())
+	tracker.window = time.Millisecond
+
+	tracker.Record("p1", "author", "viewer")
+	time.Sleep(5 * time.Millisecond)
+	tracker.Record("p1", "author", "viewer")
+
+	if got := pendingViews(tracker)[viewKey{postID: "p1", viewerID: "viewer"}]; got != 2 {
+		t.Errorf("views after the window = %d, want 2", got)
+	}
+}
+
+func TestViewTrackerFlush(t *testing.T) {
+	repo := newFakePostRepo()
+	tracker := NewViewTracker(repo)
+	ctx := context.Background()
+
+	tracker.Record("p1", "author", "viewer")
+
+	// A failed write keeps the views for the next flush
+	repo.saveViewsErr = errors.New("database down")
+	if err := tracker.Flush(ctx); err == nil {
+		t.Fatal("Flush: want error, got nil")
+	}
+	if len(pendingViews(tracker)) != 1 {
+		t.Fatalf("pending after failed flush = %v, want the view kept", pendingViews(tracker))
+	}
+
+	repo.saveViewsErr = nil
+	if err := tracker.Flush(ctx); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if len(repo.savedViews) != 1 || repo.savedViews[0].PostID != "p1" || repo.savedViews[0].Views != 1 {
+		t.Errorf("saved views = %+v, want one view of p1", repo.savedViews)
+	}
+	if len(pendingViews(tracker)) != 0 {
+		t.Errorf("pending after flush = %v, want none", pendingViews(tracker))
+	}
+}
+
+func TestGetPostCountsViewsOnlyWhenShown(t *testing.T) {
+	repo := newFakePostRepo()
+	s := newTestService(repo)
+	ctx := context.Background()
+
+	repo.add(&Post{ID: "public", UserID: "author", Content: "hello"})
+	repo.add(&Post{ID: "private", UserID: "private-author", Content: "followers only"})
+	repo.hiddenAuthors["private-author"] = true
+	repo.add(&Post{ID: "draft", UserID: "author", Content: "not yet", Status: StatusDraft})
+
+	if _, err := s.GetPost(ctx, "public", "viewer"); err != nil {
+		t.Fatalf("GetPost(public): %v", err)
+	}
+	for _, id := range []string{"private", "draft", "missing"} {
+		if _, err := s.GetPost(ctx, id, "viewer"); !errors.Is(err, ErrPostNotFound) {
+			t.Errorf("GetPost(%s): err = %v, want ErrPostNotFound", id, err)
+		}
+	}
+
+	want := map[viewKey]int{{postID: "public", viewerID: "viewer"}: 1}
+	if got := pendingViews(s.views); len(got) != 1 || got[viewKey{postID: "public", viewerID: "viewer"}] != 1 {
+		t.Errorf("pending = %v, want %v", got, want)
+	}
+}
+
+func TestGetUserPostsCountsViews(t *testing.T) {
+	repo := newFakePostRepo()
+	s := newTestService(repo)
+	ctx := context.Background()
+
+	repo.add(&Post{ID: "p1", UserID: "author", Content: "one"})
+	repo.add(&Post{ID: "p2", UserID: "author", Content: "two"})
+
+	if _, err := s.GetUserPosts(ctx, "author", "viewer", 1, 20); err != nil {
+		t.Fatalf("GetUserPosts: %v", err)
+	}
+
+	got := pendingViews(s.views)
+	for _, id := range []string{"p1", "p2"} {
+		if got[viewKey{postID: id, viewerID: "viewer"}] != 1 {
+			t.Errorf("view of %s not counted: pending = %v", id, got)
+		}
+	}
+}
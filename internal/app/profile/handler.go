@@ -1,6 +1,10 @@
 package profile
 
 import (
+	"errors"
+
+	"mockhu-app-backend/internal/app/moderation"
+
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -92,6 +96,13 @@ func (h *Handler) UpdateProfile(c *fiber.Ctx) error {
 	// Update profile
 	profile, err := h.service.UpdateProfile(c.Context(), currentUserID, &req)
 	if err != nil {
+		var rejection *moderation.RejectionError
+		if errors.As(err, &rejection) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"error":      rejection.Error(),
+				"moderation": rejection,
+			})
+		}
 		// Handle specific errors
 		errMsg := err.Error()
 		if errMsg == "username already taken" {
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 
+	"mockhu-app-backend/internal/app/moderation"
 	"mockhu-app-backend/internal/pkg/avatar"
 
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -34,15 +36,17 @@ type ProfileService interface {
 
 // profileService implements ProfileService
 type profileService struct {
-	profileRepo ProfileRepository
-	db          *pgxpool.Pool
+	profileRepo       ProfileRepository
+	moderationService moderation.ModerationService
+	db                *pgxpool.Pool
 }
 
 // NewService creates a new profile service
-func NewService(profileRepo ProfileRepository, db *pgxpool.Pool) ProfileService {
+func NewService(profileRepo ProfileRepository, moderationService moderation.ModerationService, db *pgxpool.Pool) ProfileService {
 	return &profileService{
-		profileRepo: profileRepo,
-		db:          db,
+		profileRepo:       profileRepo,
+		moderationService: moderationService,
+		db:                db,
 	}
 }
 
@@ -133,25 +137,11 @@ func (s *profileService) GetOwnProfile(ctx context.Context, userID string) (*Own
 func (s *profileService) getProfileStats(ctx context.Context, userID string) (*ProfileStats, error) {
 	stats := &ProfileStats{}
 
-	// Get posts count
-	postsQuery := `SELECT COUNT(*) FROM posts WHERE user_id = $1 AND is_active = true`
-	err := s.db.QueryRow(ctx, postsQuery, userID).Scan(&stats.PostsCount)
+	// Counts are kept on the user row, updated with every post and follow
+	query := `SELECT post_count, follower_count, following_count FROM users WHERE id = $1`
+	err := s.db.QueryRow(ctx, query, userID).Scan(&stats.PostsCount, &stats.FollowersCount, &stats.FollowingCount)
 	if err != nil {
-		stats.PostsCount = 0
-	}
-
-	// Get followers count
-	followersQuery := `SELECT COUNT(*) FROM user_follows WHERE following_id = $1`
-	err = s.db.QueryRow(ctx, followersQuery, userID).Scan(&stats.FollowersCount)
-	if err != nil {
-		stats.FollowersCount = 0
-	}
-
-	// Get following count
-	followingQuery := `SELECT COUNT(*) FROM user_follows WHERE follower_id = $1`
-	err = s.db.QueryRow(ctx, followingQuery, userID).Scan(&stats.FollowingCount)
-	if err != nil {
-		stats.FollowingCount = 0
+		return &ProfileStats{}, nil
 	}
 
 	return stats, nil
@@ -202,8 +192,14 @@ func (s *profileService) UpdateProfile(ctx context.Context, userID string, req *
 	if req.LastName != "" {
 		updates["last_name"] = req.LastName
 	}
+	var moderatedBio *moderation.Result
 	if req.Bio != "" {
-		// Sanitize bio (remove dangerous content)
+		// Moderate the bio before it is saved
+		result, err := s.moderationService.Check(ctx, moderation.ContentBio, userID, req.Bio)
+		if err != nil {
+			return nil, err
+		}
+		moderatedBio = result
 		updates["bio"] = req.Bio
 	}
 	if req.Username != "" {
@@ -229,6 +225,11 @@ func (s *profileService) UpdateProfile(ctx context.Context, userID string, req *
 		return nil, fmt.Errorf("failed to update profile: %w", err)
 	}
 
+	// A flagged bio is queued under the user's ID
+	if err := s.moderationService.QueueForReview(ctx, moderatedBio, userID); err != nil {
+		log.Printf("⚠️ Failed to queue bio of user %s for review: %v", userID, err)
+	}
+
 	// Get updated profile
 	return s.GetUserProfile(ctx, userID, userID)
 }
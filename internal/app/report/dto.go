@@ -0,0 +1,76 @@
+package report
+
+// CreateReportRequest is the request DTO for reporting a post, comment, message or user
+type CreateReportRequest struct {
+	TargetType string `json:"target_type"` // post, comment, message or user
+	TargetID   string `json:"target_id"`
+	Reason     string `json:"reason"`
+	Details    string `json:"details,omitempty"` // Optional free text, max 1000 characters
+}
+
+// ResolveCaseRequest is the request DTO for resolving a case
+type ResolveCaseRequest struct {
+	Action      string `json:"action"`                 // dismiss, hide_content, warn or suspend
+	Note        string `json:"note,omitempty"`         // Internal note for other moderators
+	SuspendDays int    `json:"suspend_days,omitempty"` // For suspend; defaults to 7
+}
+
+// ReportResponse is a report as its reporter sees it
+type ReportResponse struct {
+	ID         string `json:"id"`
+	TargetType string `json:"target_type"`
+	TargetID   string `json:"target_id"`
+	Reason     string `json:"reason"`
+	Details    string `json:"details,omitempty"`
+	Outcome    string `json:"outcome"` // pending, no_violation or action_taken
+	CreatedAt  string `json:"created_at"`
+}
+
+// ReportListResponse is a page of the user's own reports
+type ReportListResponse struct {
+	Reports    []*ReportResponse `json:"reports"`
+	Pagination PaginationInfo    `json:"pagination"`
+}
+
+// CaseReport is a report inside a case, as moderators see it
+type CaseReport struct {
+	ID         string `json:"id"`
+	ReporterID string `json:"reporter_id"`
+	Reason     string `json:"reason"`
+	Details    string `json:"details,omitempty"`
+	CreatedAt  string `json:"created_at"`
+}
+
+// CaseResponse is a case in the moderator queue
+type CaseResponse struct {
+	ID                string        `json:"id"`
+	TargetType        string        `json:"target_type"`
+	TargetID          string        `json:"target_id"`
+	TargetOwnerID     string        `json:"target_owner_id,omitempty"` // Empty for anonymous targets; see /v1/anonymity/unmask
+	TargetIsAnonymous bool          `json:"target_is_anonymous"`
+	ContentSnapshot   string        `json:"content_snapshot"`
+	ReportCount       int           `json:"report_count"`
+	Status            string        `json:"status"`
+	ClaimedBy         *string       `json:"claimed_by,omitempty"`
+	ClaimedAt         *string       `json:"claimed_at,omitempty"`
+	Action            *string       `json:"action,omitempty"`
+	ResolutionNote    *string       `json:"resolution_note,omitempty"`
+	ResolvedBy        *string       `json:"resolved_by,omitempty"`
+	ResolvedAt        *string       `json:"resolved_at,omitempty"`
+	Reports           []*CaseReport `json:"reports,omitempty"` // Only when getting a single case
+	CreatedAt         string        `json:"created_at"`
+}
+
+// CaseListResponse is a page of cases
+type CaseListResponse struct {
+	Cases      []*CaseResponse `json:"cases"`
+	Pagination PaginationInfo  `json:"pagination"`
+}
+
+// PaginationInfo contains pagination metadata
+type PaginationInfo struct {
+	Page       int `json:"page"`
+	TotalPages int `json:"total_pages"`
+	TotalItems int `json:"total_items"`
+	Limit      int `json:"limit"`
+}
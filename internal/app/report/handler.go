@@ -0,0 +1,230 @@
+package report
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles HTTP requests for reports and the moderator case queue
+type Handler struct {
+	service ReportService
+}
+
+// NewHandler creates a new report handler
+func NewHandler(service ReportService) *Handler {
+	return &Handler{service: service}
+}
+
+// CreateReport handles POST /v1/reports
+func (h *Handler) CreateReport(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	var req CreateReportRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	report, err := h.service.CreateReport(c.Context(), currentUserID, &req)
+	if err != nil {
+		switch err {
+		case ErrInvalidTargetType, ErrInvalidTargetID, ErrInvalidReason, ErrDetailsTooLong, ErrCannotReportSelf:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case ErrTargetNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case ErrAlreadyReported:
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to create report",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(report)
+}
+
+// GetMyReports handles GET /v1/reports/mine
+func (h *Handler) GetMyReports(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	response, err := h.service.GetMyReports(c.Context(), currentUserID, page, limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get reports",
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// GetCases handles GET /v1/reports/cases?status=open&target_type=post
+func (h *Handler) GetCases(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	response, err := h.service.GetCases(c.Context(), c.Query("status"), c.Query("target_type"), page, limit)
+	if err != nil {
+		if err == ErrInvalidStatus || err == ErrInvalidTargetType {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get cases",
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// GetCase handles GET /v1/reports/cases/:caseId
+func (h *Handler) GetCase(c *fiber.Ctx) error {
+	caseID := c.Params("caseId")
+	if _, err := uuid.Parse(caseID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid case ID",
+		})
+	}
+
+	response, err := h.service.GetCase(c.Context(), caseID)
+	if err != nil {
+		if err == ErrCaseNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get case",
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// ClaimCase handles POST /v1/reports/cases/:caseId/claim
+func (h *Handler) ClaimCase(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	caseID := c.Params("caseId")
+	if _, err := uuid.Parse(caseID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid case ID",
+		})
+	}
+
+	response, err := h.service.ClaimCase(c.Context(), caseID, currentUserID)
+	if err != nil {
+		return h.caseError(c, err, "failed to claim case")
+	}
+
+	return c.JSON(response)
+}
+
+// ReleaseCase handles DELETE /v1/reports/cases/:caseId/claim
+func (h *Handler) ReleaseCase(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	caseID := c.Params("caseId")
+	if _, err := uuid.Parse(caseID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid case ID",
+		})
+	}
+
+	response, err := h.service.ReleaseCase(c.Context(), caseID, currentUserID)
+	if err != nil {
+		return h.caseError(c, err, "failed to release case")
+	}
+
+	return c.JSON(response)
+}
+
+// ResolveCase handles POST /v1/reports/cases/:caseId/resolve
+func (h *Handler) ResolveCase(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	caseID := c.Params("caseId")
+	if _, err := uuid.Parse(caseID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid case ID",
+		})
+	}
+
+	var req ResolveCaseRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	response, err := h.service.ResolveCase(c.Context(), caseID, currentUserID, &req)
+	if err != nil {
+		if err == ErrInvalidAction || err == ErrInvalidSuspension {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return h.caseError(c, err, "failed to resolve case")
+	}
+
+	return c.JSON(response)
+}
+
+// caseError maps case state errors to responses
+func (h *Handler) caseError(c *fiber.Ctx, err error, message string) error {
+	switch err {
+	case ErrCaseNotFound:
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case ErrCaseNotClaimable, ErrCaseNotClaimed:
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": message,
+	})
+}
@@ -0,0 +1,116 @@
+package report
+
+import "time"
+
+// Things that can be reported
+const (
+	TargetPost    = "post"
+	TargetComment = "comment"
+	TargetMessage = "message"
+	TargetUser    = "user"
+)
+
+// Report reasons
+const (
+	ReasonSpam           = "spam"
+	ReasonHarassment     = "harassment"
+	ReasonHateSpeech     = "hate_speech"
+	ReasonViolence       = "violence"
+	ReasonSexualContent  = "sexual_content"
+	ReasonSelfHarm       = "self_harm"
+	ReasonMisinformation = "misinformation"
+	ReasonImpersonation  = "impersonation"
+	ReasonOther          = "other"
+)
+
+// Case statuses
+const (
+	CaseStatusOpen     = "open"     // Waiting for a moderator
+	CaseStatusClaimed  = "claimed"  // A moderator is reviewing it
+	CaseStatusResolved = "resolved" // Closed with an action
+)
+
+// Resolution actions
+const (
+	ActionDismiss     = "dismiss"      // No violation
+	ActionHideContent = "hide_content" // Take the post, comment or message down, or clear the user's bio
+	ActionWarn        = "warn"         // Notify the owner that the content broke the rules
+	ActionSuspend     = "suspend"      // Suspend the owner's account
+)
+
+// Outcomes shown to reporters; the specific action is not disclosed
+const (
+	OutcomePending     = "pending"
+	OutcomeNoViolation = "no_violation"
+	OutcomeActionTaken = "action_taken"
+)
+
+// Report is one user's report of a target
+type Report struct {
+	ID         string    `json:"id"`
+	CaseID     string    `json:"case_id"`
+	ReporterID string    `json:"reporter_id"`
+	Reason     string    `json:"reason"`
+	Details    string    `json:"details"`
+	CreatedAt  time.Time `json:"created_at"`
+
+	Case *Case `json:"-"` // Loaded by GetByReporter
+}
+
+// Case groups the reports about one target while moderators handle it
+type Case struct {
+	ID                string     `json:"id"`
+	TargetType        string     `json:"target_type"`
+	TargetID          string     `json:"target_id"`
+	TargetOwnerID     string     `json:"target_owner_id"`
+	TargetIsAnonymous bool       `json:"target_is_anonymous"`
+	ContentSnapshot   string     `json:"content_snapshot"`
+	ReportCount       int        `json:"report_count"`
+	Status            string     `json:"status"`
+	ClaimedBy         *string    `json:"claimed_by,omitempty"`
+	ClaimedAt         *time.Time `json:"claimed_at,omitempty"`
+	Action            *string    `json:"action,omitempty"`
+	ResolutionNote    *string    `json:"resolution_note,omitempty"`
+	ResolvedBy        *string    `json:"resolved_by,omitempty"`
+	ResolvedAt        *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// Target is the reported content as it was when the report was filed
+type Target struct {
+	OwnerID     string
+	Text        string
+	IsAnonymous bool // Anonymous post or comment
+}
+
+// Resolution is a moderator's decision on a case
+type Resolution struct {
+	Action         string
+	Note           string
+	SuspendedUntil *time.Time // Set for ActionSuspend
+}
+
+// Outcome returns what a reporter is told about the case
+func (c *Case) Outcome() string {
+	if c.Status != CaseStatusResolved || c.Action == nil {
+		return OutcomePending
+	}
+	if *c.Action == ActionDismiss {
+		return OutcomeNoViolation
+	}
+	return OutcomeActionTaken
+}
+
+// validReasons lists the accepted report reasons
+var validReasons = map[string]bool{
+	ReasonSpam:           true,
+	ReasonHarassment:     true,
+	ReasonHateSpeech:     true,
+	ReasonViolence:       true,
+	ReasonSexualContent:  true,
+	ReasonSelfHarm:       true,
+	ReasonMisinformation: true,
+	ReasonImpersonation:  true,
+	ReasonOther:          true,
+}
@@ -0,0 +1,39 @@
+package report
+
+import (
+	"context"
+	"time"
+)
+
+// ReportRepository defines the interface for report data operations
+type ReportRepository interface {
+	// GetTarget returns the owner, text and anonymity of a reportable target, or nil if it does not
+	// exist or the reporter cannot see it (posts and comments as in feeds, messages only to
+	// participants)
+	GetTarget(ctx context.Context, targetType, targetID, reporterID string) (*Target, error)
+
+	// HasReported checks whether the user already reported the target's open case
+	HasReported(ctx context.Context, targetType, targetID, reporterID string) (bool, error)
+
+	// Create adds a report to the target's open case, opening one if there is none
+	Create(ctx context.Context, report *Report, targetType, targetID string, target *Target) (*Case, error)
+
+	// GetByReporter retrieves a user's reports with their cases, newest first
+	GetByReporter(ctx context.Context, reporterID string, limit, offset int) ([]*Report, error)
+
+	// Cases
+	GetCase(ctx context.Context, caseID string) (*Case, error)
+	GetCases(ctx context.Context, status, targetType string, limit, offset int) ([]*Case, error)
+	GetCaseReports(ctx context.Context, caseID string) ([]*Report, error)
+
+	// Claim assigns an open case to a moderator. A case claimed by someone else can only be
+	// taken over once the claim is older than staleAfter. Returns pgx.ErrNoRows otherwise.
+	Claim(ctx context.Context, caseID, moderatorID string, staleAfter time.Duration) (*Case, error)
+
+	// Release puts a case claimed by the moderator back in the queue
+	Release(ctx context.Context, caseID, moderatorID string) (*Case, error)
+
+	// Resolve closes a case claimed by the moderator and applies the action.
+	// Returns pgx.ErrNoRows if the case is not claimed by the moderator.
+	Resolve(ctx context.Context, caseID, moderatorID string, resolution *Resolution) (*Case, error)
+}
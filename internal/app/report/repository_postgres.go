@@ -0,0 +1,417 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"mockhu-app-backend/internal/app/moderation"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresReportRepository implements ReportRepository for PostgreSQL
+type PostgresReportRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresReportRepository creates a new PostgreSQL report repository
+func NewPostgresReportRepository(pool *pgxpool.Pool) *PostgresReportRepository {
+	return &PostgresReportRepository{pool: pool}
+}
+
+// caseColumns are the report_cases columns read by scanCase
+const caseColumns = `
+	c.id, c.target_type, c.target_id, c.target_owner_id, c.target_is_anonymous, c.content_snapshot, c.report_count,
+	c.status, c.claimed_by, c.claimed_at, c.action, c.resolution_note, c.resolved_by, c.resolved_at,
+	c.created_at, c.updated_at
+`
+
+// GetTarget returns the owner, text and anonymity of a reportable target, or nil if it does not
+// exist or the reporter cannot see it. Posts and comments follow the feed visibility rules
+// (an inactive or blocked author hides them, anonymous or not); messages are only visible to
+// participants.
+func (r *PostgresReportRepository) GetTarget(ctx context.Context, targetType, targetID, reporterID string) (*Target, error) {
+	var query string
+	args := []interface{}{targetID}
+
+	switch targetType {
+	case TargetPost:
+		query = `
+			SELECT p.user_id, COALESCE(p.content, ''), p.is_anonymous
+			FROM posts p
+			JOIN users u ON u.id = p.user_id
+			WHERE p.id = $1 AND p.is_active = true AND p.status = 'published'
+			AND ` + visibleToViewer("u", "$2") + `
+		`
+		args = append(args, reporterID)
+	case TargetComment:
+		// The reporter must be able to see the post, and neither may have blocked the commenter
+		query = `
+			SELECT c.user_id, c.content, c.is_anonymous
+			FROM post_comments c
+			JOIN posts p ON p.id = c.post_id AND p.is_active = true AND p.status = 'published'
+			JOIN users u ON u.id = p.user_id
+			JOIN users cu ON cu.id = c.user_id AND cu.is_active = true
+			WHERE c.id = $1 AND c.is_active = true
+			AND ` + visibleToViewer("u", "$2") + `
+			AND ` + notBlocked("cu", "$2") + `
+		`
+		args = append(args, reporterID)
+	case TargetMessage:
+		query = `
+			SELECT m.sender_id, COALESCE(m.content, ''), false
+			FROM messages m
+			JOIN conversations c ON c.id = m.conversation_id
+			WHERE m.id = $1 AND m.is_deleted = false AND $2 IN (c.user1_id, c.user2_id)
+		`
+		args = append(args, reporterID)
+	case TargetUser:
+		query = `SELECT id, COALESCE(username, '') || E'\n' || COALESCE(bio, ''), false FROM users WHERE id = $1 AND is_active = true`
+	default:
+		return nil, fmt.Errorf("unknown target type %q", targetType)
+	}
+
+	target := &Target{}
+	err := r.pool.QueryRow(ctx, query, args...).Scan(&target.OwnerID, &target.Text, &target.IsAnonymous)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return target, nil
+}
+
+// HasReported checks whether the user already reported the target's open case
+func (r *PostgresReportRepository) HasReported(ctx context.Context, targetType, targetID, reporterID string) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1
+			FROM reports rp
+			JOIN report_cases c ON c.id = rp.case_id
+			WHERE c.target_type = $1 AND c.target_id = $2 AND c.status <> 'resolved' AND rp.reporter_id = $3
+		)
+	`
+
+	var exists bool
+	err := r.pool.QueryRow(ctx, query, targetType, targetID, reporterID).Scan(&exists)
+	return exists, err
+}
+
+// Create adds a report to the target's open case, opening one if there is none.
+// Returns pgx.ErrNoRows if the reporter already reported the open case.
+func (r *PostgresReportRepository) Create(ctx context.Context, report *Report, targetType, targetID string, target *Target) (*Case, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Join the open case for the target, or open one
+	err = tx.QueryRow(ctx, `
+		INSERT INTO report_cases (target_type, target_id, target_owner_id, target_is_anonymous, content_snapshot)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (target_type, target_id) WHERE status <> 'resolved' DO UPDATE
+		SET updated_at = NOW()
+		RETURNING id
+	`, targetType, targetID, target.OwnerID, target.IsAnonymous, target.Text).Scan(&report.CaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO reports (case_id, reporter_id, reason, details)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (case_id, reporter_id) DO NOTHING
+		RETURNING id, created_at
+	`, report.CaseID, report.ReporterID, report.Reason, report.Details).Scan(&report.ID, &report.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	reportCase, err := scanCase(tx.QueryRow(ctx, `
+		UPDATE report_cases c
+		SET report_count = c.report_count + 1
+		WHERE c.id = $1
+		RETURNING `+caseColumns, report.CaseID))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return reportCase, nil
+}
+
+// GetByReporter retrieves a user's reports with their cases, newest first
+func (r *PostgresReportRepository) GetByReporter(ctx context.Context, reporterID string, limit, offset int) ([]*Report, error) {
+	query := `
+		SELECT rp.id, rp.case_id, rp.reporter_id, rp.reason, rp.details, rp.created_at,` + caseColumns + `
+		FROM reports rp
+		JOIN report_cases c ON c.id = rp.case_id
+		WHERE rp.reporter_id = $1
+		ORDER BY rp.created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, reporterID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reports []*Report
+
+	for rows.Next() {
+		report := &Report{Case: &Case{}}
+		c := report.Case
+		err := rows.Scan(
+			&report.ID,
+			&report.CaseID,
+			&report.ReporterID,
+			&report.Reason,
+			&report.Details,
+			&report.CreatedAt,
+			&c.ID,
+			&c.TargetType,
+			&c.TargetID,
+			&c.TargetOwnerID,
+			&c.TargetIsAnonymous,
+			&c.ContentSnapshot,
+			&c.ReportCount,
+			&c.Status,
+			&c.ClaimedBy,
+			&c.ClaimedAt,
+			&c.Action,
+			&c.ResolutionNote,
+			&c.ResolvedBy,
+			&c.ResolvedAt,
+			&c.CreatedAt,
+			&c.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, rows.Err()
+}
+
+// GetCase retrieves a case by ID
+func (r *PostgresReportRepository) GetCase(ctx context.Context, caseID string) (*Case, error) {
+	reportCase, err := scanCase(r.pool.QueryRow(ctx, `SELECT `+caseColumns+` FROM report_cases c WHERE c.id = $1`, caseID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return reportCase, nil
+}
+
+// GetCases retrieves cases with a status, most reported first and then oldest first.
+// An empty targetType matches every target type.
+func (r *PostgresReportRepository) GetCases(ctx context.Context, status, targetType string, limit, offset int) ([]*Case, error) {
+	query := `
+		SELECT ` + caseColumns + `
+		FROM report_cases c
+		WHERE c.status = $1 AND ($2 = '' OR c.target_type = $2)
+		ORDER BY c.report_count DESC, c.created_at ASC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.pool.Query(ctx, query, status, targetType, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cases []*Case
+
+	for rows.Next() {
+		reportCase, err := scanCase(rows)
+		if err != nil {
+			return nil, err
+		}
+		cases = append(cases, reportCase)
+	}
+
+	return cases, rows.Err()
+}
+
+// GetCaseReports retrieves the reports in a case, oldest first
+func (r *PostgresReportRepository) GetCaseReports(ctx context.Context, caseID string) ([]*Report, error) {
+	query := `
+		SELECT id, case_id, reporter_id, reason, details, created_at
+		FROM reports
+		WHERE case_id = $1
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, caseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reports []*Report
+
+	for rows.Next() {
+		report := &Report{}
+		err := rows.Scan(
+			&report.ID,
+			&report.CaseID,
+			&report.ReporterID,
+			&report.Reason,
+			&report.Details,
+			&report.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, rows.Err()
+}
+
+// Claim assigns an open case to a moderator. A case claimed by someone else can only be
+// taken over once the claim is older than staleAfter. Returns pgx.ErrNoRows otherwise.
+func (r *PostgresReportRepository) Claim(ctx context.Context, caseID, moderatorID string, staleAfter time.Duration) (*Case, error) {
+	return scanCase(r.pool.QueryRow(ctx, `
+		UPDATE report_cases c
+		SET status = 'claimed', claimed_by = $2, claimed_at = NOW(), updated_at = NOW()
+		WHERE c.id = $1
+		  AND (c.status = 'open'
+		       OR (c.status = 'claimed' AND (c.claimed_by = $2 OR c.claimed_by IS NULL OR c.claimed_at < $3)))
+		RETURNING `+caseColumns, caseID, moderatorID, time.Now().Add(-staleAfter)))
+}
+
+// Release puts a case claimed by the moderator back in the queue.
+// Returns pgx.ErrNoRows if the case is not claimed by the moderator.
+func (r *PostgresReportRepository) Release(ctx context.Context, caseID, moderatorID string) (*Case, error) {
+	return scanCase(r.pool.QueryRow(ctx, `
+		UPDATE report_cases c
+		SET status = 'open', claimed_by = NULL, claimed_at = NULL, updated_at = NOW()
+		WHERE c.id = $1 AND c.status = 'claimed' AND c.claimed_by = $2
+		RETURNING `+caseColumns, caseID, moderatorID))
+}
+
+// Resolve closes a case claimed by the moderator and applies the action.
+// Returns pgx.ErrNoRows if the case is not claimed by the moderator.
+func (r *PostgresReportRepository) Resolve(ctx context.Context, caseID, moderatorID string, resolution *Resolution) (*Case, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	reportCase, err := scanCase(tx.QueryRow(ctx, `
+		UPDATE report_cases c
+		SET status = 'resolved', action = $3, resolution_note = NULLIF($4, ''),
+		    resolved_by = $2, resolved_at = NOW(), updated_at = NOW()
+		WHERE c.id = $1 AND c.status = 'claimed' AND c.claimed_by = $2
+		RETURNING `+caseColumns, caseID, moderatorID, resolution.Action, resolution.Note))
+	if err != nil {
+		return nil, err
+	}
+
+	switch resolution.Action {
+	case ActionHideContent:
+		contentType := reportCase.TargetType
+		if contentType == TargetUser {
+			contentType = moderation.ContentBio
+		}
+		if err := moderation.RemoveContent(ctx, tx, contentType, reportCase.TargetID, moderatorID); err != nil {
+			return nil, err
+		}
+
+		// The classifiers may have queued the same content; it has been reviewed now
+		_, err = tx.Exec(ctx, `
+			UPDATE moderation_queue
+			SET status = 'removed', reviewed_by = $3, reviewed_at = NOW()
+			WHERE content_type = $1 AND content_id = $2 AND status = 'pending'
+		`, contentType, reportCase.TargetID, moderatorID)
+		if err != nil {
+			return nil, err
+		}
+	case ActionSuspend:
+		// Never shorten an existing suspension
+		_, err = tx.Exec(ctx, `
+			UPDATE users
+			SET suspended_until = GREATEST(COALESCE(suspended_until, NOW()), $2), updated_at = NOW()
+			WHERE id = $1
+		`, reportCase.TargetOwnerID, resolution.SuspendedUntil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return reportCase, nil
+}
+
+// scanCase scans a row selected with caseColumns
+func scanCase(row pgx.Row) (*Case, error) {
+	c := &Case{}
+	err := row.Scan(
+		&c.ID,
+		&c.TargetType,
+		&c.TargetID,
+		&c.TargetOwnerID,
+		&c.TargetIsAnonymous,
+		&c.ContentSnapshot,
+		&c.ReportCount,
+		&c.Status,
+		&c.ClaimedBy,
+		&c.ClaimedAt,
+		&c.Action,
+		&c.ResolutionNote,
+		&c.ResolvedBy,
+		&c.ResolvedAt,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// visibleToViewer returns a SQL predicate that is true if the viewer bound to param can see
+// posts by the user aliased as alias: the author is active, their who_can_see_posts setting
+// allows it, and neither has blocked the other
+func visibleToViewer(alias, param string) string {
+	viewer := fmt.Sprintf("NULLIF(%s::text, '')::uuid", param)
+
+	return fmt.Sprintf(`%[2]s.is_active = true
+			AND (
+				%[2]s.who_can_see_posts = 'everyone'
+				OR %[2]s.id = %[1]s
+				OR (%[2]s.who_can_see_posts = 'followers' AND EXISTS (
+					SELECT 1 FROM user_follows vf
+					WHERE vf.follower_id = %[1]s AND vf.following_id = %[2]s.id
+				))
+			)
+			AND %[3]s`, viewer, alias, notBlocked(alias, param))
+}
+
+// notBlocked returns a SQL predicate that is true unless the user aliased as alias and the
+// viewer bound to param have blocked each other
+func notBlocked(alias, param string) string {
+	return fmt.Sprintf(`NOT EXISTS (
+			SELECT 1 FROM blocked_users vb
+			WHERE (vb.blocker_id = NULLIF(%[1]s::text, '')::uuid AND vb.blocked_id = %[2]s.id)
+			   OR (vb.blocker_id = %[2]s.id AND vb.blocked_id = NULLIF(%[1]s::text, '')::uuid)
+		)`, param, alias)
+}
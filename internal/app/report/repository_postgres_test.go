@@ -0,0 +1,232 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"mockhu-app-backend/internal/pkg/testdb"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// insertPost creates a published post and returns its ID
+func insertPost(t *testing.T, pool *pgxpool.Pool, userID string, anonymous bool) string {
+	t.Helper()
+
+	var id string
+	err := pool.QueryRow(context.Background(), `INSERT INTO posts (user_id, content, is_anonymous) VALUES ($1, 'reported post', $2) RETURNING id`,
+		userID, anonymous).Scan(&id)
+	if err != nil {
+		t.Fatalf("insert post: %v", err)
+	}
+	return id
+}
+
+// exec runs a statement, failing the test on error
+func exec(t *testing.T, pool *pgxpool.Pool, sql string, args ...interface{}) {
+	t.Helper()
+
+	if _, err := pool.Exec(context.Background(), sql, args...); err != nil {
+		t.Fatalf("exec %q: %v", sql, err)
+	}
+}
+
+func TestGetTargetChecksVisibility(t *testing.T) {
+	pool := testdb.Pool(t)
+	repo := NewPostgresReportRepository(pool)
+	ctx := context.Background()
+
+	reporterID := testdb.CreateUser(t, pool)
+	authorID := testdb.CreateUser(t, pool)
+	blockerID := testdb.CreateUser(t, pool)
+	deactivatedID := testdb.CreateUser(t, pool)
+	privateID := testdb.CreateUser(t, pool)
+
+	exec(t, pool, `INSERT INTO blocked_users (blocker_id, blocked_id) VALUES ($1, $2)`, blockerID, reporterID)
+	exec(t, pool, `UPDATE users SET is_active = false WHERE id = $1`, deactivatedID)
+	exec(t, pool, `UPDATE users SET who_can_see_posts = 'followers' WHERE id = $1`, privateID)
+
+	visiblePost := insertPost(t, pool, authorID, false)
+	blockerPost := insertPost(t, pool, blockerID, false)
+
+	comment := func(postID, userID string) string {
+		var id string
+		err := pool.QueryRow(ctx, `INSERT INTO post_comments (post_id, user_id, content) VALUES ($1, $2, 'a comment') RETURNING id`, postID, userID).
+			Scan(&id)
+		if err != nil {
+			t.Fatalf("insert comment: %v", err)
+		}
+		return id
+	}
+
+	tests := []struct {
+		name        string
+		targetType  string
+		targetID    string
+		wantVisible bool
+	}{
+		{"visible post", TargetPost, visiblePost, true},
+		{"anonymous post", TargetPost, insertPost(t, pool, authorID, true), true},
+		{"post by someone who blocked the reporter", TargetPost, blockerPost, false},
+		{"anonymous post by someone who blocked the reporter", TargetPost, insertPost(t, pool, blockerID, true), false},
+		{"post by a deactivated user", TargetPost, insertPost(t, pool, deactivatedID, false), false},
+		{"followers-only post", TargetPost, insertPost(t, pool, privateID, false), false},
+		{"comment", TargetComment, comment(visiblePost, authorID), true},
+		{"comment on a hidden post", TargetComment, comment(blockerPost, authorID), false},
+		{"comment by someone who blocked the reporter", TargetComment, comment(visiblePost, blockerID), false},
+		{"comment by a deactivated user", TargetComment, comment(visiblePost, deactivatedID), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := repo.GetTarget(ctx, tt.targetType, tt.targetID, reporterID)
+			if err != nil {
+				t.Fatalf("GetTarget: %v", err)
+			}
+			if (target != nil) != tt.wantVisible {
+				t.Errorf("GetTarget = %+v, want visible: %v", target, tt.wantVisible)
+			}
+		})
+	}
+}
+
+func TestReportCaseLifecycle(t *testing.T) {
+	pool := testdb.Pool(t)
+	repo := NewPostgresReportRepository(pool)
+	ctx := context.Background()
+
+	ownerID := testdb.CreateUser(t, pool)
+	reporters := []string{testdb.CreateUser(t, pool), testdb.CreateUser(t, pool)}
+	moderatorID := testdb.CreateUser(t, pool)
+	otherModeratorID := testdb.CreateUser(t, pool)
+
+	postID := insertPost(t, pool, ownerID, false)
+	target, err := repo.GetTarget(ctx, TargetPost, postID, reporters[0])
+	if err != nil || target == nil {
+		t.Fatalf("GetTarget = %+v, %v", target, err)
+	}
+
+	// Reports about the same target join one case, once per reporter
+	var caseID string
+	for _, reporterID := range reporters {
+		reportCase, err := repo.Create(ctx, &Report{ReporterID: reporterID, Reason: ReasonSpam}, TargetPost, postID, target)
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if caseID != "" && reportCase.ID != caseID {
+			t.Errorf("second report opened case %s, want it to join %s", reportCase.ID, caseID)
+		}
+		caseID = reportCase.ID
+	}
+	if _, err := repo.Create(ctx, &Report{ReporterID: reporters[0], Reason: ReasonOther}, TargetPost, postID, target); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("Create a repeated report: err = %v, want pgx.ErrNoRows", err)
+	}
+	if reported, err := repo.HasReported(ctx, TargetPost, postID, reporters[0]); err != nil || !reported {
+		t.Errorf("HasReported = %v, %v; want true", reported, err)
+	}
+	reportCase, err := repo.GetCase(ctx, caseID)
+	if err != nil || reportCase.ReportCount != 2 {
+		t.Fatalf("GetCase = %+v, %v; want 2 reports", reportCase, err)
+	}
+
+	// One moderator at a time, until the claim goes stale
+	if _, err := repo.Claim(ctx, caseID, moderatorID, time.Hour); err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+	if _, err := repo.Claim(ctx, caseID, otherModeratorID, time.Hour); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("Claim of a claimed case: err = %v, want pgx.ErrNoRows", err)
+	}
+	if _, err := repo.Release(ctx, caseID, otherModeratorID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("Release by another moderator: err = %v, want pgx.ErrNoRows", err)
+	}
+	if _, err := repo.Resolve(ctx, caseID, otherModeratorID, &Resolution{Action: ActionDismiss}); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("Resolve by another moderator: err = %v, want pgx.ErrNoRows", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if _, err := repo.Claim(ctx, caseID, otherModeratorID, time.Millisecond); err != nil {
+		t.Fatalf("Claim of a stale claim: %v", err)
+	}
+
+	resolved, err := repo.Resolve(ctx, caseID, otherModeratorID, &Resolution{Action: ActionHideContent, Note: "spam"})
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if resolved.Status != CaseStatusResolved || resolved.Action == nil || *resolved.Action != ActionHideContent {
+		t.Errorf("Resolve = %+v, want resolved with hide_content", resolved)
+	}
+
+	// The post is taken down, so it can't be reported again
+	var active, removed bool
+	err = pool.QueryRow(ctx, `SELECT is_active, removed_by_moderation FROM posts WHERE id = $1`, postID).Scan(&active, &removed)
+	if err != nil {
+		t.Fatalf("get post: %v", err)
+	}
+	if active || !removed {
+		t.Errorf("post active = %v, removed by moderation = %v; want taken down", active, removed)
+	}
+	if target, err := repo.GetTarget(ctx, TargetPost, postID, reporters[0]); err != nil || target != nil {
+		t.Errorf("GetTarget after hide_content = %+v, %v; want nil", target, err)
+	}
+}
+
+func TestResolveWarnAndSuspend(t *testing.T) {
+	pool := testdb.Pool(t)
+	repo := NewPostgresReportRepository(pool)
+	ctx := context.Background()
+
+	ownerID := testdb.CreateUser(t, pool)
+	reporterID := testdb.CreateUser(t, pool)
+	moderatorID := testdb.CreateUser(t, pool)
+
+	// resolve files a report on the owner's profile and resolves it with resolution
+	resolve := func(resolution *Resolution) {
+		t.Helper()
+
+		target, err := repo.GetTarget(ctx, TargetUser, ownerID, reporterID)
+		if err != nil || target == nil {
+			t.Fatalf("GetTarget = %+v, %v", target, err)
+		}
+		reportCase, err := repo.Create(ctx, &Report{ReporterID: reporterID, Reason: ReasonHarassment}, TargetUser, ownerID, target)
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if _, err := repo.Claim(ctx, reportCase.ID, moderatorID, time.Hour); err != nil {
+			t.Fatalf("Claim: %v", err)
+		}
+		if _, err := repo.Resolve(ctx, reportCase.ID, moderatorID, resolution); err != nil {
+			t.Fatalf("Resolve: %v", err)
+		}
+	}
+
+	suspendedUntil := func() *time.Time {
+		t.Helper()
+
+		var until *time.Time
+		if err := pool.QueryRow(ctx, `SELECT suspended_until FROM users WHERE id = $1`, ownerID).Scan(&until); err != nil {
+			t.Fatalf("get suspension: %v", err)
+		}
+		return until
+	}
+
+	// A warning leaves the account alone
+	resolve(&Resolution{Action: ActionWarn})
+	if until := suspendedUntil(); until != nil {
+		t.Errorf("warned user suspended until %v", until)
+	}
+
+	long := time.Now().Add(30 * 24 * time.Hour)
+	resolve(&Resolution{Action: ActionSuspend, SuspendedUntil: &long})
+	if until := suspendedUntil(); until == nil || until.Before(long.Add(-time.Hour)) {
+		t.Errorf("suspended until %v, want about %v", until, long)
+	}
+
+	// A shorter suspension doesn't cut the longer one short
+	short := time.Now().Add(24 * time.Hour)
+	resolve(&Resolution{Action: ActionSuspend, SuspendedUntil: &short})
+	if until := suspendedUntil(); until == nil || until.Before(long.Add(-time.Hour)) {
+		t.Errorf("suspended until %v after a shorter suspension, want the longer one kept", until)
+	}
+}
@@ -0,0 +1,27 @@
+package report
+
+import (
+	"mockhu-app-backend/internal/app/moderation"
+	"mockhu-app-backend/internal/pkg/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterRoutes registers the report routes and the moderator case queue
+func RegisterRoutes(app *fiber.App, handler *Handler, moderationService moderation.ModerationService) {
+	v1 := app.Group("/v1")
+
+	auth := middleware.AuthMiddleware()
+	moderatorOnly := moderation.RequireModerator(moderationService)
+
+	// Any user can report and follow up on their own reports
+	v1.Post("/reports", auth, handler.CreateReport)
+	v1.Get("/reports/mine", auth, handler.GetMyReports)
+
+	// Case queue (moderators only)
+	v1.Get("/reports/cases", auth, moderatorOnly, handler.GetCases)
+	v1.Get("/reports/cases/:caseId", auth, moderatorOnly, handler.GetCase)
+	v1.Post("/reports/cases/:caseId/claim", auth, moderatorOnly, handler.ClaimCase)
+	v1.Delete("/reports/cases/:caseId/claim", auth, moderatorOnly, handler.ReleaseCase)
+	v1.Post("/reports/cases/:caseId/resolve", auth, moderatorOnly, handler.ResolveCase)
+}
@@ -0,0 +1,431 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"mockhu-app-backend/internal/app/anonymity"
+	"mockhu-app-backend/internal/app/notification"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// Review tuning
+const (
+	ClaimTimeout       = 30 * time.Minute // A claim older than this can be taken over by another moderator
+	maxDetailsLength   = 1000
+	defaultSuspendDays = 7
+	maxSuspendDays     = 365
+)
+
+// Errors
+var (
+	ErrInvalidTargetType = errors.New("target_type must be post, comment, message or user")
+	ErrInvalidTargetID   = errors.New("invalid target ID")
+	ErrInvalidReason     = errors.New("invalid reason")
+	ErrDetailsTooLong    = errors.New("details must be at most 1000 characters")
+	ErrTargetNotFound    = errors.New("reported content not found")
+	ErrCannotReportSelf  = errors.New("cannot report your own content")
+	ErrAlreadyReported   = errors.New("you have already reported this")
+	ErrCaseNotFound      = errors.New("case not found")
+	ErrCaseNotClaimable  = errors.New("case is resolved or claimed by another moderator")
+	ErrCaseNotClaimed    = errors.New("case is not claimed by you")
+	ErrInvalidStatus     = errors.New("status must be open, claimed or resolved")
+	ErrInvalidAction     = errors.New("action must be dismiss, hide_content, warn or suspend")
+	ErrInvalidSuspension = errors.New("suspend_days must be between 1 and 365")
+)
+
+// ReportService defines the business logic for user reports and their review
+type ReportService interface {
+	// Reporting
+	CreateReport(ctx context.Context, reporterID string, req *CreateReportRequest) (*ReportResponse, error)
+	GetMyReports(ctx context.Context, reporterID string, page, limit int) (*ReportListResponse, error)
+
+	// Case queue (moderators only)
+	GetCases(ctx context.Context, status, targetType string, page, limit int) (*CaseListResponse, error)
+	GetCase(ctx context.Context, caseID string) (*CaseResponse, error)
+	ClaimCase(ctx context.Context, caseID, moderatorID string) (*CaseResponse, error)
+	ReleaseCase(ctx context.Context, caseID, moderatorID string) (*CaseResponse, error)
+	ResolveCase(ctx context.Context, caseID, moderatorID string, req *ResolveCaseRequest) (*CaseResponse, error)
+}
+
+// reportService implements ReportService
+type reportService struct {
+	reportRepo          ReportRepository
+	notificationService notification.NotificationService
+	anonymityService    anonymity.AnonymityService
+}
+
+// NewService creates a new report service
+func NewService(reportRepo ReportRepository, notificationService notification.NotificationService, anonymityService anonymity.AnonymityService) ReportService {
+	return &reportService{
+		reportRepo:          reportRepo,
+		notificationService: notificationService,
+		anonymityService:    anonymityService,
+	}
+}
+
+// CreateReport files a report. Reports about the same target are grouped into one open
+// case; a user can report a target once per case.
+func (s *reportService) CreateReport(ctx context.Context, reporterID string, req *CreateReportRequest) (*ReportResponse, error) {
+	// Validate request
+	if req.TargetType != TargetPost && req.TargetType != TargetComment && req.TargetType != TargetMessage && req.TargetType != TargetUser {
+		return nil, ErrInvalidTargetType
+	}
+	if _, err := uuid.Parse(req.TargetID); err != nil {
+		return nil, ErrInvalidTargetID
+	}
+	if !validReasons[req.Reason] {
+		return nil, ErrInvalidReason
+	}
+	details := strings.TrimSpace(req.Details)
+	if utf8.RuneCountInString(details) > maxDetailsLength {
+		return nil, ErrDetailsTooLong
+	}
+
+	target, err := s.reportRepo.GetTarget(ctx, req.TargetType, req.TargetID, reporterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reported content: %w", err)
+	}
+	if target == nil {
+		return nil, ErrTargetNotFound
+	}
+	if target.OwnerID == reporterID {
+		return nil, ErrCannotReportSelf
+	}
+
+	// Deduplicate repeated reports from the same user
+	hasReported, err := s.reportRepo.HasReported(ctx, req.TargetType, req.TargetID, reporterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing report: %w", err)
+	}
+	if hasReported {
+		return nil, ErrAlreadyReported
+	}
+
+	report := &Report{
+		ReporterID: reporterID,
+		Reason:     req.Reason,
+		Details:    details,
+	}
+
+	reportCase, err := s.reportRepo.Create(ctx, report, req.TargetType, req.TargetID, target)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAlreadyReported
+		}
+		return nil, fmt.Errorf("failed to create report: %w", err)
+	}
+
+	report.Case = reportCase
+	return toReportResponse(report), nil
+}
+
+// GetMyReports retrieves the reports a user filed with their outcomes, newest first
+func (s *reportService) GetMyReports(ctx context.Context, reporterID string, page, limit int) (*ReportListResponse, error) {
+	// Validate pagination
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 20
+	}
+
+	offset := (page - 1) * limit
+
+	reports, err := s.reportRepo.GetByReporter(ctx, reporterID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reports: %w", err)
+	}
+
+	responses := make([]*ReportResponse, 0, len(reports))
+	for _, report := range reports {
+		responses = append(responses, toReportResponse(report))
+	}
+
+	// Calculate total pages (simplified)
+	totalPages := 1
+	if len(responses) == limit {
+		totalPages = page + 1
+	}
+
+	return &ReportListResponse{
+		Reports: responses,
+		Pagination: PaginationInfo{
+			Page:       page,
+			TotalPages: totalPages,
+			TotalItems: len(responses),
+			Limit:      limit,
+		},
+	}, nil
+}
+
+// GetCases retrieves cases with a status (open by default), most reported first
+func (s *reportService) GetCases(ctx context.Context, status, targetType string, page, limit int) (*CaseListResponse, error) {
+	if status == "" {
+		status = CaseStatusOpen
+	}
+	if status != CaseStatusOpen && status != CaseStatusClaimed && status != CaseStatusResolved {
+		return nil, ErrInvalidStatus
+	}
+	if targetType != "" && targetType != TargetPost && targetType != TargetComment && targetType != TargetMessage && targetType != TargetUser {
+		return nil, ErrInvalidTargetType
+	}
+
+	// Validate pagination
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 20
+	}
+
+	offset := (page - 1) * limit
+
+	cases, err := s.reportRepo.GetCases(ctx, status, targetType, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cases: %w", err)
+	}
+
+	responses := make([]*CaseResponse, 0, len(cases))
+	for _, reportCase := range cases {
+		responses = append(responses, toCaseResponse(reportCase))
+	}
+
+	// Calculate total pages (simplified)
+	totalPages := 1
+	if len(responses) == limit {
+		totalPages = page + 1
+	}
+
+	return &CaseListResponse{
+		Cases: responses,
+		Pagination: PaginationInfo{
+			Page:       page,
+			TotalPages: totalPages,
+			TotalItems: len(responses),
+			Limit:      limit,
+		},
+	}, nil
+}
+
+// GetCase retrieves a case with all of its reports
+func (s *reportService) GetCase(ctx context.Context, caseID string) (*CaseResponse, error) {
+	reportCase, err := s.reportRepo.GetCase(ctx, caseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get case: %w", err)
+	}
+	if reportCase == nil {
+		return nil, ErrCaseNotFound
+	}
+
+	reports, err := s.reportRepo.GetCaseReports(ctx, caseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get case reports: %w", err)
+	}
+
+	response := toCaseResponse(reportCase)
+	response.Reports = make([]*CaseReport, 0, len(reports))
+	for _, report := range reports {
+		response.Reports = append(response.Reports, &CaseReport{
+			ID:         report.ID,
+			ReporterID: report.ReporterID,
+			Reason:     report.Reason,
+			Details:    report.Details,
+			CreatedAt:  report.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return response, nil
+}
+
+// ClaimCase assigns a case to the moderator so two moderators don't review it at once
+func (s *reportService) ClaimCase(ctx context.Context, caseID, moderatorID string) (*CaseResponse, error) {
+	reportCase, err := s.reportRepo.Claim(ctx, caseID, moderatorID, ClaimTimeout)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, s.caseError(ctx, caseID, ErrCaseNotClaimable)
+		}
+		return nil, fmt.Errorf("failed to claim case: %w", err)
+	}
+
+	return toCaseResponse(reportCase), nil
+}
+
+// ReleaseCase puts a case the moderator claimed back in the queue
+func (s *reportService) ReleaseCase(ctx context.Context, caseID, moderatorID string) (*CaseResponse, error) {
+	reportCase, err := s.reportRepo.Release(ctx, caseID, moderatorID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, s.caseError(ctx, caseID, ErrCaseNotClaimed)
+		}
+		return nil, fmt.Errorf("failed to release case: %w", err)
+	}
+
+	return toCaseResponse(reportCase), nil
+}
+
+// ResolveCase closes a case the moderator claimed, applies the action and tells the
+// reporters the outcome
+func (s *reportService) ResolveCase(ctx context.Context, caseID, moderatorID string, req *ResolveCaseRequest) (*CaseResponse, error) {
+	resolution := &Resolution{
+		Action: req.Action,
+		Note:   strings.TrimSpace(req.Note),
+	}
+
+	switch req.Action {
+	case ActionDismiss, ActionHideContent, ActionWarn:
+	case ActionSuspend:
+		days := req.SuspendDays
+		if days == 0 {
+			days = defaultSuspendDays
+		}
+		if days < 1 || days > maxSuspendDays {
+			return nil, ErrInvalidSuspension
+		}
+		until := time.Now().AddDate(0, 0, days)
+		resolution.SuspendedUntil = &until
+	default:
+		return nil, ErrInvalidAction
+	}
+
+	reportCase, err := s.reportRepo.Resolve(ctx, caseID, moderatorID, resolution)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, s.caseError(ctx, caseID, ErrCaseNotClaimed)
+		}
+		return nil, fmt.Errorf("failed to resolve case: %w", err)
+	}
+
+	if req.Action == ActionWarn {
+		s.notifyWarning(ctx, reportCase)
+	}
+	if req.Action != ActionDismiss && reportCase.TargetIsAnonymous {
+		s.recordAnonymityStrike(ctx, reportCase, moderatorID)
+	}
+	s.notifyReporters(ctx, reportCase)
+
+	return toCaseResponse(reportCase), nil
+}
+
+// Helper methods
+
+// caseError returns ErrCaseNotFound if the case does not exist, or err otherwise
+func (s *reportService) caseError(ctx context.Context, caseID string, err error) error {
+	reportCase, getErr := s.reportRepo.GetCase(ctx, caseID)
+	if getErr == nil && reportCase == nil {
+		return ErrCaseNotFound
+	}
+	return err
+}
+
+// notifyWarning tells the owner of the reported content that a moderator warned them.
+// The notification has no actor and does not say who reported them.
+func (s *reportService) notifyWarning(ctx context.Context, reportCase *Case) {
+	n := &notification.Notification{
+		UserID: reportCase.TargetOwnerID,
+		Type:   notification.TypeModerationWarning,
+	}
+	switch reportCase.TargetType {
+	case TargetPost:
+		n.PostID = &reportCase.TargetID
+	case TargetComment:
+		n.CommentID = &reportCase.TargetID
+	}
+
+	if err := s.notificationService.Notify(ctx, n); err != nil {
+		log.Printf("⚠️ Failed to notify user %s of moderation warning: %v", reportCase.TargetOwnerID, err)
+	}
+}
+
+// recordAnonymityStrike counts an upheld report on anonymous content against the author;
+// repeated strikes revoke their anonymous posting
+func (s *reportService) recordAnonymityStrike(ctx context.Context, reportCase *Case, moderatorID string) {
+	contentType := anonymity.ContentPost
+	if reportCase.TargetType == TargetComment {
+		contentType = anonymity.ContentComment
+	}
+
+	err := s.anonymityService.RecordStrike(ctx, reportCase.TargetOwnerID, contentType, reportCase.TargetID, moderatorID, anonymity.StrikeSourceReport)
+	if err != nil {
+		log.Printf("⚠️ Failed to record anonymity strike for case %s: %v", reportCase.ID, err)
+	}
+}
+
+// notifyReporters tells everyone who reported the target that their report was reviewed;
+// GetMyReports shows them the outcome
+func (s *reportService) notifyReporters(ctx context.Context, reportCase *Case) {
+	reports, err := s.reportRepo.GetCaseReports(ctx, reportCase.ID)
+	if err != nil {
+		log.Printf("⚠️ Failed to get reporters for case %s: %v", reportCase.ID, err)
+		return
+	}
+
+	for _, report := range reports {
+		reportID := report.ID
+		err := s.notificationService.Notify(ctx, &notification.Notification{
+			UserID:   report.ReporterID,
+			Type:     notification.TypeReportResolved,
+			ReportID: &reportID,
+		})
+		if err != nil {
+			log.Printf("⚠️ Failed to notify reporter %s: %v", report.ReporterID, err)
+		}
+	}
+}
+
+// toReportResponse converts a report loaded with its case to its response DTO
+func toReportResponse(report *Report) *ReportResponse {
+	return &ReportResponse{
+		ID:         report.ID,
+		TargetType: report.Case.TargetType,
+		TargetID:   report.Case.TargetID,
+		Reason:     report.Reason,
+		Details:    report.Details,
+		Outcome:    report.Case.Outcome(),
+		CreatedAt:  report.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// toCaseResponse converts a case to its response DTO
+func toCaseResponse(reportCase *Case) *CaseResponse {
+	response := &CaseResponse{
+		ID:                reportCase.ID,
+		TargetType:        reportCase.TargetType,
+		TargetID:          reportCase.TargetID,
+		TargetOwnerID:     reportCase.TargetOwnerID,
+		TargetIsAnonymous: reportCase.TargetIsAnonymous,
+		ContentSnapshot:   reportCase.ContentSnapshot,
+		ReportCount:       reportCase.ReportCount,
+		Status:            reportCase.Status,
+		ClaimedBy:         reportCase.ClaimedBy,
+		ClaimedAt:         formatTime(reportCase.ClaimedAt),
+		Action:            reportCase.Action,
+		ResolutionNote:    reportCase.ResolutionNote,
+		ResolvedBy:        reportCase.ResolvedBy,
+		ResolvedAt:        formatTime(reportCase.ResolvedAt),
+		CreatedAt:         reportCase.CreatedAt.Format(time.RFC3339),
+	}
+
+	// Moderators reveal anonymous authors through the audited unmask endpoint only
+	if reportCase.TargetIsAnonymous {
+		response.TargetOwnerID = ""
+	}
+
+	return response
+}
+
+// formatTime formats an optional timestamp as RFC3339
+func formatTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format(time.RFC3339)
+	return &formatted
+}
@@ -0,0 +1,261 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"mockhu-app-backend/internal/app/anonymity"
+	"mockhu-app-backend/internal/app/notification"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// fakeReportRepo serves fixed targets and a single case, recording resolutions
+type fakeReportRepo struct {
+	ReportRepository
+
+	targets   map[string]*Target // Target ID -> target visible to the reporter
+	reported  map[string]bool    // Reporter IDs that already reported the open case
+	createErr error
+	created   []*Report
+
+	reportCase *Case
+	reports    []*Report
+	resolution *Resolution
+}
+
+func (r *fakeReportRepo) GetTarget(ctx context.Context, targetType, targetID, reporterID string) (*Target, error) {
+	return r.targets[targetID], nil
+}
+
+func (r *fakeReportRepo) HasReported(ctx context.Context, targetType, targetID, reporterID string) (bool, error) {
+	return r.reported[reporterID], nil
+}
+
+func (r *fakeReportRepo) Create(ctx context.Context, report *Report, targetType, targetID string, target *Target) (*Case, error) {
+	if r.createErr != nil {
+		return nil, r.createErr
+	}
+	r.created = append(r.created, report)
+	return &Case{ID: "case", TargetType: targetType, TargetID: targetID, Status: CaseStatusOpen}, nil
+}
+
+func (r *fakeReportRepo) GetCase(ctx context.Context, caseID string) (*Case, error) {
+	if r.reportCase == nil || caseID != r.reportCase.ID {
+		return nil, nil
+	}
+	return r.reportCase, nil
+}
+
+func (r *fakeReportRepo) GetCaseReports(ctx context.Context, caseID string) ([]*Report, error) {
+	return r.reports, nil
+}
+
+func (r *fakeReportRepo) Claim(ctx context.Context, caseID, moderatorID string, staleAfter time.Duration) (*Case, error) {
+	if r.reportCase == nil || caseID != r.reportCase.ID || r.reportCase.Status == CaseStatusResolved {
+		return nil, pgx.ErrNoRows
+	}
+	if r.reportCase.ClaimedBy != nil && *r.reportCase.ClaimedBy != moderatorID {
+		return nil, pgx.ErrNoRows
+	}
+	r.reportCase.Status = CaseStatusClaimed
+	r.reportCase.ClaimedBy = &moderatorID
+	return r.reportCase, nil
+}
+
+func (r *fakeReportRepo) Resolve(ctx context.Context, caseID, moderatorID string, resolution *Resolution) (*Case, error) {
+	if r.reportCase == nil || caseID != r.reportCase.ID || r.reportCase.ClaimedBy == nil || *r.reportCase.ClaimedBy != moderatorID {
+		return nil, pgx.ErrNoRows
+	}
+	r.resolution = resolution
+	r.reportCase.Status = CaseStatusResolved
+	r.reportCase.Action = &resolution.Action
+	return r.reportCase, nil
+}
+
+// fakeNotificationService records notifications
+type fakeNotificationService struct {
+	notification.NotificationService
+
+	sent []*notification.Notification
+}
+
+func (s *fakeNotificationService) Notify(ctx context.Context, n *notification.Notification) error {
+	s.sent = append(s.sent, n)
+	return nil
+}
+
+// fakeAnonymityService records strikes
+type fakeAnonymityService struct {
+	anonymity.AnonymityService
+
+	strikes []string // Content IDs
+}
+
+func (s *fakeAnonymityService) RecordStrike(ctx context.Context, userID, contentType, contentID, moderatorID, source string) error {
+	s.strikes = append(s.strikes, contentID)
+	return nil
+}
+
+func newTestService(repo *fakeReportRepo) (*reportService, *fakeNotificationService, *fakeAnonymityService) {
+	notifications := &fakeNotificationService{}
+	anonymityService := &fakeAnonymityService{}
+	return NewService(repo, notifications, anonymityService).(*reportService), notifications, anonymityService
+}
+
+func TestCreateReport(t *testing.T) {
+	visibleID := uuid.NewString()
+	ownID := uuid.NewString()
+
+	tests := []struct {
+		name      string
+		req       CreateReportRequest
+		reported  bool
+		createErr error
+		wantErr   error
+	}{
+		{name: "report", req: CreateReportRequest{TargetType: TargetPost, TargetID: visibleID, Reason: ReasonSpam}},
+		{name: "unknown target type", req: CreateReportRequest{TargetType: "story", TargetID: visibleID, Reason: ReasonSpam}, wantErr: ErrInvalidTargetType},
+		{name: "invalid target ID", req: CreateReportRequest{TargetType: TargetPost, TargetID: "nope", Reason: ReasonSpam}, wantErr: ErrInvalidTargetID},
+		{name: "unknown reason", req: CreateReportRequest{TargetType: TargetPost, TargetID: visibleID, Reason: "boring"}, wantErr: ErrInvalidReason},
+		// Hidden, deleted and nonexistent targets look the same
+		{name: "target not visible", req: CreateReportRequest{TargetType: TargetPost, TargetID: uuid.NewString(), Reason: ReasonSpam}, wantErr: ErrTargetNotFound},
+		{name: "own content", req: CreateReportRequest{TargetType: TargetPost, TargetID: ownID, Reason: ReasonSpam}, wantErr: ErrCannotReportSelf},
+		{name: "already reported", req: CreateReportRequest{TargetType: TargetPost, TargetID: visibleID, Reason: ReasonSpam}, reported: true, wantErr: ErrAlreadyReported},
+		{name: "reported concurrently", req: CreateReportRequest{TargetType: TargetPost, TargetID: visibleID, Reason: ReasonSpam}, createErr: pgx.ErrNoRows, wantErr: ErrAlreadyReported},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeReportRepo{
+				targets: map[string]*Target{
+					visibleID: {OwnerID: "owner", Text: "spam spam spam"},
+					ownID:     {OwnerID: "reporter", Text: "my post"},
+				},
+				reported:  map[string]bool{"reporter": tt.reported},
+				createErr: tt.createErr,
+			}
+			s, _, _ := newTestService(repo)
+
+			resp, err := s.CreateReport(context.Background(), "reporter", &tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateReport: err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if len(repo.created) != 0 {
+					t.Errorf("created %d reports, want none", len(repo.created))
+				}
+				return
+			}
+			if resp.Outcome != OutcomePending || len(repo.created) != 1 {
+				t.Errorf("CreateReport = %+v with %d reports created, want one pending report", resp, len(repo.created))
+			}
+		})
+	}
+}
+
+func TestClaimCase(t *testing.T) {
+	other := "other-moderator"
+	repo := &fakeReportRepo{reportCase: &Case{ID: "case", Status: CaseStatusClaimed, ClaimedBy: &other}}
+	s, _, _ := newTestService(repo)
+	ctx := context.Background()
+
+	if _, err := s.ClaimCase(ctx, "case", "moderator"); !errors.Is(err, ErrCaseNotClaimable) {
+		t.Errorf("ClaimCase of a case claimed by someone else: err = %v, want ErrCaseNotClaimable", err)
+	}
+	if _, err := s.ClaimCase(ctx, "missing", "moderator"); !errors.Is(err, ErrCaseNotFound) {
+		t.Errorf("ClaimCase of a missing case: err = %v, want ErrCaseNotFound", err)
+	}
+	if _, err := s.ResolveCase(ctx, "case", "moderator", &ResolveCaseRequest{Action: ActionDismiss}); !errors.Is(err, ErrCaseNotClaimed) {
+		t.Errorf("ResolveCase of a case claimed by someone else: err = %v, want ErrCaseNotClaimed", err)
+	}
+}
+
+func TestResolveCase(t *testing.T) {
+	tests := []struct {
+		name          string
+		req           ResolveCaseRequest
+		anonymous     bool
+		wantErr       error
+		wantSuspended time.Duration // How long the owner is suspended for, 0 if not
+		wantWarning   bool
+		wantStrike    bool
+	}{
+		{name: "dismiss", req: ResolveCaseRequest{Action: ActionDismiss}},
+		{name: "dismiss anonymous", req: ResolveCaseRequest{Action: ActionDismiss}, anonymous: true},
+		{name: "hide content", req: ResolveCaseRequest{Action: ActionHideContent}},
+		{name: "hide anonymous content", req: ResolveCaseRequest{Action: ActionHideContent}, anonymous: true, wantStrike: true},
+		{name: "warn", req: ResolveCaseRequest{Action: ActionWarn}, wantWarning: true},
+		{name: "suspend", req: ResolveCaseRequest{Action: ActionSuspend}, wantSuspended: defaultSuspendDays * 24 * time.Hour},
+		{name: "suspend for 30 days", req: ResolveCaseRequest{Action: ActionSuspend, SuspendDays: 30}, wantSuspended: 30 * 24 * time.Hour},
+		{name: "suspend too long", req: ResolveCaseRequest{Action: ActionSuspend, SuspendDays: maxSuspendDays + 1}, wantErr: ErrInvalidSuspension},
+		{name: "unknown action", req: ResolveCaseRequest{Action: "ban"}, wantErr: ErrInvalidAction},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			moderator := "moderator"
+			repo := &fakeReportRepo{
+				reportCase: &Case{
+					ID:                "case",
+					TargetType:        TargetPost,
+					TargetID:          "post",
+					TargetOwnerID:     "owner",
+					TargetIsAnonymous: tt.anonymous,
+					Status:            CaseStatusClaimed,
+					ClaimedBy:         &moderator,
+				},
+				reports: []*Report{{ID: "r1", ReporterID: "reporter-1"}, {ID: "r2", ReporterID: "reporter-2"}},
+			}
+			s, notifications, anonymityService := newTestService(repo)
+
+			resp, err := s.ResolveCase(context.Background(), "case", moderator, &tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ResolveCase: err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if repo.resolution != nil {
+					t.Errorf("resolved with %+v, want the case left alone", repo.resolution)
+				}
+				return
+			}
+
+			if resp.Status != CaseStatusResolved || repo.resolution.Action != tt.req.Action {
+				t.Errorf("case %s with action %s, want resolved with %s", resp.Status, repo.resolution.Action, tt.req.Action)
+			}
+
+			until := repo.resolution.SuspendedUntil
+			switch {
+			case tt.wantSuspended == 0 && until != nil:
+				t.Errorf("owner suspended until %v, want no suspension", until)
+			case tt.wantSuspended != 0 && (until == nil || time.Until(*until) < tt.wantSuspended-time.Minute || time.Until(*until) > tt.wantSuspended):
+				t.Errorf("owner suspended until %v, want %v from now", until, tt.wantSuspended)
+			}
+
+			// Every reporter hears the outcome; a warning goes to the owner
+			var warned bool
+			resolved := make(map[string]bool)
+			for _, n := range notifications.sent {
+				switch n.Type {
+				case notification.TypeModerationWarning:
+					warned = n.UserID == "owner" && n.ActorID == nil && n.PostID != nil && *n.PostID == "post"
+				case notification.TypeReportResolved:
+					resolved[n.UserID] = true
+				}
+			}
+			if warned != tt.wantWarning {
+				t.Errorf("owner warned = %v, want %v", warned, tt.wantWarning)
+			}
+			if !resolved["reporter-1"] || !resolved["reporter-2"] {
+				t.Errorf("reporters notified: %v, want both", resolved)
+			}
+
+			if got := len(anonymityService.strikes) == 1; got != tt.wantStrike {
+				t.Errorf("anonymity strikes = %v, want a strike: %v", anonymityService.strikes, tt.wantStrike)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package search
+
+// SearchResponse is the response for a search. Only the list for the searched type is
+// set (the others are null), except for type "all" which returns a few results of every type.
+type SearchResponse struct {
+	Query      string            `json:"query"`
+	Type       string            `json:"type"`
+	Posts      []*PostResult     `json:"posts"`
+	Users      []*UserResult     `json:"users"`
+	Hashtags   []*HashtagResult  `json:"hashtags"`
+	Interests  []*InterestResult `json:"interests"`
+	HasMore    bool              `json:"has_more"`
+	NextCursor string            `json:"next_cursor,omitempty"` // Pass as ?cursor= for the next page
+}
+
+// AuthorInfo contains author information for a post result. For anonymous posts only
+// FirstName is set, to the author's pseudonym in the thread.
+type AuthorInfo struct {
+	ID          string `json:"id"`
+	Username    string `json:"username"`
+	FirstName   string `json:"first_name"`
+	AvatarURL   string `json:"avatar_url"`
+	IsAnonymous bool   `json:"is_anonymous,omitempty"`
+}
+
+// PostResult is a post matching a search. Snippets are HTML-escaped with the matching
+// terms wrapped in <mark> tags.
+type PostResult struct {
+	ID        string     `json:"id"`
+	Author    AuthorInfo `json:"author"`
+	Type      string     `json:"type"`
+	Content   string     `json:"content"`
+	Snippet   string     `json:"snippet"`
+	CreatedAt string     `json:"created_at"`
+}
+
+// UserResult is a user matching a search
+type UserResult struct {
+	ID        string `json:"id"`
+	Username  string `json:"username"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	AvatarURL string `json:"avatar_url"`
+	Snippet   string `json:"snippet,omitempty"` // Bio excerpt
+}
+
+// HashtagResult is a hashtag matching a search
+type HashtagResult struct {
+	ID        string `json:"id"`
+	Tag       string `json:"tag"`
+	PostCount int    `json:"post_count"`
+	Snippet   string `json:"snippet"`
+}
+
+// InterestResult is an interest matching a search
+type InterestResult struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Slug     string  `json:"slug"`
+	Category string  `json:"category"`
+	Icon     *string `json:"icon,omitempty"`
+	Snippet  string  `json:"snippet"`
+}
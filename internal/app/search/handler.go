@@ -0,0 +1,40 @@
+package search
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Handler handles HTTP requests for search
+type Handler struct {
+	service SearchService
+}
+
+// NewHandler creates a new search handler
+func NewHandler(service SearchService) *Handler {
+	return &Handler{service: service}
+}
+
+// Search handles GET /v1/search?q=&type=posts|users|hashtags|interests&cursor=&limit=
+func (h *Handler) Search(c *fiber.Ctx) error {
+	// Auth is optional; logged-in viewers also see followers-only posts they may see
+	viewerID, _ := c.Locals("user_id").(string)
+
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	response, err := h.service.Search(c.Context(), viewerID, c.Query("q"), c.Query("type"), c.Query("cursor"), limit)
+	if err != nil {
+		switch err {
+		case ErrQueryRequired, ErrQueryTooLong, ErrInvalidType, ErrInvalidCursor:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to search",
+		})
+	}
+
+	return c.JSON(response)
+}
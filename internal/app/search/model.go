@@ -0,0 +1,75 @@
+package search
+
+import "time"
+
+// Search types
+const (
+	TypeAll       = "all" // A few top results of every type, without pagination
+	TypePosts     = "posts"
+	TypeUsers     = "users"
+	TypeHashtags  = "hashtags"
+	TypeInterests = "interests"
+)
+
+// Highlight markers. ts_headline wraps matching terms in these control characters so the
+// service can HTML-escape the text before turning them into <mark> tags.
+const (
+	highlightStart = "\x01"
+	highlightStop  = "\x02"
+)
+
+// PostHit is a post matching a search
+type PostHit struct {
+	ID          string
+	UserID      string
+	Content     string
+	Snippet     string // Content excerpt with highlight markers
+	IsAnonymous bool
+	PostType    string
+	CreatedAt   time.Time
+
+	// Author (ignored for anonymous posts)
+	Username  string
+	FirstName string
+	AvatarURL string
+
+	Rank float64
+}
+
+// UserHit is a user matching a search
+type UserHit struct {
+	ID        string
+	Username  string
+	FirstName string
+	LastName  string
+	AvatarURL string
+	Snippet   string // Bio excerpt with highlight markers
+	Rank      float64
+}
+
+// HashtagHit is a hashtag matching a search
+type HashtagHit struct {
+	ID        string
+	Tag       string
+	PostCount int
+	Rank      float64
+}
+
+// InterestHit is an interest matching a search
+type InterestHit struct {
+	ID       string
+	Name     string
+	Slug     string
+	Category string
+	Icon     *string
+	Snippet  string // Name with highlight markers
+	Rank     float64
+}
+
+// Cursor is the position after the last result of a page. Results are ordered by rank,
+// then ID, both descending; ranks do not depend on the time, so pages stay stable.
+type Cursor struct {
+	Type string  `json:"t"`
+	Rank float64 `json:"r"`
+	ID   string  `json:"id"`
+}
@@ -0,0 +1,14 @@
+package search
+
+import "context"
+
+// SearchRepository defines the interface for search queries. Each method returns up to
+// limit hits ranked after the cursor (from the top if after is nil). Posts and users the
+// viewer may not see (private, blocked or deactivated) are never returned, nor are posts
+// the viewer muted.
+type SearchRepository interface {
+	SearchPosts(ctx context.Context, query, viewerID string, after *Cursor, limit int) ([]*PostHit, error)
+	SearchUsers(ctx context.Context, query, viewerID string, after *Cursor, limit int) ([]*UserHit, error)
+	SearchHashtags(ctx context.Context, query string, after *Cursor, limit int) ([]*HashtagHit, error)
+	SearchInterests(ctx context.Context, query string, after *Cursor, limit int) ([]*InterestHit, error)
+}
@@ -0,0 +1,295 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"mockhu-app-backend/internal/app/mute"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresSearchRepository implements SearchRepository with Postgres full-text search
+// and pg_trgm
+type PostgresSearchRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresSearchRepository creates a new PostgreSQL search repository
+func NewPostgresSearchRepository(pool *pgxpool.Pool) *PostgresSearchRepository {
+	return &PostgresSearchRepository{pool: pool}
+}
+
+// headlineOptions are the ts_headline options used for snippets
+const headlineOptions = "StartSel=" + highlightStart + ", StopSel=" + highlightStop +
+	`, MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=" … "`
+
+// userNameExpr is the full name expression indexed by idx_users_name_trgm
+const userNameExpr = `(COALESCE(u.first_name, '') || ' ' || COALESCE(u.last_name, ''))`
+
+// SearchPosts ranks published posts by how well their content matches the query.
+// Reposts are skipped; the original post is found instead. Posts the viewer muted, by
+// author or keyword, are left out.
+func (r *PostgresSearchRepository) SearchPosts(ctx context.Context, query, viewerID string, after *Cursor, limit int) ([]*PostHit, error) {
+	sql := `
+		WITH q AS (SELECT websearch_to_tsquery('english', $1) AS query),
+		hits AS (
+			SELECT p.id, p.user_id, COALESCE(p.content, '') AS content, p.is_anonymous, p.post_type, p.created_at,
+			       COALESCE(u.username, '') AS username, COALESCE(u.first_name, '') AS first_name,
+			       COALESCE(u.avatar_url, '') AS avatar_url,
+			       ts_rank_cd(p.search_vector, q.query, 1)::float8 AS rank
+			FROM posts p
+			CROSS JOIN q
+			JOIN users u ON u.id = p.user_id
+			WHERE p.search_vector @@ q.query
+			  AND p.is_active = true AND p.status = 'published' AND p.post_type <> 'repost'
+			  AND ` + visibleToViewer("u", "$2") + `
+			  AND ` + mute.PostNotMuted("p", "$2") + `
+		),
+		page AS (
+			SELECT * FROM hits
+			WHERE (rank, id) < ($3, $4::uuid)
+			ORDER BY rank DESC, id DESC
+			LIMIT $5
+		)
+		SELECT page.id, page.user_id, page.content, ts_headline('english', page.content, q.query, $6),
+		       page.is_anonymous, page.post_type, page.created_at,
+		       page.username, page.first_name, page.avatar_url, page.rank
+		FROM page
+		CROSS JOIN q
+		ORDER BY page.rank DESC, page.id DESC
+	`
+
+	rank, id := cursorPosition(after)
+	rows, err := r.pool.Query(ctx, sql, query, viewerID, rank, id, limit, headlineOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hits []*PostHit
+
+	for rows.Next() {
+		hit := &PostHit{}
+		err := rows.Scan(
+			&hit.ID,
+			&hit.UserID,
+			&hit.Content,
+			&hit.Snippet,
+			&hit.IsAnonymous,
+			&hit.PostType,
+			&hit.CreatedAt,
+			&hit.Username,
+			&hit.FirstName,
+			&hit.AvatarURL,
+			&hit.Rank,
+		)
+		if err != nil {
+			return nil, err
+		}
+		hits = append(hits, hit)
+	}
+
+	return hits, rows.Err()
+}
+
+// SearchUsers ranks active users by full-text match on username, name and bio plus
+// trigram similarity of the username and name, so typos and partial names still match
+func (r *PostgresSearchRepository) SearchUsers(ctx context.Context, query, viewerID string, after *Cursor, limit int) ([]*UserHit, error) {
+	sql := `
+		WITH q AS (SELECT websearch_to_tsquery('simple', $1) AS query),
+		hits AS (
+			SELECT u.id, u.username, COALESCE(u.first_name, '') AS first_name,
+			       COALESCE(u.last_name, '') AS last_name, COALESCE(u.avatar_url, '') AS avatar_url,
+			       COALESCE(u.bio, '') AS bio,
+			       (ts_rank_cd(u.search_vector, q.query, 1)
+			        + GREATEST(similarity(u.username, $1), word_similarity($1, ` + userNameExpr + `))
+			        + CASE WHEN u.username ILIKE $3 || '%' THEN 0.5 ELSE 0 END)::float8 AS rank
+			FROM users u
+			CROSS JOIN q
+			WHERE u.is_active = true AND u.username IS NOT NULL
+			  AND (
+				u.search_vector @@ q.query
+				OR u.username % $1
+				OR $1 <% ` + userNameExpr + `
+				OR u.username ILIKE $3 || '%'
+			  )
+			  AND ` + notBlocked("u", "$2") + `
+		),
+		page AS (
+			SELECT * FROM hits
+			WHERE (rank, id) < ($4, $5::uuid)
+			ORDER BY rank DESC, id DESC
+			LIMIT $6
+		)
+		SELECT page.id, page.username, page.first_name, page.last_name, page.avatar_url,
+		       CASE WHEN page.bio = '' THEN '' ELSE ts_headline('simple', page.bio, q.query, $7) END,
+		       page.rank
+		FROM page
+		CROSS JOIN q
+		ORDER BY page.rank DESC, page.id DESC
+	`
+
+	rank, id := cursorPosition(after)
+	rows, err := r.pool.Query(ctx, sql, query, viewerID, likeEscape(query), rank, id, limit, headlineOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hits []*UserHit
+
+	for rows.Next() {
+		hit := &UserHit{}
+		err := rows.Scan(
+			&hit.ID,
+			&hit.Username,
+			&hit.FirstName,
+			&hit.LastName,
+			&hit.AvatarURL,
+			&hit.Snippet,
+			&hit.Rank,
+		)
+		if err != nil {
+			return nil, err
+		}
+		hits = append(hits, hit)
+	}
+
+	return hits, rows.Err()
+}
+
+// SearchHashtags ranks hashtags by similarity to the query (an exact tag first, then
+// prefix matches). Hashtags without any active post are skipped.
+func (r *PostgresSearchRepository) SearchHashtags(ctx context.Context, query string, after *Cursor, limit int) ([]*HashtagHit, error) {
+	sql := `
+		WITH hits AS (
+			SELECT h.id, h.tag, COUNT(p.id) AS post_count,
+			       (similarity(h.tag, $1)
+			        + CASE WHEN h.tag = $1 THEN 1 WHEN h.tag LIKE $2 || '%' THEN 0.5 ELSE 0 END)::float8 AS rank
+			FROM hashtags h
+			JOIN post_hashtags ph ON ph.hashtag_id = h.id
+			JOIN posts p ON p.id = ph.post_id AND p.is_active = true AND p.status = 'published'
+			WHERE h.tag LIKE $2 || '%' OR h.tag % $1
+			GROUP BY h.id
+		)
+		SELECT id, tag, post_count, rank
+		FROM hits
+		WHERE (rank, id) < ($3, $4::uuid)
+		ORDER BY rank DESC, id DESC
+		LIMIT $5
+	`
+
+	rank, id := cursorPosition(after)
+	rows, err := r.pool.Query(ctx, sql, query, likeEscape(query), rank, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hits []*HashtagHit
+
+	for rows.Next() {
+		hit := &HashtagHit{}
+		if err := rows.Scan(&hit.ID, &hit.Tag, &hit.PostCount, &hit.Rank); err != nil {
+			return nil, err
+		}
+		hits = append(hits, hit)
+	}
+
+	return hits, rows.Err()
+}
+
+// SearchInterests ranks interests by full-text match on name and category plus trigram
+// similarity of the name
+func (r *PostgresSearchRepository) SearchInterests(ctx context.Context, query string, after *Cursor, limit int) ([]*InterestHit, error) {
+	sql := `
+		WITH q AS (SELECT websearch_to_tsquery('english', $1) AS query),
+		hits AS (
+			SELECT i.id, i.name, i.slug, i.category, i.icon,
+			       (ts_rank_cd(i.search_vector, q.query) + similarity(i.name, $1))::float8 AS rank
+			FROM interests i
+			CROSS JOIN q
+			WHERE i.search_vector @@ q.query OR i.name % $1 OR i.name ILIKE $2 || '%'
+		)
+		SELECT hits.id, hits.name, hits.slug, hits.category, hits.icon,
+		       ts_headline('english', hits.name, q.query, $6), hits.rank
+		FROM hits
+		CROSS JOIN q
+		WHERE (hits.rank, hits.id) < ($3, $4::uuid)
+		ORDER BY hits.rank DESC, hits.id DESC
+		LIMIT $5
+	`
+
+	rank, id := cursorPosition(after)
+	rows, err := r.pool.Query(ctx, sql, query, likeEscape(query), rank, id, limit, headlineOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hits []*InterestHit
+
+	for rows.Next() {
+		hit := &InterestHit{}
+		err := rows.Scan(
+			&hit.ID,
+			&hit.Name,
+			&hit.Slug,
+			&hit.Category,
+			&hit.Icon,
+			&hit.Snippet,
+			&hit.Rank,
+		)
+		if err != nil {
+			return nil, err
+		}
+		hits = append(hits, hit)
+	}
+
+	return hits, rows.Err()
+}
+
+// cursorPosition returns the keyset position to search after; without a cursor it is
+// above every result
+func cursorPosition(after *Cursor) (float64, string) {
+	if after == nil {
+		return math.Inf(1), "ffffffff-ffff-ffff-ffff-ffffffffffff"
+	}
+	return after.Rank, after.ID
+}
+
+// likeEscape escapes LIKE wildcards so the text matches literally
+func likeEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// notBlocked returns a SQL predicate that is true unless the user aliased as alias and the
+// viewer (param, may be empty) have blocked each other
+func notBlocked(alias, param string) string {
+	return fmt.Sprintf(`NOT EXISTS (
+			SELECT 1 FROM blocked_users vb
+			WHERE (vb.blocker_id = NULLIF(%[1]s::text, '')::uuid AND vb.blocked_id = %[2]s.id)
+			   OR (vb.blocker_id = %[2]s.id AND vb.blocked_id = NULLIF(%[1]s::text, '')::uuid)
+		)`, param, alias)
+}
+
+// visibleToViewer returns a SQL predicate that is true if the viewer (param, may be empty)
+// can see posts by the user aliased as alias: the author is active, their post privacy
+// allows it, and neither has blocked the other
+func visibleToViewer(alias, param string) string {
+	viewer := fmt.Sprintf("NULLIF(%s::text, '')::uuid", param)
+
+	return fmt.Sprintf(`%[2]s.is_active = true
+			AND (
+				%[2]s.who_can_see_posts = 'everyone'
+				OR %[2]s.id = %[1]s
+				OR (%[2]s.who_can_see_posts = 'followers' AND EXISTS (
+					SELECT 1 FROM user_follows vf
+					WHERE vf.follower_id = %[1]s AND vf.following_id = %[2]s.id
+				))
+			)
+			AND %[3]s`, viewer, alias, notBlocked(alias, param))
+}
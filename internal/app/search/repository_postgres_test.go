@@ -0,0 +1,165 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"mockhu-app-backend/internal/pkg/testdb"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// exec runs a statement, failing the test on error
+func exec(t *testing.T, pool *pgxpool.Pool, sql string, args ...interface{}) {
+	t.Helper()
+
+	if _, err := pool.Exec(context.Background(), sql, args...); err != nil {
+		t.Fatalf("exec %q: %v", sql, err)
+	}
+}
+
+// insertPost creates a published post and returns its ID
+func insertPost(t *testing.T, pool *pgxpool.Pool, userID, content string, anonymous bool) string {
+	t.Helper()
+
+	var id string
+	err := pool.QueryRow(context.Background(), `INSERT INTO posts (user_id, content, is_anonymous) VALUES ($1, $2, $3) RETURNING id`,
+		userID, content, anonymous).Scan(&id)
+	if err != nil {
+		t.Fatalf("insert post: %v", err)
+	}
+	return id
+}
+
+// searchPostIDs returns the IDs of posts matching query for viewerID
+func searchPostIDs(t *testing.T, repo *PostgresSearchRepository, query, viewerID string) map[string]*PostHit {
+	t.Helper()
+
+	hits, err := repo.SearchPosts(context.Background(), query, viewerID, nil, 50)
+	if err != nil {
+		t.Fatalf("SearchPosts: %v", err)
+	}
+	ids := make(map[string]*PostHit, len(hits))
+	for _, hit := range hits {
+		ids[hit.ID] = hit
+	}
+	return ids
+}
+
+func TestSearchPostsChecksVisibility(t *testing.T) {
+	pool := testdb.Pool(t)
+	repo := NewPostgresSearchRepository(pool)
+
+	viewerID := testdb.CreateUser(t, pool)
+	authorID := testdb.CreateUser(t, pool)
+	blockerID := testdb.CreateUser(t, pool)
+	blockedID := testdb.CreateUser(t, pool)
+	deactivatedID := testdb.CreateUser(t, pool)
+	privateID := testdb.CreateUser(t, pool)
+
+	exec(t, pool, `INSERT INTO blocked_users (blocker_id, blocked_id) VALUES ($1, $2), ($2, $3)`, blockerID, viewerID, blockedID)
+	exec(t, pool, `UPDATE users SET is_active = false WHERE id = $1`, deactivatedID)
+	exec(t, pool, `UPDATE users SET who_can_see_posts = 'followers' WHERE id = $1`, privateID)
+
+	// A word no other test uses, so only these posts match
+	word := "quokka" + uuid.NewString()[:8]
+	content := "notes about " + word
+
+	visible := insertPost(t, pool, authorID, content, false)
+	anonymous := insertPost(t, pool, authorID, content, true)
+	hidden := []string{
+		insertPost(t, pool, blockerID, content, false),
+		insertPost(t, pool, blockerID, content, true),
+		insertPost(t, pool, blockedID, content, false),
+		insertPost(t, pool, deactivatedID, content, false),
+		insertPost(t, pool, privateID, content, false),
+	}
+	var repost string
+	err := pool.QueryRow(context.Background(), `INSERT INTO posts (user_id, post_type, quoted_post_id) VALUES ($1, 'repost', $2) RETURNING id`,
+		viewerID, visible).Scan(&repost)
+	if err != nil {
+		t.Fatalf("insert repost: %v", err)
+	}
+	hidden = append(hidden, repost)
+
+	hits := searchPostIDs(t, repo, word, viewerID)
+	if len(hits) != 2 || hits[visible] == nil || hits[anonymous] == nil {
+		t.Fatalf("SearchPosts = %v, want only %s and %s", hits, visible, anonymous)
+	}
+	for _, id := range hidden {
+		if hits[id] != nil {
+			t.Errorf("SearchPosts returned hidden post %s", id)
+		}
+	}
+	if !hits[anonymous].IsAnonymous || hits[visible].IsAnonymous {
+		t.Errorf("anonymous flags: %s = %v, %s = %v", visible, hits[visible].IsAnonymous, anonymous, hits[anonymous].IsAnonymous)
+	}
+
+	// Logged-out visitors aren't blocked by anyone but still can't see private posts
+	hits = searchPostIDs(t, repo, word, "")
+	if len(hits) != 5 || hits[hidden[0]] == nil || hits[hidden[2]] == nil {
+		t.Errorf("SearchPosts logged out = %v, want every public post by an active user", hits)
+	}
+}
+
+func TestSearchUsersSkipsBlockedAndDeactivated(t *testing.T) {
+	pool := testdb.Pool(t)
+	repo := NewPostgresSearchRepository(pool)
+
+	viewerID := testdb.CreateUser(t, pool)
+	blockerID := testdb.CreateUser(t, pool)
+	deactivatedID := testdb.CreateUser(t, pool)
+	visibleID := testdb.CreateUser(t, pool)
+
+	name := "Wombat" + uuid.NewString()[:8]
+	exec(t, pool, `UPDATE users SET first_name = $1 WHERE id IN ($2, $3, $4)`, name, blockerID, deactivatedID, visibleID)
+	exec(t, pool, `INSERT INTO blocked_users (blocker_id, blocked_id) VALUES ($1, $2)`, blockerID, viewerID)
+	exec(t, pool, `UPDATE users SET is_active = false WHERE id = $1`, deactivatedID)
+
+	hits, err := repo.SearchUsers(context.Background(), name, viewerID, nil, 50)
+	if err != nil {
+		t.Fatalf("SearchUsers: %v", err)
+	}
+	if len(hits) != 1 || hits[0].ID != visibleID {
+		t.Errorf("SearchUsers = %v, want only %s", hits, visibleID)
+	}
+}
+
+func TestSearchPostsSkipsMutedContent(t *testing.T) {
+	pool := testdb.Pool(t)
+	repo := NewPostgresSearchRepository(pool)
+
+	viewerID := testdb.CreateUser(t, pool)
+	mutedID := testdb.CreateUser(t, pool)
+	authorID := testdb.CreateUser(t, pool)
+
+	exec(t, pool, `INSERT INTO user_mutes (muter_id, muted_id) VALUES ($1, $2)`, viewerID, mutedID)
+	exec(t, pool, `INSERT INTO muted_keywords (user_id, keyword, pattern) VALUES ($1, 'spoilers', '(^|[^[:alnum:]_])spoilers($|[^[:alnum:]_])')`, viewerID)
+
+	word := "numbat" + uuid.NewString()[:8]
+
+	visible := insertPost(t, pool, authorID, "all about "+word, false)
+	// Account mutes never apply to anonymous posts, so muting can't reveal who wrote them
+	anonymous := insertPost(t, pool, mutedID, "anonymous "+word, true)
+	muted := []string{
+		insertPost(t, pool, mutedID, "muted "+word, false),
+		insertPost(t, pool, authorID, word+" spoilers ahead", false),
+		insertPost(t, pool, mutedID, word+" spoilers, anonymously", true),
+	}
+
+	hits := searchPostIDs(t, repo, word, viewerID)
+	if len(hits) != 2 || hits[visible] == nil || hits[anonymous] == nil {
+		t.Errorf("SearchPosts = %v, want only %s and %s", hits, visible, anonymous)
+	}
+	for _, id := range muted {
+		if hits[id] != nil {
+			t.Errorf("SearchPosts returned muted post %s", id)
+		}
+	}
+
+	// Mutes are the viewer's own
+	if hits := searchPostIDs(t, repo, word, authorID); len(hits) != 5 {
+		t.Errorf("SearchPosts by another user returned %d posts, want 5", len(hits))
+	}
+}
@@ -0,0 +1,15 @@
+package search
+
+import (
+	"mockhu-app-backend/internal/pkg/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterRoutes registers the search route
+func RegisterRoutes(app *fiber.App, handler *Handler) {
+	v1 := app.Group("/v1")
+
+	// Unified search (auth optional, results respect the viewer's blocks and privacy)
+	v1.Get("/search", middleware.OptionalAuthMiddleware(), handler.Search)
+}
@@ -0,0 +1,332 @@
+package search
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"mockhu-app-backend/internal/app/anonymity"
+
+	"github.com/google/uuid"
+)
+
+// Search limits
+const (
+	maxQueryLength = 100
+	defaultLimit   = 20
+	maxLimit       = 50
+	allTypeLimit   = 5 // Results of each type for type "all"
+)
+
+// Errors
+var (
+	ErrQueryRequired = errors.New("query parameter 'q' is required")
+	ErrQueryTooLong  = errors.New("query must be at most 100 characters")
+	ErrInvalidType   = errors.New("type must be posts, users, hashtags or interests")
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// SearchService defines the business logic for search
+type SearchService interface {
+	// Search finds posts, users, hashtags or interests matching query, best match first.
+	// searchType "" searches every type; cursor is the next_cursor of the previous page.
+	Search(ctx context.Context, viewerID, query, searchType, cursor string, limit int) (*SearchResponse, error)
+}
+
+// searchService implements SearchService
+type searchService struct {
+	searchRepo       SearchRepository
+	anonymityService anonymity.AnonymityService
+}
+
+// NewService creates a new search service
+func NewService(searchRepo SearchRepository, anonymityService anonymity.AnonymityService) SearchService {
+	return &searchService{
+		searchRepo:       searchRepo,
+		anonymityService: anonymityService,
+	}
+}
+
+// Search finds posts, users, hashtags or interests matching query, best match first.
+// searchType "" searches every type; cursor is the next_cursor of the previous page.
+func (s *searchService) Search(ctx context.Context, viewerID, query, searchType, cursor string, limit int) (*SearchResponse, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, ErrQueryRequired
+	}
+	if utf8.RuneCountInString(query) > maxQueryLength {
+		return nil, ErrQueryTooLong
+	}
+
+	switch searchType {
+	case "":
+		searchType = TypeAll
+	case TypeAll, TypePosts, TypeUsers, TypeHashtags, TypeInterests:
+	default:
+		return nil, ErrInvalidType
+	}
+	if limit < 1 || limit > maxLimit {
+		limit = defaultLimit
+	}
+
+	after, err := decodeCursor(cursor, searchType)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SearchResponse{Query: query, Type: searchType}
+	var next *Cursor
+
+	switch searchType {
+	case TypePosts:
+		response.Posts, next, err = s.searchPosts(ctx, query, viewerID, after, limit)
+	case TypeUsers:
+		response.Users, next, err = s.searchUsers(ctx, query, viewerID, after, limit)
+	case TypeHashtags:
+		response.Hashtags, next, err = s.searchHashtags(ctx, query, after, limit)
+	case TypeInterests:
+		response.Interests, next, err = s.searchInterests(ctx, query, after, limit)
+	case TypeAll:
+		err = s.searchAll(ctx, response, query, viewerID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to search %s: %w", searchType, err)
+	}
+
+	if next != nil {
+		response.HasMore = true
+		response.NextCursor = encodeCursor(next)
+	}
+
+	return response, nil
+}
+
+// searchAll fills the response with the top few results of every type
+func (s *searchService) searchAll(ctx context.Context, response *SearchResponse, query, viewerID string) error {
+	var err error
+	if response.Posts, _, err = s.searchPosts(ctx, query, viewerID, nil, allTypeLimit); err != nil {
+		return err
+	}
+	if response.Users, _, err = s.searchUsers(ctx, query, viewerID, nil, allTypeLimit); err != nil {
+		return err
+	}
+	if response.Hashtags, _, err = s.searchHashtags(ctx, query, nil, allTypeLimit); err != nil {
+		return err
+	}
+	response.Interests, _, err = s.searchInterests(ctx, query, nil, allTypeLimit)
+	return err
+}
+
+// searchPosts returns a page of matching posts and the cursor of the next page, if any
+func (s *searchService) searchPosts(ctx context.Context, query, viewerID string, after *Cursor, limit int) ([]*PostResult, *Cursor, error) {
+	// Fetch one extra to know whether there is another page
+	hits, err := s.searchRepo.SearchPosts(ctx, query, viewerID, after, limit+1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next *Cursor
+	if len(hits) > limit {
+		hits = hits[:limit]
+		last := hits[limit-1]
+		next = &Cursor{Type: TypePosts, Rank: last.Rank, ID: last.ID}
+	}
+
+	// Pseudonyms of anonymous authors, all at once
+	threads := make([]anonymity.ThreadKey, 0)
+	for _, hit := range hits {
+		if hit.IsAnonymous {
+			threads = append(threads, anonymity.ThreadKey{PostID: hit.ID, UserID: hit.UserID})
+		}
+	}
+	pseudonyms := s.anonymityService.Pseudonyms(ctx, threads)
+
+	results := make([]*PostResult, 0, len(hits))
+	for _, hit := range hits {
+		results = append(results, &PostResult{
+			ID:        hit.ID,
+			Author:    postAuthor(hit, pseudonyms),
+			Type:      hit.PostType,
+			Content:   hit.Content,
+			Snippet:   renderSnippet(hit.Snippet),
+			CreatedAt: hit.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return results, next, nil
+}
+
+// searchUsers returns a page of matching users and the cursor of the next page, if any
+func (s *searchService) searchUsers(ctx context.Context, query, viewerID string, after *Cursor, limit int) ([]*UserResult, *Cursor, error) {
+	// "@jane" searches for the username jane
+	query = strings.TrimSpace(strings.TrimPrefix(query, "@"))
+	if query == "" {
+		return []*UserResult{}, nil, nil
+	}
+
+	hits, err := s.searchRepo.SearchUsers(ctx, query, viewerID, after, limit+1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next *Cursor
+	if len(hits) > limit {
+		hits = hits[:limit]
+		last := hits[limit-1]
+		next = &Cursor{Type: TypeUsers, Rank: last.Rank, ID: last.ID}
+	}
+
+	results := make([]*UserResult, 0, len(hits))
+	for _, hit := range hits {
+		results = append(results, &UserResult{
+			ID:        hit.ID,
+			Username:  hit.Username,
+			FirstName: hit.FirstName,
+			LastName:  hit.LastName,
+			AvatarURL: hit.AvatarURL,
+			Snippet:   renderSnippet(hit.Snippet),
+		})
+	}
+
+	return results, next, nil
+}
+
+// searchHashtags returns a page of matching hashtags and the cursor of the next page, if any
+func (s *searchService) searchHashtags(ctx context.Context, query string, after *Cursor, limit int) ([]*HashtagResult, *Cursor, error) {
+	tag := normalizeTag(query)
+	if tag == "" {
+		return []*HashtagResult{}, nil, nil
+	}
+
+	hits, err := s.searchRepo.SearchHashtags(ctx, tag, after, limit+1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next *Cursor
+	if len(hits) > limit {
+		hits = hits[:limit]
+		last := hits[limit-1]
+		next = &Cursor{Type: TypeHashtags, Rank: last.Rank, ID: last.ID}
+	}
+
+	results := make([]*HashtagResult, 0, len(hits))
+	for _, hit := range hits {
+		results = append(results, &HashtagResult{
+			ID:        hit.ID,
+			Tag:       hit.Tag,
+			PostCount: hit.PostCount,
+			Snippet:   highlightPrefix(hit.Tag, tag),
+		})
+	}
+
+	return results, next, nil
+}
+
+// searchInterests returns a page of matching interests and the cursor of the next page, if any
+func (s *searchService) searchInterests(ctx context.Context, query string, after *Cursor, limit int) ([]*InterestResult, *Cursor, error) {
+	hits, err := s.searchRepo.SearchInterests(ctx, query, after, limit+1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next *Cursor
+	if len(hits) > limit {
+		hits = hits[:limit]
+		last := hits[limit-1]
+		next = &Cursor{Type: TypeInterests, Rank: last.Rank, ID: last.ID}
+	}
+
+	results := make([]*InterestResult, 0, len(hits))
+	for _, hit := range hits {
+		results = append(results, &InterestResult{
+			ID:       hit.ID,
+			Name:     hit.Name,
+			Slug:     hit.Slug,
+			Category: hit.Category,
+			Icon:     hit.Icon,
+			Snippet:  renderSnippet(hit.Snippet),
+		})
+	}
+
+	return results, next, nil
+}
+
+// Helper methods
+
+// postAuthor returns the author shown on a post result; anonymous posts show the author's
+// pseudonym in the thread and never their account
+func postAuthor(hit *PostHit, pseudonyms map[anonymity.ThreadKey]string) AuthorInfo {
+	if hit.IsAnonymous {
+		return AuthorInfo{
+			FirstName:   pseudonyms[anonymity.ThreadKey{PostID: hit.ID, UserID: hit.UserID}],
+			IsAnonymous: true,
+		}
+	}
+	return AuthorInfo{
+		ID:        hit.UserID,
+		Username:  hit.Username,
+		FirstName: hit.FirstName,
+		AvatarURL: hit.AvatarURL,
+	}
+}
+
+// encodeCursor encodes a cursor as an opaque URL-safe string
+func encodeCursor(cursor *Cursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor decodes a cursor of the given search type; an empty string is the first page
+func decodeCursor(encoded, searchType string) (*Cursor, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	cursor := &Cursor{}
+	if err := json.Unmarshal(data, cursor); err != nil || cursor.Type != searchType {
+		return nil, ErrInvalidCursor
+	}
+	if _, err := uuid.Parse(cursor.ID); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
+
+// renderSnippet HTML-escapes a ts_headline snippet and turns its highlight markers into
+// <mark> tags
+func renderSnippet(snippet string) string {
+	return strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>").Replace(html.EscapeString(snippet))
+}
+
+// highlightPrefix marks the part of a tag that matches the searched prefix
+func highlightPrefix(tag, prefix string) string {
+	if !strings.HasPrefix(tag, prefix) {
+		return html.EscapeString(tag)
+	}
+	return "<mark>" + html.EscapeString(prefix) + "</mark>" + html.EscapeString(tag[len(prefix):])
+}
+
+// normalizeTag turns a query into hashtag form: lowercase letters, digits and underscores,
+// without the leading #
+func normalizeTag(query string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, query)
+}
@@ -0,0 +1,74 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"mockhu-app-backend/internal/app/anonymity"
+)
+
+// fakeSearchRepo returns fixed post hits
+type fakeSearchRepo struct {
+	SearchRepository
+
+	posts []*PostHit
+}
+
+func (r *fakeSearchRepo) SearchPosts(ctx context.Context, query, viewerID string, after *Cursor, limit int) ([]*PostHit, error) {
+	if len(r.posts) > limit {
+		return r.posts[:limit], nil
+	}
+	return r.posts, nil
+}
+
+// fakeAnonymityService names every anonymous author the same
+type fakeAnonymityService struct {
+	anonymity.AnonymityService
+}
+
+func (s *fakeAnonymityService) Pseudonyms(ctx context.Context, keys []anonymity.ThreadKey) map[anonymity.ThreadKey]string {
+	pseudonyms := make(map[anonymity.ThreadKey]string, len(keys))
+	for _, key := range keys {
+		pseudonyms[key] = "Anonymous Owl"
+	}
+	return pseudonyms
+}
+
+func TestSearchPostsHidesAnonymousAuthors(t *testing.T) {
+	repo := &fakeSearchRepo{posts: []*PostHit{
+		{ID: "signed", UserID: "user-7", Content: "exam tips", Snippet: "\x01exam\x02 tips", PostType: "text", Username: "ada_l", FirstName: "Ada", AvatarURL: "/a.jpg"},
+		{ID: "anonymous", UserID: "user-7", Content: "exam panic", Snippet: "\x01exam\x02 panic", IsAnonymous: true, PostType: "text", Username: "ada_l", FirstName: "Ada", AvatarURL: "/a.jpg"},
+	}}
+	s := NewService(repo, &fakeAnonymityService{})
+
+	resp, err := s.Search(context.Background(), "viewer", "exam", TypePosts, "", 0)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(resp.Posts) != 2 {
+		t.Fatalf("got %d posts, want 2", len(resp.Posts))
+	}
+
+	if author := resp.Posts[0].Author; author.ID != "user-7" || author.Username != "ada_l" || author.IsAnonymous {
+		t.Errorf("signed post author = %+v, want the account", author)
+	}
+
+	anonymous := resp.Posts[1]
+	want := AuthorInfo{FirstName: "Anonymous Owl", IsAnonymous: true}
+	if anonymous.Author != want {
+		t.Errorf("anonymous post author = %+v, want %+v", anonymous.Author, want)
+	}
+
+	// Nothing in the response ties the anonymous post to its author
+	data, err := json.Marshal(anonymous)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	for _, leak := range []string{"user-7", "ada_l", "Ada", "/a.jpg"} {
+		if strings.Contains(string(data), `"`+leak+`"`) {
+			t.Errorf("anonymous result %s contains %q", data, leak)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package share
 
+import "mockhu-app-backend/internal/app/post"
+
 // CreateShareRequest is the request DTO for creating a share
 type CreateShareRequest struct {
 	SharedToType string `json:"shared_to_type" validate:"required,oneof=timeline dm external"`
@@ -7,11 +9,12 @@ type CreateShareRequest struct {
 
 // ShareResponse is the response DTO for a share with enriched data
 type ShareResponse struct {
-	ID           string   `json:"id"`
-	PostID       string   `json:"post_id"`
-	User         UserInfo `json:"user"`
-	SharedToType string   `json:"shared_to_type"`
-	CreatedAt    string   `json:"created_at"`
+	ID           string             `json:"id"`
+	PostID       string             `json:"post_id"`
+	User         UserInfo           `json:"user"`
+	SharedToType string             `json:"shared_to_type"`
+	Repost       *post.PostResponse `json:"repost,omitempty"` // The repost created by a timeline share
+	CreatedAt    string             `json:"created_at"`
 }
 
 // UserInfo contains user information for a share
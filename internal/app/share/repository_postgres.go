@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 
+	"mockhu-app-backend/internal/app/counter"
+
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -26,13 +28,26 @@ func (r *PostgresShareRepository) Create(ctx context.Context, share *Share) erro
 		RETURNING id, created_at
 	`
 
-	err := r.pool.QueryRow(ctx, query,
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, query,
 		share.PostID,
 		share.UserID,
 		share.SharedToType,
 	).Scan(&share.ID, &share.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	if err := counter.Add(ctx, tx, counter.PostShares, share.PostID, 1); err != nil {
+		return err
+	}
 
-	return err
+	return tx.Commit(ctx)
 }
 
 // GetByID retrieves a single share by its ID
@@ -138,24 +153,29 @@ func (r *PostgresShareRepository) GetByUserID(ctx context.Context, userID string
 
 // Delete removes a share from the database
 func (r *PostgresShareRepository) Delete(ctx context.Context, id string) error {
-	query := `DELETE FROM post_shares WHERE id = $1`
+	query := `DELETE FROM post_shares WHERE id = $1 RETURNING post_id`
 
-	result, err := r.pool.Exec(ctx, query, id)
+	tx, err := r.pool.Begin(ctx)
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback(ctx)
 
-	rowsAffected := result.RowsAffected()
-	if rowsAffected == 0 {
-		return pgx.ErrNoRows
+	var postID string
+	if err := tx.QueryRow(ctx, query, id).Scan(&postID); err != nil {
+		return err // pgx.ErrNoRows if the share doesn't exist
+	}
+
+	if err := counter.Add(ctx, tx, counter.PostShares, postID, -1); err != nil {
+		return err
 	}
 
-	return nil
+	return tx.Commit(ctx)
 }
 
 // GetShareCount returns the total number of shares for a post
 func (r *PostgresShareRepository) GetShareCount(ctx context.Context, postID string) (int, error) {
-	query := `SELECT COUNT(*) FROM post_shares WHERE post_id = $1`
+	query := `SELECT COALESCE((SELECT share_count FROM posts WHERE id = $1), 0)`
 
 	var count int
 	err := r.pool.QueryRow(ctx, query, postID).Scan(&count)
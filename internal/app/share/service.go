@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"time"
 
 	"mockhu-app-backend/internal/app/auth"
@@ -32,17 +33,19 @@ type ShareService interface {
 
 // shareService implements ShareService
 type shareService struct {
-	shareRepo ShareRepository
-	userRepo  auth.UserRepository
-	postRepo  post.PostRepository
+	shareRepo   ShareRepository
+	userRepo    auth.UserRepository
+	postRepo    post.PostRepository
+	postService post.PostService
 }
 
 // NewService creates a new share service
-func NewService(shareRepo ShareRepository, userRepo auth.UserRepository, postRepo post.PostRepository) ShareService {
+func NewService(shareRepo ShareRepository, userRepo auth.UserRepository, postRepo post.PostRepository, postService post.PostService) ShareService {
 	return &shareService{
-		shareRepo: shareRepo,
-		userRepo:  userRepo,
-		postRepo:  postRepo,
+		shareRepo:   shareRepo,
+		userRepo:    userRepo,
+		postRepo:    postRepo,
+		postService: postService,
 	}
 }
 
@@ -54,11 +57,11 @@ func (s *shareService) CreateShare(ctx context.Context, postID, userID string, r
 	}
 
 	// Validate post exists
-	post, err := s.postRepo.GetByID(ctx, postID)
+	sharedPost, err := s.postRepo.GetByID(ctx, postID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get post: %w", err)
 	}
-	if post == nil {
+	if sharedPost == nil {
 		return nil, ErrPostNotFound
 	}
 
@@ -71,6 +74,21 @@ func (s *shareService) CreateShare(ctx context.Context, postID, userID string, r
 		return nil, ErrAlreadyShared
 	}
 
+	// Timeline shares are reposts into the sharer's followers' feeds
+	var repost *post.PostResponse
+	if req.SharedToType == "timeline" {
+		repost, err = s.postService.Repost(ctx, postID, userID)
+		if err != nil {
+			if err == post.ErrPostNotFound {
+				return nil, ErrPostNotFound
+			}
+			if err == post.ErrAlreadyReposted {
+				return nil, ErrAlreadyShared
+			}
+			return nil, err
+		}
+	}
+
 	// Create share
 	share := &Share{
 		PostID:       postID,
@@ -79,11 +97,14 @@ func (s *shareService) CreateShare(ctx context.Context, postID, userID string, r
 	}
 
 	if err := s.shareRepo.Create(ctx, share); err != nil {
+		if repost != nil {
+			_ = s.postService.Unrepost(ctx, postID, userID)
+		}
 		return nil, fmt.Errorf("failed to create share: %w", err)
 	}
 
 	// Get user info
-	user, err := s.getUserInfo(ctx, userID)
+	user, err := s.getUserInfo(ctx, auth.NewUserLoader(s.userRepo), userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user info: %w", err)
 	}
@@ -94,6 +115,7 @@ func (s *shareService) CreateShare(ctx context.Context, postID, userID string, r
 		PostID:       share.PostID,
 		User:         *user,
 		SharedToType: share.SharedToType,
+		Repost:       repost,
 		CreatedAt:    share.CreatedAt.Format(time.RFC3339),
 	}
 
@@ -110,7 +132,7 @@ func (s *shareService) GetShare(ctx context.Context, shareID, currentUserID stri
 		return nil, ErrShareNotFound
 	}
 
-	return s.convertToResponse(ctx, share)
+	return s.convertToResponse(ctx, auth.NewUserLoader(s.userRepo), share)
 }
 
 // GetPostShares retrieves all shares for a post
@@ -132,14 +154,7 @@ func (s *shareService) GetPostShares(ctx context.Context, postID, currentUserID
 	}
 
 	// Convert to response
-	shareResponses := make([]*ShareResponse, 0, len(shares))
-	for _, share := range shares {
-		response, err := s.convertToResponse(ctx, share)
-		if err != nil {
-			continue // Skip shares with errors
-		}
-		shareResponses = append(shareResponses, response)
-	}
+	shareResponses := s.convertSharesToResponse(ctx, shares)
 
 	// Get total count for pagination
 	totalCount, _ := s.shareRepo.GetShareCount(ctx, postID)
@@ -178,14 +193,7 @@ func (s *shareService) GetUserShares(ctx context.Context, userID, currentUserID
 	}
 
 	// Convert to response
-	shareResponses := make([]*ShareResponse, 0, len(shares))
-	for _, share := range shares {
-		response, err := s.convertToResponse(ctx, share)
-		if err != nil {
-			continue // Skip shares with errors
-		}
-		shareResponses = append(shareResponses, response)
-	}
+	shareResponses := s.convertSharesToResponse(ctx, shares)
 
 	// Calculate total pages (simplified)
 	totalPages := 1
@@ -226,6 +234,13 @@ func (s *shareService) DeleteShare(ctx context.Context, shareID, userID string)
 		return fmt.Errorf("failed to delete share: %w", err)
 	}
 
+	// Take the repost out of followers' feeds too
+	if share.SharedToType == "timeline" {
+		if err := s.postService.Unrepost(ctx, share.PostID, userID); err != nil && err != post.ErrNotReposted {
+			log.Printf("⚠️ Failed to remove repost of post %s: %v", share.PostID, err)
+		}
+	}
+
 	return nil
 }
 
@@ -242,12 +257,12 @@ func (s *shareService) HasUserShared(ctx context.Context, postID, userID string)
 // Helper methods
 
 // getUserInfo retrieves user information for a share
-func (s *shareService) getUserInfo(ctx context.Context, userID string) (*UserInfo, error) {
-	user, err := s.userRepo.FindByID(ctx, userID)
+func (s *shareService) getUserInfo(ctx context.Context, users *auth.UserLoader, userID string) (*UserInfo, error) {
+	user, ok, err := users.Load(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
-	if user == nil {
+	if !ok {
 		return nil, errors.New("user not found")
 	}
 
@@ -260,9 +275,9 @@ func (s *shareService) getUserInfo(ctx context.Context, userID string) (*UserInf
 }
 
 // convertToResponse converts a share to a response
-func (s *shareService) convertToResponse(ctx context.Context, share *Share) (*ShareResponse, error) {
+func (s *shareService) convertToResponse(ctx context.Context, users *auth.UserLoader, share *Share) (*ShareResponse, error) {
 	// Get user info
-	user, err := s.getUserInfo(ctx, share.UserID)
+	user, err := s.getUserInfo(ctx, users, share.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -276,3 +291,24 @@ func (s *shareService) convertToResponse(ctx context.Context, share *Share) (*Sh
 	}, nil
 }
 
+// convertSharesToResponse converts a page of shares, loading all their users in one query
+func (s *shareService) convertSharesToResponse(ctx context.Context, shares []*Share) []*ShareResponse {
+	users := auth.NewUserLoader(s.userRepo)
+	userIDs := make([]string, 0, len(shares))
+	for _, share := range shares {
+		userIDs = append(userIDs, share.UserID)
+	}
+	if err := users.Prime(ctx, userIDs); err != nil {
+		log.Printf("⚠️ Failed to load share users: %v", err)
+	}
+
+	responses := make([]*ShareResponse, 0, len(shares))
+	for _, share := range shares {
+		response, err := s.convertToResponse(ctx, users, share)
+		if err != nil {
+			continue // Skip shares with errors
+		}
+		responses = append(responses, response)
+	}
+	return responses
+}
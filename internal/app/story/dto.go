@@ -0,0 +1,88 @@
+package story
+
+// CreateStoryRequest is the request body for sharing a story. A story needs text, an image
+// uploaded via POST /v1/media, or both (the text is then a caption).
+type CreateStoryRequest struct {
+	Content string `json:"content" validate:"max=500"`
+	MediaID string `json:"media_id"`
+	AltText string `json:"alt_text"`
+}
+
+// ReplyRequest is the request body for replying to a story
+type ReplyRequest struct {
+	Content string `json:"content" validate:"required,max=1000"`
+}
+
+// AuthorInfo contains basic information about a story's author or viewer
+type AuthorInfo struct {
+	ID        string `json:"id"`
+	Username  string `json:"username"`
+	FirstName string `json:"first_name"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+// StoryMediaInfo is the image of a story
+type StoryMediaInfo struct {
+	ID      string            `json:"id"`
+	AltText string            `json:"alt_text"`
+	URLs    map[string]string `json:"urls"` // Size variant name -> URL
+}
+
+// StoryResponse is a single story
+type StoryResponse struct {
+	ID        string          `json:"id"`
+	Author    AuthorInfo      `json:"author"`
+	Content   string          `json:"content,omitempty"`
+	Media     *StoryMediaInfo `json:"media,omitempty"`
+	IsSeen    bool            `json:"is_seen"`
+	ViewCount *int            `json:"view_count,omitempty"` // Only shown to the author
+	CreatedAt string          `json:"created_at"`
+	ExpiresAt string          `json:"expires_at"`
+}
+
+// UserStoriesResponse is the active stories of one user, oldest first
+type UserStoriesResponse struct {
+	Author  AuthorInfo       `json:"author"`
+	Stories []*StoryResponse `json:"stories"`
+}
+
+// TrayItemResponse is a user in the story tray
+type TrayItemResponse struct {
+	Author     AuthorInfo `json:"author"`
+	StoryCount int        `json:"story_count"`
+	HasUnseen  bool       `json:"has_unseen"`
+	LatestAt   string     `json:"latest_at"`
+}
+
+// TrayResponse is the story tray: the viewer's own stories first, then followed users with
+// unseen stories, then followed users whose stories were all seen, newest first
+type TrayResponse struct {
+	Users []*TrayItemResponse `json:"users"`
+}
+
+// ViewerResponse is a user who viewed a story
+type ViewerResponse struct {
+	User     AuthorInfo `json:"user"`
+	ViewedAt string     `json:"viewed_at"`
+}
+
+// ViewersResponse is the response for listing the viewers of a story
+type ViewersResponse struct {
+	Viewers    []*ViewerResponse `json:"viewers"`
+	ViewCount  int               `json:"view_count"`
+	Pagination PaginationInfo    `json:"pagination"`
+}
+
+// ReplyResponse is the direct message a story reply was sent as
+type ReplyResponse struct {
+	ConversationID string `json:"conversation_id"`
+	MessageID      string `json:"message_id"`
+}
+
+// PaginationInfo contains pagination metadata
+type PaginationInfo struct {
+	Page       int `json:"page"`
+	TotalPages int `json:"total_pages"`
+	TotalItems int `json:"total_items"`
+	Limit      int `json:"limit"`
+}
@@ -0,0 +1,309 @@
+package story
+
+import (
+	"errors"
+	"strconv"
+
+	"mockhu-app-backend/internal/app/moderation"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles HTTP requests for stories
+type Handler struct {
+	service StoryService
+}
+
+// NewHandler creates a new story handler
+func NewHandler(service StoryService) *Handler {
+	return &Handler{service: service}
+}
+
+// CreateStory handles POST /v1/stories
+func (h *Handler) CreateStory(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	// Parse request body
+	var req CreateStoryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	story, err := h.service.CreateStory(c.Context(), currentUserID, &req)
+	if err != nil {
+		var rejection *moderation.RejectionError
+		if errors.As(err, &rejection) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"error":      rejection.Error(),
+				"moderation": rejection,
+			})
+		}
+		if err == ErrEmptyStory || err == ErrContentTooLong || errors.Is(err, ErrInvalidMedia) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to create story",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(story)
+}
+
+// GetTray handles GET /v1/stories/tray
+func (h *Handler) GetTray(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	response, err := h.service.GetTray(c.Context(), currentUserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get story tray",
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// GetStory handles GET /v1/stories/:storyId
+func (h *Handler) GetStory(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	storyID := c.Params("storyId")
+	if _, err := uuid.Parse(storyID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid story ID",
+		})
+	}
+
+	story, err := h.service.GetStory(c.Context(), storyID, currentUserID)
+	if err != nil {
+		if err == ErrStoryNotFound || err == ErrUserNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "story not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get story",
+		})
+	}
+
+	return c.JSON(story)
+}
+
+// GetUserStories handles GET /v1/users/:userId/stories
+func (h *Handler) GetUserStories(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	userID := c.Params("userId")
+	if _, err := uuid.Parse(userID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid user ID",
+		})
+	}
+
+	response, err := h.service.GetUserStories(c.Context(), userID, currentUserID)
+	if err != nil {
+		if err == ErrUserNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get stories",
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// DeleteStory handles DELETE /v1/stories/:storyId
+func (h *Handler) DeleteStory(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	storyID := c.Params("storyId")
+	if _, err := uuid.Parse(storyID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid story ID",
+		})
+	}
+
+	if err := h.service.DeleteStory(c.Context(), storyID, currentUserID); err != nil {
+		if err == ErrStoryNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to delete story",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "story deleted successfully",
+	})
+}
+
+// ViewStory handles POST /v1/stories/:storyId/view
+func (h *Handler) ViewStory(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	storyID := c.Params("storyId")
+	if _, err := uuid.Parse(storyID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid story ID",
+		})
+	}
+
+	if err := h.service.ViewStory(c.Context(), storyID, currentUserID); err != nil {
+		if err == ErrStoryNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to record story view",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "story marked as seen",
+	})
+}
+
+// GetViewers handles GET /v1/stories/:storyId/viewers (author only)
+func (h *Handler) GetViewers(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	storyID := c.Params("storyId")
+	if _, err := uuid.Parse(storyID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid story ID",
+		})
+	}
+
+	// Parse pagination
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	response, err := h.service.GetViewers(c.Context(), storyID, currentUserID, page, limit)
+	if err != nil {
+		switch err {
+		case ErrStoryNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case ErrUnauthorized:
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "only the author can see who viewed a story",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get story viewers",
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// ReplyToStory handles POST /v1/stories/:storyId/reply
+func (h *Handler) ReplyToStory(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	storyID := c.Params("storyId")
+	if _, err := uuid.Parse(storyID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid story ID",
+		})
+	}
+
+	// Parse request body
+	var req ReplyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	response, err := h.service.ReplyToStory(c.Context(), storyID, currentUserID, &req)
+	if err != nil {
+		var rejection *moderation.RejectionError
+		if errors.As(err, &rejection) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"error":      rejection.Error(),
+				"moderation": rejection,
+			})
+		}
+		if errors.Is(err, ErrCannotReply) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		switch err {
+		case ErrStoryNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case ErrInvalidReply, ErrReplyOwnStory:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to reply to story",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
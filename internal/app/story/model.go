@@ -0,0 +1,52 @@
+package story
+
+import "time"
+
+// Story limits
+const (
+	Lifetime         = 24 * time.Hour // How long a story is shown after it is shared
+	MaxContentLength = 500
+	MaxReplyLength   = 1000
+)
+
+// Story is an image or text post that disappears after Lifetime
+type Story struct {
+	ID        string
+	UserID    string
+	Content   *string // Text, or the caption of an image story
+	MediaID   *string
+	AltText   string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+
+	IsSeen    bool // Whether the viewer has seen the story
+	ViewCount int  // Number of distinct viewers
+}
+
+// Author is the user who shared a story
+type Author struct {
+	ID        string
+	Username  string
+	FirstName string
+	AvatarURL string
+}
+
+// TrayEntry is a user with active stories in the viewer's story tray
+type TrayEntry struct {
+	Author
+	StoryCount int
+	HasUnseen  bool      // The viewer hasn't seen at least one of the stories
+	LatestAt   time.Time // When the newest story was shared
+}
+
+// Viewer is a user who viewed a story
+type Viewer struct {
+	Author
+	ViewedAt time.Time
+}
+
+// CleanupResult describes a run of the expired story cleanup
+type CleanupResult struct {
+	Stories  int64
+	MediaIDs []string // Images whose files the caller should delete
+}
@@ -0,0 +1,32 @@
+package story
+
+import "context"
+
+// StoryRepository defines the interface for story data operations. Expired stories are
+// never returned.
+type StoryRepository interface {
+	// Create saves a new story
+	Create(ctx context.Context, story *Story) error
+
+	// GetVisible retrieves an active story if the viewer may see it (nil otherwise)
+	GetVisible(ctx context.Context, storyID, viewerID string) (*Story, error)
+
+	// GetByUser retrieves a user's active stories, oldest first, if the viewer may see them
+	GetByUser(ctx context.Context, authorID, viewerID string) ([]*Story, error)
+
+	// GetTray retrieves the viewer and the followed users who have active stories the viewer
+	// may see, with whether any of them are unseen
+	GetTray(ctx context.Context, viewerID string, limit int) ([]*TrayEntry, error)
+
+	// RecordView marks a story as seen by the viewer; repeated views are not counted
+	RecordView(ctx context.Context, storyID, viewerID string) error
+
+	// GetViewers retrieves the users who viewed a story, most recent first
+	GetViewers(ctx context.Context, storyID string, limit, offset int) ([]*Viewer, error)
+
+	// Delete deletes one of the user's stories and its media row (Stories is 0 if not found)
+	Delete(ctx context.Context, storyID, userID string) (*CleanupResult, error)
+
+	// DeleteExpired deletes up to limit expired stories along with their media rows
+	DeleteExpired(ctx context.Context, limit int) (*CleanupResult, error)
+}
@@ -0,0 +1,325 @@
+package story
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"mockhu-app-backend/internal/app/mute"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresStoryRepository implements StoryRepository using PostgreSQL
+type PostgresStoryRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresStoryRepository creates a new PostgreSQL story repository
+func NewPostgresStoryRepository(pool *pgxpool.Pool) *PostgresStoryRepository {
+	return &PostgresStoryRepository{pool: pool}
+}
+
+// storyColumns selects a story (aliased s) with whether the viewer bound to $1 saw it and
+// its number of viewers
+const storyColumns = `s.id, s.user_id, s.content, s.media_id, s.alt_text, s.created_at, s.expires_at,
+		       EXISTS (SELECT 1 FROM story_views sv WHERE sv.story_id = s.id AND sv.viewer_id = $1),
+		       (SELECT COUNT(*) FROM story_views sv WHERE sv.story_id = s.id)`
+
+// Create saves a new story
+func (r *PostgresStoryRepository) Create(ctx context.Context, story *Story) error {
+	query := `
+		INSERT INTO stories (user_id, content, media_id, alt_text, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+
+	return r.pool.QueryRow(ctx, query,
+		story.UserID,
+		story.Content,
+		story.MediaID,
+		story.AltText,
+		story.ExpiresAt,
+	).Scan(&story.ID, &story.CreatedAt)
+}
+
+// GetVisible retrieves an active story if the viewer may see it (nil otherwise)
+func (r *PostgresStoryRepository) GetVisible(ctx context.Context, storyID, viewerID string) (*Story, error) {
+	query := `
+		SELECT ` + storyColumns + `
+		FROM stories s
+		JOIN users u ON u.id = s.user_id
+		WHERE s.id = $2 AND s.expires_at > NOW()
+		AND ` + visibleToViewer("u", "$1") + `
+	`
+
+	story, err := scanStory(r.pool.QueryRow(ctx, query, viewerID, storyID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return story, err
+}
+
+// GetByUser retrieves a user's active stories, oldest first, if the viewer may see them
+func (r *PostgresStoryRepository) GetByUser(ctx context.Context, authorID, viewerID string) ([]*Story, error) {
+	query := `
+		SELECT ` + storyColumns + `
+		FROM stories s
+		JOIN users u ON u.id = s.user_id
+		WHERE s.user_id = $2 AND s.expires_at > NOW()
+		AND ` + visibleToViewer("u", "$1") + `
+		ORDER BY s.created_at
+	`
+
+	rows, err := r.pool.Query(ctx, query, viewerID, authorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stories []*Story
+
+	for rows.Next() {
+		story, err := scanStory(rows)
+		if err != nil {
+			return nil, err
+		}
+		stories = append(stories, story)
+	}
+
+	return stories, rows.Err()
+}
+
+// GetTray retrieves the viewer and the followed users who have active stories the viewer may
+// see. The viewer comes first, then users with unseen stories, each group newest first.
+// Muted users are left out.
+func (r *PostgresStoryRepository) GetTray(ctx context.Context, viewerID string, limit int) ([]*TrayEntry, error) {
+	query := `
+		SELECT u.id, COALESCE(u.username, ''), COALESCE(u.first_name, ''), COALESCE(u.avatar_url, ''),
+		       COUNT(*),
+		       bool_or(NOT EXISTS (SELECT 1 FROM story_views sv WHERE sv.story_id = s.id AND sv.viewer_id = $1)),
+		       MAX(s.created_at)
+		FROM stories s
+		JOIN users u ON u.id = s.user_id
+		WHERE s.expires_at > NOW()
+		AND (
+			u.id = $1
+			OR EXISTS (SELECT 1 FROM user_follows f WHERE f.follower_id = $1 AND f.following_id = u.id)
+		)
+		AND ` + visibleToViewer("u", "$1") + `
+		AND ` + mute.UserNotMuted("u.id", "$1") + `
+		GROUP BY u.id
+		ORDER BY u.id = $1 DESC, 6 DESC, 7 DESC
+		LIMIT $2
+	`
+
+	rows, err := r.pool.Query(ctx, query, viewerID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*TrayEntry
+
+	for rows.Next() {
+		entry := &TrayEntry{}
+		err := rows.Scan(
+			&entry.ID,
+			&entry.Username,
+			&entry.FirstName,
+			&entry.AvatarURL,
+			&entry.StoryCount,
+			&entry.HasUnseen,
+			&entry.LatestAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// RecordView marks a story as seen by the viewer; repeated views are not counted
+func (r *PostgresStoryRepository) RecordView(ctx context.Context, storyID, viewerID string) error {
+	query := `
+		INSERT INTO story_views (story_id, viewer_id)
+		VALUES ($1, $2)
+		ON CONFLICT (story_id, viewer_id) DO NOTHING
+	`
+
+	_, err := r.pool.Exec(ctx, query, storyID, viewerID)
+	return err
+}
+
+// GetViewers retrieves the users who viewed a story, most recent first. Deactivated users
+// are left out.
+func (r *PostgresStoryRepository) GetViewers(ctx context.Context, storyID string, limit, offset int) ([]*Viewer, error) {
+	query := `
+		SELECT u.id, COALESCE(u.username, ''), COALESCE(u.first_name, ''), COALESCE(u.avatar_url, ''), sv.viewed_at
+		FROM story_views sv
+		JOIN users u ON u.id = sv.viewer_id AND u.is_active = true
+		WHERE sv.story_id = $1
+		ORDER BY sv.viewed_at DESC, u.id
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, storyID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var viewers []*Viewer
+
+	for rows.Next() {
+		viewer := &Viewer{}
+		err := rows.Scan(
+			&viewer.ID,
+			&viewer.Username,
+			&viewer.FirstName,
+			&viewer.AvatarURL,
+			&viewer.ViewedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		viewers = append(viewers, viewer)
+	}
+
+	return viewers, rows.Err()
+}
+
+// Delete deletes one of the user's stories and its media row (Stories is 0 if not found)
+func (r *PostgresStoryRepository) Delete(ctx context.Context, storyID, userID string) (*CleanupResult, error) {
+	return r.deleteStories(ctx, `
+		DELETE FROM stories
+		WHERE id = $1 AND user_id = $2
+		RETURNING media_id
+	`, storyID, userID)
+}
+
+// DeleteExpired deletes up to limit expired stories along with their media rows. Rows locked
+// by a concurrent run are skipped.
+func (r *PostgresStoryRepository) DeleteExpired(ctx context.Context, limit int) (*CleanupResult, error) {
+	return r.deleteStories(ctx, `
+		DELETE FROM stories
+		WHERE id IN (
+			SELECT id FROM stories
+			WHERE expires_at <= NOW()
+			ORDER BY expires_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING media_id
+	`, limit)
+}
+
+// deleteStories runs a DELETE on stories returning their media IDs and deletes those media
+// rows too, in a single transaction. Media attached to a post is kept.
+func (r *PostgresStoryRepository) deleteStories(ctx context.Context, query string, args ...any) (*CleanupResult, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CleanupResult{}
+	var mediaIDs []string
+
+	for rows.Next() {
+		var mediaID *string
+		if err := rows.Scan(&mediaID); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		result.Stories++
+		if mediaID != nil {
+			mediaIDs = append(mediaIDs, *mediaID)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(mediaIDs) > 0 {
+		rows, err := tx.Query(ctx, `
+			DELETE FROM media m
+			WHERE m.id = ANY($1::uuid[])
+			AND NOT EXISTS (SELECT 1 FROM post_media pm WHERE pm.media_id = m.id)
+			RETURNING m.id
+		`, mediaIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			result.MediaIDs = append(result.MediaIDs, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// scanStory scans a row selected with storyColumns
+func scanStory(row pgx.Row) (*Story, error) {
+	story := &Story{}
+	err := row.Scan(
+		&story.ID,
+		&story.UserID,
+		&story.Content,
+		&story.MediaID,
+		&story.AltText,
+		&story.CreatedAt,
+		&story.ExpiresAt,
+		&story.IsSeen,
+		&story.ViewCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return story, nil
+}
+
+// visibleToViewer returns a SQL predicate that is true if the viewer bound to param can see
+// stories by the user aliased as alias: the author is active, their who_can_see_posts
+// setting allows it, and neither has blocked the other
+func visibleToViewer(alias, param string) string {
+	viewer := fmt.Sprintf("NULLIF(%s::text, '')::uuid", param)
+
+	return fmt.Sprintf(`%[2]s.is_active = true
+		AND (
+			%[2]s.who_can_see_posts = 'everyone'
+			OR %[2]s.id = %[1]s
+			OR (%[2]s.who_can_see_posts = 'followers' AND EXISTS (
+				SELECT 1 FROM user_follows vf
+				WHERE vf.follower_id = %[1]s AND vf.following_id = %[2]s.id
+			))
+		)
+		AND NOT EXISTS (
+			SELECT 1 FROM blocked_users vb
+			WHERE (vb.blocker_id = %[1]s AND vb.blocked_id = %[2]s.id)
+			   OR (vb.blocker_id = %[2]s.id AND vb.blocked_id = %[1]s)
+		)`, viewer, alias)
+}
@@ -0,0 +1,232 @@
+package story
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"mockhu-app-backend/internal/pkg/testdb"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// exec runs a statement, failing the test on error
+func exec(t *testing.T, pool *pgxpool.Pool, sql string, args ...interface{}) {
+	t.Helper()
+
+	if _, err := pool.Exec(context.Background(), sql, args...); err != nil {
+		t.Fatalf("exec %q: %v", sql, err)
+	}
+}
+
+// createStory saves a text story that expires after lifetime and returns its ID
+func createStory(t *testing.T, repo *PostgresStoryRepository, userID string, lifetime time.Duration) string {
+	t.Helper()
+
+	content := "story"
+	story := &Story{UserID: userID, Content: &content, ExpiresAt: time.Now().Add(lifetime)}
+	if err := repo.Create(context.Background(), story); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	return story.ID
+}
+
+// insertMedia records an uploaded image and returns its ID
+func insertMedia(t *testing.T, pool *pgxpool.Pool, userID string) string {
+	t.Helper()
+
+	id := uuid.NewString()
+	exec(t, pool, `INSERT INTO media (id, user_id, width, height, size_bytes) VALUES ($1, $2, 10, 10, 100)`, id, userID)
+	return id
+}
+
+func TestExpiredStoriesAreHidden(t *testing.T) {
+	pool := testdb.Pool(t)
+	repo := NewPostgresStoryRepository(pool)
+	ctx := context.Background()
+
+	authorID := testdb.CreateUser(t, pool)
+	viewerID := testdb.CreateUser(t, pool)
+	exec(t, pool, `INSERT INTO user_follows (follower_id, following_id) VALUES ($1, $2)`, viewerID, authorID)
+
+	live := createStory(t, repo, authorID, Lifetime)
+	expired := createStory(t, repo, authorID, -Lifetime)
+
+	if story, err := repo.GetVisible(ctx, expired, viewerID); err != nil || story != nil {
+		t.Errorf("GetVisible of an expired story = %+v, %v; want nil", story, err)
+	}
+	if story, err := repo.GetVisible(ctx, live, viewerID); err != nil || story == nil {
+		t.Errorf("GetVisible of a live story = %+v, %v", story, err)
+	}
+
+	stories, err := repo.GetByUser(ctx, authorID, viewerID)
+	if err != nil {
+		t.Fatalf("GetByUser: %v", err)
+	}
+	if len(stories) != 1 || stories[0].ID != live {
+		t.Errorf("GetByUser = %v, want only %s", stories, live)
+	}
+
+	tray, err := repo.GetTray(ctx, viewerID, maxTrayUsers)
+	if err != nil {
+		t.Fatalf("GetTray: %v", err)
+	}
+	if len(tray) != 1 || tray[0].ID != authorID || tray[0].StoryCount != 1 || !tray[0].HasUnseen {
+		t.Errorf("GetTray = %+v, want the author with 1 unseen story", tray)
+	}
+
+	// Seen stories stay in the tray, without the unseen ring
+	if err := repo.RecordView(ctx, live, viewerID); err != nil {
+		t.Fatalf("RecordView: %v", err)
+	}
+	if err := repo.RecordView(ctx, live, viewerID); err != nil {
+		t.Fatalf("RecordView again: %v", err)
+	}
+	tray, err = repo.GetTray(ctx, viewerID, maxTrayUsers)
+	if err != nil {
+		t.Fatalf("GetTray: %v", err)
+	}
+	if len(tray) != 1 || tray[0].HasUnseen {
+		t.Errorf("GetTray after viewing = %+v, want the author with no unseen stories", tray)
+	}
+	if story, err := repo.GetVisible(ctx, live, authorID); err != nil || story == nil || story.ViewCount != 1 {
+		t.Errorf("GetVisible by the author = %+v, %v; want 1 view", story, err)
+	}
+}
+
+func TestGetVisibleRespectsBlocksAndPrivacy(t *testing.T) {
+	pool := testdb.Pool(t)
+	repo := NewPostgresStoryRepository(pool)
+	ctx := context.Background()
+
+	authorID := testdb.CreateUser(t, pool)
+	followerID := testdb.CreateUser(t, pool)
+	strangerID := testdb.CreateUser(t, pool)
+	blockedID := testdb.CreateUser(t, pool)
+	blockerID := testdb.CreateUser(t, pool)
+
+	exec(t, pool, `INSERT INTO user_follows (follower_id, following_id) VALUES ($1, $2), ($3, $2), ($4, $2)`, followerID, authorID, blockedID, blockerID)
+	exec(t, pool, `INSERT INTO blocked_users (blocker_id, blocked_id) VALUES ($1, $2), ($3, $1)`, authorID, blockedID, blockerID)
+
+	storyID := createStory(t, repo, authorID, Lifetime)
+
+	// visible reports which viewers can see the story
+	visible := func(viewers map[string]string) map[string]bool {
+		t.Helper()
+
+		seen := make(map[string]bool, len(viewers))
+		for name, viewerID := range viewers {
+			story, err := repo.GetVisible(ctx, storyID, viewerID)
+			if err != nil {
+				t.Fatalf("GetVisible as %s: %v", name, err)
+			}
+			seen[name] = story != nil
+		}
+		return seen
+	}
+	viewers := map[string]string{
+		"author":     authorID,
+		"follower":   followerID,
+		"stranger":   strangerID,
+		"logged out": "",
+		"blocked":    blockedID,
+		"blocker":    blockerID,
+	}
+
+	tests := []struct {
+		name    string
+		setting string
+		active  bool
+		want    map[string]bool
+	}{
+		{
+			name:    "public",
+			setting: "everyone",
+			active:  true,
+			want:    map[string]bool{"author": true, "follower": true, "stranger": true, "logged out": true},
+		},
+		{
+			name:    "followers only",
+			setting: "followers",
+			active:  true,
+			want:    map[string]bool{"author": true, "follower": true},
+		},
+		{
+			name:    "deactivated author",
+			setting: "everyone",
+			active:  false,
+			want:    map[string]bool{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exec(t, pool, `UPDATE users SET who_can_see_posts = $1, is_active = $2 WHERE id = $3`, tt.setting, tt.active, authorID)
+
+			got := visible(viewers)
+			for name := range viewers {
+				if got[name] != tt.want[name] {
+					t.Errorf("visible to %s = %v, want %v", name, got[name], tt.want[name])
+				}
+			}
+		})
+	}
+}
+
+func TestDeleteExpiredRemovesMedia(t *testing.T) {
+	pool := testdb.Pool(t)
+	repo := NewPostgresStoryRepository(pool)
+	ctx := context.Background()
+
+	authorID := testdb.CreateUser(t, pool)
+
+	// newStory saves an image story that expires after lifetime, returning its media ID
+	newStory := func(lifetime time.Duration) string {
+		t.Helper()
+
+		mediaID := insertMedia(t, pool, authorID)
+		story := &Story{UserID: authorID, MediaID: &mediaID, ExpiresAt: time.Now().Add(lifetime)}
+		if err := repo.Create(ctx, story); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		return mediaID
+	}
+
+	expired := newStory(-Lifetime)
+	live := newStory(Lifetime)
+
+	// Expired stories from other tests may be deleted too, so only these are checked
+	result, err := repo.DeleteExpired(ctx, 1000)
+	if err != nil {
+		t.Fatalf("DeleteExpired: %v", err)
+	}
+	deleted := make(map[string]bool, len(result.MediaIDs))
+	for _, id := range result.MediaIDs {
+		deleted[id] = true
+	}
+	if !deleted[expired] || deleted[live] {
+		t.Errorf("DeleteExpired media = %v, want %s but not %s", result.MediaIDs, expired, live)
+	}
+
+	for mediaID, wantRows := range map[string]int{expired: 0, live: 1} {
+		var stories, media int
+		err := pool.QueryRow(ctx, `SELECT (SELECT COUNT(*) FROM stories WHERE media_id = $1), (SELECT COUNT(*) FROM media WHERE id = $1)`, mediaID).Scan(&stories, &media)
+		if err != nil {
+			t.Fatalf("count rows: %v", err)
+		}
+		if stories != wantRows || media != wantRows {
+			t.Errorf("media %s: %d stories and %d media rows, want %d of each", mediaID, stories, media, wantRows)
+		}
+	}
+
+	// Deleting early works only for the author
+	otherID := testdb.CreateUser(t, pool)
+	storyID := createStory(t, repo, authorID, Lifetime)
+	if result, err := repo.Delete(ctx, storyID, otherID); err != nil || result.Stories != 0 {
+		t.Errorf("Delete by another user = %+v, %v; want nothing deleted", result, err)
+	}
+	if result, err := repo.Delete(ctx, storyID, authorID); err != nil || result.Stories != 1 {
+		t.Errorf("Delete by the author = %+v, %v; want 1 story deleted", result, err)
+	}
+}
@@ -0,0 +1,27 @@
+package story
+
+import (
+	"mockhu-app-backend/internal/pkg/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterRoutes registers all story routes (auth required)
+func RegisterRoutes(app *fiber.App, handler *Handler) {
+	v1 := app.Group("/v1")
+
+	auth := middleware.AuthMiddleware()
+
+	v1.Post("/stories", auth, handler.CreateStory)
+	v1.Get("/stories/tray", auth, handler.GetTray)
+	v1.Get("/stories/:storyId", auth, handler.GetStory)
+	v1.Delete("/stories/:storyId", auth, handler.DeleteStory)
+	v1.Post("/stories/:storyId/view", auth, handler.ViewStory)
+	v1.Post("/stories/:storyId/reply", auth, handler.ReplyToStory)
+
+	// Viewer list (author only)
+	v1.Get("/stories/:storyId/viewers", auth, handler.GetViewers)
+
+	// Active stories of a user
+	v1.Get("/users/:userId/stories", auth, handler.GetUserStories)
+}
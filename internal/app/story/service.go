@@ -0,0 +1,396 @@
+package story
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"mockhu-app-backend/internal/app/auth"
+	"mockhu-app-backend/internal/app/media"
+	"mockhu-app-backend/internal/app/messaging"
+	"mockhu-app-backend/internal/app/moderation"
+	mediapkg "mockhu-app-backend/internal/pkg/media"
+)
+
+// Story job and listing tuning
+const (
+	CleanupInterval  = 10 * time.Minute // How often expired stories are deleted
+	cleanupBatchSize = 500              // Stories deleted per job run
+	maxTrayUsers     = 100
+)
+
+// Errors
+var (
+	ErrEmptyStory     = errors.New("a story needs text or an image")
+	ErrContentTooLong = errors.New("story text too long (max 500 characters)")
+	ErrInvalidMedia   = errors.New("invalid media")
+	ErrStoryNotFound  = errors.New("story not found")
+	ErrUserNotFound   = errors.New("user not found")
+	ErrUnauthorized   = errors.New("only the author can do this")
+	ErrReplyOwnStory  = errors.New("cannot reply to your own story")
+	ErrInvalidReply   = errors.New("reply must be between 1 and 1000 characters")
+	ErrCannotReply    = errors.New("cannot reply to this story")
+)
+
+// StoryService defines the business logic for stories
+type StoryService interface {
+	CreateStory(ctx context.Context, userID string, req *CreateStoryRequest) (*StoryResponse, error)
+	GetStory(ctx context.Context, storyID, viewerID string) (*StoryResponse, error)
+	GetUserStories(ctx context.Context, authorID, viewerID string) (*UserStoriesResponse, error)
+	GetTray(ctx context.Context, viewerID string) (*TrayResponse, error)
+	DeleteStory(ctx context.Context, storyID, userID string) error
+
+	// ViewStory marks a story as seen; the author's own views are not recorded
+	ViewStory(ctx context.Context, storyID, viewerID string) error
+
+	// GetViewers lists who viewed one of the user's stories
+	GetViewers(ctx context.Context, storyID, userID string, page, limit int) (*ViewersResponse, error)
+
+	// ReplyToStory sends a reply to the author as a direct message
+	ReplyToStory(ctx context.Context, storyID, userID string, req *ReplyRequest) (*ReplyResponse, error)
+
+	// Cleanup deletes expired stories and their images; run periodically by a background job
+	Cleanup(ctx context.Context) error
+}
+
+// storyService implements StoryService
+type storyService struct {
+	storyRepo         StoryRepository
+	userRepo          auth.UserRepository
+	mediaService      media.MediaService
+	moderationService moderation.ModerationService
+	messagingService  messaging.MessagingService
+}
+
+// NewService creates a new story service
+func NewService(storyRepo StoryRepository, userRepo auth.UserRepository, mediaService media.MediaService, moderationService moderation.ModerationService, messagingService messaging.MessagingService) StoryService {
+	return &storyService{
+		storyRepo:         storyRepo,
+		userRepo:          userRepo,
+		mediaService:      mediaService,
+		moderationService: moderationService,
+		messagingService:  messagingService,
+	}
+}
+
+// CreateStory shares a text or image story that expires after Lifetime
+func (s *storyService) CreateStory(ctx context.Context, userID string, req *CreateStoryRequest) (*StoryResponse, error) {
+	content := strings.TrimSpace(req.Content)
+	if content == "" && req.MediaID == "" {
+		return nil, ErrEmptyStory
+	}
+	if len([]rune(content)) > MaxContentLength {
+		return nil, ErrContentTooLong
+	}
+
+	story := &Story{
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(Lifetime),
+	}
+
+	if content != "" {
+		// Stories are gone within a day, so only rejected text is stopped; nothing is queued
+		if _, err := s.moderationService.Check(ctx, moderation.ContentPost, userID, content); err != nil {
+			return nil, err
+		}
+		story.Content = &content
+	}
+
+	if req.MediaID != "" {
+		// Only an image uploaded by the author that no post or story uses yet
+		items, err := s.mediaService.PrepareForPost(ctx, userID, "", []media.PostMediaInput{{MediaID: req.MediaID, AltText: req.AltText}})
+		if err != nil {
+			if errors.Is(err, media.ErrInvalidMedia) || errors.Is(err, media.ErrMediaInUse) || errors.Is(err, media.ErrAltTextTooLong) {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidMedia, err)
+			}
+			return nil, err
+		}
+		story.MediaID = &items[0].MediaID
+		story.AltText = items[0].AltText
+	}
+
+	if err := s.storyRepo.Create(ctx, story); err != nil {
+		return nil, fmt.Errorf("failed to create story: %w", err)
+	}
+
+	author, err := s.getAuthor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toResponse(story, author, userID), nil
+}
+
+// GetStory retrieves a single active story
+func (s *storyService) GetStory(ctx context.Context, storyID, viewerID string) (*StoryResponse, error) {
+	story, err := s.storyRepo.GetVisible(ctx, storyID, viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get story: %w", err)
+	}
+	if story == nil {
+		return nil, ErrStoryNotFound
+	}
+
+	author, err := s.getAuthor(ctx, story.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toResponse(story, author, viewerID), nil
+}
+
+// GetUserStories retrieves a user's active stories, oldest first. The list is empty when
+// the viewer may not see the user's stories.
+func (s *storyService) GetUserStories(ctx context.Context, authorID, viewerID string) (*UserStoriesResponse, error) {
+	author, err := s.getAuthor(ctx, authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	stories, err := s.storyRepo.GetByUser(ctx, authorID, viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stories: %w", err)
+	}
+
+	responses := make([]*StoryResponse, 0, len(stories))
+	for _, story := range stories {
+		responses = append(responses, s.toResponse(story, author, viewerID))
+	}
+
+	return &UserStoriesResponse{
+		Author:  *author,
+		Stories: responses,
+	}, nil
+}
+
+// GetTray retrieves the story tray: the viewer's own stories, then followed users with
+// unseen stories, then the rest
+func (s *storyService) GetTray(ctx context.Context, viewerID string) (*TrayResponse, error) {
+	entries, err := s.storyRepo.GetTray(ctx, viewerID, maxTrayUsers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get story tray: %w", err)
+	}
+
+	items := make([]*TrayItemResponse, 0, len(entries))
+	for _, entry := range entries {
+		items = append(items, &TrayItemResponse{
+			Author:     toAuthorInfo(&entry.Author),
+			StoryCount: entry.StoryCount,
+			HasUnseen:  entry.HasUnseen,
+			LatestAt:   entry.LatestAt.Format(time.RFC3339),
+		})
+	}
+
+	return &TrayResponse{Users: items}, nil
+}
+
+// DeleteStory deletes one of the user's stories before it expires
+func (s *storyService) DeleteStory(ctx context.Context, storyID, userID string) error {
+	result, err := s.storyRepo.Delete(ctx, storyID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete story: %w", err)
+	}
+	if result.Stories == 0 {
+		return ErrStoryNotFound
+	}
+
+	deleteFiles(result.MediaIDs)
+	return nil
+}
+
+// ViewStory marks a story as seen; the author's own views are not recorded
+func (s *storyService) ViewStory(ctx context.Context, storyID, viewerID string) error {
+	story, err := s.storyRepo.GetVisible(ctx, storyID, viewerID)
+	if err != nil {
+		return fmt.Errorf("failed to get story: %w", err)
+	}
+	if story == nil {
+		return ErrStoryNotFound
+	}
+	if story.UserID == viewerID {
+		return nil
+	}
+
+	if err := s.storyRepo.RecordView(ctx, storyID, viewerID); err != nil {
+		return fmt.Errorf("failed to record story view: %w", err)
+	}
+
+	return nil
+}
+
+// GetViewers lists who viewed one of the user's stories, most recent first
+func (s *storyService) GetViewers(ctx context.Context, storyID, userID string, page, limit int) (*ViewersResponse, error) {
+	story, err := s.storyRepo.GetVisible(ctx, storyID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get story: %w", err)
+	}
+	if story == nil {
+		return nil, ErrStoryNotFound
+	}
+	if story.UserID != userID {
+		return nil, ErrUnauthorized
+	}
+
+	// Validate pagination
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 20
+	}
+
+	offset := (page - 1) * limit
+
+	viewers, err := s.storyRepo.GetViewers(ctx, storyID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get story viewers: %w", err)
+	}
+
+	responses := make([]*ViewerResponse, 0, len(viewers))
+	for _, viewer := range viewers {
+		responses = append(responses, &ViewerResponse{
+			User:     toAuthorInfo(&viewer.Author),
+			ViewedAt: viewer.ViewedAt.Format(time.RFC3339),
+		})
+	}
+
+	// Calculate total pages (simplified)
+	totalPages := 1
+	if len(responses) == limit {
+		totalPages = page + 1
+	}
+
+	return &ViewersResponse{
+		Viewers:   responses,
+		ViewCount: story.ViewCount,
+		Pagination: PaginationInfo{
+			Page:       page,
+			TotalPages: totalPages,
+			TotalItems: len(responses),
+			Limit:      limit,
+		},
+	}, nil
+}
+
+// ReplyToStory sends a reply to the author as a direct message pointing to the story. The
+// author's messaging privacy applies as for any other message.
+func (s *storyService) ReplyToStory(ctx context.Context, storyID, userID string, req *ReplyRequest) (*ReplyResponse, error) {
+	content := strings.TrimSpace(req.Content)
+	if content == "" || len([]rune(content)) > MaxReplyLength {
+		return nil, ErrInvalidReply
+	}
+
+	story, err := s.storyRepo.GetVisible(ctx, storyID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get story: %w", err)
+	}
+	if story == nil {
+		return nil, ErrStoryNotFound
+	}
+	if story.UserID == userID {
+		return nil, ErrReplyOwnStory
+	}
+
+	permission, err := s.messagingService.CanMessage(ctx, userID, story.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check messaging permission: %w", err)
+	}
+	if !permission.CanMessage {
+		return nil, fmt.Errorf("%w: %s", ErrCannotReply, permission.Reason)
+	}
+
+	message, err := s.messagingService.ReplyToStory(ctx, userID, story.UserID, story.ID, content)
+	if err != nil {
+		var rejection *moderation.RejectionError
+		if errors.As(err, &rejection) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to send reply: %w", err)
+	}
+
+	return &ReplyResponse{
+		ConversationID: message.ConversationID,
+		MessageID:      message.ID,
+	}, nil
+}
+
+// Cleanup deletes expired stories and the files of their images
+func (s *storyService) Cleanup(ctx context.Context) error {
+	result, err := s.storyRepo.DeleteExpired(ctx, cleanupBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to delete expired stories: %w", err)
+	}
+
+	deleteFiles(result.MediaIDs)
+
+	if result.Stories > 0 {
+		log.Printf("🧹 Deleted %d expired stories and %d images", result.Stories, len(result.MediaIDs))
+	}
+
+	return nil
+}
+
+// Helper functions
+
+// getAuthor returns the public info of a user
+func (s *storyService) getAuthor(ctx context.Context, userID string) (*AuthorInfo, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil || user == nil || !user.IsActive {
+		return nil, ErrUserNotFound
+	}
+
+	return &AuthorInfo{
+		ID:        user.ID,
+		Username:  user.Username,
+		FirstName: user.FirstName,
+		AvatarURL: user.AvatarURL,
+	}, nil
+}
+
+// toResponse builds the response for a story; the view count is only shown to the author
+func (s *storyService) toResponse(story *Story, author *AuthorInfo, viewerID string) *StoryResponse {
+	response := &StoryResponse{
+		ID:        story.ID,
+		Author:    *author,
+		IsSeen:    story.IsSeen,
+		CreatedAt: story.CreatedAt.Format(time.RFC3339),
+		ExpiresAt: story.ExpiresAt.Format(time.RFC3339),
+	}
+	if story.Content != nil {
+		response.Content = *story.Content
+	}
+	if story.MediaID != nil {
+		response.Media = &StoryMediaInfo{
+			ID:      *story.MediaID,
+			AltText: story.AltText,
+			URLs:    media.VariantURLs(*story.MediaID),
+		}
+	}
+	if story.UserID == viewerID {
+		viewCount := story.ViewCount
+		response.ViewCount = &viewCount
+	}
+	return response
+}
+
+// toAuthorInfo converts a user row to its response
+func toAuthorInfo(author *Author) AuthorInfo {
+	return AuthorInfo{
+		ID:        author.ID,
+		Username:  author.Username,
+		FirstName: author.FirstName,
+		AvatarURL: author.AvatarURL,
+	}
+}
+
+// deleteFiles removes the stored variants of deleted story images
+func deleteFiles(mediaIDs []string) {
+	for _, mediaID := range mediaIDs {
+		if err := mediapkg.Delete(mediaID); err != nil {
+			log.Printf("⚠️ Failed to delete files of story media %s: %v", mediaID, err)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package story
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"mockhu-app-backend/internal/app/auth"
+	mediapkg "mockhu-app-backend/internal/pkg/media"
+)
+
+// fakeStoryRepo serves the stories each viewer may see, recording views
+type fakeStoryRepo struct {
+	StoryRepository
+
+	stories map[string]*Story          // Story ID -> story
+	hidden  map[string]map[string]bool // Story ID -> viewers who may not see it
+	views   []string                   // Viewer IDs, in order
+	expired *CleanupResult
+}
+
+func (r *fakeStoryRepo) GetVisible(ctx context.Context, storyID, viewerID string) (*Story, error) {
+	if r.hidden[storyID][viewerID] {
+		return nil, nil
+	}
+	return r.stories[storyID], nil
+}
+
+func (r *fakeStoryRepo) RecordView(ctx context.Context, storyID, viewerID string) error {
+	r.views = append(r.views, viewerID)
+	return nil
+}
+
+func (r *fakeStoryRepo) GetViewers(ctx context.Context, storyID string, limit, offset int) ([]*Viewer, error) {
+	return []*Viewer{{Author: Author{ID: "viewer", Username: "viewer"}}}, nil
+}
+
+func (r *fakeStoryRepo) DeleteExpired(ctx context.Context, limit int) (*CleanupResult, error) {
+	return r.expired, nil
+}
+
+// fakeUserRepo knows every user ID it is asked for
+type fakeUserRepo struct {
+	auth.UserRepository
+}
+
+func (r *fakeUserRepo) FindByID(ctx context.Context, id string) (*auth.User, error) {
+	return &auth.User{ID: id, Username: id, IsActive: true}, nil
+}
+
+func TestGetViewersIsOwnerOnly(t *testing.T) {
+	repo := &fakeStoryRepo{
+		stories: map[string]*Story{"story": {ID: "story", UserID: "author", ViewCount: 1}},
+		hidden:  map[string]map[string]bool{"story": {"blocked": true}},
+	}
+	s := NewService(repo, &fakeUserRepo{}, nil, nil, nil)
+
+	tests := []struct {
+		name    string
+		storyID string
+		userID  string
+		wantErr error
+	}{
+		{name: "author", storyID: "story", userID: "author"},
+		{name: "another user", storyID: "story", userID: "viewer", wantErr: ErrUnauthorized},
+		{name: "blocked user", storyID: "story", userID: "blocked", wantErr: ErrStoryNotFound},
+		{name: "expired or missing story", storyID: "gone", userID: "author", wantErr: ErrStoryNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.GetViewers(context.Background(), tt.storyID, tt.userID, 1, 20)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetViewers: err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (len(resp.Viewers) != 1 || resp.ViewCount != 1) {
+				t.Errorf("GetViewers = %+v, want the one viewer", resp)
+			}
+		})
+	}
+}
+
+func TestViewCountIsShownToTheAuthorOnly(t *testing.T) {
+	repo := &fakeStoryRepo{
+		stories: map[string]*Story{"story": {ID: "story", UserID: "author", ViewCount: 3}},
+	}
+	s := NewService(repo, &fakeUserRepo{}, nil, nil, nil)
+
+	for userID, wantCount := range map[string]bool{"author": true, "viewer": false, "": false} {
+		resp, err := s.GetStory(context.Background(), "story", userID)
+		if err != nil {
+			t.Fatalf("GetStory as %q: %v", userID, err)
+		}
+		if (resp.ViewCount != nil) != wantCount {
+			t.Errorf("GetStory as %q: view count = %v, shown want %v", userID, resp.ViewCount, wantCount)
+		}
+	}
+}
+
+func TestViewStorySkipsTheAuthor(t *testing.T) {
+	repo := &fakeStoryRepo{
+		stories: map[string]*Story{"story": {ID: "story", UserID: "author"}},
+		hidden:  map[string]map[string]bool{"story": {"blocked": true}},
+	}
+	s := NewService(repo, &fakeUserRepo{}, nil, nil, nil)
+	ctx := context.Background()
+
+	if err := s.ViewStory(ctx, "story", "author"); err != nil {
+		t.Fatalf("ViewStory by the author: %v", err)
+	}
+	if err := s.ViewStory(ctx, "story", "viewer"); err != nil {
+		t.Fatalf("ViewStory: %v", err)
+	}
+	if err := s.ViewStory(ctx, "story", "blocked"); !errors.Is(err, ErrStoryNotFound) {
+		t.Errorf("ViewStory by a blocked user: err = %v, want ErrStoryNotFound", err)
+	}
+
+	if len(repo.views) != 1 || repo.views[0] != "viewer" {
+		t.Errorf("recorded views = %v, want only viewer", repo.views)
+	}
+}
+
+func TestCleanupDeletesMediaFiles(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	// Files of the expired story's image, and of an image still in use
+	for _, key := range []string{"expired", "kept"} {
+		dir := filepath.Join(mediapkg.StorageDir, key)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("create %s: %v", dir, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "large.jpg"), []byte("jpeg"), 0o644); err != nil {
+			t.Fatalf("write %s: %v", dir, err)
+		}
+	}
+
+	repo := &fakeStoryRepo{expired: &CleanupResult{Stories: 2, MediaIDs: []string{"expired"}}}
+	s := NewService(repo, &fakeUserRepo{}, nil, nil, nil)
+
+	if err := s.Cleanup(context.Background()); err != nil {
+		t.Fatalf("Cleanup: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(mediapkg.StorageDir, "expired")); !os.IsNotExist(err) {
+		t.Errorf("files of the expired story's image still exist (stat err = %v)", err)
+	}
+	if _, err := os.Stat(filepath.Join(mediapkg.StorageDir, "kept")); err != nil {
+		t.Errorf("files of another image were deleted: %v", err)
+	}
+}
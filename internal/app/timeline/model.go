@@ -0,0 +1,27 @@
+package timeline
+
+import "time"
+
+const (
+	// MaxLength is how many entries a stored home timeline keeps; older ones are trimmed
+	MaxLength = 800
+
+	// FanoutFollowerLimit is the follower count at which an author's posts stop being pushed
+	// to followers' timelines on publish and are pulled in when timelines are read instead
+	FanoutFollowerLimit = 10000
+
+	// BackfillSize is how many recent posts of a newly followed user are added to the
+	// follower's timeline
+	BackfillSize = 20
+
+	// StoredTTL is how long the Redis store keeps the timeline of a user who stops reading
+	// it; it is rebuilt on their next visit
+	StoredTTL = 7 * 24 * time.Hour
+)
+
+// Entry is a post in a home timeline
+type Entry struct {
+	PostID    string
+	AuthorID  string
+	CreatedAt time.Time
+}
@@ -0,0 +1,29 @@
+package timeline
+
+import "context"
+
+// TimelineRepository defines the interface for reading the posts and follows timelines are
+// built from. Only published, non-anonymous posts are timeline entries; visibility to the
+// reader is checked when the feed is loaded.
+type TimelineRepository interface {
+	// GetEntry returns the entry for a post, or nil if the post isn't a feed post
+	GetEntry(ctx context.Context, postID string) (*Entry, error)
+
+	// GetFollowerIDs returns the IDs of a user's followers
+	GetFollowerIDs(ctx context.Context, userID string) ([]string, error)
+
+	// GetFollowerCount returns a user's follower count
+	GetFollowerCount(ctx context.Context, userID string) (int, error)
+
+	// GetAuthorEntries returns the latest entries of one author, newest first
+	GetAuthorEntries(ctx context.Context, authorID string, limit int) ([]Entry, error)
+
+	// GetPushedEntries returns the latest entries of the users a user follows that have
+	// fewer than maxFollowers followers, newest first
+	GetPushedEntries(ctx context.Context, userID string, maxFollowers, limit int) ([]Entry, error)
+
+	// GetPulledEntries returns the latest entries that are never pushed to a user's
+	// timeline: those of followed users with at least minFollowers followers and posts
+	// tagged with interests the user follows, newest first
+	GetPulledEntries(ctx context.Context, userID string, minFollowers, limit int) ([]Entry, error)
+}
@@ -0,0 +1,153 @@
+package timeline
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// feedPost is true for posts aliased as p that can appear in home feeds
+const feedPost = `p.is_active = true AND p.status = 'published' AND p.is_anonymous = false`
+
+// PostgresTimelineRepository implements TimelineRepository using PostgreSQL
+type PostgresTimelineRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresTimelineRepository creates a new PostgreSQL timeline repository
+func NewPostgresTimelineRepository(pool *pgxpool.Pool) *PostgresTimelineRepository {
+	return &PostgresTimelineRepository{pool: pool}
+}
+
+// GetEntry returns the entry for a post, or nil if the post isn't a feed post
+func (r *PostgresTimelineRepository) GetEntry(ctx context.Context, postID string) (*Entry, error) {
+	query := `
+		SELECT p.id, p.user_id, p.created_at
+		FROM posts p
+		WHERE p.id = $1 AND ` + feedPost + `
+	`
+
+	entry := &Entry{}
+	err := r.pool.QueryRow(ctx, query, postID).Scan(&entry.PostID, &entry.AuthorID, &entry.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// GetFollowerIDs returns the IDs of a user's followers
+func (r *PostgresTimelineRepository) GetFollowerIDs(ctx context.Context, userID string) ([]string, error) {
+	rows, err := r.pool.Query(ctx, `SELECT follower_id FROM user_follows WHERE following_id = $1`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// GetFollowerCount returns a user's follower count
+func (r *PostgresTimelineRepository) GetFollowerCount(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx, `SELECT follower_count FROM users WHERE id = $1`, userID).Scan(&count)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+	return count, err
+}
+
+// GetAuthorEntries returns the latest entries of one author, newest first
+func (r *PostgresTimelineRepository) GetAuthorEntries(ctx context.Context, authorID string, limit int) ([]Entry, error) {
+	query := `
+		SELECT p.id, p.user_id, p.created_at
+		FROM posts p
+		WHERE p.user_id = $1 AND ` + feedPost + `
+		ORDER BY p.created_at DESC, p.id DESC
+		LIMIT $2
+	`
+
+	return r.queryEntries(ctx, query, authorID, limit)
+}
+
+// GetPushedEntries returns the latest entries of the followed users below maxFollowers
+func (r *PostgresTimelineRepository) GetPushedEntries(ctx context.Context, userID string, maxFollowers, limit int) ([]Entry, error) {
+	query := `
+		SELECT p.id, p.user_id, p.created_at
+		FROM posts p
+		JOIN user_follows f ON f.following_id = p.user_id AND f.follower_id = $1
+		JOIN users u ON u.id = p.user_id
+		WHERE u.follower_count < $2 AND ` + feedPost + `
+		ORDER BY p.created_at DESC, p.id DESC
+		LIMIT $3
+	`
+
+	return r.queryEntries(ctx, query, userID, maxFollowers, limit)
+}
+
+// GetPulledEntries returns the latest entries of followed users with at least minFollowers
+// followers, and of other users' posts tagged with interests the user follows
+func (r *PostgresTimelineRepository) GetPulledEntries(ctx context.Context, userID string, minFollowers, limit int) ([]Entry, error) {
+	query := `
+		SELECT p.id, p.user_id, p.created_at
+		FROM posts p
+		WHERE (
+			p.user_id IN (
+				SELECT f.following_id FROM user_follows f
+				JOIN users u ON u.id = f.following_id
+				WHERE f.follower_id = $1 AND u.follower_count >= $2
+			)
+			OR (
+				p.user_id <> $1
+				AND p.id IN (
+					SELECT pi.post_id FROM post_interests pi
+					JOIN interest_follows inf ON inf.interest_id = pi.interest_id
+					WHERE inf.user_id = $1
+				)
+			)
+		)
+		AND ` + feedPost + `
+		ORDER BY p.created_at DESC, p.id DESC
+		LIMIT $3
+	`
+
+	return r.queryEntries(ctx, query, userID, minFollowers, limit)
+}
+
+// Helper methods
+
+// queryEntries runs a query selecting post ID, author ID and creation time
+func (r *PostgresTimelineRepository) queryEntries(ctx context.Context, query string, args ...interface{}) ([]Entry, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []Entry
+
+	for rows.Next() {
+		var entry Entry
+		if err := rows.Scan(&entry.PostID, &entry.AuthorID, &entry.CreatedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
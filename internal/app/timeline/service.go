@@ -0,0 +1,184 @@
+package timeline
+
+import (
+	"context"
+	"fmt"
+	"log"
+)
+
+// TimelineService defines the business logic for precomputed home timelines. Posts are
+// pushed to followers' timelines when published (fan-out on write), except for authors
+// with FanoutFollowerLimit followers or more, whose posts are pulled in when a timeline is
+// read (fan-out on read) along with posts from followed interests.
+type TimelineService interface {
+	// Publish pushes a newly published (or restored) post to its author's followers
+	Publish(ctx context.Context, postID string) error
+
+	// Remove removes a deleted post from its author's followers' timelines
+	Remove(ctx context.Context, postID, authorID string) error
+
+	// Follow backfills the follower's timeline with recent posts of the followed user
+	Follow(ctx context.Context, followerID, followingID string) error
+
+	// Unfollow removes the unfollowed user's posts from the follower's timeline
+	Unfollow(ctx context.Context, followerID, followingID string) error
+
+	// Block removes each user's posts from the other's timeline
+	Block(ctx context.Context, blockerID, blockedID string) error
+
+	// GetHome returns the latest limit entries of a user's home timeline, newest first,
+	// building the stored timeline first if needed
+	GetHome(ctx context.Context, userID string, limit int) ([]Entry, error)
+}
+
+// timelineService implements TimelineService
+type timelineService struct {
+	timelineRepo TimelineRepository
+	store        Store
+}
+
+// NewService creates a new timeline service
+func NewService(timelineRepo TimelineRepository, store Store) TimelineService {
+	return &timelineService{
+		timelineRepo: timelineRepo,
+		store:        store,
+	}
+}
+
+// Publish pushes a post to the timelines of its author's followers. Anonymous and
+// unpublished posts are never pushed, nor are posts of authors above FanoutFollowerLimit.
+func (s *timelineService) Publish(ctx context.Context, postID string) error {
+	entry, err := s.timelineRepo.GetEntry(ctx, postID)
+	if err != nil {
+		return fmt.Errorf("failed to get post: %w", err)
+	}
+	if entry == nil {
+		return nil
+	}
+
+	followerIDs, pushed, err := s.pushTargets(ctx, entry.AuthorID)
+	if err != nil || !pushed {
+		return err
+	}
+
+	if err := s.store.Add(ctx, followerIDs, []Entry{*entry}); err != nil {
+		return fmt.Errorf("failed to fan out post: %w", err)
+	}
+
+	return nil
+}
+
+// Remove removes a post from the timelines of its author's followers. Posts of authors
+// above the limit may still be stored from before they crossed it, so every follower is
+// cleaned up.
+func (s *timelineService) Remove(ctx context.Context, postID, authorID string) error {
+	followerIDs, err := s.timelineRepo.GetFollowerIDs(ctx, authorID)
+	if err != nil {
+		return fmt.Errorf("failed to get followers: %w", err)
+	}
+
+	if err := s.store.RemovePost(ctx, followerIDs, Entry{PostID: postID, AuthorID: authorID}); err != nil {
+		return fmt.Errorf("failed to remove post from timelines: %w", err)
+	}
+
+	return nil
+}
+
+// Follow backfills the follower's timeline with the followed user's latest posts, unless
+// they are pulled on read anyway
+func (s *timelineService) Follow(ctx context.Context, followerID, followingID string) error {
+	count, err := s.timelineRepo.GetFollowerCount(ctx, followingID)
+	if err != nil {
+		return fmt.Errorf("failed to get follower count: %w", err)
+	}
+	if count >= FanoutFollowerLimit {
+		return nil
+	}
+
+	entries, err := s.timelineRepo.GetAuthorEntries(ctx, followingID, BackfillSize)
+	if err != nil {
+		return fmt.Errorf("failed to get posts: %w", err)
+	}
+
+	if err := s.store.Add(ctx, []string{followerID}, entries); err != nil {
+		return fmt.Errorf("failed to backfill timeline: %w", err)
+	}
+
+	return nil
+}
+
+// Unfollow removes the unfollowed user's posts from the follower's timeline
+func (s *timelineService) Unfollow(ctx context.Context, followerID, followingID string) error {
+	if err := s.store.RemoveAuthor(ctx, followerID, followingID); err != nil {
+		return fmt.Errorf("failed to prune timeline: %w", err)
+	}
+	return nil
+}
+
+// Block removes each user's posts from the other's timeline
+func (s *timelineService) Block(ctx context.Context, blockerID, blockedID string) error {
+	if err := s.store.RemoveAuthor(ctx, blockerID, blockedID); err != nil {
+		return fmt.Errorf("failed to prune timeline: %w", err)
+	}
+	if err := s.store.RemoveAuthor(ctx, blockedID, blockerID); err != nil {
+		return fmt.Errorf("failed to prune timeline: %w", err)
+	}
+	return nil
+}
+
+// GetHome merges the stored timeline with the pulled entries, newest first. A missing
+// timeline is rebuilt from the followed users' posts.
+func (s *timelineService) GetHome(ctx context.Context, userID string, limit int) ([]Entry, error) {
+	pushed, built, err := s.store.Get(ctx, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get timeline: %w", err)
+	}
+
+	if !built {
+		all, err := s.timelineRepo.GetPushedEntries(ctx, userID, FanoutFollowerLimit, MaxLength)
+		if err != nil {
+			return nil, fmt.Errorf("failed to rebuild timeline: %w", err)
+		}
+		if err := s.store.Replace(ctx, userID, all); err != nil {
+			log.Printf("⚠️ Failed to store timeline of user %s: %v", userID, err)
+		}
+
+		pushed = all
+		if len(pushed) > limit {
+			pushed = pushed[:limit]
+		}
+	}
+
+	pulled, err := s.timelineRepo.GetPulledEntries(ctx, userID, FanoutFollowerLimit, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pulled posts: %w", err)
+	}
+
+	entries := merge(pushed, pulled)
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	return entries, nil
+}
+
+// Helper methods
+
+// pushTargets returns the followers an author's posts are pushed to; pushed is false if
+// the author has too many followers and their posts are pulled on read instead
+func (s *timelineService) pushTargets(ctx context.Context, authorID string) ([]string, bool, error) {
+	count, err := s.timelineRepo.GetFollowerCount(ctx, authorID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get follower count: %w", err)
+	}
+	if count >= FanoutFollowerLimit {
+		return nil, false, nil
+	}
+
+	followerIDs, err := s.timelineRepo.GetFollowerIDs(ctx, authorID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get followers: %w", err)
+	}
+
+	return followerIDs, true, nil
+}
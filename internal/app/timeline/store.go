@@ -0,0 +1,89 @@
+package timeline
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"mockhu-app-backend/internal/infra/redis"
+)
+
+// Store keeps precomputed home timelines. A timeline exists only once it has been built
+// with Replace; Add skips users whose timeline isn't built, since it is rebuilt from
+// scratch on their next read anyway.
+type Store interface {
+	// Get returns up to limit entries of a user's timeline, newest first. built is false
+	// if the timeline doesn't exist (never built or expired) and must be rebuilt.
+	Get(ctx context.Context, userID string, limit int) (entries []Entry, built bool, err error)
+
+	// Replace sets a user's timeline to entries and marks it built
+	Replace(ctx context.Context, userID string, entries []Entry) error
+
+	// Add adds entries to the built timelines among userIDs, trimming each to MaxLength
+	Add(ctx context.Context, userIDs []string, entries []Entry) error
+
+	// RemovePost removes a post from the timelines of userIDs
+	RemovePost(ctx context.Context, userIDs []string, entry Entry) error
+
+	// RemoveAuthor removes every post by authorID from a user's timeline
+	RemoveAuthor(ctx context.Context, userID, authorID string) error
+}
+
+// Store backends
+const (
+	BackendMemory   = "memory"
+	BackendPostgres = "postgres"
+	BackendRedis    = "redis"
+)
+
+// Config selects and configures the timeline store
+type Config struct {
+	Backend   string
+	RedisAddr string
+}
+
+// ConfigFromEnv reads the timeline store config from the environment. TIMELINE_STORE picks
+// the backend (postgres by default); the redis backend connects to REDIS_ADDR.
+func ConfigFromEnv() Config {
+	cfg := Config{
+		Backend:   BackendPostgres,
+		RedisAddr: redis.AddrFromEnv(),
+	}
+	if v := os.Getenv("TIMELINE_STORE"); v != "" {
+		cfg.Backend = v
+	}
+	return cfg
+}
+
+// NewStore creates the configured store. The in-memory store is only suitable for a single
+// instance, since every instance would keep its own timelines.
+func NewStore(ctx context.Context, cfg Config, pool *pgxpool.Pool) (Store, error) {
+	switch cfg.Backend {
+	case BackendMemory:
+		return NewMemoryStore(), nil
+	case BackendPostgres:
+		return NewPostgresStore(pool), nil
+	case BackendRedis:
+		client, err := redis.New(ctx, cfg.RedisAddr)
+		if err != nil {
+			return nil, err
+		}
+		return NewRedisStore(client), nil
+	}
+	return nil, fmt.Errorf("unknown timeline store %q", cfg.Backend)
+}
+
+// Helper functions
+
+// sortEntries sorts entries newest first, by post ID among posts created at the same time
+func sortEntries(entries []Entry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].CreatedAt.Equal(entries[j].CreatedAt) {
+			return entries[i].CreatedAt.After(entries[j].CreatedAt)
+		}
+		return entries[i].PostID > entries[j].PostID
+	})
+}
@@ -0,0 +1,112 @@
+package timeline
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryStore keeps timelines in process memory; they are lost on restart
+type MemoryStore struct {
+	mu        sync.Mutex
+	timelines map[string][]Entry // Newest first, by user ID
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{timelines: make(map[string][]Entry)}
+}
+
+// Get returns up to limit entries of a user's timeline, newest first
+func (s *MemoryStore) Get(ctx context.Context, userID string, limit int) ([]Entry, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, built := s.timelines[userID]
+	if !built {
+		return nil, false, nil
+	}
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return append([]Entry(nil), entries...), true, nil
+}
+
+// Replace sets a user's timeline to entries and marks it built
+func (s *MemoryStore) Replace(ctx context.Context, userID string, entries []Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.timelines[userID] = merge(nil, entries)
+	return nil
+}
+
+// Add adds entries to the built timelines among userIDs
+func (s *MemoryStore) Add(ctx context.Context, userIDs []string, entries []Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, userID := range userIDs {
+		if existing, built := s.timelines[userID]; built {
+			s.timelines[userID] = merge(existing, entries)
+		}
+	}
+	return nil
+}
+
+// RemovePost removes a post from the timelines of userIDs
+func (s *MemoryStore) RemovePost(ctx context.Context, userIDs []string, entry Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, userID := range userIDs {
+		if existing, built := s.timelines[userID]; built {
+			s.timelines[userID] = filter(existing, func(e Entry) bool { return e.PostID != entry.PostID })
+		}
+	}
+	return nil
+}
+
+// RemoveAuthor removes every post by authorID from a user's timeline
+func (s *MemoryStore) RemoveAuthor(ctx context.Context, userID, authorID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, built := s.timelines[userID]; built {
+		s.timelines[userID] = filter(existing, func(e Entry) bool { return e.AuthorID != authorID })
+	}
+	return nil
+}
+
+// Helper functions
+
+// merge returns a new timeline of existing and added entries without duplicates, newest
+// first and trimmed to MaxLength
+func merge(existing, added []Entry) []Entry {
+	seen := make(map[string]bool, len(existing)+len(added))
+	entries := make([]Entry, 0, len(existing)+len(added))
+	for _, list := range [][]Entry{existing, added} {
+		for _, entry := range list {
+			if !seen[entry.PostID] {
+				seen[entry.PostID] = true
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	sortEntries(entries)
+	if len(entries) > MaxLength {
+		entries = entries[:MaxLength]
+	}
+	return entries
+}
+
+// filter returns a new timeline of the entries for which keep returns true
+func filter(entries []Entry, keep func(Entry) bool) []Entry {
+	kept := make([]Entry, 0, len(entries))
+	for _, entry := range entries {
+		if keep(entry) {
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,162 @@
+package timeline
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresStore keeps timelines in the home_timelines table
+type PostgresStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresStore creates a new PostgreSQL timeline store
+func NewPostgresStore(pool *pgxpool.Pool) *PostgresStore {
+	return &PostgresStore{pool: pool}
+}
+
+// Get returns up to limit entries of a user's timeline, newest first
+func (s *PostgresStore) Get(ctx context.Context, userID string, limit int) ([]Entry, bool, error) {
+	var built bool
+	err := s.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM home_timeline_builds WHERE user_id = $1)`, userID).Scan(&built)
+	if err != nil || !built {
+		return nil, false, err
+	}
+
+	query := `
+		SELECT post_id, author_id, created_at
+		FROM home_timelines
+		WHERE user_id = $1
+		ORDER BY created_at DESC, post_id DESC
+		LIMIT $2
+	`
+
+	rows, err := s.pool.Query(ctx, query, userID, limit)
+	if err != nil {
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	var entries []Entry
+
+	for rows.Next() {
+		var entry Entry
+		if err := rows.Scan(&entry.PostID, &entry.AuthorID, &entry.CreatedAt); err != nil {
+			return nil, false, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, true, rows.Err()
+}
+
+// Replace sets a user's timeline to entries and marks it built
+func (s *PostgresStore) Replace(ctx context.Context, userID string, entries []Entry) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM home_timelines WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	if len(entries) > MaxLength {
+		entries = entries[:MaxLength]
+	}
+	postIDs, authorIDs, createdAts := columns(entries)
+
+	query := `
+		INSERT INTO home_timelines (user_id, post_id, author_id, created_at)
+		SELECT $1, e.post_id, e.author_id, e.created_at
+		FROM unnest($2::uuid[], $3::uuid[], $4::timestamp[]) AS e(post_id, author_id, created_at)
+		ON CONFLICT (user_id, post_id) DO NOTHING
+	`
+
+	if _, err := tx.Exec(ctx, query, userID, postIDs, authorIDs, createdAts); err != nil {
+		return err
+	}
+
+	query = `
+		INSERT INTO home_timeline_builds (user_id, built_at)
+		VALUES ($1, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET built_at = NOW()
+	`
+
+	if _, err := tx.Exec(ctx, query, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Add adds entries to the built timelines among userIDs, then trims those timelines
+func (s *PostgresStore) Add(ctx context.Context, userIDs []string, entries []Entry) error {
+	if len(userIDs) == 0 || len(entries) == 0 {
+		return nil
+	}
+
+	postIDs, authorIDs, createdAts := columns(entries)
+
+	query := `
+		INSERT INTO home_timelines (user_id, post_id, author_id, created_at)
+		SELECT b.user_id, e.post_id, e.author_id, e.created_at
+		FROM home_timeline_builds b
+		CROSS JOIN unnest($2::uuid[], $3::uuid[], $4::timestamp[]) AS e(post_id, author_id, created_at)
+		WHERE b.user_id = ANY($1::uuid[])
+		ON CONFLICT (user_id, post_id) DO NOTHING
+	`
+
+	if _, err := s.pool.Exec(ctx, query, userIDs, postIDs, authorIDs, createdAts); err != nil {
+		return err
+	}
+
+	query = `
+		DELETE FROM home_timelines h
+		USING (
+			SELECT user_id, post_id,
+			       ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY created_at DESC, post_id DESC) AS position
+			FROM home_timelines
+			WHERE user_id = ANY($1::uuid[])
+		) ranked
+		WHERE h.user_id = ranked.user_id AND h.post_id = ranked.post_id
+		AND ranked.position > $2
+	`
+
+	_, err := s.pool.Exec(ctx, query, userIDs, MaxLength)
+	return err
+}
+
+// RemovePost removes a post from the timelines of userIDs
+func (s *PostgresStore) RemovePost(ctx context.Context, userIDs []string, entry Entry) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	_, err := s.pool.Exec(ctx, `DELETE FROM home_timelines WHERE post_id = $1 AND user_id = ANY($2::uuid[])`, entry.PostID, userIDs)
+	return err
+}
+
+// RemoveAuthor removes every post by authorID from a user's timeline
+func (s *PostgresStore) RemoveAuthor(ctx context.Context, userID, authorID string) error {
+	_, err := s.pool.Exec(ctx, `DELETE FROM home_timelines WHERE user_id = $1 AND author_id = $2`, userID, authorID)
+	return err
+}
+
+// Helper functions
+
+// columns splits entries into one array per column, for unnest
+func columns(entries []Entry) ([]string, []string, []time.Time) {
+	postIDs := make([]string, len(entries))
+	authorIDs := make([]string, len(entries))
+	createdAts := make([]time.Time, len(entries))
+	for i, entry := range entries {
+		postIDs[i] = entry.PostID
+		authorIDs[i] = entry.AuthorID
+		createdAts[i] = entry.CreatedAt
+	}
+	return postIDs, authorIDs, createdAts
+}
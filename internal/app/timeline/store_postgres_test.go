@@ -0,0 +1,28 @@
+package timeline
+
+import (
+	"context"
+	"testing"
+
+	"mockhu-app-backend/internal/pkg/testdb"
+)
+
+func TestPostgresStore(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	userID := testdb.CreateUser(t, pool)
+	unbuiltID := testdb.CreateUser(t, pool)
+	authors := []string{testdb.CreateUser(t, pool), testdb.CreateUser(t, pool)}
+
+	// Timeline entries must point at real posts
+	entries := fakeEntries(MaxLength+1, authors...)
+	for i := range entries {
+		err := pool.QueryRow(ctx, `INSERT INTO posts (user_id, content) VALUES ($1, 'post') RETURNING id`, entries[i].AuthorID).Scan(&entries[i].PostID)
+		if err != nil {
+			t.Fatalf("create post: %v", err)
+		}
+	}
+
+	testStore(t, NewPostgresStore(pool), userID, unbuiltID, entries)
+}
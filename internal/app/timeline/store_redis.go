@@ -0,0 +1,211 @@
+package timeline
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"mockhu-app-backend/internal/infra/redis"
+)
+
+// RedisStore keeps each timeline in a sorted set of "postID:authorID" members scored by
+// creation time in microseconds, next to a marker key saying it was built. Both keys
+// expire StoredTTL after the user last read their timeline.
+type RedisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore creates a timeline store on a Redis-compatible server
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+// Get returns up to limit entries of a user's timeline, newest first, and extends its expiry
+func (s *RedisStore) Get(ctx context.Context, userID string, limit int) ([]Entry, bool, error) {
+	key, builtKey := timelineKey(userID), builtKey(userID)
+	ttl := strconv.Itoa(int(StoredTTL.Seconds()))
+
+	replies, err := s.client.Pipeline(ctx, [][]string{
+		{"EXISTS", builtKey},
+		{"ZREVRANGE", key, "0", strconv.Itoa(limit - 1), "WITHSCORES"},
+		{"EXPIRE", builtKey, ttl},
+		{"EXPIRE", key, ttl},
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if err := firstError(replies); err != nil {
+		return nil, false, err
+	}
+	if replies[0].Int == 0 {
+		return nil, false, nil
+	}
+
+	members := replies[1].Array
+	entries := make([]Entry, 0, len(members)/2)
+	for i := 0; i+1 < len(members); i += 2 {
+		entry, err := parseMember(members[i].Str, members[i+1].Str)
+		if err != nil {
+			return nil, false, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, true, nil
+}
+
+// Replace sets a user's timeline to entries and marks it built, atomically
+func (s *RedisStore) Replace(ctx context.Context, userID string, entries []Entry) error {
+	key, builtKey := timelineKey(userID), builtKey(userID)
+	ttl := strconv.Itoa(int(StoredTTL.Seconds()))
+
+	if len(entries) > MaxLength {
+		entries = entries[:MaxLength]
+	}
+
+	cmds := [][]string{{"MULTI"}, {"DEL", key}}
+	if len(entries) > 0 {
+		cmds = append(cmds, zadd(key, entries), []string{"EXPIRE", key, ttl})
+	}
+	cmds = append(cmds, []string{"SET", builtKey, "1", "EX", ttl}, []string{"EXEC"})
+
+	return s.exec(ctx, cmds)
+}
+
+// Add adds entries to the built timelines among userIDs, trimming each to MaxLength.
+// Each timeline is updated by one script, so a timeline that expires or is rebuilt
+// meanwhile is never left partially written or without an expiry.
+func (s *RedisStore) Add(ctx context.Context, userIDs []string, entries []Entry) error {
+	if len(userIDs) == 0 || len(entries) == 0 {
+		return nil
+	}
+
+	members := scoredMembers(entries)
+	cmds := make([][]string, len(userIDs))
+	for i, userID := range userIDs {
+		cmd := make([]string, 0, 6+len(members))
+		cmd = append(cmd, "EVAL", addScript, "2", timelineKey(userID), builtKey(userID), strconv.Itoa(MaxLength))
+		cmds[i] = append(cmd, members...)
+	}
+
+	return s.exec(ctx, cmds)
+}
+
+// addScript adds score/member pairs from ARGV[2:] to the timeline at KEYS[1] if its
+// marker KEYS[2] exists, keeps the newest ARGV[1] members and expires the timeline with
+// its marker
+const addScript = `
+local ttl = redis.call('PTTL', KEYS[2])
+if ttl == -2 then
+	return 0
+end
+redis.call('ZADD', KEYS[1], unpack(ARGV, 2))
+redis.call('ZREMRANGEBYRANK', KEYS[1], 0, -tonumber(ARGV[1]) - 1)
+if ttl > 0 then
+	redis.call('PEXPIRE', KEYS[1], ttl)
+end
+return 1
+`
+
+// RemovePost removes a post from the timelines of userIDs
+func (s *RedisStore) RemovePost(ctx context.Context, userIDs []string, entry Entry) error {
+	member := formatMember(entry)
+
+	cmds := make([][]string, len(userIDs))
+	for i, userID := range userIDs {
+		cmds[i] = []string{"ZREM", timelineKey(userID), member}
+	}
+
+	return s.exec(ctx, cmds)
+}
+
+// RemoveAuthor removes every post by authorID from a user's timeline
+func (s *RedisStore) RemoveAuthor(ctx context.Context, userID, authorID string) error {
+	key := timelineKey(userID)
+
+	members, err := s.client.Do(ctx, "ZRANGE", key, "0", "-1")
+	if err != nil {
+		return err
+	}
+
+	cmd := []string{"ZREM", key}
+	for _, member := range members.Array {
+		if strings.HasSuffix(member.Str, ":"+authorID) {
+			cmd = append(cmd, member.Str)
+		}
+	}
+	if len(cmd) == 2 {
+		return nil
+	}
+
+	_, err = s.client.Do(ctx, cmd...)
+	return err
+}
+
+// Helper methods
+
+// exec runs a pipeline and returns the first error reply, if any
+func (s *RedisStore) exec(ctx context.Context, cmds [][]string) error {
+	replies, err := s.client.Pipeline(ctx, cmds)
+	if err != nil {
+		return err
+	}
+	return firstError(replies)
+}
+
+// Helper functions
+
+func timelineKey(userID string) string {
+	return "timeline:" + userID
+}
+
+func builtKey(userID string) string {
+	return "timeline:" + userID + ":built"
+}
+
+// zadd builds a ZADD of entries to key
+func zadd(key string, entries []Entry) []string {
+	return append([]string{"ZADD", key}, scoredMembers(entries)...)
+}
+
+// scoredMembers lists the score and member of each entry, as ZADD takes them
+func scoredMembers(entries []Entry) []string {
+	args := make([]string, 0, 2*len(entries))
+	for _, entry := range entries {
+		args = append(args, strconv.FormatInt(entry.CreatedAt.UnixMicro(), 10), formatMember(entry))
+	}
+	return args
+}
+
+func formatMember(entry Entry) string {
+	return entry.PostID + ":" + entry.AuthorID
+}
+
+// parseMember parses a sorted set member and its score back into an entry
+func parseMember(member, score string) (Entry, error) {
+	postID, authorID, ok := strings.Cut(member, ":")
+	if !ok {
+		return Entry{}, fmt.Errorf("malformed timeline member %q", member)
+	}
+	micros, err := strconv.ParseFloat(score, 64)
+	if err != nil {
+		return Entry{}, fmt.Errorf("malformed timeline score %q: %w", score, err)
+	}
+	return Entry{PostID: postID, AuthorID: authorID, CreatedAt: time.UnixMicro(int64(micros)).UTC()}, nil
+}
+
+// firstError returns the first error reply among replies, including errors inside the
+// reply to EXEC
+func firstError(replies []redis.Value) error {
+	for _, reply := range replies {
+		if reply.Err != nil {
+			return reply.Err
+		}
+		if err := firstError(reply.Array); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package timeline
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"mockhu-app-backend/internal/infra/redis"
+)
+
+func TestRedisStore(t *testing.T) {
+	client := testRedis(t)
+	userID, unbuiltID := testTimelineUser(t, client), testTimelineUser(t, client)
+
+	testStore(t, NewRedisStore(client), userID, unbuiltID, fakeEntries(MaxLength+1, uuid.NewString(), uuid.NewString()))
+}
+
+func TestRedisStoreAddExpires(t *testing.T) {
+	client := testRedis(t)
+	store := NewRedisStore(client)
+	ctx := context.Background()
+	userID, unbuiltID := testTimelineUser(t, client), testTimelineUser(t, client)
+
+	// An empty timeline has only its marker, so Add creates the sorted set
+	if err := store.Replace(ctx, userID, nil); err != nil {
+		t.Fatalf("Replace: %v", err)
+	}
+	if err := store.Add(ctx, []string{userID, unbuiltID}, fakeEntries(1, "author")); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	ttl, err := client.Do(ctx, "PTTL", timelineKey(userID))
+	if err != nil {
+		t.Fatalf("PTTL: %v", err)
+	}
+	if ttl.Int <= 0 || time.Duration(ttl.Int)*time.Millisecond > StoredTTL {
+		t.Errorf("timeline expires in %dms, want within StoredTTL", ttl.Int)
+	}
+
+	exists, err := client.Do(ctx, "EXISTS", timelineKey(unbuiltID))
+	if err != nil {
+		t.Fatalf("EXISTS: %v", err)
+	}
+	if exists.Int != 0 {
+		t.Error("Add created the timeline of a user whose timeline isn't built")
+	}
+}
+
+// testRedis connects to the server at TEST_REDIS_ADDR, skipping the test when it isn't set
+func testRedis(t *testing.T) *redis.Client {
+	t.Helper()
+
+	addr := os.Getenv("TEST_REDIS_ADDR")
+	if addr == "" {
+		t.Skip("TEST_REDIS_ADDR not set")
+	}
+
+	client, err := redis.New(context.Background(), addr)
+	if err != nil {
+		t.Fatalf("connect to test redis: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	return client
+}
+
+// testTimelineUser returns a new user ID whose timeline keys are deleted when the test ends
+func testTimelineUser(t *testing.T, client *redis.Client) string {
+	t.Helper()
+
+	userID := uuid.NewString()
+	t.Cleanup(func() {
+		_, _ = client.Do(context.Background(), "DEL", timelineKey(userID), builtKey(userID))
+	})
+
+	return userID
+}
@@ -0,0 +1,149 @@
+package timeline
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore(), "user", "unbuilt", fakeEntries(MaxLength+1, "a", "b"))
+}
+
+func TestMerge(t *testing.T) {
+	entries := fakeEntries(4, "a")
+
+	// Duplicates keep the first copy; the result is sorted newest first
+	got := merge([]Entry{entries[2], entries[0]}, []Entry{entries[1], entries[2], entries[3]})
+	if ids := postIDs(got); fmt.Sprint(ids) != fmt.Sprint(postIDs(entries)) {
+		t.Errorf("merge = %v, want %v", ids, postIDs(entries))
+	}
+}
+
+// testStore checks the behaviour every Store shares. entries must hold MaxLength+1
+// entries newest first, alternating between two authors; userID and unbuiltID must have
+// no stored timeline yet.
+func testStore(t *testing.T, store Store, userID, unbuiltID string, entries []Entry) {
+	t.Helper()
+	ctx := context.Background()
+
+	if _, built, err := store.Get(ctx, userID, 10); err != nil || built {
+		t.Fatalf("Get before Replace: built = %v, err = %v; want not built", built, err)
+	}
+
+	// Replace keeps the newest MaxLength entries
+	if err := store.Replace(ctx, userID, entries); err != nil {
+		t.Fatalf("Replace: %v", err)
+	}
+	assertTimeline(t, store, userID, entries[:MaxLength])
+
+	// Add trims the timeline back to MaxLength by dropping the oldest entries
+	if err := store.Replace(ctx, userID, entries[1:]); err != nil {
+		t.Fatalf("Replace: %v", err)
+	}
+	if err := store.Add(ctx, []string{userID, unbuiltID}, entries[:1]); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	assertTimeline(t, store, userID, entries[:MaxLength])
+
+	// Adding an entry again doesn't duplicate it
+	if err := store.Add(ctx, []string{userID}, entries[:2]); err != nil {
+		t.Fatalf("Add again: %v", err)
+	}
+	assertTimeline(t, store, userID, entries[:MaxLength])
+
+	// Unbuilt timelines are left alone
+	if _, built, err := store.Get(ctx, unbuiltID, 10); err != nil || built {
+		t.Errorf("Get unbuilt after Add: built = %v, err = %v; want not built", built, err)
+	}
+
+	got, _, err := store.Get(ctx, userID, 3)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	assertEntries(t, got, entries[:3])
+
+	if err := store.RemovePost(ctx, []string{userID, unbuiltID}, entries[0]); err != nil {
+		t.Fatalf("RemovePost: %v", err)
+	}
+	got, _, err = store.Get(ctx, userID, 2)
+	if err != nil {
+		t.Fatalf("Get after RemovePost: %v", err)
+	}
+	assertEntries(t, got, entries[1:3])
+
+	if err := store.RemoveAuthor(ctx, userID, entries[1].AuthorID); err != nil {
+		t.Fatalf("RemoveAuthor: %v", err)
+	}
+	got, _, err = store.Get(ctx, userID, MaxLength)
+	if err != nil {
+		t.Fatalf("Get after RemoveAuthor: %v", err)
+	}
+	// The other author's posts are left, except the one removed above
+	if len(got) != MaxLength/2-1 {
+		t.Errorf("after RemoveAuthor got %d entries, want %d", len(got), MaxLength/2-1)
+	}
+	for _, entry := range got {
+		if entry.AuthorID == entries[1].AuthorID {
+			t.Fatalf("post %s by the removed author is still in the timeline", entry.PostID)
+		}
+	}
+
+	// Replacing with nothing still marks the timeline built
+	if err := store.Replace(ctx, userID, nil); err != nil {
+		t.Fatalf("Replace with nothing: %v", err)
+	}
+	if got, built, err := store.Get(ctx, userID, 10); err != nil || !built || len(got) != 0 {
+		t.Errorf("Get empty timeline = %d entries, built %v, err %v; want built and empty", len(got), built, err)
+	}
+}
+
+// fakeEntries makes n entries a minute apart, newest first, alternating between authors
+func fakeEntries(n int, authors ...string) []Entry {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	entries := make([]Entry, n)
+	for i := range entries {
+		entries[i] = Entry{
+			PostID:    fmt.Sprintf("post-%04d", n-i),
+			AuthorID:  authors[i%len(authors)],
+			CreatedAt: now.Add(-time.Duration(i) * time.Minute),
+		}
+	}
+	return entries
+}
+
+// assertTimeline checks a user's whole timeline is want
+func assertTimeline(t *testing.T, store Store, userID string, want []Entry) {
+	t.Helper()
+
+	got, built, err := store.Get(context.Background(), userID, MaxLength+10)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if !built {
+		t.Fatal("Get: timeline not built")
+	}
+	assertEntries(t, got, want)
+}
+
+func assertEntries(t *testing.T, got, want []Entry) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].PostID != want[i].PostID || got[i].AuthorID != want[i].AuthorID || !got[i].CreatedAt.Equal(want[i].CreatedAt) {
+			t.Fatalf("entry %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func postIDs(entries []Entry) []string {
+	ids := make([]string, len(entries))
+	for i, entry := range entries {
+		ids[i] = entry.PostID
+	}
+	return ids
+}
@@ -0,0 +1,26 @@
+package trash
+
+// TrashItemResponse is a deleted post or comment that can still be restored
+type TrashItemResponse struct {
+	Type      string `json:"type"` // post or comment
+	ID        string `json:"id"`
+	PostID    string `json:"post_id,omitempty"` // The post a comment belongs to
+	Content   string `json:"content"`
+	Status    string `json:"status,omitempty"` // For posts: published, draft or scheduled
+	DeletedAt string `json:"deleted_at"`
+	ExpiresAt string `json:"expires_at"` // When the item is permanently deleted
+}
+
+// TrashResponse is the response for listing the trash
+type TrashResponse struct {
+	Items      []*TrashItemResponse `json:"items"`
+	Pagination PaginationInfo       `json:"pagination"`
+}
+
+// PaginationInfo contains pagination metadata
+type PaginationInfo struct {
+	Page       int `json:"page"`
+	TotalPages int `json:"total_pages"`
+	TotalItems int `json:"total_items"`
+	Limit      int `json:"limit"`
+}
@@ -0,0 +1,98 @@
+package trash
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles HTTP requests for the trash
+type Handler struct {
+	service TrashService
+}
+
+// NewHandler creates a new trash handler
+func NewHandler(service TrashService) *Handler {
+	return &Handler{service: service}
+}
+
+// GetTrash handles GET /v1/users/me/trash?type=post|comment
+func (h *Handler) GetTrash(c *fiber.Ctx) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	// Parse pagination
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	response, err := h.service.GetTrash(c.Context(), currentUserID, c.Query("type"), page, limit)
+	if err != nil {
+		if err == ErrInvalidType {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get trash",
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// RestorePost handles POST /v1/users/me/trash/posts/:postId/restore
+func (h *Handler) RestorePost(c *fiber.Ctx) error {
+	return h.restore(c, ItemPost, c.Params("postId"))
+}
+
+// RestoreComment handles POST /v1/users/me/trash/comments/:commentId/restore
+func (h *Handler) RestoreComment(c *fiber.Ctx) error {
+	return h.restore(c, ItemComment, c.Params("commentId"))
+}
+
+// restore restores one of the current user's deleted posts or comments
+func (h *Handler) restore(c *fiber.Ctx, itemType, id string) error {
+	// Get current user ID from JWT
+	currentUserID, ok := c.Locals("user_id").(string)
+	if !ok || currentUserID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "unauthorized",
+		})
+	}
+
+	if _, err := uuid.Parse(id); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid " + itemType + " ID",
+		})
+	}
+
+	if err := h.service.Restore(c.Context(), currentUserID, itemType, id); err != nil {
+		switch err {
+		case ErrItemNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case ErrRemovedByModeration:
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case ErrPostDeleted:
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to restore " + itemType,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": itemType + " restored successfully",
+	})
+}
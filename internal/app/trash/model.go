@@ -0,0 +1,30 @@
+package trash
+
+import "time"
+
+// Item types
+const (
+	ItemPost    = "post"
+	ItemComment = "comment"
+)
+
+// Item is a deleted post or comment in its author's trash
+type Item struct {
+	Type                string
+	ID                  string
+	UserID              string
+	PostID              string // The comment's post; the post itself for a post
+	Content             string
+	Status              string // Post status (published, draft or scheduled); empty for comments
+	IsActive            bool
+	RemovedByModeration bool
+	PostIsActive        bool // Whether the comment's post is active; always true for a post
+	DeletedAt           *time.Time
+}
+
+// PurgeResult counts what a purge run removed
+type PurgeResult struct {
+	Posts    int64
+	Comments int64
+	MediaIDs []string // Media of purged posts, whose files must be deleted
+}
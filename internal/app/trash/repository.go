@@ -0,0 +1,24 @@
+package trash
+
+import (
+	"context"
+	"time"
+)
+
+// TrashRepository defines the interface for trash data operations. Reposts and content
+// removed by moderators are never in the trash.
+type TrashRepository interface {
+	// GetItems retrieves a user's posts and comments deleted after since, most recently
+	// deleted first; itemType "" lists both
+	GetItems(ctx context.Context, userID, itemType string, since time.Time, limit, offset int) ([]*Item, error)
+
+	// GetItem retrieves a post or comment whether or not it is deleted (nil if not found)
+	GetItem(ctx context.Context, itemType, id string) (*Item, error)
+
+	// Restore undeletes a post or comment; returns false if it is not in the trash
+	Restore(ctx context.Context, itemType, id string) (bool, error)
+
+	// PurgeExpired permanently deletes up to limit posts and up to limit comments deleted
+	// before the cutoff, with the media of the posts
+	PurgeExpired(ctx context.Context, before time.Time, limit int) (*PurgeResult, error)
+}
@@ -0,0 +1,293 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"mockhu-app-backend/internal/app/counter"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresTrashRepository implements TrashRepository using PostgreSQL
+type PostgresTrashRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresTrashRepository creates a new PostgreSQL trash repository
+func NewPostgresTrashRepository(pool *pgxpool.Pool) *PostgresTrashRepository {
+	return &PostgresTrashRepository{pool: pool}
+}
+
+// purgedCommentContent replaces the text of a purged comment that still has replies; the
+// row is kept so the replies are not deleted with it
+const purgedCommentContent = "[deleted]"
+
+// GetItems retrieves a user's posts and comments deleted after since, most recently deleted
+// first; itemType "" lists both
+func (r *PostgresTrashRepository) GetItems(ctx context.Context, userID, itemType string, since time.Time, limit, offset int) ([]*Item, error) {
+	query := `
+		SELECT * FROM (
+			SELECT 'post' AS type, p.id, p.user_id, p.id AS post_id, COALESCE(p.content, '') AS content,
+			       p.status, p.is_active, p.removed_by_moderation, true AS post_is_active, p.deleted_at
+			FROM posts p
+			WHERE p.user_id = $1 AND $2::text IN ('', 'post')
+			  AND p.is_active = false AND p.removed_by_moderation = false
+			  AND p.post_type <> 'repost' AND p.deleted_at > $3
+
+			UNION ALL
+
+			SELECT 'comment', c.id, c.user_id, c.post_id, c.content,
+			       '', c.is_active, c.removed_by_moderation, p.is_active, c.deleted_at
+			FROM post_comments c
+			JOIN posts p ON p.id = c.post_id
+			WHERE c.user_id = $1 AND $2::text IN ('', 'comment')
+			  AND c.is_active = false AND c.removed_by_moderation = false
+			  AND c.deleted_at > $3
+		) items
+		ORDER BY deleted_at DESC, id DESC
+		LIMIT $4 OFFSET $5
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID, itemType, since, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*Item
+
+	for rows.Next() {
+		item := &Item{}
+		err := rows.Scan(
+			&item.Type,
+			&item.ID,
+			&item.UserID,
+			&item.PostID,
+			&item.Content,
+			&item.Status,
+			&item.IsActive,
+			&item.RemovedByModeration,
+			&item.PostIsActive,
+			&item.DeletedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// GetItem retrieves a post or comment whether or not it is deleted (nil if not found).
+// Reposts are not found.
+func (r *PostgresTrashRepository) GetItem(ctx context.Context, itemType, id string) (*Item, error) {
+	var query string
+	switch itemType {
+	case ItemPost:
+		query = `
+			SELECT 'post', p.id, p.user_id, p.id, COALESCE(p.content, ''), p.status,
+			       p.is_active, p.removed_by_moderation, true, p.deleted_at
+			FROM posts p
+			WHERE p.id = $1 AND p.post_type <> 'repost'
+		`
+	case ItemComment:
+		query = `
+			SELECT 'comment', c.id, c.user_id, c.post_id, c.content, '',
+			       c.is_active, c.removed_by_moderation, p.is_active, c.deleted_at
+			FROM post_comments c
+			JOIN posts p ON p.id = c.post_id
+			WHERE c.id = $1
+		`
+	default:
+		return nil, fmt.Errorf("unknown item type %q", itemType)
+	}
+
+	item := &Item{}
+	err := r.pool.QueryRow(ctx, query, id).Scan(
+		&item.Type,
+		&item.ID,
+		&item.UserID,
+		&item.PostID,
+		&item.Content,
+		&item.Status,
+		&item.IsActive,
+		&item.RemovedByModeration,
+		&item.PostIsActive,
+		&item.DeletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// Restore undeletes a post or comment; returns false if it is not in the trash. The counter
+// the item counts toward (the author's posts or the post's comments) goes back up with it.
+func (r *PostgresTrashRepository) Restore(ctx context.Context, itemType, id string) (bool, error) {
+	var query string
+	switch itemType {
+	case ItemPost:
+		query = `
+			UPDATE posts
+			SET is_active = true, deleted_at = NULL, updated_at = NOW()
+			WHERE id = $1 AND is_active = false AND removed_by_moderation = false
+			  AND post_type <> 'repost' AND deleted_at IS NOT NULL
+			RETURNING user_id, status = 'published'
+		`
+	case ItemComment:
+		query = `
+			UPDATE post_comments
+			SET is_active = true, deleted_at = NULL, updated_at = NOW()
+			WHERE id = $1 AND is_active = false AND removed_by_moderation = false
+			  AND deleted_at IS NOT NULL
+			RETURNING post_id, true
+		`
+	default:
+		return false, fmt.Errorf("unknown item type %q", itemType)
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	var ownerID string
+	var counted bool
+	if err := tx.QueryRow(ctx, query, id).Scan(&ownerID, &counted); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if counted {
+		c := counter.UserPosts
+		if itemType == ItemComment {
+			c = counter.PostComments
+		}
+		if err := counter.Add(ctx, tx, c, ownerID, 1); err != nil {
+			return false, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// PurgeExpired permanently deletes up to limit posts and up to limit comments deleted before
+// the cutoff, in a single transaction. Reposts of purged posts go with them, and the media
+// rows of purged posts are deleted (the caller removes the files). Comments that still have
+// replies are emptied instead of deleted, so the replies survive.
+func (r *PostgresTrashRepository) PurgeExpired(ctx context.Context, before time.Time, limit int) (*PurgeResult, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	result := &PurgeResult{}
+
+	postIDs, err := collectIDs(ctx, tx, `
+		SELECT id FROM posts
+		WHERE is_active = false AND removed_by_moderation = false AND deleted_at < $1
+		ORDER BY deleted_at
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`, before, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(postIDs) > 0 {
+		result.MediaIDs, err = collectIDs(ctx, tx, `SELECT media_id FROM post_media WHERE post_id = ANY($1::uuid[])`, postIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		// A repost can't outlive its original
+		if _, err := tx.Exec(ctx, `DELETE FROM posts WHERE post_type = 'repost' AND quoted_post_id = ANY($1::uuid[])`, postIDs); err != nil {
+			return nil, err
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM media WHERE id = ANY($1::uuid[])`, result.MediaIDs); err != nil {
+			return nil, err
+		}
+
+		deleted, err := tx.Exec(ctx, `DELETE FROM posts WHERE id = ANY($1::uuid[])`, postIDs)
+		if err != nil {
+			return nil, err
+		}
+		result.Posts = deleted.RowsAffected()
+	}
+
+	commentIDs, err := collectIDs(ctx, tx, `
+		SELECT id FROM post_comments
+		WHERE is_active = false AND removed_by_moderation = false AND deleted_at < $1
+		ORDER BY deleted_at
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`, before, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(commentIDs) > 0 {
+		// Empty comments with replies and take them out of the trash
+		emptied, err := tx.Exec(ctx, `
+			UPDATE post_comments c
+			SET content = $2, deleted_at = NULL
+			WHERE c.id = ANY($1::uuid[])
+			  AND EXISTS (SELECT 1 FROM post_comments r WHERE r.parent_comment_id = c.id)
+		`, commentIDs, purgedCommentContent)
+		if err != nil {
+			return nil, err
+		}
+
+		deleted, err := tx.Exec(ctx, `
+			DELETE FROM post_comments
+			WHERE id = ANY($1::uuid[]) AND deleted_at IS NOT NULL
+		`, commentIDs)
+		if err != nil {
+			return nil, err
+		}
+		result.Comments = emptied.RowsAffected() + deleted.RowsAffected()
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// collectIDs runs a query returning a single UUID column and collects the IDs
+func collectIDs(ctx context.Context, tx pgx.Tx, query string, args ...any) ([]string, error) {
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
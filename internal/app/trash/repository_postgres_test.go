@@ -0,0 +1,99 @@
+package trash
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"mockhu-app-backend/internal/pkg/testdb"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// insertDeletedPost creates a post deleted at deletedAt and returns its ID
+func insertDeletedPost(t *testing.T, pool *pgxpool.Pool, userID string, deletedAt time.Time) string {
+	t.Helper()
+
+	var id string
+	err := pool.QueryRow(context.Background(), `
+		INSERT INTO posts (user_id, content, is_active, deleted_at)
+		VALUES ($1, 'deleted post', false, $2)
+		RETURNING id
+	`, userID, deletedAt).Scan(&id)
+	if err != nil {
+		t.Fatalf("insert post: %v", err)
+	}
+	return id
+}
+
+// insertComment creates a comment, deleted at deletedAt unless it is nil
+func insertComment(t *testing.T, pool *pgxpool.Pool, postID, userID string, parentID *string, deletedAt *time.Time) string {
+	t.Helper()
+
+	var id string
+	err := pool.QueryRow(context.Background(), `
+		INSERT INTO post_comments (post_id, user_id, parent_comment_id, content, is_active, deleted_at)
+		VALUES ($1, $2, $3, 'a comment', $4::timestamp IS NULL, $4)
+		RETURNING id
+	`, postID, userID, parentID, deletedAt).Scan(&id)
+	if err != nil {
+		t.Fatalf("insert comment: %v", err)
+	}
+	return id
+}
+
+func TestPurgeExpired(t *testing.T) {
+	pool := testdb.Pool(t)
+	repo := NewPostgresTrashRepository(pool)
+	ctx := context.Background()
+
+	userID := testdb.CreateUser(t, pool)
+	cutoff := time.Now().Add(-RetentionPeriod)
+	expired := cutoff.Add(-time.Hour)
+	recent := time.Now().Add(-time.Hour)
+
+	expiredPost := insertDeletedPost(t, pool, userID, expired)
+	recentPost := insertDeletedPost(t, pool, userID, recent)
+
+	var livePost string
+	err := pool.QueryRow(ctx, `INSERT INTO posts (user_id, content) VALUES ($1, 'live') RETURNING id`, userID).Scan(&livePost)
+	if err != nil {
+		t.Fatalf("insert post: %v", err)
+	}
+	lonelyComment := insertComment(t, pool, livePost, userID, nil, &expired)
+	parentComment := insertComment(t, pool, livePost, userID, nil, &expired)
+	insertComment(t, pool, livePost, userID, &parentComment, nil)
+
+	if _, err := repo.PurgeExpired(ctx, cutoff, 1000); err != nil {
+		t.Fatalf("PurgeExpired: %v", err)
+	}
+
+	exists := func(table, id string) bool {
+		var found bool
+		if err := pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM `+table+` WHERE id = $1)`, id).Scan(&found); err != nil {
+			t.Fatalf("check %s: %v", table, err)
+		}
+		return found
+	}
+
+	if exists("posts", expiredPost) {
+		t.Error("expired post was not purged")
+	}
+	if !exists("posts", recentPost) {
+		t.Error("recently deleted post was purged")
+	}
+	if exists("post_comments", lonelyComment) {
+		t.Error("expired comment was not purged")
+	}
+
+	// A comment with replies is emptied so the replies survive
+	var content string
+	var deletedAt *time.Time
+	err = pool.QueryRow(ctx, `SELECT content, deleted_at FROM post_comments WHERE id = $1`, parentComment).Scan(&content, &deletedAt)
+	if err != nil {
+		t.Fatalf("get parent comment: %v", err)
+	}
+	if content != purgedCommentContent || deletedAt != nil {
+		t.Errorf("parent comment = %q deleted at %v, want emptied and out of the trash", content, deletedAt)
+	}
+}
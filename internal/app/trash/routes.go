@@ -0,0 +1,18 @@
+package trash
+
+import (
+	"mockhu-app-backend/internal/pkg/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterRoutes registers all trash routes (owner only)
+func RegisterRoutes(app *fiber.App, handler *Handler) {
+	v1 := app.Group("/v1")
+
+	auth := middleware.AuthMiddleware()
+
+	v1.Get("/users/me/trash", auth, handler.GetTrash)
+	v1.Post("/users/me/trash/posts/:postId/restore", auth, handler.RestorePost)
+	v1.Post("/users/me/trash/comments/:commentId/restore", auth, handler.RestoreComment)
+}
@@ -0,0 +1,170 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"mockhu-app-backend/internal/app/timeline"
+	mediapkg "mockhu-app-backend/internal/pkg/media"
+)
+
+// Trash tuning
+const (
+	RetentionPeriod = 30 * 24 * time.Hour // How long deleted content can be restored
+	PurgeInterval   = time.Hour           // How often expired content is purged
+	purgeBatchSize  = 500                 // Posts and comments purged per job run, each
+)
+
+// Errors
+var (
+	ErrInvalidType         = errors.New("type must be post or comment")
+	ErrItemNotFound        = errors.New("item not found in trash")
+	ErrRemovedByModeration = errors.New("this content was removed by a moderator and cannot be restored")
+	ErrPostDeleted         = errors.New("restore the post this comment belongs to first")
+)
+
+// TrashService defines the business logic for the trash of deleted posts and comments
+type TrashService interface {
+	// GetTrash lists the user's restorable posts and comments; itemType "" lists both
+	GetTrash(ctx context.Context, userID, itemType string, page, limit int) (*TrashResponse, error)
+
+	// Restore undeletes one of the user's posts or comments
+	Restore(ctx context.Context, userID, itemType, id string) error
+
+	// Purge permanently deletes expired content; run periodically by a background job
+	Purge(ctx context.Context) error
+}
+
+// trashService implements TrashService
+type trashService struct {
+	trashRepo       TrashRepository
+	timelineService timeline.TimelineService
+}
+
+// NewService creates a new trash service
+func NewService(trashRepo TrashRepository, timelineService timeline.TimelineService) TrashService {
+	return &trashService{
+		trashRepo:       trashRepo,
+		timelineService: timelineService,
+	}
+}
+
+// GetTrash lists the user's restorable posts and comments; itemType "" lists both
+func (s *trashService) GetTrash(ctx context.Context, userID, itemType string, page, limit int) (*TrashResponse, error) {
+	if itemType != "" && itemType != ItemPost && itemType != ItemComment {
+		return nil, ErrInvalidType
+	}
+
+	// Validate pagination
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 20
+	}
+
+	offset := (page - 1) * limit
+
+	items, err := s.trashRepo.GetItems(ctx, userID, itemType, time.Now().Add(-RetentionPeriod), limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trash: %w", err)
+	}
+
+	responses := make([]*TrashItemResponse, 0, len(items))
+	for _, item := range items {
+		response := &TrashItemResponse{
+			Type:      item.Type,
+			ID:        item.ID,
+			Content:   item.Content,
+			Status:    item.Status,
+			DeletedAt: item.DeletedAt.Format(time.RFC3339),
+			ExpiresAt: item.DeletedAt.Add(RetentionPeriod).Format(time.RFC3339),
+		}
+		if item.Type == ItemComment {
+			response.PostID = item.PostID
+		}
+		responses = append(responses, response)
+	}
+
+	// Calculate total pages (simplified)
+	totalPages := 1
+	if len(responses) == limit {
+		totalPages = page + 1
+	}
+
+	return &TrashResponse{
+		Items: responses,
+		Pagination: PaginationInfo{
+			Page:       page,
+			TotalPages: totalPages,
+			TotalItems: len(responses),
+			Limit:      limit,
+		},
+	}, nil
+}
+
+// Restore undeletes one of the user's posts or comments. Content removed by a moderator
+// stays removed, and a comment can't be restored while its post is deleted.
+func (s *trashService) Restore(ctx context.Context, userID, itemType, id string) error {
+	if itemType != ItemPost && itemType != ItemComment {
+		return ErrInvalidType
+	}
+
+	item, err := s.trashRepo.GetItem(ctx, itemType, id)
+	if err != nil {
+		return fmt.Errorf("failed to get %s: %w", itemType, err)
+	}
+	if item == nil || item.UserID != userID || item.IsActive || item.DeletedAt == nil {
+		return ErrItemNotFound
+	}
+	if item.RemovedByModeration {
+		return ErrRemovedByModeration
+	}
+	if time.Since(*item.DeletedAt) > RetentionPeriod {
+		return ErrItemNotFound // Waiting to be purged
+	}
+	if !item.PostIsActive {
+		return ErrPostDeleted
+	}
+
+	restored, err := s.trashRepo.Restore(ctx, itemType, id)
+	if err != nil {
+		return fmt.Errorf("failed to restore %s: %w", itemType, err)
+	}
+	if !restored {
+		return ErrItemNotFound // Purged or removed in the meantime
+	}
+
+	// A restored post goes back into followers' feeds
+	if itemType == ItemPost {
+		if err := s.timelineService.Publish(ctx, id); err != nil {
+			log.Printf("⚠️ Failed to fan out restored post %s: %v", id, err)
+		}
+	}
+
+	return nil
+}
+
+// Purge permanently deletes content that has been in the trash longer than the retention
+// period, along with the files of purged media
+func (s *trashService) Purge(ctx context.Context) error {
+	result, err := s.trashRepo.PurgeExpired(ctx, time.Now().Add(-RetentionPeriod), purgeBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to purge trash: %w", err)
+	}
+
+	for _, mediaID := range result.MediaIDs {
+		if err := mediapkg.Delete(mediaID); err != nil {
+			log.Printf("⚠️ Failed to delete files of purged media %s: %v", mediaID, err)
+		}
+	}
+
+	if result.Posts > 0 || result.Comments > 0 {
+		log.Printf("🗑️ Purged %d posts, %d comments and %d media from the trash", result.Posts, result.Comments, len(result.MediaIDs))
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"mockhu-app-backend/internal/app/timeline"
+)
+
+// fakeTrashRepo serves items from memory and records purge calls
+type fakeTrashRepo struct {
+	TrashRepository
+
+	items        map[string]*Item
+	restored     []string
+	purgedBefore time.Time
+	purgeLimit   int
+}
+
+func (r *fakeTrashRepo) GetItem(ctx context.Context, itemType, id string) (*Item, error) {
+	item, ok := r.items[id]
+	if !ok || item.Type != itemType {
+		return nil, nil
+	}
+	return item, nil
+}
+
+func (r *fakeTrashRepo) Restore(ctx context.Context, itemType, id string) (bool, error) {
+	r.restored = append(r.restored, id)
+	return true, nil
+}
+
+func (r *fakeTrashRepo) PurgeExpired(ctx context.Context, before time.Time, limit int) (*PurgeResult, error) {
+	r.purgedBefore, r.purgeLimit = before, limit
+	return &PurgeResult{Posts: 1}, nil
+}
+
+// fakeTimelineService records the posts published to timelines
+type fakeTimelineService struct {
+	timeline.TimelineService
+
+	published []string
+}
+
+func (s *fakeTimelineService) Publish(ctx context.Context, postID string) error {
+	s.published = append(s.published, postID)
+	return nil
+}
+
+func TestRestore(t *testing.T) {
+	recently := time.Now().Add(-time.Hour)
+	expired := time.Now().Add(-RetentionPeriod - time.Hour)
+
+	item := func(itemType, id string, modify func(i *Item)) *Item {
+		i := &Item{Type: itemType, ID: id, UserID: "owner", PostIsActive: true, DeletedAt: &recently}
+		if modify != nil {
+			modify(i)
+		}
+		return i
+	}
+
+	repo := &fakeTrashRepo{items: map[string]*Item{
+		"post":       item(ItemPost, "post", nil),
+		"comment":    item(ItemComment, "comment", nil),
+		"active":     item(ItemPost, "active", func(i *Item) { i.IsActive = true; i.DeletedAt = nil }),
+		"moderated":  item(ItemPost, "moderated", func(i *Item) { i.RemovedByModeration = true }),
+		"expired":    item(ItemPost, "expired", func(i *Item) { i.DeletedAt = &expired }),
+		"orphan":     item(ItemComment, "orphan", func(i *Item) { i.PostIsActive = false }),
+		"not-theirs": item(ItemPost, "not-theirs", func(i *Item) { i.UserID = "someone-else" }),
+	}}
+	timelines := &fakeTimelineService{}
+	s := NewService(repo, timelines)
+
+	tests := []struct {
+		itemType string
+		id       string
+		wantErr  error
+	}{
+		{ItemPost, "post", nil},
+		{ItemComment, "comment", nil},
+		{"video", "post", ErrInvalidType},
+		{ItemComment, "post", ErrItemNotFound},
+		{ItemPost, "missing", ErrItemNotFound},
+		{ItemPost, "active", ErrItemNotFound},
+		{ItemPost, "moderated", ErrRemovedByModeration},
+		{ItemPost, "expired", ErrItemNotFound},
+		{ItemComment, "orphan", ErrPostDeleted},
+		{ItemPost, "not-theirs", ErrItemNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.itemType+"/"+tt.id, func(t *testing.T) {
+			if err := s.Restore(context.Background(), "owner", tt.itemType, tt.id); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Restore: err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if len(repo.restored) != 2 {
+		t.Errorf("restored %v, want only post and comment", repo.restored)
+	}
+	// Only restored posts go back into feeds
+	if len(timelines.published) != 1 || timelines.published[0] != "post" {
+		t.Errorf("published %v, want [post]", timelines.published)
+	}
+}
+
+func TestPurgeUsesRetentionCutoff(t *testing.T) {
+	repo := &fakeTrashRepo{}
+	s := NewService(repo, &fakeTimelineService{})
+
+	if err := s.Purge(context.Background()); err != nil {
+		t.Fatalf("Purge: %v", err)
+	}
+
+	cutoff := time.Now().Add(-RetentionPeriod)
+	if diff := cutoff.Sub(repo.purgedBefore); diff < 0 || diff > time.Minute {
+		t.Errorf("purged before %v, want %v", repo.purgedBefore, cutoff)
+	}
+	if repo.purgeLimit != purgeBatchSize {
+		t.Errorf("purge limit = %d, want %d", repo.purgeLimit, purgeBatchSize)
+	}
+}
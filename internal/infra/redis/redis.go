@@ -0,0 +1,236 @@
+package redis
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Client is a minimal client for servers speaking the Redis protocol (RESP2): Redis,
+// Valkey, KeyDB, Dragonfly. It keeps a small pool of connections and supports single
+// commands and pipelines. It is safe for concurrent use.
+type Client struct {
+	addr string
+
+	mu   sync.Mutex
+	idle []*conn
+}
+
+// Value is a reply from the server
+type Value struct {
+	Str   string  // Simple and bulk strings
+	Int   int64   // Integers
+	Array []Value // Arrays
+	Nil   bool    // Null bulk string or array
+	Err   error   // Error reply, as an Error
+}
+
+// Error is an error reply from the server
+type Error string
+
+func (e Error) Error() string { return string(e) }
+
+const (
+	maxIdleConns = 10
+	dialTimeout  = 5 * time.Second
+	ioTimeout    = 5 * time.Second // Used when the context has no deadline
+)
+
+// New connects to the server at addr and checks it answers PING
+func New(ctx context.Context, addr string) (*Client, error) {
+	c := &Client{addr: addr}
+	if _, err := c.Do(ctx, "PING"); err != nil {
+		return nil, fmt.Errorf("ping redis: %w", err)
+	}
+	return c, nil
+}
+
+// Close closes the idle connections
+func (c *Client) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, cn := range c.idle {
+		cn.Close()
+	}
+	c.idle = nil
+}
+
+// Do sends one command and returns its reply. Error replies are returned as Error.
+func (c *Client) Do(ctx context.Context, args ...string) (Value, error) {
+	replies, err := c.Pipeline(ctx, [][]string{args})
+	if err != nil {
+		return Value{}, err
+	}
+	if replies[0].Err != nil {
+		return Value{}, replies[0].Err
+	}
+	return replies[0], nil
+}
+
+// Pipeline sends several commands in one round trip and returns their replies in order.
+// An error reply to one command doesn't fail the others; check each reply's Err.
+func (c *Client) Pipeline(ctx context.Context, cmds [][]string) ([]Value, error) {
+	if len(cmds) == 0 {
+		return nil, nil
+	}
+
+	cn, err := c.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(ioTimeout)
+	}
+	if err := cn.SetDeadline(deadline); err != nil {
+		cn.Close()
+		return nil, err
+	}
+
+	for _, args := range cmds {
+		writeCommand(cn.w, args)
+	}
+	if err := cn.w.Flush(); err != nil {
+		cn.Close()
+		return nil, err
+	}
+
+	replies := make([]Value, len(cmds))
+	for i := range cmds {
+		if replies[i], err = readValue(cn.r); err != nil {
+			cn.Close() // The connection is out of sync
+			return nil, err
+		}
+	}
+
+	c.put(cn)
+	return replies, nil
+}
+
+// Helper functions
+
+// conn is a pooled connection with buffered I/O
+type conn struct {
+	net.Conn
+	r *bufio.Reader
+	w *bufio.Writer
+}
+
+// get takes an idle connection or dials a new one
+func (c *Client) get(ctx context.Context) (*conn, error) {
+	c.mu.Lock()
+	if n := len(c.idle); n > 0 {
+		cn := c.idle[n-1]
+		c.idle = c.idle[:n-1]
+		c.mu.Unlock()
+		return cn, nil
+	}
+	c.mu.Unlock()
+
+	dialer := net.Dialer{Timeout: dialTimeout}
+	nc, err := dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{Conn: nc, r: bufio.NewReader(nc), w: bufio.NewWriter(nc)}, nil
+}
+
+// put returns a healthy connection to the pool
+func (c *Client) put(cn *conn) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.idle) >= maxIdleConns {
+		cn.Close()
+		return
+	}
+	c.idle = append(c.idle, cn)
+}
+
+// writeCommand writes a command as an array of bulk strings
+func writeCommand(w *bufio.Writer, args []string) {
+	w.WriteString("*" + strconv.Itoa(len(args)) + "\r\n")
+	for _, arg := range args {
+		w.WriteString("$" + strconv.Itoa(len(arg)) + "\r\n")
+		w.WriteString(arg)
+		w.WriteString("\r\n")
+	}
+}
+
+// readValue reads one reply
+func readValue(r *bufio.Reader) (Value, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return Value{}, err
+	}
+	if len(line) == 0 {
+		return Value{}, errors.New("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return Value{Str: line[1:]}, nil
+	case '-':
+		return Value{Err: Error(line[1:])}, nil
+	case ':':
+		n, err := strconv.ParseInt(line[1:], 10, 64)
+		return Value{Int: n}, err
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return Value{}, err
+		}
+		if n < 0 {
+			return Value{Nil: true}, nil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return Value{}, err
+		}
+		return Value{Str: string(buf[:n])}, nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return Value{}, err
+		}
+		if n < 0 {
+			return Value{Nil: true}, nil
+		}
+		array := make([]Value, n)
+		for i := range array {
+			if array[i], err = readValue(r); err != nil {
+				return Value{}, err
+			}
+		}
+		return Value{Array: array}, nil
+	}
+
+	return Value{}, fmt.Errorf("redis: unknown reply type %q", line[0])
+}
+
+// readLine reads a CRLF-terminated line without the CRLF
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return "", errors.New("redis: malformed reply")
+	}
+	return line[:len(line)-2], nil
+}
+
+// AddrFromEnv reads the server address from REDIS_ADDR, defaulting to a local server
+func AddrFromEnv() string {
+	if v := os.Getenv("REDIS_ADDR"); v != "" {
+		return v
+	}
+	return "localhost:6379"
+}
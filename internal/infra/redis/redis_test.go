@@ -0,0 +1,264 @@
+package redis
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestReadValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		reply string
+		want  Value
+	}{
+		{"simple string", "+OK\r\n", Value{Str: "OK"}},
+		{"error", "-ERR wrong type\r\n", Value{Err: Error("ERR wrong type")}},
+		{"integer", ":-42\r\n", Value{Int: -42}},
+		{"bulk string", "$5\r\nhello\r\n", Value{Str: "hello"}},
+		{"bulk string with CRLF", "$4\r\na\r\nb\r\n", Value{Str: "a\r\nb"}},
+		{"empty bulk string", "$0\r\n\r\n", Value{Str: ""}},
+		{"null bulk string", "$-1\r\n", Value{Nil: true}},
+		{"null array", "*-1\r\n", Value{Nil: true}},
+		{"empty array", "*0\r\n", Value{Array: []Value{}}},
+		{
+			name:  "nested array",
+			reply: "*3\r\n$1\r\na\r\n:1\r\n*2\r\n+OK\r\n-ERR no\r\n",
+			want: Value{Array: []Value{
+				{Str: "a"},
+				{Int: 1},
+				{Array: []Value{{Str: "OK"}, {Err: Error("ERR no")}}},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := bufio.NewReader(strings.NewReader(tt.reply))
+			got, err := readValue(r)
+			if err != nil {
+				t.Fatalf("readValue: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readValue = %+v, want %+v", got, tt.want)
+			}
+			if r.Buffered() != 0 {
+				t.Errorf("%d bytes left unread", r.Buffered())
+			}
+		})
+	}
+}
+
+func TestReadValueMalformed(t *testing.T) {
+	replies := []string{
+		"",             // Connection closed
+		"\r\n",         // Empty line
+		"+OK\n",        // No CR
+		"?what\r\n",    // Unknown type
+		":abc\r\n",     // Bad integer
+		"$x\r\n",       // Bad length
+		"$5\r\nhi\r\n", // Short bulk string
+		"*2\r\n:1\r\n", // Short array
+		"*x\r\n:1\r\n", // Bad array length
+	}
+
+	for _, reply := range replies {
+		if v, err := readValue(bufio.NewReader(strings.NewReader(reply))); err == nil {
+			t.Errorf("readValue(%q) = %+v, want error", reply, v)
+		}
+	}
+}
+
+func TestWriteCommand(t *testing.T) {
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	writeCommand(w, []string{"SET", "key", "a\r\nb", ""})
+	w.Flush()
+
+	want := "*4\r\n$3\r\nSET\r\n$3\r\nkey\r\n$4\r\na\r\nb\r\n$0\r\n\r\n"
+	if buf.String() != want {
+		t.Errorf("writeCommand wrote %q, want %q", buf.String(), want)
+	}
+
+	// What the client writes, a server reads back as the same arguments
+	v, err := readValue(bufio.NewReader(&buf))
+	if err != nil {
+		t.Fatalf("readValue: %v", err)
+	}
+	if args := strings.Join(values(v.Array), "|"); args != "SET|key|a\r\nb|" {
+		t.Errorf("read back %q", args)
+	}
+}
+
+func TestDo(t *testing.T) {
+	server := newFakeServer(t, func(args []string) string {
+		switch args[0] {
+		case "PING":
+			return "+PONG\r\n"
+		case "GET":
+			if args[1] == "missing" {
+				return "$-1\r\n"
+			}
+			return "$5\r\nvalue\r\n"
+		}
+		return "-ERR unknown command '" + args[0] + "'\r\n"
+	})
+	ctx := context.Background()
+
+	client, err := New(ctx, server.addr)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer client.Close()
+
+	if v, err := client.Do(ctx, "GET", "key"); err != nil || v.Str != "value" {
+		t.Errorf("GET key = %+v, %v; want value", v, err)
+	}
+	if v, err := client.Do(ctx, "GET", "missing"); err != nil || !v.Nil {
+		t.Errorf("GET missing = %+v, %v; want nil", v, err)
+	}
+
+	_, err = client.Do(ctx, "NOPE")
+	var replyErr Error
+	if !errors.As(err, &replyErr) || !strings.HasPrefix(string(replyErr), "ERR unknown command") {
+		t.Errorf("NOPE: err = %v, want an error reply", err)
+	}
+
+	// An error reply leaves the connection usable, so it is reused
+	if v, err := client.Do(ctx, "PING"); err != nil || v.Str != "PONG" {
+		t.Errorf("PING = %+v, %v; want PONG", v, err)
+	}
+	if n := server.connections(); n != 1 {
+		t.Errorf("opened %d connections, want 1", n)
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	server := newFakeServer(t, func(args []string) string {
+		if args[0] == "FAIL" {
+			return "-ERR failed\r\n"
+		}
+		return "+" + strings.Join(args, " ") + "\r\n"
+	})
+	client := &Client{addr: server.addr}
+	defer client.Close()
+
+	replies, err := client.Pipeline(context.Background(), [][]string{
+		{"ECHO", "one"},
+		{"FAIL"},
+		{"ECHO", "three"},
+	})
+	if err != nil {
+		t.Fatalf("Pipeline: %v", err)
+	}
+
+	want := []Value{{Str: "ECHO one"}, {Err: Error("ERR failed")}, {Str: "ECHO three"}}
+	if !reflect.DeepEqual(replies, want) {
+		t.Errorf("Pipeline = %+v, want %+v", replies, want)
+	}
+	if got := server.received(); len(got) != 3 {
+		t.Errorf("server received %v, want 3 commands", got)
+	}
+}
+
+func TestPipelineDropsBrokenConnection(t *testing.T) {
+	server := newFakeServer(t, func(args []string) string {
+		if args[0] == "BAD" {
+			return "?garbage\r\n"
+		}
+		return "+OK\r\n"
+	})
+	client := &Client{addr: server.addr}
+	defer client.Close()
+	ctx := context.Background()
+
+	if _, err := client.Do(ctx, "BAD"); err == nil {
+		t.Fatal("BAD: want a protocol error, got nil")
+	}
+	if v, err := client.Do(ctx, "OK"); err != nil || v.Str != "OK" {
+		t.Fatalf("OK = %+v, %v", v, err)
+	}
+	// The connection that returned garbage was closed rather than reused
+	if n := server.connections(); n != 2 {
+		t.Errorf("opened %d connections, want 2", n)
+	}
+}
+
+// fakeServer answers RESP commands with replies from a handler
+type fakeServer struct {
+	addr string
+
+	mu       sync.Mutex
+	accepted int
+	commands [][]string
+}
+
+func newFakeServer(t *testing.T, handle func(args []string) string) *fakeServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	s := &fakeServer{addr: listener.Addr().String()}
+	go func() {
+		for {
+			nc, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.accepted++
+			s.mu.Unlock()
+			go s.serve(nc, handle)
+		}
+	}()
+
+	return s
+}
+
+func (s *fakeServer) serve(nc net.Conn, handle func(args []string) string) {
+	defer nc.Close()
+	r := bufio.NewReader(nc)
+	for {
+		v, err := readValue(r)
+		if err != nil {
+			return
+		}
+		args := values(v.Array)
+		s.mu.Lock()
+		s.commands = append(s.commands, args)
+		s.mu.Unlock()
+		if _, err := nc.Write([]byte(handle(args))); err != nil {
+			return
+		}
+	}
+}
+
+func (s *fakeServer) connections() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accepted
+}
+
+func (s *fakeServer) received() [][]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.commands
+}
+
+func values(array []Value) []string {
+	strs := make([]string, len(array))
+	for i, v := range array {
+		strs[i] = v.Str
+	}
+	return strs
+}
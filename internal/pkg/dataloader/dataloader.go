@@ -0,0 +1,78 @@
+package dataloader
+
+import (
+	"context"
+	"sync"
+)
+
+// FetchFunc loads the values of many keys in one round trip. Keys without a value are
+// left out of the result.
+type FetchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader batches and caches lookups by key. Create one per request (or per response being
+// built) so a list endpoint loads every kind of data with one query instead of one per item.
+// It is safe for concurrent use.
+type Loader[K comparable, V any] struct {
+	fetch FetchFunc[K, V]
+
+	mu      sync.Mutex
+	values  map[K]V
+	fetched map[K]bool // Keys already loaded, found or not
+}
+
+// New creates a loader that fetches missing keys with fetch
+func New[K comparable, V any](fetch FetchFunc[K, V]) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:   fetch,
+		values:  make(map[K]V),
+		fetched: make(map[K]bool),
+	}
+}
+
+// Prime loads every key that hasn't been loaded yet with a single fetch. Keys stay unloaded
+// if the fetch fails, so a later Load retries them.
+func (l *Loader[K, V]) Prime(ctx context.Context, keys []K) error {
+	l.mu.Lock()
+	missing := make([]K, 0, len(keys))
+	seen := make(map[K]bool, len(keys))
+	for _, key := range keys {
+		if !l.fetched[key] && !seen[key] {
+			seen[key] = true
+			missing = append(missing, key)
+		}
+	}
+	l.mu.Unlock()
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	values, err := l.fetch(ctx, missing)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, key := range missing {
+		l.fetched[key] = true
+		if value, ok := values[key]; ok {
+			l.values[key] = value
+		}
+	}
+
+	return nil
+}
+
+// Load returns the value of a key, fetching it on its own if it wasn't primed. ok is false
+// if the key has no value.
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (value V, ok bool, err error) {
+	if err := l.Prime(ctx, []K{key}); err != nil {
+		return value, false, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	value, ok = l.values[key]
+	return value, ok, nil
+}
@@ -0,0 +1,151 @@
+package entities
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Limits for recognised entities (without the leading symbol)
+const (
+	MaxHashtagLength  = 100
+	MinUsernameLength = 3
+	MaxUsernameLength = 30
+)
+
+// Entity is a token found in user text. Start and End are character (rune) offsets
+// into the original text, End exclusive, covering the leading symbol.
+type Entity struct {
+	Text  string `json:"text"`  // Token as written, including the leading symbol
+	Value string `json:"value"` // Normalized value without the symbol
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+}
+
+// ExtractHashtags finds #hashtags in text. A hashtag starts with # at the beginning of the
+// text or after a non-word character, is made of letters, digits and underscores, and must
+// contain at least one letter (so "#1" is not a tag). Values are lowercased.
+func ExtractHashtags(text string) []Entity {
+	found := extract(text, '#', isWordRune, func(word []rune) bool {
+		if len(word) > MaxHashtagLength {
+			return false
+		}
+		for _, r := range word {
+			if unicode.IsLetter(r) {
+				return true
+			}
+		}
+		return false
+	})
+
+	for i := range found {
+		found[i].Value = strings.ToLower(found[i].Value)
+	}
+	return found
+}
+
+// HashtagValues returns the distinct normalized hashtags in text, in order of first use
+func HashtagValues(text string) []string {
+	return distinctValues(ExtractHashtags(text))
+}
+
+// NormalizeHashtag turns user input such as "#GoLang" into its stored form ("golang").
+// ok is false if the input is not a valid hashtag.
+func NormalizeHashtag(input string) (tag string, ok bool) {
+	word := []rune(strings.TrimPrefix(strings.TrimSpace(input), "#"))
+	if len(word) == 0 || len(word) > MaxHashtagLength {
+		return "", false
+	}
+
+	hasLetter := false
+	for _, r := range word {
+		if !isWordRune(r) {
+			return "", false
+		}
+		if unicode.IsLetter(r) {
+			hasLetter = true
+		}
+	}
+	if !hasLetter {
+		return "", false
+	}
+
+	return strings.ToLower(string(word)), true
+}
+
+// ExtractMentions finds @username mentions in text. Usernames follow the profile rules
+// (3-30 ASCII letters, digits or underscores) and are returned as written, so an email
+// address such as a@b.com is not a mention.
+func ExtractMentions(text string) []Entity {
+	return extract(text, '@', isUsernameRune, func(word []rune) bool {
+		return len(word) >= MinUsernameLength && len(word) <= MaxUsernameLength
+	})
+}
+
+// MentionValues returns the distinct usernames mentioned in text, in order of first use
+func MentionValues(text string) []string {
+	return distinctValues(ExtractMentions(text))
+}
+
+// extract scans text for symbol-prefixed runs of runes accepted by isPart and keeps
+// the words accepted by valid. Values are returned as written.
+func extract(text string, symbol rune, isPart func(r rune) bool, valid func(word []rune) bool) []Entity {
+	runes := []rune(text)
+	var found []Entity
+
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != symbol {
+			continue
+		}
+		if i > 0 && isWordRune(runes[i-1]) {
+			continue
+		}
+
+		end := i + 1
+		for end < len(runes) && isPart(runes[end]) {
+			end++
+		}
+
+		// A run cut short by another word rune (e.g. "@josé") is not an entity
+		cutShort := end < len(runes) && isWordRune(runes[end])
+
+		word := runes[i+1 : end]
+		if len(word) > 0 && !cutShort && valid(word) {
+			found = append(found, Entity{
+				Text:  string(runes[i:end]),
+				Value: string(word),
+				Start: i,
+				End:   end,
+			})
+		}
+
+		i = end - 1
+	}
+
+	return found
+}
+
+// distinctValues returns entity values with duplicates removed, keeping first-use order
+func distinctValues(found []Entity) []string {
+	seen := make(map[string]bool, len(found))
+	values := make([]string, 0, len(found))
+
+	for _, e := range found {
+		if seen[e.Value] {
+			continue
+		}
+		seen[e.Value] = true
+		values = append(values, e.Value)
+	}
+
+	return values
+}
+
+// isWordRune reports whether r can be part of a hashtag or mention
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)
+}
+
+// isUsernameRune reports whether r can be part of a username
+func isUsernameRune(r rune) bool {
+	return r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
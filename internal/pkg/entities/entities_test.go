@@ -0,0 +1,157 @@
+package entities
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExtractHashtags(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []Entity
+	}{
+		{
+			name: "start of text",
+			text: "#GoLang is fun",
+			want: []Entity{{Text: "#GoLang", Value: "golang", Start: 0, End: 7}},
+		},
+		{
+			name: "after punctuation",
+			text: "love (#go), #rust!",
+			want: []Entity{
+				{Text: "#go", Value: "go", Start: 6, End: 9},
+				{Text: "#rust", Value: "rust", Start: 12, End: 17},
+			},
+		},
+		{
+			name: "rune offsets",
+			text: "café #über",
+			want: []Entity{{Text: "#über", Value: "über", Start: 5, End: 10}},
+		},
+		{
+			name: "inside a word",
+			text: "issue#42 and c#sharp",
+		},
+		{
+			name: "digits only",
+			text: "#1 and #2024",
+		},
+		{
+			name: "digits with a letter",
+			text: "#2024goals",
+			want: []Entity{{Text: "#2024goals", Value: "2024goals", Start: 0, End: 10}},
+		},
+		{
+			name: "bare symbol",
+			text: "# and ##",
+		},
+		{
+			name: "too long",
+			text: "#" + strings.Repeat("a", MaxHashtagLength+1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractHashtags(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractHashtags(%q) = %+v, want %+v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHashtagValues(t *testing.T) {
+	got := HashtagValues("#Go #rust #go #RUST #zig")
+	want := []string{"go", "rust", "zig"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("HashtagValues = %v, want %v", got, want)
+	}
+}
+
+func TestNormalizeHashtag(t *testing.T) {
+	tests := []struct {
+		input  string
+		want   string
+		wantOK bool
+	}{
+		{"#GoLang", "golang", true},
+		{"  golang ", "golang", true},
+		{"go_lang2", "go_lang2", true},
+		{"#", "", false},
+		{"", "", false},
+		{"123", "", false},
+		{"go-lang", "", false},
+		{"two words", "", false},
+		{strings.Repeat("a", MaxHashtagLength+1), "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := NormalizeHashtag(tt.input)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("NormalizeHashtag(%q) = %q, %v; want %q, %v", tt.input, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestExtractMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []Entity
+	}{
+		{
+			name: "start of text",
+			text: "@Alice hi",
+			want: []Entity{{Text: "@Alice", Value: "Alice", Start: 0, End: 6}},
+		},
+		{
+			name: "after punctuation",
+			text: "cc:@bob_1, (@carol)",
+			want: []Entity{
+				{Text: "@bob_1", Value: "bob_1", Start: 3, End: 9},
+				{Text: "@carol", Value: "carol", Start: 12, End: 18},
+			},
+		},
+		{
+			name: "email address",
+			text: "mail alice@example.com",
+		},
+		{
+			name: "too short",
+			text: "@ab",
+		},
+		{
+			name: "too long",
+			text: "@" + strings.Repeat("a", MaxUsernameLength+1),
+		},
+		{
+			name: "non-ASCII letter",
+			text: "@josé",
+		},
+		{
+			name: "rune offsets",
+			text: "héllo @dave",
+			want: []Entity{{Text: "@dave", Value: "dave", Start: 6, End: 11}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractMentions(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractMentions(%q) = %+v, want %+v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMentionValues(t *testing.T) {
+	got := MentionValues("@alice @bob @alice @Alice")
+	want := []string{"alice", "bob", "Alice"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MentionValues = %v, want %v", got, want)
+	}
+}
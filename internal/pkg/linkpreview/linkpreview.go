@@ -0,0 +1,325 @@
+package linkpreview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+)
+
+// Fetch limits
+const (
+	MaxBodySize    = 512 * 1024      // Only the start of a page is read; metadata lives in <head>
+	FetchTimeout   = 5 * time.Second // Whole request, including redirects
+	MaxRedirects   = 5
+	MaxURLLength   = 2048
+	maxTitleLength = 300
+	maxTextLength  = 1000
+)
+
+// userAgent identifies the fetcher to the sites it visits
+const userAgent = "MockhuBot/1.0 (+link previews)"
+
+var (
+	ErrInvalidURL     = errors.New("invalid url")
+	ErrBlockedAddress = errors.New("url resolves to a blocked address")
+	ErrNotHTML        = errors.New("url is not an html page")
+	ErrNoMetadata     = errors.New("page has no preview metadata")
+)
+
+// Metadata is the preview information found on a page
+type Metadata struct {
+	Title       string
+	Description string
+	ImageURL    string
+	SiteName    string
+}
+
+// Fetcher downloads pages and reads their OpenGraph / Twitter card metadata
+type Fetcher struct {
+	client *http.Client
+}
+
+// NewFetcher creates a fetcher using client, or a client that refuses private
+// addresses (NewSafeClient) if client is nil. Tests can pass a plain client to
+// fetch from a local server.
+func NewFetcher(client *http.Client) *Fetcher {
+	if client == nil {
+		client = NewSafeClient()
+	}
+	return &Fetcher{client: client}
+}
+
+// NewSafeClient returns an HTTP client for fetching untrusted URLs. Every connection,
+// including those made for redirects, is checked after DNS resolution, so a public
+// hostname that resolves to a private address is refused too.
+func NewSafeClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: FetchTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return ErrBlockedAddress
+			}
+			addr, err := netip.ParseAddr(host)
+			if err != nil || !IsPublicAddr(addr) {
+				return ErrBlockedAddress
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: FetchTimeout,
+		Transport: &http.Transport{
+			Proxy:                 nil, // A proxy would connect on our behalf and bypass the address check
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   FetchTimeout,
+			ResponseHeaderTimeout: FetchTimeout,
+			MaxIdleConns:          10,
+			IdleConnTimeout:       30 * time.Second,
+		},
+		CheckRedirect: checkRedirect,
+	}
+}
+
+// Fetch downloads rawURL and returns its preview metadata
+func (f *Fetcher) Fetch(ctx context.Context, rawURL string) (*Metadata, error) {
+	target, err := parseHTTPURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, FetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, ErrInvalidURL
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		if errors.Is(err, ErrBlockedAddress) {
+			return nil, ErrBlockedAddress
+		}
+		return nil, fmt.Errorf("failed to fetch url: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("failed to fetch url: status %d", resp.StatusCode)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return nil, ErrNotHTML
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read page: %w", err)
+	}
+
+	metadata := Parse(body, resp.Request.URL)
+	if metadata.Title == "" && metadata.Description == "" && metadata.ImageURL == "" {
+		return nil, ErrNoMetadata
+	}
+
+	return metadata, nil
+}
+
+var (
+	metaTagPattern   = regexp.MustCompile(`(?is)<meta\s[^>]*>`)
+	attributePattern = regexp.MustCompile(`(?is)([a-z:_-]+)\s*=\s*("[^"]*"|'[^']*'|[^\s"'>]+)`)
+	titlePattern     = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+)
+
+// Parse reads preview metadata from an HTML page. OpenGraph tags are preferred over
+// Twitter card tags, which are preferred over the plain <title> and description.
+// Relative image URLs are resolved against base.
+func Parse(body []byte, base *url.URL) *Metadata {
+	page := strings.ToValidUTF8(string(body), "")
+
+	meta := make(map[string]string)
+	for _, tag := range metaTagPattern.FindAllString(page, -1) {
+		attrs := make(map[string]string)
+		for _, match := range attributePattern.FindAllStringSubmatch(tag, -1) {
+			attrs[strings.ToLower(match[1])] = strings.Trim(match[2], `"'`)
+		}
+
+		key := attrs["property"]
+		if key == "" {
+			key = attrs["name"]
+		}
+		key = strings.ToLower(key)
+		if _, seen := meta[key]; key != "" && !seen {
+			meta[key] = attrs["content"]
+		}
+	}
+
+	title := ""
+	if match := titlePattern.FindStringSubmatch(page); match != nil {
+		title = match[1]
+	}
+
+	metadata := &Metadata{
+		Title:       clean(first(meta["og:title"], meta["twitter:title"], title), maxTitleLength),
+		Description: clean(first(meta["og:description"], meta["twitter:description"], meta["description"]), maxTextLength),
+		SiteName:    clean(meta["og:site_name"], maxTitleLength),
+	}
+
+	image := clean(first(meta["og:image:secure_url"], meta["og:image"], meta["og:image:url"], meta["twitter:image"], meta["twitter:image:src"]), MaxURLLength)
+	if image != "" {
+		if resolved, err := base.Parse(image); err == nil && (resolved.Scheme == "http" || resolved.Scheme == "https") {
+			metadata.ImageURL = resolved.String()
+		}
+	}
+
+	return metadata
+}
+
+// urlPattern finds http(s) links in user text
+var urlPattern = regexp.MustCompile(`(?i)\bhttps?://[^\s<>"']+`)
+
+// FirstURL returns the first http(s) URL in text, normalized, or "" if there is none
+func FirstURL(text string) string {
+	for _, match := range urlPattern.FindAllString(text, -1) {
+		// Trailing punctuation usually ends the sentence, not the link
+		match = strings.TrimRight(match, ".,;:!?")
+		if strings.HasSuffix(match, ")") && !strings.Contains(match, "(") {
+			match = strings.TrimSuffix(match, ")")
+		}
+
+		if normalized, err := Normalize(match); err == nil {
+			return normalized
+		}
+	}
+	return ""
+}
+
+// Normalize returns the canonical form of an http(s) URL used as its cache key:
+// lowercase scheme and host, no default port, no fragment, and "/" for an empty path
+func Normalize(rawURL string) (string, error) {
+	u, err := parseHTTPURL(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	port := u.Port()
+	if (u.Scheme == "http" && port == "80") || (u.Scheme == "https" && port == "443") {
+		port = ""
+	}
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	if port != "" {
+		host += ":" + port
+	}
+	u.Host = host
+
+	u.User = nil
+	u.Fragment = ""
+	u.RawFragment = ""
+	if u.Path == "" {
+		u.Path = "/"
+	}
+
+	normalized := u.String()
+	if len(normalized) > MaxURLLength {
+		return "", ErrInvalidURL
+	}
+	return normalized, nil
+}
+
+// IsPublicAddr reports whether addr is a publicly routable address that may be fetched.
+// Loopback, private, link-local, multicast and other special-purpose ranges are refused.
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || !addr.IsGlobalUnicast() || addr.IsPrivate() || addr.IsLoopback() ||
+		addr.IsLinkLocalUnicast() || addr.IsMulticast() || addr.IsUnspecified() {
+		return false
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// blockedPrefixes are special-purpose ranges not covered by the netip checks
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // "This" network
+	netip.MustParsePrefix("100.64.0.0/10"),   // Carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // Documentation
+	netip.MustParsePrefix("198.18.0.0/15"),   // Benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // Documentation
+	netip.MustParsePrefix("203.0.113.0/24"),  // Documentation
+	netip.MustParsePrefix("240.0.0.0/4"),     // Reserved
+	netip.MustParsePrefix("64:ff9b::/96"),    // NAT64, can reach IPv4 private ranges
+	netip.MustParsePrefix("64:ff9b:1::/48"),  // Local-use NAT64
+	netip.MustParsePrefix("2001:db8::/32"),   // Documentation
+}
+
+// checkRedirect limits redirects and keeps them on http(s)
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= MaxRedirects {
+		return fmt.Errorf("stopped after %d redirects", MaxRedirects)
+	}
+	if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+		return ErrInvalidURL
+	}
+	return nil
+}
+
+// parseHTTPURL parses an absolute http(s) URL with a host
+func parseHTTPURL(rawURL string) (*url.URL, error) {
+	if len(rawURL) > MaxURLLength {
+		return nil, ErrInvalidURL
+	}
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Hostname() == "" {
+		return nil, ErrInvalidURL
+	}
+	scheme := strings.ToLower(u.Scheme)
+	if scheme != "http" && scheme != "https" {
+		return nil, ErrInvalidURL
+	}
+	return u, nil
+}
+
+// first returns the first non-blank value
+func first(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// clean decodes HTML entities, collapses whitespace and truncates to max runes
+func clean(s string, max int) string {
+	s = strings.Join(strings.Fields(html.UnescapeString(s)), " ")
+	if utf8.RuneCountInString(s) > max {
+		s = string([]rune(s)[:max])
+	}
+	return s
+}
@@ -0,0 +1,268 @@
+package linkpreview
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	base, _ := url.Parse("https://example.com/articles/1")
+
+	tests := []struct {
+		name string
+		page string
+		want Metadata
+	}{
+		{
+			name: "open graph",
+			page: `<html><head>
+				<title>Plain title</title>
+				<meta property="og:title" content="OG &amp; title">
+				<meta property="og:description" content='OG description'>
+				<meta property="og:image" content="/img/cover.png">
+				<meta property="og:site_name" content="Example">
+				<meta name="twitter:title" content="Twitter title">
+			</head></html>`,
+			want: Metadata{
+				Title:       "OG & title",
+				Description: "OG description",
+				ImageURL:    "https://example.com/img/cover.png",
+				SiteName:    "Example",
+			},
+		},
+		{
+			name: "twitter card",
+			page: `<meta name="twitter:title" content="Twitter title">
+				<meta name="twitter:image" content="https://cdn.example.com/t.png">
+				<title>Plain title</title>`,
+			want: Metadata{Title: "Twitter title", ImageURL: "https://cdn.example.com/t.png"},
+		},
+		{
+			name: "title fallback",
+			page: `<TITLE>
+				Plain
+				title </TITLE><meta name="description" content="Plain description">`,
+			want: Metadata{Title: "Plain title", Description: "Plain description"},
+		},
+		{
+			name: "first tag wins",
+			page: `<meta property="og:title" content="First"><meta property="og:title" content="Second">`,
+			want: Metadata{Title: "First"},
+		},
+		{
+			name: "non-http image",
+			page: `<meta property="og:title" content="Title"><meta property="og:image" content="javascript:alert(1)">`,
+			want: Metadata{Title: "Title"},
+		},
+		{
+			name: "no metadata",
+			page: `<html><body>hello</body></html>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Parse([]byte(tt.page), base); *got != tt.want {
+				t.Errorf("Parse = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTruncatesTitle(t *testing.T) {
+	base, _ := url.Parse("https://example.com/")
+	page := `<meta property="og:title" content="` + strings.Repeat("é", maxTitleLength+10) + `">`
+
+	if got := Parse([]byte(page), base).Title; len([]rune(got)) != maxTitleLength {
+		t.Errorf("title has %d runes, want %d", len([]rune(got)), maxTitleLength)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") != userAgent {
+			http.Error(w, "unexpected user agent", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(`<title>Page</title><meta property="og:image" content="cover.png">`))
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/docs/page", http.StatusFound)
+	})
+	mux.HandleFunc("/docs/page", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<meta property="og:title" content="Moved"><meta property="og:image" content="cover.png">`))
+	})
+	mux.HandleFunc("/json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"title": "not a page"}`))
+	})
+	mux.HandleFunc("/empty", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<p>nothing to see</p>`))
+	})
+	mux.HandleFunc("/huge", func(w http.ResponseWriter, r *http.Request) {
+		// Metadata past the read limit is never seen
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(strings.Repeat(" ", MaxBodySize)))
+		w.Write([]byte(`<title>Too late</title>`))
+	})
+	mux.HandleFunc("/missing", http.NotFound)
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	fetcher := NewFetcher(server.Client())
+	ctx := context.Background()
+
+	t.Run("html page", func(t *testing.T) {
+		got, err := fetcher.Fetch(ctx, server.URL+"/page")
+		if err != nil {
+			t.Fatalf("Fetch: %v", err)
+		}
+		if got.Title != "Page" || got.ImageURL != server.URL+"/cover.png" {
+			t.Errorf("Fetch = %+v", got)
+		}
+	})
+
+	t.Run("redirect", func(t *testing.T) {
+		got, err := fetcher.Fetch(ctx, server.URL+"/moved")
+		if err != nil {
+			t.Fatalf("Fetch: %v", err)
+		}
+		// Relative URLs resolve against the final page, not the requested one
+		if got.Title != "Moved" || got.ImageURL != server.URL+"/docs/cover.png" {
+			t.Errorf("Fetch = %+v", got)
+		}
+	})
+
+	errorTests := []struct {
+		name    string
+		url     string
+		wantErr error
+	}{
+		{"not html", server.URL + "/json", ErrNotHTML},
+		{"no metadata", server.URL + "/empty", ErrNoMetadata},
+		{"metadata past the size limit", server.URL + "/huge", ErrNoMetadata},
+		{"not an http url", "ftp://example.com/file", ErrInvalidURL},
+		{"no host", "https:///path", ErrInvalidURL},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := fetcher.Fetch(ctx, tt.url); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Fetch(%s): err = %v, want %v", tt.url, err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("error status", func(t *testing.T) {
+		if _, err := fetcher.Fetch(ctx, server.URL+"/missing"); err == nil {
+			t.Error("Fetch: want error for a 404, got nil")
+		}
+	})
+}
+
+func TestSafeClientBlocksPrivateAddresses(t *testing.T) {
+	private := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<title>Internal</title>`))
+	}))
+	defer private.Close()
+
+	// Stands in for a public site that redirects to an internal address
+	public := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, private.URL+"/admin", http.StatusFound)
+	}))
+	defer public.Close()
+
+	client := NewSafeClient()
+	safe := client.Transport
+	client.Transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Host == "public.example" {
+			req = req.Clone(req.Context())
+			req.URL.Host = strings.TrimPrefix(public.URL, "http://")
+			return http.DefaultTransport.RoundTrip(req)
+		}
+		return safe.RoundTrip(req)
+	})
+	fetcher := NewFetcher(client)
+	ctx := context.Background()
+
+	if _, err := fetcher.Fetch(ctx, private.URL); !errors.Is(err, ErrBlockedAddress) {
+		t.Errorf("Fetch(loopback): err = %v, want ErrBlockedAddress", err)
+	}
+	if _, err := fetcher.Fetch(ctx, "http://public.example/"); !errors.Is(err, ErrBlockedAddress) {
+		t.Errorf("Fetch(redirect to loopback): err = %v, want ErrBlockedAddress", err)
+	}
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false}, // Cloud metadata
+		{"fe80::1", false},
+		{"fc00::1", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"224.0.0.1", false},
+		{"100.64.0.1", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:10.0.0.1", false},
+		{"::ffff:93.184.216.34", true},
+		{"64:ff9b::a00:1", false},
+		{"2001:db8::1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			if got := IsPublicAddr(netip.MustParseAddr(tt.addr)); got != tt.want {
+				t.Errorf("IsPublicAddr(%s) = %v, want %v", tt.addr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFirstURL(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"see https://Example.com.", "https://example.com/"},
+		{"(https://example.com/a)", "https://example.com/a"},
+		{"https://en.wikipedia.org/wiki/Go_(game)", "https://en.wikipedia.org/wiki/Go_(game)"},
+		{"http://example.com:80/x#top and https://other.com", "http://example.com/x"},
+		{"no links here", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := FirstURL(tt.text); got != tt.want {
+				t.Errorf("FirstURL(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"os"
+	"path/filepath"
+
+	"github.com/disintegration/imaging"
+
+	_ "golang.org/x/image/webp" // Register WebP decoder
+)
+
+const (
+	MaxFileSize = 10 * 1024 * 1024 // 10MB
+	MaxPixels   = 40 * 1000 * 1000 // Reject decompression bombs before decoding
+	StorageDir  = "storage/media"
+	BaseURL     = "/media" // Will be updated for S3 later
+)
+
+// Size variants, each fit within a square of the given size (never upscaled)
+const (
+	VariantThumb  = "thumb"
+	VariantMedium = "medium"
+	VariantLarge  = "large"
+)
+
+// Variant describes a size variant
+type Variant struct {
+	Name    string
+	MaxSide int
+}
+
+// Variants are produced for every upload, smallest first
+var Variants = []Variant{
+	{Name: VariantThumb, MaxSide: 320},
+	{Name: VariantMedium, MaxSide: 1080},
+	{Name: VariantLarge, MaxSide: 2048},
+}
+
+var (
+	ErrFileTooBig       = errors.New("file size exceeds 10MB")
+	ErrInvalidFileType  = errors.New("invalid file type, only JPEG, PNG, and WebP allowed")
+	ErrImageTooLarge    = errors.New("image dimensions too large")
+	ErrProcessingFailed = errors.New("failed to process image")
+)
+
+// Result describes a processed image
+type Result struct {
+	Width  int // Width after applying EXIF orientation
+	Height int // Height after applying EXIF orientation
+}
+
+// ProcessAndSave validates an image, applies its EXIF orientation, and saves every size
+// variant as a re-encoded JPEG under key. Re-encoding drops all metadata, including EXIF
+// and GPS location.
+func ProcessAndSave(fileData []byte, key string) (*Result, error) {
+	// Validate file size
+	if len(fileData) > MaxFileSize {
+		return nil, ErrFileTooBig
+	}
+
+	// Detect and validate file type
+	if detectImageType(fileData) == "" {
+		return nil, ErrInvalidFileType
+	}
+
+	// Check dimensions from the header before decoding the whole image
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(fileData))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrProcessingFailed, err)
+	}
+	if cfg.Width*cfg.Height > MaxPixels {
+		return nil, ErrImageTooLarge
+	}
+
+	// Decode, rotating according to the EXIF orientation tag since the tag itself is dropped
+	img, err := imaging.Decode(bytes.NewReader(fileData), imaging.AutoOrientation(true))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrProcessingFailed, err)
+	}
+
+	// Flatten transparency onto white, JPEG has no alpha channel
+	bounds := img.Bounds()
+	flat := imaging.New(bounds.Dx(), bounds.Dy(), color.White)
+	flat = imaging.Overlay(flat, img, image.Pt(0, 0), 1.0)
+
+	dir := filepath.Join(StorageDir, key)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create media directory: %w", err)
+	}
+
+	for _, v := range Variants {
+		resized := image.Image(flat)
+		if bounds.Dx() > v.MaxSide || bounds.Dy() > v.MaxSide {
+			resized = imaging.Fit(flat, v.MaxSide, v.MaxSide, imaging.Lanczos)
+		}
+
+		if err := saveJPEG(resized, filepath.Join(dir, v.Name+".jpg")); err != nil {
+			_ = os.RemoveAll(dir)
+			return nil, fmt.Errorf("failed to save file: %w", err)
+		}
+	}
+
+	return &Result{Width: bounds.Dx(), Height: bounds.Dy()}, nil
+}
+
+// URL returns the public URL of a size variant
+func URL(key, variant string) string {
+	return fmt.Sprintf("%s/%s/%s.jpg", BaseURL, key, variant)
+}
+
+// Delete removes all variants stored under key
+func Delete(key string) error {
+	if key == "" || key != filepath.Base(key) {
+		return nil
+	}
+
+	if err := os.RemoveAll(filepath.Join(StorageDir, key)); err != nil {
+		return fmt.Errorf("failed to delete media: %w", err)
+	}
+
+	return nil
+}
+
+// detectImageType detects the image type from file data
+func detectImageType(data []byte) string {
+	// Check PNG
+	if len(data) >= 8 && string(data[0:8]) == "\x89PNG\r\n\x1a\n" {
+		return "png"
+	}
+	// Check JPEG
+	if len(data) >= 3 && data[0] == 0xFF && data[1] == 0xD8 && data[2] == 0xFF {
+		return "jpeg"
+	}
+	// Check WebP
+	if len(data) >= 12 && string(data[0:4]) == "RIFF" && string(data[8:12]) == "WEBP" {
+		return "webp"
+	}
+	return ""
+}
+
+// saveJPEG encodes img as JPEG to path. The standard encoder writes no metadata.
+func saveJPEG(img image.Image, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return jpeg.Encode(file, img, &jpeg.Options{Quality: 85})
+}
@@ -0,0 +1,250 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// encodeJPEG returns a JPEG of the given size
+func encodeJPEG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		img.Set(x, 0, color.RGBA{R: 200, A: 255})
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatalf("encode jpeg: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// withEXIF splices an APP1 EXIF segment with the given orientation and a camera model
+// right after the JPEG start of image marker
+func withEXIF(data []byte, orientation uint16) []byte {
+	const model = "SecretCam GPS 51.5N\x00"
+
+	// Little endian TIFF header followed by one IFD with Orientation and Model
+	tiff := []byte("II*\x00")
+	tiff = binary.LittleEndian.AppendUint32(tiff, 8)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 2)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 0x0112) // Orientation, SHORT
+	tiff = binary.LittleEndian.AppendUint16(tiff, 3)
+	tiff = binary.LittleEndian.AppendUint32(tiff, 1)
+	tiff = binary.LittleEndian.AppendUint16(tiff, orientation)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 0)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 0x0110) // Model, ASCII
+	tiff = binary.LittleEndian.AppendUint16(tiff, 2)
+	tiff = binary.LittleEndian.AppendUint32(tiff, uint32(len(model)))
+	tiff = binary.LittleEndian.AppendUint32(tiff, 8+2+2*12+4)
+	tiff = binary.LittleEndian.AppendUint32(tiff, 0)
+	tiff = append(tiff, model...)
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1}
+	segment = binary.BigEndian.AppendUint16(segment, uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	out := append([]byte{}, data[:2]...)
+	out = append(out, segment...)
+	return append(out, data[2:]...)
+}
+
+// pngHeader returns a PNG that declares the given size but holds no pixel data
+func pngHeader(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	data := buf.Bytes()
+
+	// The IHDR chunk follows the 8 byte signature: length, type, width, height, ...
+	binary.BigEndian.PutUint32(data[16:], uint32(width))
+	binary.BigEndian.PutUint32(data[20:], uint32(height))
+	binary.BigEndian.PutUint32(data[29:], crc32.ChecksumIEEE(data[12:29]))
+	return data
+}
+
+// inTempDir runs the test from an empty directory so StorageDir doesn't touch the tree
+func inTempDir(t *testing.T) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+}
+
+// readVariant decodes a stored variant, returning its raw bytes and size
+func readVariant(t *testing.T, key, variant string) ([]byte, image.Config) {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join(StorageDir, key, variant+".jpg"))
+	if err != nil {
+		t.Fatalf("read %s: %v", variant, err)
+	}
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || format != "jpeg" {
+		t.Fatalf("decode %s: format %q, %v", variant, format, err)
+	}
+	return data, cfg
+}
+
+func TestProcessAndSaveRejects(t *testing.T) {
+	inTempDir(t)
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{"too big", append(encodeJPEG(t, 10, 10), make([]byte, MaxFileSize)...), ErrFileTooBig},
+		{"not an image", []byte("GIF89a, or maybe a script"), ErrInvalidFileType},
+		{"empty", nil, ErrInvalidFileType},
+		{"corrupt", []byte("\xFF\xD8\xFF garbage"), ErrProcessingFailed},
+		{"over MaxPixels", pngHeader(t, 10000, 5000), ErrImageTooLarge},
+		{"over MaxPixels in one dimension", pngHeader(t, 100000, 401), ErrImageTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ProcessAndSave(tt.data, "rejected")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ProcessAndSave = %+v, %v; want %v", result, err, tt.wantErr)
+			}
+			if _, err := os.Stat(filepath.Join(StorageDir, "rejected")); !os.IsNotExist(err) {
+				t.Errorf("rejected image left files behind: %v", err)
+			}
+		})
+	}
+}
+
+func TestProcessAndSaveVariants(t *testing.T) {
+	inTempDir(t)
+
+	tests := []struct {
+		name          string
+		width, height int
+		want          map[string][2]int // Variant -> width, height
+	}{
+		{
+			name:  "landscape",
+			width: 4000, height: 3000,
+			want: map[string][2]int{VariantThumb: {320, 240}, VariantMedium: {1080, 810}, VariantLarge: {2048, 1536}},
+		},
+		{
+			name:  "portrait",
+			width: 1500, height: 3000,
+			want: map[string][2]int{VariantThumb: {160, 320}, VariantMedium: {540, 1080}, VariantLarge: {1024, 2048}},
+		},
+		{
+			name:  "between sizes",
+			width: 600, height: 400,
+			want: map[string][2]int{VariantThumb: {320, 213}, VariantMedium: {600, 400}, VariantLarge: {600, 400}},
+		},
+		{
+			name:  "smaller than a thumbnail is never upscaled",
+			width: 200, height: 100,
+			want: map[string][2]int{VariantThumb: {200, 100}, VariantMedium: {200, 100}, VariantLarge: {200, 100}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ProcessAndSave(encodeJPEG(t, tt.width, tt.height), "variants")
+			if err != nil {
+				t.Fatalf("ProcessAndSave: %v", err)
+			}
+			defer Delete("variants")
+
+			if result.Width != tt.width || result.Height != tt.height {
+				t.Errorf("result %dx%d, want %dx%d", result.Width, result.Height, tt.width, tt.height)
+			}
+			for _, v := range Variants {
+				_, cfg := readVariant(t, "variants", v.Name)
+				if want := tt.want[v.Name]; cfg.Width != want[0] || cfg.Height != want[1] {
+					t.Errorf("%s is %dx%d, want %dx%d", v.Name, cfg.Width, cfg.Height, want[0], want[1])
+				}
+			}
+		})
+	}
+}
+
+func TestProcessAndSaveStripsEXIF(t *testing.T) {
+	inTempDir(t)
+
+	tests := []struct {
+		name                  string
+		orientation           uint16
+		wantWidth, wantHeight int
+	}{
+		{"upright", 1, 300, 150},
+		{"rotated 90 degrees", 6, 150, 300},
+		{"rotated 180 degrees", 3, 300, 150},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := withEXIF(encodeJPEG(t, 300, 150), tt.orientation)
+			if !bytes.Contains(data, []byte("SecretCam")) {
+				t.Fatal("test image has no EXIF")
+			}
+
+			result, err := ProcessAndSave(data, "exif")
+			if err != nil {
+				t.Fatalf("ProcessAndSave: %v", err)
+			}
+			defer Delete("exif")
+
+			// The orientation is applied to the pixels since the tag itself is dropped
+			if result.Width != tt.wantWidth || result.Height != tt.wantHeight {
+				t.Errorf("result %dx%d, want %dx%d", result.Width, result.Height, tt.wantWidth, tt.wantHeight)
+			}
+			for _, v := range Variants {
+				stored, cfg := readVariant(t, "exif", v.Name)
+				if bytes.Contains(stored, []byte("Exif")) || bytes.Contains(stored, []byte("SecretCam")) {
+					t.Errorf("%s kept the EXIF metadata", v.Name)
+				}
+				if cfg.Width != tt.wantWidth || cfg.Height != tt.wantHeight {
+					t.Errorf("%s is %dx%d, want %dx%d", v.Name, cfg.Width, cfg.Height, tt.wantWidth, tt.wantHeight)
+				}
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	inTempDir(t)
+
+	if _, err := ProcessAndSave(encodeJPEG(t, 50, 50), "deleted"); err != nil {
+		t.Fatalf("ProcessAndSave: %v", err)
+	}
+	if err := Delete("deleted"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(StorageDir, "deleted")); !os.IsNotExist(err) {
+		t.Errorf("variants still stored: %v", err)
+	}
+
+	// Keys never reach outside the storage directory
+	if err := os.WriteFile("keep", nil, 0644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	for _, key := range []string{"", "../../keep", "a/../../.."} {
+		if err := Delete(key); err != nil {
+			t.Errorf("Delete(%q): %v", key, err)
+		}
+	}
+	if _, err := os.Stat("keep"); err != nil {
+		t.Errorf("Delete escaped the storage directory: %v", err)
+	}
+}
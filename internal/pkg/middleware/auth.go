@@ -69,3 +69,25 @@ func GetUsername(c *fiber.Ctx) string {
 	}
 	return ""
 }
+
+// OptionalAuthMiddleware populates the user context when a valid token is present
+// but lets anonymous requests through, for endpoints that work logged-out
+func OptionalAuthMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		parts := strings.Split(c.Get("Authorization"), " ")
+		if len(parts) != 2 || parts[0] != "Bearer" {
+			return c.Next()
+		}
+
+		claims, err := jwt.ValidateAccessToken(parts[1])
+		if err != nil {
+			return c.Next()
+		}
+
+		c.Locals("user_id", claims.UserID)
+		c.Locals("email", claims.Email)
+		c.Locals("username", claims.Username)
+
+		return c.Next()
+	}
+}
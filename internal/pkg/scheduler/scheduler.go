@@ -0,0 +1,43 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Job is a unit of periodic background work
+type Job func(ctx context.Context) error
+
+// Every runs job once immediately and then on every interval until ctx is cancelled.
+// Errors are logged and never stop the schedule. Call it in its own goroutine.
+func Every(ctx context.Context, name string, interval time.Duration, job Job) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		run(ctx, name, job)
+
+		select {
+		case <-ctx.Done():
+			log.Printf("⏹️  Job %s stopped", name)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// run executes a single job iteration, recovering from panics so one bad run
+// does not take the whole process down
+func run(ctx context.Context, name string, job Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("❌ Job %s panicked: %v", name, r)
+		}
+	}()
+
+	start := time.Now()
+	if err := job(ctx); err != nil {
+		log.Printf("❌ Job %s failed after %s: %v", name, time.Since(start), err)
+	}
+}
@@ -0,0 +1,58 @@
+// Package testdb connects repository tests to a migrated PostgreSQL database
+package testdb
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Pool connects to the database at TEST_DATABASE_URL, skipping the test when it isn't set.
+// The database must be migrated first (make migrate DATABASE_URL=...).
+func Pool(t testing.TB) *pgxpool.Pool {
+	t.Helper()
+
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+
+	pool, err := pgxpool.New(context.Background(), url)
+	if err != nil {
+		t.Fatalf("connect to test database: %v", err)
+	}
+	if err := pool.Ping(context.Background()); err != nil {
+		pool.Close()
+		t.Fatalf("ping test database: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	return pool
+}
+
+// CreateUser inserts a user and returns its ID. The user, and everything that cascades
+// from it, is deleted when the test ends.
+func CreateUser(t testing.TB, pool *pgxpool.Pool) string {
+	t.Helper()
+
+	name := "test_" + uuid.NewString()[:8]
+
+	var id string
+	err := pool.QueryRow(context.Background(), `
+		INSERT INTO users (email, password_hash, username, first_name)
+		VALUES ($1, 'x', $2, $2)
+		RETURNING id
+	`, name+"@example.com", name).Scan(&id)
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_, _ = pool.Exec(context.Background(), `DELETE FROM users WHERE id = $1`, id)
+	})
+
+	return id
+}
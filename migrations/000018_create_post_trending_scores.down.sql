@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_post_comments_created;
+DROP INDEX IF EXISTS idx_post_reactions_created;
+DROP TABLE IF EXISTS post_trending_scores;
@@ -0,0 +1,14 @@
+-- Trending scores for the explore feed, recomputed periodically by a background job
+CREATE TABLE IF NOT EXISTS post_trending_scores (
+    post_id UUID PRIMARY KEY REFERENCES posts(id) ON DELETE CASCADE,
+    score DOUBLE PRECISION NOT NULL,
+    computed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_trending_scores_score ON post_trending_scores(score DESC);
+
+-- Engagement lookups by time window used when computing scores
+CREATE INDEX IF NOT EXISTS idx_post_reactions_created ON post_reactions(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_post_comments_created ON post_comments(created_at DESC);
+
+COMMENT ON TABLE post_trending_scores IS 'Time-decayed engagement score per post for the explore feed';
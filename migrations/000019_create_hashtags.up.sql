@@ -0,0 +1,50 @@
+-- Normalized hashtags (stored lowercase without the leading #)
+CREATE TABLE IF NOT EXISTS hashtags (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tag VARCHAR(100) NOT NULL UNIQUE,
+    interest_id UUID REFERENCES interests(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT hashtag_lowercase CHECK (tag = LOWER(tag))
+);
+
+-- Posts using each hashtag
+CREATE TABLE IF NOT EXISTS post_hashtags (
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    hashtag_id UUID NOT NULL REFERENCES hashtags(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (post_id, hashtag_id)
+);
+
+-- Prefix search for autocomplete
+CREATE INDEX IF NOT EXISTS idx_hashtags_tag_prefix ON hashtags(tag text_pattern_ops);
+
+-- Hashtag pages and trending windows
+CREATE INDEX IF NOT EXISTS idx_post_hashtags_hashtag_created ON post_hashtags(hashtag_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_post_hashtags_created ON post_hashtags(created_at DESC);
+
+-- Backfill hashtags from existing posts
+INSERT INTO hashtags (tag)
+SELECT DISTINCT LOWER(m[1])
+FROM posts p
+CROSS JOIN LATERAL regexp_matches(p.content, '(?:^|[^[:alnum:]_])#([[:alnum:]_]*[[:alpha:]][[:alnum:]_]*)', 'g') AS m
+WHERE char_length(m[1]) <= 100
+ON CONFLICT (tag) DO NOTHING;
+
+INSERT INTO post_hashtags (post_id, hashtag_id, created_at)
+SELECT DISTINCT p.id, h.id, p.created_at
+FROM posts p
+CROSS JOIN LATERAL regexp_matches(p.content, '(?:^|[^[:alnum:]_])#([[:alnum:]_]*[[:alpha:]][[:alnum:]_]*)', 'g') AS m
+JOIN hashtags h ON h.tag = LOWER(m[1])
+ON CONFLICT DO NOTHING;
+
+-- Link hashtags to interests with a matching slug (#webdev, #web_dev -> web-dev)
+UPDATE hashtags h
+SET interest_id = i.id
+FROM interests i
+WHERE h.interest_id IS NULL
+  AND h.tag IN (i.slug, REPLACE(i.slug, '-', ''), REPLACE(i.slug, '-', '_'));
+
+COMMENT ON TABLE hashtags IS 'Normalized hashtags parsed from post content';
+COMMENT ON TABLE post_hashtags IS 'Junction table linking posts to their hashtags';
+COMMENT ON COLUMN hashtags.interest_id IS 'Interest whose slug matches this hashtag, if any';
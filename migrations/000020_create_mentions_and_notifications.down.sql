@@ -0,0 +1,3 @@
+-- Drop mentions and notifications tables
+DROP TABLE IF EXISTS notifications CASCADE;
+DROP TABLE IF EXISTS mentions CASCADE;
@@ -0,0 +1,52 @@
+-- Users mentioned (@username) in posts and comments
+CREATE TABLE IF NOT EXISTS mentions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Where the mention appears (comment_id is NULL for mentions in the post itself)
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    comment_id UUID REFERENCES post_comments(id) ON DELETE CASCADE,
+
+    -- Who mentioned whom
+    author_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    mentioned_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    username VARCHAR(30) NOT NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- One row per mentioned user per post or comment
+CREATE UNIQUE INDEX IF NOT EXISTS idx_mentions_post_user
+    ON mentions(post_id, mentioned_user_id) WHERE comment_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_mentions_comment_user
+    ON mentions(comment_id, mentioned_user_id) WHERE comment_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_mentions_mentioned_user ON mentions(mentioned_user_id, created_at DESC);
+
+-- In-app notifications
+CREATE TABLE IF NOT EXISTS notifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Recipient and the user who caused it (NULL when the actor is anonymous)
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    actor_id UUID REFERENCES users(id) ON DELETE CASCADE,
+
+    type VARCHAR(30) NOT NULL,
+
+    -- Related content
+    post_id UUID REFERENCES posts(id) ON DELETE CASCADE,
+    comment_id UUID REFERENCES post_comments(id) ON DELETE CASCADE,
+
+    is_read BOOLEAN NOT NULL DEFAULT false,
+    read_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT notification_type_check CHECK (type IN ('mention'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_created ON notifications(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_unread ON notifications(user_id) WHERE is_read = false;
+
+COMMENT ON TABLE mentions IS 'Resolved @username mentions in posts and comments';
+COMMENT ON COLUMN mentions.username IS 'Username as written in the content, used to match entity offsets';
+COMMENT ON TABLE notifications IS 'In-app notifications for users';
+COMMENT ON COLUMN notifications.actor_id IS 'User who triggered the notification, NULL for anonymous content';
@@ -0,0 +1,2 @@
+-- Drop post_views table
+DROP TABLE IF EXISTS post_views CASCADE;
@@ -0,0 +1,21 @@
+-- Distinct viewers per post (logged-in users only)
+CREATE TABLE IF NOT EXISTS post_views (
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    viewer_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Counted views by this viewer (deduplicated per time window)
+    view_count INTEGER NOT NULL DEFAULT 1,
+
+    first_viewed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_viewed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (post_id, viewer_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_views_viewer ON post_views(viewer_id, last_viewed_at DESC);
+
+-- Seeded posts may have NULL view counts
+UPDATE posts SET view_count = 0 WHERE view_count IS NULL;
+
+COMMENT ON TABLE post_views IS 'Distinct viewers per post; posts.view_count holds the total';
+COMMENT ON COLUMN post_views.view_count IS 'Views by this viewer, at most one per dedupe window';
@@ -0,0 +1,3 @@
+-- Drop media tables
+DROP TABLE IF EXISTS post_media CASCADE;
+DROP TABLE IF EXISTS media CASCADE;
@@ -0,0 +1,34 @@
+-- Uploaded images; size variants are stored under storage/media/<id>/
+CREATE TABLE IF NOT EXISTS media (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Dimensions after applying EXIF orientation
+    width INTEGER NOT NULL CHECK (width > 0),
+    height INTEGER NOT NULL CHECK (height > 0),
+    size_bytes INTEGER NOT NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_media_user_created ON media(user_id, created_at DESC);
+
+-- Images attached to posts (each upload can be used by one post)
+CREATE TABLE IF NOT EXISTS post_media (
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    media_id UUID NOT NULL UNIQUE REFERENCES media(id) ON DELETE CASCADE,
+    position SMALLINT NOT NULL,
+    alt_text VARCHAR(1000) NOT NULL DEFAULT '',
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (post_id, media_id),
+    CONSTRAINT post_media_position_check CHECK (position >= 0 AND position < 10)
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_media_post_position ON post_media(post_id, position);
+
+COMMENT ON TABLE media IS 'Uploaded images, re-encoded without metadata in several size variants';
+COMMENT ON TABLE post_media IS 'Junction table linking posts to their uploaded images';
+COMMENT ON COLUMN post_media.alt_text IS 'Image description for screen readers';
@@ -0,0 +1,6 @@
+-- Drop polls and the post type column
+DROP TABLE IF EXISTS poll_votes CASCADE;
+DROP TABLE IF EXISTS poll_options CASCADE;
+DROP TABLE IF EXISTS polls CASCADE;
+ALTER TABLE posts DROP CONSTRAINT IF EXISTS post_type_check;
+ALTER TABLE posts DROP COLUMN IF EXISTS post_type;
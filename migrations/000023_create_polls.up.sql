@@ -0,0 +1,39 @@
+-- Post type discriminator
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS post_type VARCHAR(20) NOT NULL DEFAULT 'text';
+ALTER TABLE posts ADD CONSTRAINT post_type_check CHECK (post_type IN ('text', 'poll'));
+
+-- Poll settings (one per poll post)
+CREATE TABLE IF NOT EXISTS polls (
+    post_id UUID PRIMARY KEY REFERENCES posts(id) ON DELETE CASCADE,
+    allows_multiple BOOLEAN NOT NULL DEFAULT false,
+    public_votes BOOLEAN NOT NULL DEFAULT false,
+    hide_results_until_voted BOOLEAN NOT NULL DEFAULT false,
+    closes_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS poll_options (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    post_id UUID NOT NULL REFERENCES polls(post_id) ON DELETE CASCADE,
+    position SMALLINT NOT NULL,
+    text VARCHAR(100) NOT NULL,
+
+    UNIQUE (post_id, position),
+    CONSTRAINT poll_option_position_check CHECK (position >= 0 AND position < 6)
+);
+
+CREATE TABLE IF NOT EXISTS poll_votes (
+    post_id UUID NOT NULL REFERENCES polls(post_id) ON DELETE CASCADE,
+    option_id UUID NOT NULL REFERENCES poll_options(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (option_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_poll_votes_post_user ON poll_votes(post_id, user_id);
+
+COMMENT ON COLUMN posts.post_type IS 'Post type: text or poll';
+COMMENT ON TABLE polls IS 'Poll settings for posts with post_type = poll';
+COMMENT ON COLUMN polls.public_votes IS 'Whether voters are shown for each option';
+COMMENT ON COLUMN polls.hide_results_until_voted IS 'Hide tallies until the viewer votes or the poll closes';
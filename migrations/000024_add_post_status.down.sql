@@ -0,0 +1,7 @@
+-- Remove drafts and scheduled posts
+DROP INDEX IF EXISTS idx_posts_user_unpublished;
+DROP INDEX IF EXISTS idx_posts_scheduled;
+ALTER TABLE posts DROP CONSTRAINT IF EXISTS post_publish_at_check;
+ALTER TABLE posts DROP CONSTRAINT IF EXISTS post_status_check;
+ALTER TABLE posts DROP COLUMN IF EXISTS publish_at;
+ALTER TABLE posts DROP COLUMN IF EXISTS status;
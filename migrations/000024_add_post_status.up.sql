@@ -0,0 +1,14 @@
+-- Drafts and scheduled posts
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'published';
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP;
+ALTER TABLE posts ADD CONSTRAINT post_status_check CHECK (status IN ('draft', 'scheduled', 'published'));
+ALTER TABLE posts ADD CONSTRAINT post_publish_at_check CHECK (status <> 'scheduled' OR publish_at IS NOT NULL);
+
+-- Find due scheduled posts
+CREATE INDEX IF NOT EXISTS idx_posts_scheduled ON posts(publish_at) WHERE status = 'scheduled' AND is_active = true;
+
+-- List a user's drafts
+CREATE INDEX IF NOT EXISTS idx_posts_user_unpublished ON posts(user_id, updated_at DESC) WHERE status <> 'published' AND is_active = true;
+
+COMMENT ON COLUMN posts.status IS 'Post status: draft, scheduled or published; only published posts are shown';
+COMMENT ON COLUMN posts.publish_at IS 'When a scheduled post is published';
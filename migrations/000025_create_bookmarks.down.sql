@@ -0,0 +1,3 @@
+-- Drop bookmarks and collections
+DROP TABLE IF EXISTS bookmarks CASCADE;
+DROP TABLE IF EXISTS bookmark_collections CASCADE;
@@ -0,0 +1,2 @@
+-- Drop post pins
+DROP TABLE IF EXISTS post_pins CASCADE;
@@ -0,0 +1,13 @@
+-- Posts pinned to the top of their author's profile (max 3, enforced by the app)
+CREATE TABLE IF NOT EXISTS post_pins (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    post_id UUID NOT NULL UNIQUE REFERENCES posts(id) ON DELETE CASCADE,
+    position SMALLINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (user_id, post_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_pins_user_position ON post_pins(user_id, position);
+
+COMMENT ON COLUMN post_pins.position IS 'Display order on the profile, starting at 0';
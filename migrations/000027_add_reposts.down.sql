@@ -0,0 +1,13 @@
+-- Remove reposts and quote posts
+DELETE FROM posts WHERE post_type IN ('repost', 'quote');
+DROP INDEX IF EXISTS idx_posts_quoted;
+DROP INDEX IF EXISTS idx_posts_unique_repost;
+ALTER TABLE posts DROP CONSTRAINT IF EXISTS post_quoted_check;
+ALTER TABLE posts DROP CONSTRAINT IF EXISTS content_length;
+ALTER TABLE posts ADD CONSTRAINT content_length CHECK (
+    char_length(content) >= 1 AND
+    char_length(content) <= 5000
+);
+ALTER TABLE posts DROP CONSTRAINT IF EXISTS post_type_check;
+ALTER TABLE posts ADD CONSTRAINT post_type_check CHECK (post_type IN ('text', 'poll'));
+ALTER TABLE posts DROP COLUMN IF EXISTS quoted_post_id;
@@ -0,0 +1,21 @@
+-- Reposts and quote posts reference the post they share
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS quoted_post_id UUID REFERENCES posts(id) ON DELETE SET NULL;
+
+ALTER TABLE posts DROP CONSTRAINT IF EXISTS post_type_check;
+ALTER TABLE posts ADD CONSTRAINT post_type_check CHECK (post_type IN ('text', 'poll', 'repost', 'quote'));
+ALTER TABLE posts ADD CONSTRAINT post_quoted_check CHECK (post_type NOT IN ('repost', 'quote') OR quoted_post_id IS NOT NULL);
+
+-- Reposts have no content of their own
+ALTER TABLE posts DROP CONSTRAINT IF EXISTS content_length;
+ALTER TABLE posts ADD CONSTRAINT content_length CHECK (
+    char_length(content) <= 5000
+    AND (post_type = 'repost' OR char_length(content) >= 1)
+);
+
+-- A user reposts a post at most once
+CREATE UNIQUE INDEX IF NOT EXISTS idx_posts_unique_repost ON posts(user_id, quoted_post_id)
+    WHERE post_type = 'repost' AND is_active = true;
+
+CREATE INDEX IF NOT EXISTS idx_posts_quoted ON posts(quoted_post_id) WHERE quoted_post_id IS NOT NULL;
+
+COMMENT ON COLUMN posts.quoted_post_id IS 'Original post of a repost (no content of its own) or quote post (with commentary)';
@@ -0,0 +1,2 @@
+-- Drop link previews
+DROP TABLE IF EXISTS link_previews CASCADE;
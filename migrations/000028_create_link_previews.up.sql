@@ -0,0 +1,21 @@
+-- Link preview cache, keyed by normalized URL; pending rows are the fetch queue
+CREATE TABLE IF NOT EXISTS link_previews (
+    url TEXT PRIMARY KEY,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    title VARCHAR(300) NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    image_url TEXT NOT NULL DEFAULT '',
+    site_name VARCHAR(300) NOT NULL DEFAULT '',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    fetched_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT link_preview_status_check CHECK (status IN ('pending', 'ready', 'failed'))
+);
+
+-- Find URLs due for fetching
+CREATE INDEX IF NOT EXISTS idx_link_previews_pending ON link_previews(next_attempt_at) WHERE status = 'pending';
+
+COMMENT ON TABLE link_previews IS 'OpenGraph / Twitter card metadata for URLs in posts and messages';
+COMMENT ON COLUMN link_previews.status IS 'pending (queued for fetching), ready, or failed (gave up)';
@@ -0,0 +1,3 @@
+-- Drop moderation queue
+DROP TABLE IF EXISTS moderation_queue CASCADE;
+ALTER TABLE users DROP COLUMN IF EXISTS is_moderator;
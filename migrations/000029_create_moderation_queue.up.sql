@@ -0,0 +1,28 @@
+-- Moderators can review flagged content
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_moderator BOOLEAN NOT NULL DEFAULT false;
+
+-- Content flagged by the moderation classifiers, waiting for review
+CREATE TABLE IF NOT EXISTS moderation_queue (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    content_type VARCHAR(20) NOT NULL,
+    content_id UUID NOT NULL,
+    author_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    content_text TEXT NOT NULL,
+    classifier VARCHAR(50) NOT NULL,
+    category VARCHAR(50) NOT NULL DEFAULT '',
+    reason TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT moderation_content_type_check CHECK (content_type IN ('post', 'comment', 'message', 'bio')),
+    CONSTRAINT moderation_status_check CHECK (status IN ('pending', 'approved', 'removed'))
+);
+
+-- One pending item per piece of content; edits update it
+CREATE UNIQUE INDEX IF NOT EXISTS idx_moderation_queue_pending_content ON moderation_queue(content_type, content_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_moderation_queue_status ON moderation_queue(status, created_at);
+
+COMMENT ON TABLE moderation_queue IS 'Flagged posts, comments, messages and bios for moderator review';
+COMMENT ON COLUMN moderation_queue.content_id IS 'ID of the post, comment or message; the user ID for a bio';
@@ -0,0 +1,9 @@
+-- Drop reports
+DELETE FROM notifications WHERE type IN ('report_resolved', 'moderation_warning');
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS notification_type_check;
+ALTER TABLE notifications ADD CONSTRAINT notification_type_check CHECK (type IN ('mention'));
+ALTER TABLE notifications DROP COLUMN IF EXISTS report_id;
+
+DROP TABLE IF EXISTS reports CASCADE;
+DROP TABLE IF EXISTS report_cases CASCADE;
+ALTER TABLE users DROP COLUMN IF EXISTS suspended_until;
@@ -0,0 +1,60 @@
+-- Moderators can suspend accounts; login is refused until the suspension ends
+ALTER TABLE users ADD COLUMN IF NOT EXISTS suspended_until TIMESTAMP;
+
+-- One case per reported target while it is being handled; repeated reports join the open case
+CREATE TABLE IF NOT EXISTS report_cases (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- What was reported and who owns it
+    target_type VARCHAR(20) NOT NULL,
+    target_id UUID NOT NULL,
+    target_owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    content_snapshot TEXT NOT NULL DEFAULT '',
+
+    report_count INTEGER NOT NULL DEFAULT 0,
+
+    -- Review
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    claimed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    claimed_at TIMESTAMP,
+    action VARCHAR(20),
+    resolution_note TEXT,
+    resolved_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    resolved_at TIMESTAMP,
+
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT report_target_type_check CHECK (target_type IN ('post', 'comment', 'message', 'user')),
+    CONSTRAINT report_case_status_check CHECK (status IN ('open', 'claimed', 'resolved')),
+    CONSTRAINT report_case_action_check CHECK (action IS NULL OR action IN ('dismiss', 'hide_content', 'warn', 'suspend'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_report_cases_active_target ON report_cases(target_type, target_id) WHERE status <> 'resolved';
+CREATE INDEX IF NOT EXISTS idx_report_cases_status ON report_cases(status, created_at);
+
+-- Individual reports; a user reports a target at most once per case
+CREATE TABLE IF NOT EXISTS reports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    case_id UUID NOT NULL REFERENCES report_cases(id) ON DELETE CASCADE,
+    reporter_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason VARCHAR(30) NOT NULL,
+    details TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT report_reason_check CHECK (reason IN ('spam', 'harassment', 'hate_speech', 'violence', 'sexual_content', 'self_harm', 'misinformation', 'impersonation', 'other')),
+    CONSTRAINT report_details_length CHECK (char_length(details) <= 1000),
+    CONSTRAINT unique_report_per_case UNIQUE (case_id, reporter_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reports_reporter ON reports(reporter_id, created_at DESC);
+
+-- Reporters are told the outcome; warned users are notified too
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS report_id UUID REFERENCES reports(id) ON DELETE CASCADE;
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS notification_type_check;
+ALTER TABLE notifications ADD CONSTRAINT notification_type_check CHECK (type IN ('mention', 'report_resolved', 'moderation_warning'));
+
+COMMENT ON TABLE report_cases IS 'User reports grouped by target for moderator review';
+COMMENT ON COLUMN report_cases.target_id IS 'ID of the post, comment, message or user';
+COMMENT ON COLUMN report_cases.content_snapshot IS 'Reported text when the case was opened, kept if the owner edits or deletes it';
+COMMENT ON COLUMN blocked_users.reason IS 'Optional reason for blocking, private to the blocker; use reports to ask for moderator review';
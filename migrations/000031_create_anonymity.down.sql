@@ -0,0 +1,10 @@
+-- Drop accountable anonymity
+ALTER TABLE report_cases DROP COLUMN IF EXISTS target_is_anonymous;
+DROP INDEX IF EXISTS idx_comments_anonymous_user;
+DROP INDEX IF EXISTS idx_posts_anonymous_user;
+DROP TABLE IF EXISTS anonymity_strikes CASCADE;
+DROP TABLE IF EXISTS anonymity_unmask_log CASCADE;
+DROP TABLE IF EXISTS anonymous_identities CASCADE;
+ALTER TABLE users DROP COLUMN IF EXISTS anonymous_restored_at;
+ALTER TABLE users DROP COLUMN IF EXISTS anonymous_revoked_reason;
+ALTER TABLE users DROP COLUMN IF EXISTS anonymous_revoked_at;
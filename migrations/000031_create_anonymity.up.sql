@@ -0,0 +1,59 @@
+-- Anonymous posting can be revoked for abuse; strikes before a restore no longer count
+ALTER TABLE users ADD COLUMN IF NOT EXISTS anonymous_revoked_at TIMESTAMP;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS anonymous_revoked_reason TEXT;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS anonymous_restored_at TIMESTAMP;
+
+-- Pseudonym of each anonymous participant in a thread (a post and its comments)
+CREATE TABLE IF NOT EXISTS anonymous_identities (
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    pseudonym VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (post_id, user_id),
+    CONSTRAINT unique_pseudonym_per_thread UNIQUE (post_id, pseudonym)
+);
+
+-- Every time a moderator reveals an anonymous author; kept when accounts are deleted
+CREATE TABLE IF NOT EXISTS anonymity_unmask_log (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    moderator_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    content_type VARCHAR(20) NOT NULL,
+    content_id UUID NOT NULL,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT unmask_content_type_check CHECK (content_type IN ('post', 'comment')),
+    CONSTRAINT unmask_reason_length CHECK (char_length(reason) BETWEEN 10 AND 500)
+);
+
+CREATE INDEX IF NOT EXISTS idx_unmask_log_created ON anonymity_unmask_log(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_unmask_log_user ON anonymity_unmask_log(user_id, created_at DESC);
+
+-- Abusive anonymous content found by moderators, at most one strike per piece of content
+CREATE TABLE IF NOT EXISTS anonymity_strikes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    content_type VARCHAR(20) NOT NULL,
+    content_id UUID NOT NULL,
+    moderator_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    source VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT strike_content_type_check CHECK (content_type IN ('post', 'comment')),
+    CONSTRAINT strike_source_check CHECK (source IN ('report', 'unmask')),
+    CONSTRAINT unique_strike_per_content UNIQUE (user_id, content_type, content_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_anonymity_strikes_user ON anonymity_strikes(user_id, created_at DESC);
+
+-- Rate limit lookups count a user's recent anonymous posts and comments
+CREATE INDEX IF NOT EXISTS idx_posts_anonymous_user ON posts(user_id, created_at DESC) WHERE is_anonymous = true;
+CREATE INDEX IF NOT EXISTS idx_comments_anonymous_user ON post_comments(user_id, created_at DESC) WHERE is_anonymous = true;
+
+-- Report cases on anonymous content keep the owner hidden from moderators until unmasked
+ALTER TABLE report_cases ADD COLUMN IF NOT EXISTS target_is_anonymous BOOLEAN NOT NULL DEFAULT false;
+
+COMMENT ON TABLE anonymous_identities IS 'Stable pseudonyms ("Anonymous Owl") for anonymous authors within a thread';
+COMMENT ON TABLE anonymity_unmask_log IS 'Audit log of moderators revealing anonymous authors, with the reason given';
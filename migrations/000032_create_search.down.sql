@@ -0,0 +1,14 @@
+-- Drop full-text search
+DROP INDEX IF EXISTS idx_hashtags_tag_trgm;
+DROP INDEX IF EXISTS idx_interests_name_trgm;
+DROP INDEX IF EXISTS idx_users_name_trgm;
+DROP INDEX IF EXISTS idx_users_username_trgm;
+DROP INDEX IF EXISTS idx_interests_search;
+DROP INDEX IF EXISTS idx_users_search;
+DROP INDEX IF EXISTS idx_posts_search;
+
+ALTER TABLE interests DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE users DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE posts DROP COLUMN IF EXISTS search_vector;
+
+DROP EXTENSION IF EXISTS pg_trgm;
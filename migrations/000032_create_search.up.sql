@@ -0,0 +1,35 @@
+-- Fuzzy matching for usernames, names, hashtags and interests
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Full-text search vectors. They are generated columns, so Postgres keeps them (and their
+-- GIN indexes) current on every insert and update; deleted and hidden rows are filtered
+-- out at query time by is_active and status.
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (to_tsvector('english', COALESCE(content, ''))) STORED;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', COALESCE(username, '')), 'A') ||
+        setweight(to_tsvector('simple', COALESCE(first_name, '') || ' ' || COALESCE(last_name, '')), 'B') ||
+        setweight(to_tsvector('simple', COALESCE(bio, '')), 'C')
+    ) STORED;
+
+ALTER TABLE interests ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', name), 'A') ||
+        setweight(to_tsvector('english', COALESCE(category, '')), 'B')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_posts_search ON posts USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_users_search ON users USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_interests_search ON interests USING GIN (search_vector);
+
+-- Trigram indexes for typo-tolerant and partial matches
+CREATE INDEX IF NOT EXISTS idx_users_username_trgm ON users USING GIN (username gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_users_name_trgm ON users USING GIN ((COALESCE(first_name, '') || ' ' || COALESCE(last_name, '')) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_interests_name_trgm ON interests USING GIN (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_hashtags_tag_trgm ON hashtags USING GIN (tag gin_trgm_ops);
+
+COMMENT ON COLUMN posts.search_vector IS 'Full-text search vector of the post content (english)';
+COMMENT ON COLUMN users.search_vector IS 'Full-text search vector: username (A), name (B), bio (C)';
+COMMENT ON COLUMN interests.search_vector IS 'Full-text search vector: name (A), category (B)';
@@ -0,0 +1,3 @@
+-- Drop mutes
+DROP TABLE IF EXISTS muted_keywords CASCADE;
+DROP TABLE IF EXISTS user_mutes CASCADE;
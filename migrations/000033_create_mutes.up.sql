@@ -0,0 +1,33 @@
+-- Muted accounts: their posts, comments and notifications are hidden from the muter.
+-- The muted user is never told.
+CREATE TABLE IF NOT EXISTS user_mutes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    muter_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    muted_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    UNIQUE(muter_id, muted_id),
+    CHECK (muter_id != muted_id)
+);
+
+-- Muted words, phrases and hashtags
+CREATE TABLE IF NOT EXISTS muted_keywords (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    keyword VARCHAR(100) NOT NULL,
+    pattern TEXT NOT NULL,
+    expires_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    UNIQUE(user_id, keyword)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_mutes_muted ON user_mutes(muted_id);
+
+COMMENT ON TABLE user_mutes IS 'Accounts a user muted; hidden from their feeds, comments and notifications';
+COMMENT ON TABLE muted_keywords IS 'Words, phrases and hashtags a user muted';
+COMMENT ON COLUMN user_mutes.expires_at IS 'When the mute ends; NULL mutes until removed';
+COMMENT ON COLUMN muted_keywords.keyword IS 'Lowercase word or phrase, or #hashtag';
+COMMENT ON COLUMN muted_keywords.pattern IS 'Case-insensitive regular expression matching the keyword as a whole word';
+COMMENT ON COLUMN muted_keywords.expires_at IS 'When the mute ends; NULL mutes until removed';
@@ -0,0 +1,2 @@
+-- Drop feed feedback
+DROP TABLE IF EXISTS post_feedback;
@@ -0,0 +1,16 @@
+-- Feed feedback: posts a user hid from their feeds, or marked as a topic they are not
+-- interested in. Both hide the post; "not interested" also down-ranks posts sharing its
+-- hashtags and interests in the ranked feed.
+CREATE TABLE IF NOT EXISTS post_feedback (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('hidden', 'not_interested')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (user_id, post_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_feedback_user_created ON post_feedback(user_id, created_at DESC);
+
+COMMENT ON TABLE post_feedback IS 'Posts a user hid from their feeds';
+COMMENT ON COLUMN post_feedback.kind IS 'hidden or not_interested (also down-ranks the post''s topics)';
@@ -0,0 +1,2 @@
+-- Drop post metric rollups
+DROP TABLE IF EXISTS post_metrics_hourly;
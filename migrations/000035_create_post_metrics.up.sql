@@ -0,0 +1,62 @@
+-- Hourly rollups of post activity for author insights. Views are added by the view
+-- tracker when it flushes; reactions, comments and shares are rolled up from their event
+-- tables by a background job, so insights never scan the event tables.
+CREATE TABLE IF NOT EXISTS post_metrics_hourly (
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    bucket TIMESTAMP NOT NULL,
+    metric VARCHAR(30) NOT NULL,
+    dimension VARCHAR(30) NOT NULL DEFAULT '',
+    value INTEGER NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (post_id, bucket, metric, dimension)
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_metrics_hourly_bucket ON post_metrics_hourly(bucket);
+
+COMMENT ON TABLE post_metrics_hourly IS 'Post activity per hour, for author insights';
+COMMENT ON COLUMN post_metrics_hourly.bucket IS 'Start of the hour';
+COMMENT ON COLUMN post_metrics_hourly.metric IS 'views, follower_views, viewers, follower_viewers, reactions, comments or shares';
+COMMENT ON COLUMN post_metrics_hourly.dimension IS 'Reaction type for reactions, shared_to_type for shares, otherwise empty';
+COMMENT ON COLUMN post_metrics_hourly.value IS 'Count in the hour; viewers count accounts seeing the post for the first time';
+
+-- Backfill engagement from existing events
+INSERT INTO post_metrics_hourly (post_id, bucket, metric, dimension, value)
+SELECT post_id, date_trunc('hour', created_at), 'reactions', COALESCE(reaction_type, 'fire'), COUNT(*)
+FROM post_reactions
+GROUP BY 1, 2, 4
+ON CONFLICT DO NOTHING;
+
+INSERT INTO post_metrics_hourly (post_id, bucket, metric, dimension, value)
+SELECT post_id, date_trunc('hour', created_at), 'comments', '', COUNT(*)
+FROM post_comments
+WHERE is_active = true
+GROUP BY 1, 2
+ON CONFLICT DO NOTHING;
+
+INSERT INTO post_metrics_hourly (post_id, bucket, metric, dimension, value)
+SELECT post_id, date_trunc('hour', created_at), 'shares', shared_to_type, COUNT(*)
+FROM post_shares
+GROUP BY 1, 2, 4
+ON CONFLICT DO NOTHING;
+
+-- Backfill views from post_views. Per-view times were never stored, so each viewer's views
+-- are counted in the hour of their first view, and follower status is as of now.
+INSERT INTO post_metrics_hourly (post_id, bucket, metric, dimension, value)
+SELECT pv.post_id, date_trunc('hour', pv.first_viewed_at), m.metric, '', SUM(m.value)
+FROM post_views pv
+JOIN posts p ON p.id = pv.post_id
+CROSS JOIN LATERAL (
+    SELECT EXISTS (
+        SELECT 1 FROM user_follows f
+        WHERE f.follower_id = pv.viewer_id AND f.following_id = p.user_id
+    ) AS is_follower
+) f
+CROSS JOIN LATERAL (VALUES
+    ('views', pv.view_count),
+    ('viewers', 1),
+    ('follower_views', CASE WHEN f.is_follower THEN pv.view_count ELSE 0 END),
+    ('follower_viewers', CASE WHEN f.is_follower THEN 1 ELSE 0 END)
+) m(metric, value)
+WHERE m.value > 0
+GROUP BY 1, 2, 3
+ON CONFLICT DO NOTHING;
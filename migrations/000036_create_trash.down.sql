@@ -0,0 +1,12 @@
+-- Drop the trash
+DROP INDEX IF EXISTS idx_post_comments_trash;
+DROP INDEX IF EXISTS idx_posts_trash;
+
+-- Quote posts whose original was purged no longer satisfy the old check
+ALTER TABLE posts DROP CONSTRAINT IF EXISTS post_quoted_check;
+ALTER TABLE posts ADD CONSTRAINT post_quoted_check CHECK (post_type NOT IN ('repost', 'quote') OR quoted_post_id IS NOT NULL) NOT VALID;
+
+ALTER TABLE post_comments DROP COLUMN IF EXISTS removed_by_moderation;
+ALTER TABLE post_comments DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE posts DROP COLUMN IF EXISTS removed_by_moderation;
+ALTER TABLE posts DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,33 @@
+-- Deleted posts and comments stay in their author's trash for 30 days, restorable, and are
+-- then purged. Content removed by moderators never shows in the trash.
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS removed_by_moderation BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE post_comments ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+ALTER TABLE post_comments ADD COLUMN IF NOT EXISTS removed_by_moderation BOOLEAN NOT NULL DEFAULT false;
+
+-- Purging a quoted post leaves the quote post without an original (reposts of it are purged)
+ALTER TABLE posts DROP CONSTRAINT IF EXISTS post_quoted_check;
+ALTER TABLE posts ADD CONSTRAINT post_quoted_check CHECK (post_type <> 'repost' OR quoted_post_id IS NOT NULL);
+
+-- Content deleted before the trash existed expires 30 days after it was deleted
+UPDATE posts SET deleted_at = COALESCE(updated_at, NOW()) WHERE is_active = false AND deleted_at IS NULL;
+UPDATE post_comments SET deleted_at = COALESCE(updated_at, NOW()) WHERE is_active = false AND deleted_at IS NULL;
+
+UPDATE posts p SET removed_by_moderation = true
+WHERE p.is_active = false AND (
+    EXISTS (SELECT 1 FROM moderation_queue mq WHERE mq.content_type = 'post' AND mq.content_id = p.id AND mq.status = 'removed')
+    OR EXISTS (SELECT 1 FROM report_cases rc WHERE rc.target_type = 'post' AND rc.target_id = p.id AND rc.action = 'hide_content')
+);
+UPDATE post_comments c SET removed_by_moderation = true
+WHERE c.is_active = false AND (
+    EXISTS (SELECT 1 FROM moderation_queue mq WHERE mq.content_type = 'comment' AND mq.content_id = c.id AND mq.status = 'removed')
+    OR EXISTS (SELECT 1 FROM report_cases rc WHERE rc.target_type = 'comment' AND rc.target_id = c.id AND rc.action = 'hide_content')
+);
+
+CREATE INDEX IF NOT EXISTS idx_posts_trash ON posts(user_id, deleted_at DESC) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_post_comments_trash ON post_comments(user_id, deleted_at DESC) WHERE deleted_at IS NOT NULL;
+
+COMMENT ON COLUMN posts.deleted_at IS 'When the post was deleted; purged 30 days later';
+COMMENT ON COLUMN posts.removed_by_moderation IS 'Removed by a moderator; the author cannot restore it';
+COMMENT ON COLUMN post_comments.deleted_at IS 'When the comment was deleted; purged 30 days later';
+COMMENT ON COLUMN post_comments.removed_by_moderation IS 'Removed by a moderator; the author cannot restore it';
@@ -0,0 +1,3 @@
+-- Drop post interests and interest follows
+DROP TABLE IF EXISTS interest_follows;
+DROP TABLE IF EXISTS post_interests;
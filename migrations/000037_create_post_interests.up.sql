@@ -0,0 +1,35 @@
+-- Interests a post is about: up to 3 picked by the author, plus any inferred from hashtags
+-- linked to an interest
+CREATE TABLE IF NOT EXISTS post_interests (
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    interest_id UUID NOT NULL REFERENCES interests(id) ON DELETE CASCADE,
+    source VARCHAR(10) NOT NULL DEFAULT 'author',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (post_id, interest_id),
+    CONSTRAINT post_interest_source_check CHECK (source IN ('author', 'hashtag'))
+);
+
+-- Interests a user follows; posts tagged with them reach the user's home feed
+CREATE TABLE IF NOT EXISTS interest_follows (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    interest_id UUID NOT NULL REFERENCES interests(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (user_id, interest_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_interests_interest ON post_interests(interest_id, post_id);
+CREATE INDEX IF NOT EXISTS idx_interest_follows_interest ON interest_follows(interest_id);
+
+-- Infer interests of existing posts from their hashtags
+INSERT INTO post_interests (post_id, interest_id, source)
+SELECT DISTINCT ph.post_id, h.interest_id, 'hashtag'
+FROM post_hashtags ph
+JOIN hashtags h ON h.id = ph.hashtag_id
+WHERE h.interest_id IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+COMMENT ON TABLE post_interests IS 'Interests a post is tagged with';
+COMMENT ON COLUMN post_interests.source IS 'author (picked when posting, max 3) or hashtag (inferred)';
+COMMENT ON TABLE interest_follows IS 'Interests a user follows for their home feed, separate from profile interests';
@@ -0,0 +1,5 @@
+-- Drop stories
+ALTER TABLE messages DROP COLUMN IF EXISTS story_id;
+
+DROP TABLE IF EXISTS story_views;
+DROP TABLE IF EXISTS stories;
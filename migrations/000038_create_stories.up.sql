@@ -0,0 +1,34 @@
+-- Stories: image or text posts that disappear 24 hours after they are shared
+CREATE TABLE IF NOT EXISTS stories (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    content TEXT,
+    media_id UUID REFERENCES media(id) ON DELETE CASCADE,
+    alt_text TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL,
+
+    CONSTRAINT story_content_check CHECK (content IS NOT NULL OR media_id IS NOT NULL),
+    CONSTRAINT story_content_length CHECK (content IS NULL OR char_length(content) <= 500)
+);
+
+-- Who saw each story, for the author's viewer list and the unseen ring in the tray
+CREATE TABLE IF NOT EXISTS story_views (
+    story_id UUID NOT NULL REFERENCES stories(id) ON DELETE CASCADE,
+    viewer_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    viewed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (story_id, viewer_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_stories_user_expires ON stories(user_id, expires_at DESC);
+CREATE INDEX IF NOT EXISTS idx_stories_expires ON stories(expires_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stories_media ON stories(media_id) WHERE media_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_story_views_viewer ON story_views(viewer_id);
+
+-- Replies to a story are sent as direct messages that point back to it
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS story_id UUID REFERENCES stories(id) ON DELETE SET NULL;
+
+COMMENT ON TABLE stories IS 'Ephemeral posts, hidden after expires_at and deleted with their media by a background job';
+COMMENT ON TABLE story_views IS 'One row per user who viewed a story';
+COMMENT ON COLUMN messages.story_id IS 'Story this message replies to (NULL once the story is deleted)';
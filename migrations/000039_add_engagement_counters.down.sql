@@ -0,0 +1,9 @@
+-- Drop engagement counters
+DROP TABLE IF EXISTS counter_reconciliations;
+
+ALTER TABLE users DROP COLUMN IF EXISTS post_count;
+ALTER TABLE users DROP COLUMN IF EXISTS following_count;
+ALTER TABLE users DROP COLUMN IF EXISTS follower_count;
+ALTER TABLE posts DROP COLUMN IF EXISTS share_count;
+ALTER TABLE posts DROP COLUMN IF EXISTS comment_count;
+ALTER TABLE posts DROP COLUMN IF EXISTS reaction_count;
@@ -0,0 +1,40 @@
+-- Engagement counters kept on posts and users instead of counting rows on every read. They
+-- are updated in the same transaction as the rows they count, and a background job
+-- recomputes them to repair any drift.
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS reaction_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS comment_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS share_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS follower_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS following_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS post_count INTEGER NOT NULL DEFAULT 0;
+
+-- Backfill from the existing rows
+UPDATE posts p SET
+    reaction_count = (SELECT COUNT(*) FROM post_reactions r WHERE r.post_id = p.id),
+    comment_count = (SELECT COUNT(*) FROM post_comments c WHERE c.post_id = p.id AND c.is_active = true),
+    share_count = (SELECT COUNT(*) FROM post_shares s WHERE s.post_id = p.id);
+
+UPDATE users u SET
+    follower_count = (SELECT COUNT(*) FROM user_follows f WHERE f.following_id = u.id),
+    following_count = (SELECT COUNT(*) FROM user_follows f WHERE f.follower_id = u.id),
+    post_count = (SELECT COUNT(*) FROM posts p WHERE p.user_id = u.id AND p.is_active = true AND p.status = 'published' AND p.post_type <> 'repost');
+
+-- Drift found by each reconciliation run, one row per counter
+CREATE TABLE IF NOT EXISTS counter_reconciliations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    counter VARCHAR(50) NOT NULL,
+    rows_fixed INTEGER NOT NULL,
+    total_drift BIGINT NOT NULL,
+    max_drift INTEGER NOT NULL,
+    ran_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_counter_reconciliations_counter ON counter_reconciliations(counter, ran_at DESC);
+
+COMMENT ON COLUMN posts.reaction_count IS 'Number of post_reactions rows';
+COMMENT ON COLUMN posts.comment_count IS 'Number of active post_comments rows, replies included';
+COMMENT ON COLUMN posts.share_count IS 'Number of post_shares rows';
+COMMENT ON COLUMN users.follower_count IS 'Number of user_follows rows following the user';
+COMMENT ON COLUMN users.following_count IS 'Number of user_follows rows by the user';
+COMMENT ON COLUMN users.post_count IS 'Number of active published posts by the user, not counting reposts';
+COMMENT ON TABLE counter_reconciliations IS 'Counter drift repaired by the reconciliation job: rows fixed, and the sum and largest absolute difference';
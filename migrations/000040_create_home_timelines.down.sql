@@ -0,0 +1,3 @@
+-- Drop home timeline tables
+DROP TABLE IF EXISTS home_timeline_builds;
+DROP TABLE IF EXISTS home_timelines;
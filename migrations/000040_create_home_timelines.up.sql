@@ -0,0 +1,21 @@
+-- Precomputed home timelines for the postgres timeline store: post IDs pushed to followers
+-- when published, newest first by created_at
+CREATE TABLE IF NOT EXISTS home_timelines (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    author_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, post_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_home_timelines_user_created ON home_timelines(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_home_timelines_user_author ON home_timelines(user_id, author_id);
+
+-- Users whose timeline has been built; posts are only pushed to built timelines
+CREATE TABLE IF NOT EXISTS home_timeline_builds (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    built_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE home_timelines IS 'Posts pushed to each user''s home timeline (fan-out on write)';
+COMMENT ON TABLE home_timeline_builds IS 'Home timelines that have been built and receive pushed posts';